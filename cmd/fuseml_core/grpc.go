@@ -2,7 +2,7 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net"
 	"net/url"
 	"sync"
@@ -13,12 +13,21 @@ import (
 	codesetsvr "github.com/fuseml/fuseml-core/gen/grpc/codeset/server"
 	extensionpb "github.com/fuseml/fuseml-core/gen/grpc/extension/pb"
 	extensionsvr "github.com/fuseml/fuseml-core/gen/grpc/extension/server"
+	notificationpb "github.com/fuseml/fuseml-core/gen/grpc/notification/pb"
+	notificationsvr "github.com/fuseml/fuseml-core/gen/grpc/notification/server"
 	projectpb "github.com/fuseml/fuseml-core/gen/grpc/project/pb"
 	projectsvr "github.com/fuseml/fuseml-core/gen/grpc/project/server"
 	runnablepb "github.com/fuseml/fuseml-core/gen/grpc/runnable/pb"
 	runnablesvr "github.com/fuseml/fuseml-core/gen/grpc/runnable/server"
+	statuspb "github.com/fuseml/fuseml-core/gen/grpc/status/pb"
+	statussvr "github.com/fuseml/fuseml-core/gen/grpc/status/server"
+	watchpb "github.com/fuseml/fuseml-core/gen/grpc/watch/pb"
+	watchsvr "github.com/fuseml/fuseml-core/gen/grpc/watch/server"
 	workflowpb "github.com/fuseml/fuseml-core/gen/grpc/workflow/pb"
 	workflowsvr "github.com/fuseml/fuseml-core/gen/grpc/workflow/server"
+	"github.com/fuseml/fuseml-core/pkg/logging"
+	"github.com/fuseml/fuseml-core/pkg/metrics"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 
 	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpcmdlwr "goa.design/goa/v3/grpc/middleware"
@@ -29,13 +38,13 @@ import (
 
 // handleGRPCServer starts configures and starts a gRPC server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleGRPCServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleGRPCServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg *sync.WaitGroup, errc chan error, logger *slog.Logger, debug bool) {
 	// Setup goa log adapter.
 	var (
 		adapter middleware.Logger
 	)
 	{
-		adapter = middleware.NewLogger(logger)
+		adapter = logging.Adapter(logger)
 	}
 
 	// Wrap the endpoints with the transport specific layers. The generated
@@ -43,12 +52,15 @@ func handleGRPCServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 	// the service input and output data structures to gRPC requests and
 	// responses.
 	var (
-		applicationServer *applicationsvr.Server
-		runnableServer    *runnablesvr.Server
-		codesetServer     *codesetsvr.Server
-		projectServer     *projectsvr.Server
-		workflowServer    *workflowsvr.Server
-		extensionServer   *extensionsvr.Server
+		applicationServer  *applicationsvr.Server
+		runnableServer     *runnablesvr.Server
+		codesetServer      *codesetsvr.Server
+		projectServer      *projectsvr.Server
+		workflowServer     *workflowsvr.Server
+		extensionServer    *extensionsvr.Server
+		watchServer        *watchsvr.Server
+		notificationServer *notificationsvr.Server
+		statusServer       *statussvr.Server
 	)
 	{
 		applicationServer = applicationsvr.New(endpoints.application, nil)
@@ -57,6 +69,9 @@ func handleGRPCServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 		projectServer = projectsvr.New(endpoints.project, nil)
 		workflowServer = workflowsvr.New(endpoints.workflow, nil)
 		extensionServer = extensionsvr.New(endpoints.extension, nil)
+		watchServer = watchsvr.New(endpoints.watch, nil)
+		notificationServer = notificationsvr.New(endpoints.notification, nil)
+		statusServer = statussvr.New(endpoints.status, nil)
 	}
 
 	// Initialize gRPC server with the middleware.
@@ -64,6 +79,14 @@ func handleGRPCServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
+			tracing.UnaryServer(),
+			metrics.UnaryServer(),
+		),
+		grpcmiddleware.WithStreamServerChain(
+			grpcmdlwr.StreamRequestID(),
+			grpcmdlwr.StreamServerLog(adapter),
+			tracing.StreamServer(),
+			metrics.StreamServer(),
 		),
 	)
 
@@ -74,10 +97,13 @@ func handleGRPCServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 	projectpb.RegisterProjectServer(srv, projectServer)
 	workflowpb.RegisterWorkflowServer(srv, workflowServer)
 	extensionpb.RegisterExtensionServer(srv, extensionServer)
+	watchpb.RegisterWatchServer(srv, watchServer)
+	notificationpb.RegisterNotificationServer(srv, notificationServer)
+	statuspb.RegisterStatusServer(srv, statusServer)
 
 	for svc, info := range srv.GetServiceInfo() {
 		for _, m := range info.Methods {
-			logger.Printf("serving gRPC method %s", svc+"/"+m.Name)
+			logger.Info("Serving gRPC method", "method", svc+"/"+m.Name)
 		}
 	}
 
@@ -95,12 +121,12 @@ func handleGRPCServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 			if err != nil {
 				errc <- err
 			}
-			logger.Printf("gRPC server listening on %q", u.Host)
+			logger.Info("gRPC server listening", "address", u.Host)
 			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down gRPC server at %q", u.Host)
+		logger.Info("Shutting down gRPC server", "address", u.Host)
 		srv.Stop()
 	}()
 }
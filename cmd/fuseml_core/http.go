@@ -2,25 +2,35 @@ package main
 
 import (
 	"context"
-	"log"
+	"io"
+	"log/slog"
 	"mime"
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
 
+	adminsvr "github.com/fuseml/fuseml-core/gen/http/admin/server"
 	applicationsvr "github.com/fuseml/fuseml-core/gen/http/application/server"
 	codesetsvr "github.com/fuseml/fuseml-core/gen/http/codeset/server"
 	extensionsvr "github.com/fuseml/fuseml-core/gen/http/extension/server"
+	notificationsvr "github.com/fuseml/fuseml-core/gen/http/notification/server"
 	openapisvr "github.com/fuseml/fuseml-core/gen/http/openapi/server"
 	projectsvr "github.com/fuseml/fuseml-core/gen/http/project/server"
 	runnablesvr "github.com/fuseml/fuseml-core/gen/http/runnable/server"
+	statussvr "github.com/fuseml/fuseml-core/gen/http/status/server"
 	versionsvr "github.com/fuseml/fuseml-core/gen/http/version/server"
+	watchsvr "github.com/fuseml/fuseml-core/gen/http/watch/server"
 	workflowsvr "github.com/fuseml/fuseml-core/gen/http/workflow/server"
+	"github.com/fuseml/fuseml-core/pkg/logging"
+	"github.com/fuseml/fuseml-core/pkg/metrics"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 
 	"github.com/goccy/go-yaml"
+	"github.com/gorilla/websocket"
 	goahttp "goa.design/goa/v3/http"
 	httpmdlwr "goa.design/goa/v3/http/middleware"
 	"goa.design/goa/v3/middleware"
@@ -28,13 +38,13 @@ import (
 
 // handleHTTPServer starts configures and starts a HTTP server on the given
 // URL. It shuts down the server if any error is received in the error channel.
-func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg *sync.WaitGroup, errc chan error, logger *slog.Logger, debug bool) {
 	// Setup goa log adapter.
 	var (
 		adapter middleware.Logger
 	)
 	{
-		adapter = middleware.NewLogger(logger)
+		adapter = logging.Adapter(logger)
 	}
 
 	// Provide the transport specific request decoder and response encoder.
@@ -58,17 +68,21 @@ func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 	// the service input and output data structures to HTTP requests and
 	// responses.
 	var (
-		versionServer     *versionsvr.Server
-		applicationServer *applicationsvr.Server
-		runnableServer    *runnablesvr.Server
-		codesetServer     *codesetsvr.Server
-		projectServer     *projectsvr.Server
-		openapiServer     *openapisvr.Server
-		workflowServer    *workflowsvr.Server
-		extensionServer   *extensionsvr.Server
+		versionServer      *versionsvr.Server
+		applicationServer  *applicationsvr.Server
+		runnableServer     *runnablesvr.Server
+		codesetServer      *codesetsvr.Server
+		projectServer      *projectsvr.Server
+		openapiServer      *openapisvr.Server
+		workflowServer     *workflowsvr.Server
+		extensionServer    *extensionsvr.Server
+		watchServer        *watchsvr.Server
+		notificationServer *notificationsvr.Server
+		statusServer       *statussvr.Server
+		adminServer        *adminsvr.Server
 	)
 	{
-		eh := errorHandler(logger)
+		eh := errorHandler(enc, logger)
 		versionServer = versionsvr.New(endpoints.version, mux, dec, enc, eh, nil)
 		applicationServer = applicationsvr.New(endpoints.application, mux, dec, enc, eh, nil)
 		runnableServer = runnablesvr.New(endpoints.runnable, mux, dec, enc, eh, nil)
@@ -77,6 +91,11 @@ func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 		workflowServer = workflowsvr.New(endpoints.workflow, mux, dec, enc, eh, nil)
 		extensionServer = extensionsvr.New(endpoints.extension, mux, dec, enc, eh, nil)
 		openapiServer = openapisvr.New(nil, mux, dec, enc, eh, nil, nil, nil, nil, nil)
+		watchServer = watchsvr.New(endpoints.watch, mux, dec, enc, eh, nil, &websocket.Upgrader{},
+			watchsvr.NewConnConfigurer(cancelOnClose))
+		notificationServer = notificationsvr.New(endpoints.notification, mux, dec, enc, eh, nil)
+		statusServer = statussvr.New(endpoints.status, mux, dec, enc, eh, nil)
+		adminServer = adminsvr.New(endpoints.admin, mux, dec, enc, eh, nil)
 		if debug {
 			servers := goahttp.Servers{
 				versionServer,
@@ -87,6 +106,10 @@ func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 				openapiServer,
 				workflowServer,
 				extensionServer,
+				watchServer,
+				notificationServer,
+				statusServer,
+				adminServer,
 			}
 			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
 		}
@@ -100,11 +123,18 @@ func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 	openapisvr.Mount(mux, openapiServer)
 	workflowsvr.Mount(mux, workflowServer)
 	extensionsvr.Mount(mux, extensionServer)
+	watchsvr.Mount(mux, watchServer)
+	notificationsvr.Mount(mux, notificationServer)
+	statussvr.Mount(mux, statusServer)
+	adminsvr.Mount(mux, adminServer)
+	mux.Handle(http.MethodGet, "/metrics", metrics.Handler().ServeHTTP)
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
 	var handler http.Handler = mux
 	{
+		handler = metrics.HTTP()(handler)
+		handler = tracing.HTTP()(handler)
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
 	}
@@ -113,29 +143,42 @@ func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 	// configure the server as required by your service.
 	srv := &http.Server{Addr: u.Host, Handler: handler}
 	for _, m := range versionServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
 	for _, m := range applicationServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
 	for _, m := range runnableServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
 	for _, m := range codesetServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
 	for _, m := range projectServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
 	for _, m := range openapiServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
 	for _, m := range workflowServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
 	for _, m := range extensionServer.Mounts {
-		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
 	}
+	for _, m := range watchServer.Mounts {
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
+	}
+	for _, m := range notificationServer.Mounts {
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
+	}
+	for _, m := range statusServer.Mounts {
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
+	}
+	for _, m := range adminServer.Mounts {
+		logger.Info("HTTP method mounted", "method", m.Method, "verb", m.Verb, "pattern", m.Pattern)
+	}
+	logger.Info("HTTP metrics mounted", "verb", http.MethodGet, "pattern", "/metrics")
 
 	(*wg).Add(1)
 	go func() {
@@ -143,12 +186,12 @@ func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 
 		// Start HTTP server in a separate goroutine.
 		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
+			logger.Info("HTTP server listening", "address", u.Host)
 			errc <- srv.ListenAndServe()
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down HTTP server at %q", u.Host)
+		logger.Info("Shutting down HTTP server", "address", u.Host)
 
 		// Shutdown gracefully with a 30s timeout.
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -158,14 +201,36 @@ func handleHTTPServer(ctx context.Context, u *url.URL, endpoints *endpoints, wg
 	}()
 }
 
+// cancelOnClose configures a streaming websocket connection to cancel the request context when the
+// client closes the connection. Streaming endpoints only write to the connection, so the messages sent
+// by the client are read and discarded to detect the close.
+func cancelOnClose(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn {
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+	return conn
+}
+
 // errorHandler returns a function that writes and logs the given error.
-// The function also writes and logs the error unique ID so that it's possible
-// to correlate.
-func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+// The error is written as a goa error response, identified by the request
+// ID, so that it's possible to correlate it with the logged error.
+func errorHandler(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, logger *slog.Logger) func(context.Context, http.ResponseWriter, error) {
 	return func(ctx context.Context, w http.ResponseWriter, err error) {
-		id := ctx.Value(middleware.RequestIDKey).(string)
-		_, _ = w.Write([]byte("[" + id + "] encoding: " + err.Error()))
-		logger.Printf("[%s] ERROR: %s", id, err.Error())
+		resp := goahttp.NewErrorResponse(err).(*goahttp.ErrorResponse)
+		if id, ok := ctx.Value(middleware.RequestIDKey).(string); ok {
+			resp.ID = id
+		}
+		logger.ErrorContext(ctx, "Failed writing response", "error", err)
+		enc := encoder(ctx, w)
+		w.WriteHeader(resp.StatusCode())
+		if err := enc.Encode(resp); err != nil {
+			logger.ErrorContext(ctx, "Failed encoding error response", "error", err)
+		}
 	}
 }
 
@@ -187,12 +252,76 @@ func requestDecoder(r *http.Request) goahttp.Decoder {
 	case ct == "", ct == "application/x-yaml", ct == "text/x-yaml":
 		fallthrough
 	case strings.HasSuffix(ct, "+yaml"):
-		return yaml.NewDecoder(r.Body)
+		return &multiDocumentDecoder{yaml.NewDecoder(r.Body)}
 	default:
 		return goahttp.RequestDecoder(r)
 	}
 }
 
+// multiDocumentDecoder is a YAML decoder that also accepts multi-document YAML
+// streams for list payloads. Each document holds either a single list element
+// or a list of elements, and the elements of all documents are concatenated.
+type multiDocumentDecoder struct {
+	dec *yaml.Decoder
+}
+
+// Decode decodes the YAML stream into v.
+func (d *multiDocumentDecoder) Decode(v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Slice {
+		return d.dec.Decode(v)
+	}
+
+	list := target.Elem()
+	decoded := false
+	for {
+		var doc interface{}
+		err := d.dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		decoded = true
+		if doc == nil {
+			continue
+		}
+
+		// re-decode the generic document into the list or list element type
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		if _, isList := doc.([]interface{}); isList {
+			elems := reflect.New(list.Type())
+			if err = yaml.Unmarshal(data, elems.Interface()); err != nil {
+				return err
+			}
+			list.Set(reflect.AppendSlice(list, elems.Elem()))
+		} else {
+			// the YAML decoder doesn't allocate pointers to structs on its own
+			elemType := list.Type().Elem()
+			isPtr := elemType.Kind() == reflect.Ptr
+			if isPtr {
+				elemType = elemType.Elem()
+			}
+			elem := reflect.New(elemType)
+			if err = yaml.Unmarshal(data, elem.Interface()); err != nil {
+				return err
+			}
+			if !isPtr {
+				elem = elem.Elem()
+			}
+			list.Set(reflect.Append(list, elem))
+		}
+	}
+	if !decoded {
+		return io.EOF
+	}
+	return nil
+}
+
 // responseEncoder implements the goahttp.Encoder interface.
 // Its return defaults to a YAML encoder, when a specific content type other
 // than YAML is requested it returns the Encoder from the Goa ResponseEncoder
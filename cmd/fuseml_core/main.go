@@ -4,7 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
 	"net/url"
 	"os"
@@ -12,37 +12,192 @@ import (
 	"sync"
 	"syscall"
 
+	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/timshannon/badgerhold/v3"
+	goa "goa.design/goa/v3/pkg"
 
+	"github.com/fuseml/fuseml-core/gen/admin"
 	"github.com/fuseml/fuseml-core/gen/application"
 	"github.com/fuseml/fuseml-core/gen/codeset"
 	"github.com/fuseml/fuseml-core/gen/extension"
+	"github.com/fuseml/fuseml-core/gen/notification"
 	"github.com/fuseml/fuseml-core/gen/project"
 	"github.com/fuseml/fuseml-core/gen/runnable"
+	"github.com/fuseml/fuseml-core/gen/status"
 	"github.com/fuseml/fuseml-core/gen/version"
+	"github.com/fuseml/fuseml-core/gen/watch"
 	"github.com/fuseml/fuseml-core/gen/workflow"
+	"github.com/fuseml/fuseml-core/pkg/core"
 	"github.com/fuseml/fuseml-core/pkg/core/config"
+	"github.com/fuseml/fuseml-core/pkg/core/gitea"
+	"github.com/fuseml/fuseml-core/pkg/core/leader"
+	"github.com/fuseml/fuseml-core/pkg/core/manager"
+	"github.com/fuseml/fuseml-core/pkg/core/store/badger"
+	"github.com/fuseml/fuseml-core/pkg/core/store/sqlstore"
+	"github.com/fuseml/fuseml-core/pkg/core/tekton"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/kubernetes"
+	"github.com/fuseml/fuseml-core/pkg/logging"
+	"github.com/fuseml/fuseml-core/pkg/metrics"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 	ver "github.com/fuseml/fuseml-core/pkg/version"
 )
 
 type coreInit struct {
-	endpoints *endpoints
-	store     *badgerhold.Store
+	endpoints       *endpoints
+	store           *badgerhold.Store
+	sqlStore        *sqlstore.Store
+	healthChecker   *manager.ExtensionHealthChecker
+	discovery       *manager.ExtensionDiscovery
+	workflowManager *manager.WorkflowManager
+	notifications   *manager.NotificationManager
+	reconciler      *manager.WorkflowReconciler
+	elector         *leader.Elector
 }
 
 type endpoints struct {
-	application *application.Endpoints
-	codeset     *codeset.Endpoints
-	project     *project.Endpoints
-	runnable    *runnable.Endpoints
-	version     *version.Endpoints
-	workflow    *workflow.Endpoints
-	extension   *extension.Endpoints
+	application  *application.Endpoints
+	codeset      *codeset.Endpoints
+	project      *project.Endpoints
+	runnable     *runnable.Endpoints
+	version      *version.Endpoints
+	workflow     *workflow.Endpoints
+	extension    *extension.Endpoints
+	watch        *watch.Endpoints
+	notification *notification.Endpoints
+	status       *status.Endpoints
+	admin        *admin.Endpoints
+}
+
+// use applies the endpoint middleware to the endpoints of all services
+func (e *endpoints) use(m func(goa.Endpoint) goa.Endpoint) {
+	e.application.Use(m)
+	e.codeset.Use(m)
+	e.project.Use(m)
+	e.runnable.Use(m)
+	e.version.Use(m)
+	e.workflow.Use(m)
+	e.extension.Use(m)
+	e.watch.Use(m)
+	e.notification.Use(m)
+	e.status.Use(m)
+	e.admin.Use(m)
+}
+
+// newStoreOptions returns the options of the badger database backing the stores
+func newStoreOptions(cfg *config.Storage) badgerhold.Options {
+	storeOptions := badgerhold.DefaultOptions
+	storeOptions.Dir = cfg.Dir
+	storeOptions.ValueDir = cfg.Dir
+	return storeOptions
+}
+
+// newSQLStore opens the PostgreSQL database holding the workflows, extensions, applications and runnables,
+// if it is the configured storage backend. It returns nil otherwise.
+func newSQLStore(logger *slog.Logger, cfg *config.Storage) (*sqlstore.Store, error) {
+	if cfg.Backend != config.StoragePostgres {
+		return nil, nil
+	}
+	return sqlstore.Open(logger, "postgres", cfg.DatabaseURL)
+}
+
+// newWorkflowStore returns the workflow store of the configured storage backend
+func newWorkflowStore(store *badgerhold.Store, sqlStore *sqlstore.Store) domain.WorkflowStore {
+	if sqlStore != nil {
+		return sqlstore.NewWorkflowStore(sqlStore)
+	}
+	return badger.NewWorkflowStore(store)
+}
+
+// newExtensionStore returns the extension store of the configured storage backend
+func newExtensionStore(store *badgerhold.Store, sqlStore *sqlstore.Store) domain.ExtensionStore {
+	if sqlStore != nil {
+		return sqlstore.NewExtensionStore(sqlStore)
+	}
+	return badger.NewExtensionStore(store)
+}
+
+// newApplicationStore returns the application store of the configured storage backend
+func newApplicationStore(store *badgerhold.Store, sqlStore *sqlstore.Store) domain.ApplicationStore {
+	if sqlStore != nil {
+		return sqlstore.NewApplicationStore(sqlStore)
+	}
+	return badger.NewApplicationStore(store)
+}
+
+// newExtensionUsageStore returns the extension usage store of the configured storage backend
+func newExtensionUsageStore(store *badgerhold.Store, sqlStore *sqlstore.Store) domain.ExtensionUsageStore {
+	if sqlStore != nil {
+		return sqlstore.NewExtensionUsageStore(sqlStore)
+	}
+	return badger.NewExtensionUsageStore(store)
+}
+
+// newNotificationStore returns the notification store of the configured storage backend
+func newNotificationStore(store *badgerhold.Store, sqlStore *sqlstore.Store) domain.NotificationStore {
+	if sqlStore != nil {
+		return sqlstore.NewNotificationStore(sqlStore)
+	}
+	return badger.NewNotificationStore(store)
+}
+
+// newRunnableStore returns the runnable store of the configured storage backend. The runnables are kept in
+// memory by the badger backend.
+func newRunnableStore(sqlStore *sqlstore.Store) domain.RunnableStore {
+	if sqlStore != nil {
+		return sqlstore.NewRunnableStore(sqlStore)
+	}
+	return core.NewRunnableStore()
+}
+
+// newStatusManager returns the status manager checking all the services fuseml-core depends on
+func newStatusManager(logger *slog.Logger, fuseMLNamespace string, store *badgerhold.Store, sqlStore *sqlstore.Store,
+	gitAdmin *gitea.AdminClient, backend *tekton.WorkflowBackend) *manager.StatusManager {
+	checkers := []domain.DependencyChecker{badger.NewStatusChecker(store)}
+	if sqlStore != nil {
+		checkers = append(checkers, sqlstore.NewStatusChecker(sqlStore))
+	}
+	checkers = append(checkers, gitAdmin, backend)
+	return manager.NewStatusManager(logger, fuseMLNamespace, backend.DashboardURL(), checkers...)
+}
+
+// newElector returns the elector of the server running the leader-only background work. The kubernetes
+// client holding the Lease is only created if leader election is enabled.
+func newElector(logger *slog.Logger, cfg *config.LeaderElection) (*leader.Elector, error) {
+	if !cfg.Enabled {
+		return leader.NewElector(logger, cfg, nil)
+	}
+	client, err := kubernetes.NewClientset()
+	if err != nil {
+		return nil, err
+	}
+	return leader.NewElector(logger, cfg, client.CoordinationV1())
+}
+
+// configFlags maps the command line flags that override the server configuration to the configuration keys
+var configFlags = map[string]string{
+	"namespace":                     "namespace",
+	"data-dir":                      "storage.dir",
+	"reconcile-interval":            "workflows.reconcile-interval",
+	"extension-health-interval":     "extensions.health-interval",
+	"extension-discovery":           "extensions.discovery",
+	"extension-discovery-namespace": "extensions.discovery-namespace",
+	"smtp-server":                   "notifications.smtp-server",
+	"smtp-from":                     "notifications.smtp-from",
+	"otlp-endpoint":                 "tracing.endpoint",
+	"otlp-insecure":                 "tracing.insecure",
+	"trace-sample-ratio":            "tracing.sample-ratio",
+	"log-level":                     "log.level",
+	"log-format":                    "log.format",
+	"leader-election":               "leader-election.enabled",
 }
 
 func main() {
 	// Define command line flags, add any other flag required to configure the
-	// service.
+	// service. The flags overriding the server configuration default to the
+	// default configuration, and only override it when set.
+	defaults := config.Default()
 	var (
 		hostF     = flag.String("host", "dev", "Server host (valid values: dev, prod)")
 		domainF   = flag.String("domain", "", "Host domain name (overrides host domain specified in service design)")
@@ -50,29 +205,72 @@ func main() {
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", os.Getenv("FUSEML_CONFIG"), "(FUSEML_CONFIG) Server configuration file (YAML)")
 	)
+	flag.String("namespace", defaults.Namespace, "Kubernetes namespace where FuseML workloads are created")
+	flag.String("data-dir", defaults.Storage.Dir, "Directory holding the FuseML database")
+	flag.Duration("reconcile-interval", defaults.Workflows.ReconcileInterval, "Interval between the reconciliations of the workflow resources (0 only reconciles them at startup)")
+	flag.Duration("extension-health-interval", defaults.Extensions.HealthInterval, "Interval between extension endpoint health checks (0 disables health checking)")
+	flag.Bool("extension-discovery", defaults.Extensions.Discovery, "Discover extensions from annotated kubernetes services, ingresses and secrets")
+	flag.String("extension-discovery-namespace", defaults.Extensions.DiscoveryNamespace, "Namespace watched for extensions (defaults to all namespaces)")
+	flag.String("smtp-server", defaults.Notifications.SMTPServer, "SMTP server (host:port) used to send email notifications")
+	flag.String("smtp-from", defaults.Notifications.SMTPFrom, "Sender address of email notifications")
+	flag.String("otlp-endpoint", defaults.Tracing.Endpoint, "OTLP gRPC endpoint (host:port) traces are exported to (traces are not exported if empty)")
+	flag.Bool("otlp-insecure", defaults.Tracing.Insecure, "Connect to the OTLP endpoint without transport security")
+	flag.Float64("trace-sample-ratio", defaults.Tracing.SampleRatio, "Fraction of the traces started by fuseml-core that are sampled")
+	flag.String("log-level", defaults.Log.Level, "Minimum level of the logged records (valid values: debug, info, warn, error)")
+	flag.String("log-format", defaults.Log.Format, "Format of the logged records (valid values: text, json)")
+	flag.Bool("leader-election", defaults.LeaderElection.Enabled, "Elect the server running the background work among the servers sharing the storage backend")
 	flag.Parse()
 
-	// Setup logger. Replace logger with your own log package of choice.
-	var (
-		logger *log.Logger
-	)
-	{
-		logger = log.New(os.Stderr, "[fuseml] ", log.Ltime)
+	// Load the server configuration: the configuration file is overridden by the
+	// environment, which is overridden by the flags that are set.
+	overrides := map[string]interface{}{}
+	flag.Visit(func(f *flag.Flag) {
+		if key, ok := configFlags[f.Name]; ok {
+			overrides[key] = f.Value.String()
+		}
+	})
+	cfg, err := config.Load(*configF, overrides)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to load configuration: ", err.Error())
+		os.Exit(1)
 	}
 
-	logger.Printf("version: %s", ver.GetInfoStr())
+	// Setup logger.
+	logger, err := logging.New(os.Stderr, &logging.Config{Level: cfg.Log.Level, Format: cfg.Log.Format})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to setup logging: ", err.Error())
+		os.Exit(1)
+	}
 
-	storeOptions := badgerhold.DefaultOptions
-	storeOptions.Dir = "./data"
-	storeOptions.ValueDir = storeOptions.Dir
+	logger.Info("Starting fuseml-core", "version", ver.GetInfoStr())
+
+	// Setup tracing before anything that may start spans.
+	shutdownTracing, err := tracing.Setup(context.Background(), &tracing.Config{
+		Endpoint:       cfg.Tracing.Endpoint,
+		Insecure:       cfg.Tracing.Insecure,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+		ServiceName:    "fuseml-core",
+		ServiceVersion: ver.GetInfo().Version,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to setup tracing: ", err.Error())
+		os.Exit(1)
+	}
 
-	coreInit, err := InitializeCore(logger, storeOptions, config.FuseMLNamespace)
+	coreInit, err := InitializeCore(logger, cfg)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Failed to initialize fuseml-core: ", err.Error())
 		os.Exit(1)
 	}
 
+	// Label the request metrics and spans collected by the HTTP and gRPC servers with the service methods,
+	// and expose the workflow metrics.
+	coreInit.endpoints.use(metrics.Endpoint)
+	coreInit.endpoints.use(tracing.Endpoint)
+	prometheus.MustRegister(coreInit.workflowManager)
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -200,15 +398,63 @@ func main() {
 		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: dev|prod)\n", *hostF)
 	}
 
+	// Start watching the workflow runs in the background, to stream their changes to the watch clients. Every
+	// server watches the workflow runs, for its own watch clients and workflow metrics.
+	if err := coreInit.workflowManager.Start(ctx, &wg); err != nil {
+		logger.Error("Failed to start watching workflow runs", "error", err)
+	}
+
+	// Start the background work that must only be done once, by the leader when several servers share the
+	// storage backend.
+	err = coreInit.elector.Start(ctx, &wg,
+		// Reconcile the workflow resources with the stored workflows and codeset assignments.
+		func(ctx context.Context, wg *sync.WaitGroup) {
+			coreInit.reconciler.Start(ctx, wg, cfg.Workflows.ReconcileInterval)
+		},
+		// Probe the registered extension endpoints.
+		func(ctx context.Context, wg *sync.WaitGroup) {
+			coreInit.healthChecker.Start(ctx, wg, cfg.Extensions.HealthInterval)
+		},
+		// Discover extensions, if enabled.
+		func(ctx context.Context, wg *sync.WaitGroup) {
+			if !cfg.Extensions.Discovery {
+				return
+			}
+			if err := coreInit.discovery.Start(ctx, wg, cfg.Extensions.DiscoveryNamespace); err != nil {
+				logger.Error("Failed to start extension discovery", "error", err)
+			}
+		},
+		// Deliver notifications for workflow run state changes.
+		func(ctx context.Context, wg *sync.WaitGroup) {
+			coreInit.notifications.Start(ctx, wg, &manager.SMTPConfig{
+				Address:  cfg.Notifications.SMTPServer,
+				From:     cfg.Notifications.SMTPFrom,
+				Username: cfg.Notifications.SMTPUsername,
+				Password: cfg.Notifications.SMTPPassword,
+			})
+		},
+	)
+	if err != nil {
+		logger.Error("Failed to start leader election", "error", err)
+	}
+
 	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
+	logger.Info("Exiting", "reason", <-errc)
 
 	// Send cancellation signal to the goroutines.
 	cancel()
 
 	// Closes the stores.
 	coreInit.store.Close()
+	if coreInit.sqlStore != nil {
+		coreInit.sqlStore.Close()
+	}
 
 	wg.Wait()
-	logger.Println("exited")
+
+	// Export the spans that have not been exported yet.
+	if err := shutdownTracing(context.Background()); err != nil {
+		logger.Error("Failed to stop tracing", "error", err)
+	}
+	logger.Info("Exited")
 }
@@ -4,19 +4,23 @@
 package main
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/google/wire"
-	"github.com/timshannon/badgerhold/v3"
 
+	"github.com/fuseml/fuseml-core/gen/admin"
 	"github.com/fuseml/fuseml-core/gen/application"
 	"github.com/fuseml/fuseml-core/gen/codeset"
 	"github.com/fuseml/fuseml-core/gen/extension"
+	"github.com/fuseml/fuseml-core/gen/notification"
 	"github.com/fuseml/fuseml-core/gen/project"
 	"github.com/fuseml/fuseml-core/gen/runnable"
+	"github.com/fuseml/fuseml-core/gen/status"
 	"github.com/fuseml/fuseml-core/gen/version"
+	"github.com/fuseml/fuseml-core/gen/watch"
 	"github.com/fuseml/fuseml-core/gen/workflow"
 	"github.com/fuseml/fuseml-core/pkg/core"
+	"github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/core/gitea"
 	"github.com/fuseml/fuseml-core/pkg/core/manager"
 	"github.com/fuseml/fuseml-core/pkg/core/store/badger"
@@ -25,29 +29,54 @@ import (
 	"github.com/fuseml/fuseml-core/pkg/svc"
 )
 
+var configSet = wire.NewSet(
+	wire.FieldsOf(new(*config.Server), "Namespace", "Storage", "Gitea", "Tekton", "Workflows", "LeaderElection"),
+)
+
 var storeSet = wire.NewSet(
-	badgerhold.Open,
-	badger.NewApplicationStore,
-	wire.Bind(new(domain.ApplicationStore), new(*badger.ApplicationStore)),
+	core.NewEventBus,
+	wire.Bind(new(domain.EventBus), new(*core.EventBus)),
+	core.NewEventWatcher,
+	wire.Bind(new(domain.Watcher), new(*core.EventWatcher)),
+	newStoreOptions,
+	badger.OpenStore,
+	newSQLStore,
+	newApplicationStore,
 	gitea.NewAdminClient,
 	wire.Bind(new(domain.GitAdminClient), new(*gitea.AdminClient)),
 	core.NewGitCodesetStore,
 	wire.Bind(new(domain.CodesetStore), new(*core.GitCodesetStore)),
+	core.NewCodesetTemplateStore,
+	wire.Bind(new(domain.CodesetTemplateStore), new(*core.CodesetTemplateStore)),
 	core.NewGitProjectStore,
 	wire.Bind(new(domain.ProjectStore), new(*core.GitProjectStore)),
-	core.NewRunnableStore,
-	wire.Bind(new(domain.RunnableStore), new(*core.RunnableStore)),
-	badger.NewWorkflowStore,
-	wire.Bind(new(domain.WorkflowStore), new(*badger.WorkflowStore)),
-	badger.NewExtensionStore,
-	wire.Bind(new(domain.ExtensionStore), new(*badger.ExtensionStore)),
+	newRunnableStore,
+	newWorkflowStore,
+	newExtensionStore,
+	newExtensionUsageStore,
+	newNotificationStore,
+	badger.NewBackupStore,
+	wire.Bind(new(domain.BackupStore), new(*badger.BackupStore)),
 )
 
 var managerSet = wire.NewSet(
+	manager.NewApplicationManager,
+	wire.Bind(new(domain.ApplicationManager), new(*manager.ApplicationManager)),
 	manager.NewWorkflowManager,
 	wire.Bind(new(domain.WorkflowManager), new(*manager.WorkflowManager)),
 	manager.NewExtensionRegistry,
 	wire.Bind(new(domain.ExtensionRegistry), new(*manager.ExtensionRegistry)),
+	manager.NewExtensionHealthChecker,
+	manager.NewExtensionDiscovery,
+	manager.NewNotificationManager,
+	wire.Bind(new(domain.NotificationManager), new(*manager.NotificationManager)),
+	newStatusManager,
+	wire.Bind(new(domain.StatusManager), new(*manager.StatusManager)),
+	manager.NewBackupManager,
+	wire.Bind(new(domain.BackupManager), new(*manager.BackupManager)),
+	manager.NewWorkflowReconciler,
+	wire.Bind(new(domain.WorkflowReconciler), new(*manager.WorkflowReconciler)),
+	newElector,
 )
 
 var backendSet = wire.NewSet(
@@ -70,10 +99,19 @@ var endpointsSet = wire.NewSet(
 	workflow.NewEndpoints,
 	svc.NewExtensionRegistryService,
 	extension.NewEndpoints,
+	svc.NewWatchService,
+	watch.NewEndpoints,
+	svc.NewNotificationService,
+	notification.NewEndpoints,
+	svc.NewStatusService,
+	status.NewEndpoints,
+	svc.NewAdminService,
+	admin.NewEndpoints,
 )
 
-func InitializeCore(logger *log.Logger, storeOptions badgerhold.Options, fuseMLNamespace string) (*coreInit, error) {
+func InitializeCore(logger *slog.Logger, cfg *config.Server) (*coreInit, error) {
 	wire.Build(
+		configSet,
 		storeSet,
 		managerSet,
 		backendSet,
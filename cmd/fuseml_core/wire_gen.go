@@ -6,14 +6,19 @@
 package main
 
 import (
+	"github.com/fuseml/fuseml-core/gen/admin"
 	"github.com/fuseml/fuseml-core/gen/application"
 	"github.com/fuseml/fuseml-core/gen/codeset"
 	"github.com/fuseml/fuseml-core/gen/extension"
+	"github.com/fuseml/fuseml-core/gen/notification"
 	"github.com/fuseml/fuseml-core/gen/project"
 	"github.com/fuseml/fuseml-core/gen/runnable"
+	"github.com/fuseml/fuseml-core/gen/status"
 	"github.com/fuseml/fuseml-core/gen/version"
+	"github.com/fuseml/fuseml-core/gen/watch"
 	"github.com/fuseml/fuseml-core/gen/workflow"
 	"github.com/fuseml/fuseml-core/pkg/core"
+	"github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/core/gitea"
 	"github.com/fuseml/fuseml-core/pkg/core/manager"
 	"github.com/fuseml/fuseml-core/pkg/core/store/badger"
@@ -21,69 +26,120 @@ import (
 	"github.com/fuseml/fuseml-core/pkg/domain"
 	"github.com/fuseml/fuseml-core/pkg/svc"
 	"github.com/google/wire"
-	"github.com/timshannon/badgerhold/v3"
-	"log"
+	"log/slog"
 )
 
 // Injectors from wire.go:
 
-func InitializeCore(logger *log.Logger, storeOptions badgerhold.Options, fuseMLNamespace string) (*coreInit, error) {
-	store, err := badgerhold.Open(storeOptions)
+func InitializeCore(logger *slog.Logger, cfg *config.Server) (*coreInit, error) {
+	storage := &cfg.Storage
+	options := newStoreOptions(storage)
+	store, err := badger.OpenStore(logger, options)
 	if err != nil {
 		return nil, err
 	}
-	applicationStore := badger.NewApplicationStore(store)
-	service := svc.NewApplicationService(logger, applicationStore)
+	sqlstoreStore, err := newSQLStore(logger, storage)
+	if err != nil {
+		return nil, err
+	}
+	applicationStore := newApplicationStore(store, sqlstoreStore)
+	workflowStore := newWorkflowStore(store, sqlstoreStore)
+	eventBus := core.NewEventBus()
+	applicationManager := manager.NewApplicationManager(logger, applicationStore, workflowStore, eventBus)
+	service := svc.NewApplicationService(logger, applicationManager)
 	applicationEndpoints := application.NewEndpoints(service)
-	adminClient, err := gitea.NewAdminClient(logger)
+	configGitea := &cfg.Gitea
+	adminClient, err := gitea.NewAdminClient(logger, configGitea)
+	if err != nil {
+		return nil, err
+	}
+	gitCodesetStore := core.NewGitCodesetStore(adminClient, eventBus)
+	codesetTemplateStore, err := core.NewCodesetTemplateStore()
 	if err != nil {
 		return nil, err
 	}
-	gitCodesetStore := core.NewGitCodesetStore(adminClient)
-	codesetService := svc.NewCodesetService(logger, gitCodesetStore)
+	runnableStore := newRunnableStore(sqlstoreStore)
+	codesetService := svc.NewCodesetService(logger, gitCodesetStore, codesetTemplateStore, runnableStore)
 	codesetEndpoints := codeset.NewEndpoints(codesetService)
-	gitProjectStore := core.NewGitProjectStore(adminClient)
-	projectService := svc.NewProjectService(logger, gitProjectStore)
+	gitProjectStore := core.NewGitProjectStore(adminClient, gitCodesetStore, eventBus)
+	string2 := cfg.Namespace
+	configTekton := &cfg.Tekton
+	workflowBackend, err := tekton.NewWorkflowBackend(logger, string2, configTekton)
+	if err != nil {
+		return nil, err
+	}
+	extensionStore := newExtensionStore(store, sqlstoreStore)
+	extensionUsageStore := newExtensionUsageStore(store, sqlstoreStore)
+	extensionRegistry := manager.NewExtensionRegistry(extensionStore, extensionUsageStore, eventBus)
+	workflows := &cfg.Workflows
+	workflowManager := manager.NewWorkflowManager(logger, workflows, workflowBackend, workflowStore, gitCodesetStore, extensionRegistry, eventBus)
+	projectService := svc.NewProjectService(logger, gitProjectStore, gitCodesetStore, workflowManager, applicationManager)
 	projectEndpoints := project.NewEndpoints(projectService)
-	runnableStore := core.NewRunnableStore()
 	runnableService := svc.NewRunnableService(logger, runnableStore)
 	runnableEndpoints := runnable.NewEndpoints(runnableService)
 	versionService := svc.NewVersionService(logger)
 	versionEndpoints := version.NewEndpoints(versionService)
-	workflowBackend, err := tekton.NewWorkflowBackend(logger, fuseMLNamespace)
-	if err != nil {
-		return nil, err
-	}
-	workflowStore := badger.NewWorkflowStore(store)
-	extensionStore := badger.NewExtensionStore(store)
-	extensionRegistry := manager.NewExtensionRegistry(extensionStore)
-	workflowManager := manager.NewWorkflowManager(workflowBackend, workflowStore, gitCodesetStore, extensionRegistry)
 	workflowService := svc.NewWorkflowService(logger, workflowManager)
 	workflowEndpoints := workflow.NewEndpoints(workflowService)
 	extensionService := svc.NewExtensionRegistryService(logger, extensionRegistry)
 	extensionEndpoints := extension.NewEndpoints(extensionService)
+	eventWatcher := core.NewEventWatcher(eventBus)
+	watchService := svc.NewWatchService(logger, eventWatcher)
+	watchEndpoints := watch.NewEndpoints(watchService)
+	notificationStore := newNotificationStore(store, sqlstoreStore)
+	notificationManager := manager.NewNotificationManager(logger, notificationStore, eventBus)
+	notificationService := svc.NewNotificationService(logger, notificationManager)
+	notificationEndpoints := notification.NewEndpoints(notificationService)
+	statusManager := newStatusManager(logger, string2, store, sqlstoreStore, adminClient, workflowBackend)
+	statusService := svc.NewStatusService(logger, statusManager)
+	statusEndpoints := status.NewEndpoints(statusService)
+	backupStore := badger.NewBackupStore(logger, store)
+	backupManager := manager.NewBackupManager(logger, workflows, backupStore, workflowStore, workflowBackend, gitCodesetStore)
+	workflowReconciler := manager.NewWorkflowReconciler(logger, workflows, workflowStore, workflowBackend, gitCodesetStore)
+	adminService := svc.NewAdminService(logger, backupManager, workflowReconciler)
+	adminEndpoints := admin.NewEndpoints(adminService)
 	mainEndpoints := &endpoints{
-		application: applicationEndpoints,
-		codeset:     codesetEndpoints,
-		project:     projectEndpoints,
-		runnable:    runnableEndpoints,
-		version:     versionEndpoints,
-		workflow:    workflowEndpoints,
-		extension:   extensionEndpoints,
+		application:  applicationEndpoints,
+		codeset:      codesetEndpoints,
+		project:      projectEndpoints,
+		runnable:     runnableEndpoints,
+		version:      versionEndpoints,
+		workflow:     workflowEndpoints,
+		extension:    extensionEndpoints,
+		watch:        watchEndpoints,
+		notification: notificationEndpoints,
+		status:       statusEndpoints,
+		admin:        adminEndpoints,
+	}
+	extensionHealthChecker := manager.NewExtensionHealthChecker(logger, extensionRegistry)
+	extensionDiscovery := manager.NewExtensionDiscovery(logger, extensionRegistry)
+	leaderElection := &cfg.LeaderElection
+	elector, err := newElector(logger, leaderElection)
+	if err != nil {
+		return nil, err
 	}
 	mainCoreInit := &coreInit{
-		endpoints: mainEndpoints,
-		store:     store,
+		endpoints:       mainEndpoints,
+		store:           store,
+		sqlStore:        sqlstoreStore,
+		healthChecker:   extensionHealthChecker,
+		discovery:       extensionDiscovery,
+		workflowManager: workflowManager,
+		notifications:   notificationManager,
+		reconciler:      workflowReconciler,
+		elector:         elector,
 	}
 	return mainCoreInit, nil
 }
 
 // wire.go:
 
-var storeSet = wire.NewSet(badgerhold.Open, badger.NewApplicationStore, wire.Bind(new(domain.ApplicationStore), new(*badger.ApplicationStore)), gitea.NewAdminClient, wire.Bind(new(domain.GitAdminClient), new(*gitea.AdminClient)), core.NewGitCodesetStore, wire.Bind(new(domain.CodesetStore), new(*core.GitCodesetStore)), core.NewGitProjectStore, wire.Bind(new(domain.ProjectStore), new(*core.GitProjectStore)), core.NewRunnableStore, wire.Bind(new(domain.RunnableStore), new(*core.RunnableStore)), badger.NewWorkflowStore, wire.Bind(new(domain.WorkflowStore), new(*badger.WorkflowStore)), badger.NewExtensionStore, wire.Bind(new(domain.ExtensionStore), new(*badger.ExtensionStore)))
+var configSet = wire.NewSet(wire.FieldsOf(new(*config.Server), "Namespace", "Storage", "Gitea", "Tekton", "Workflows", "LeaderElection"))
+
+var storeSet = wire.NewSet(core.NewEventBus, wire.Bind(new(domain.EventBus), new(*core.EventBus)), core.NewEventWatcher, wire.Bind(new(domain.Watcher), new(*core.EventWatcher)), newStoreOptions, badger.OpenStore, newSQLStore, newApplicationStore, gitea.NewAdminClient, wire.Bind(new(domain.GitAdminClient), new(*gitea.AdminClient)), core.NewGitCodesetStore, wire.Bind(new(domain.CodesetStore), new(*core.GitCodesetStore)), core.NewCodesetTemplateStore, wire.Bind(new(domain.CodesetTemplateStore), new(*core.CodesetTemplateStore)), core.NewGitProjectStore, wire.Bind(new(domain.ProjectStore), new(*core.GitProjectStore)), newRunnableStore, newWorkflowStore, newExtensionStore, newExtensionUsageStore, newNotificationStore, badger.NewBackupStore, wire.Bind(new(domain.BackupStore), new(*badger.BackupStore)))
 
-var managerSet = wire.NewSet(manager.NewWorkflowManager, wire.Bind(new(domain.WorkflowManager), new(*manager.WorkflowManager)), manager.NewExtensionRegistry, wire.Bind(new(domain.ExtensionRegistry), new(*manager.ExtensionRegistry)))
+var managerSet = wire.NewSet(manager.NewApplicationManager, wire.Bind(new(domain.ApplicationManager), new(*manager.ApplicationManager)), manager.NewWorkflowManager, wire.Bind(new(domain.WorkflowManager), new(*manager.WorkflowManager)), manager.NewExtensionRegistry, wire.Bind(new(domain.ExtensionRegistry), new(*manager.ExtensionRegistry)), manager.NewExtensionHealthChecker, manager.NewExtensionDiscovery, manager.NewNotificationManager, wire.Bind(new(domain.NotificationManager), new(*manager.NotificationManager)), newStatusManager, wire.Bind(new(domain.StatusManager), new(*manager.StatusManager)), manager.NewBackupManager, wire.Bind(new(domain.BackupManager), new(*manager.BackupManager)), manager.NewWorkflowReconciler, wire.Bind(new(domain.WorkflowReconciler), new(*manager.WorkflowReconciler)), newElector)
 
 var backendSet = wire.NewSet(tekton.NewWorkflowBackend, wire.Bind(new(domain.WorkflowBackend), new(*tekton.WorkflowBackend)))
 
-var endpointsSet = wire.NewSet(svc.NewApplicationService, application.NewEndpoints, svc.NewCodesetService, codeset.NewEndpoints, svc.NewProjectService, project.NewEndpoints, svc.NewRunnableService, runnable.NewEndpoints, svc.NewVersionService, version.NewEndpoints, svc.NewWorkflowService, workflow.NewEndpoints, svc.NewExtensionRegistryService, extension.NewEndpoints)
+var endpointsSet = wire.NewSet(svc.NewApplicationService, application.NewEndpoints, svc.NewCodesetService, codeset.NewEndpoints, svc.NewProjectService, project.NewEndpoints, svc.NewRunnableService, runnable.NewEndpoints, svc.NewVersionService, version.NewEndpoints, svc.NewWorkflowService, workflow.NewEndpoints, svc.NewExtensionRegistryService, extension.NewEndpoints, svc.NewWatchService, watch.NewEndpoints, svc.NewNotificationService, notification.NewEndpoints, svc.NewStatusService, status.NewEndpoints, svc.NewAdminService, admin.NewEndpoints)
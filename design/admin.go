@@ -0,0 +1,158 @@
+package design
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var _ = Service("admin", func() {
+	Description("The admin service backs up and restores the state of the FuseML server, and reconciles the workflow " +
+		"resources with it.")
+
+	HTTP(func() {
+		Path("/admin")
+	})
+
+	Method("backup", func() {
+		Description("Stream a consistent snapshot of the workflows, assignments, extensions, applications and notification " +
+			"targets stored by FuseML. The snapshot starts with a versioned header and can be restored with the restore method.")
+
+		HTTP(func() {
+			GET("/backup")
+			SkipResponseBodyEncodeDecode()
+			Response(StatusOK, func() {
+				ContentType("application/octet-stream")
+			})
+		})
+	})
+
+	Method("restore", func() {
+		Description("Restore a snapshot produced by the backup method, streamed in the request body, into a FuseML " +
+			"server that does not store any data yet.")
+
+		Payload(func() {
+			Field(1, "recreate", Boolean, "Re-create the Tekton resources of the restored workflows and the webhooks of their "+
+				"codeset assignments, to repopulate a rebuilt cluster", func() {
+				Default(false)
+			})
+		})
+
+		Result(RestoreResult)
+
+		Error("BadRequest", func() {
+			Description("If the snapshot is not valid, should return 400 Bad Request.")
+		})
+		Error("Conflict", func() {
+			Description("If the server already stores data, should return 409 Conflict.")
+		})
+
+		HTTP(func() {
+			POST("/restore")
+			Param("recreate")
+			SkipRequestBodyEncodeDecode()
+			Response(StatusOK)
+			Response("BadRequest", StatusBadRequest)
+			Response("Conflict", StatusConflict)
+		})
+	})
+
+	Method("reconcile", func() {
+		Description("Compare the stored workflows and codeset assignments with the Tekton pipelines and listeners and the " +
+			"codeset webhooks, re-create the missing resources and delete the orphaned ones.")
+
+		Payload(func() {
+			Field(1, "dryRun", Boolean, "Only report the resources that drifted, without fixing them", func() {
+				Default(false)
+			})
+		})
+
+		Result(ReconcileReport)
+
+		HTTP(func() {
+			POST("/reconcile")
+			Param("dryRun")
+			Response(StatusOK)
+		})
+	})
+
+	Method("getReconcileReport", func() {
+		Description("Retrieve the report of the last reconciliation run by the server, at startup, periodically or " +
+			"through the reconcile method.")
+
+		Result(ReconcileReport)
+
+		Error("NotFound", func() {
+			Description("If the server did not reconcile the workflows yet, should return 404 Not Found.")
+		})
+
+		HTTP(func() {
+			GET("/reconcile")
+			Response(StatusOK)
+			Response("NotFound", StatusNotFound)
+		})
+	})
+})
+
+// RestoreResult describes the outcome of a restore
+var RestoreResult = Type("RestoreResult", func() {
+	Field(1, "serverVersion", String, "The version of the FuseML server the snapshot was taken from", func() {
+		Example("v0.3.0")
+	})
+	Field(2, "created", String, "The time when the snapshot was taken", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:25Z")
+	})
+	Field(3, "workflows", Int, "The number of workflows restored", func() {
+		Example(3)
+	})
+	Field(4, "assignments", Int, "The number of codeset assignments restored", func() {
+		Example(2)
+	})
+	Field(5, "recreated", Boolean, "Whether the Tekton resources and the webhooks were re-created")
+	Field(6, "errors", ArrayOf(String), "The resources that could not be re-created", func() {
+		Example([]string{`workflow "mlflow-sklearn-e2e": codeset "workspace/mlflow-app-01" not found`})
+	})
+
+	Required("workflows", "assignments", "recreated")
+})
+
+// ReconcileFix describes a workflow resource fixed by a reconciliation
+var ReconcileFix = Type("ReconcileFix", func() {
+	Field(1, "action", String, "The action taken to fix the resource", func() {
+		Enum("created", "deleted", "adopted")
+		Example("created")
+	})
+	Field(2, "resource", String, "The kind of resource", func() {
+		Enum("pipeline", "listener", "webhook")
+		Example("webhook")
+	})
+	Field(3, "workflow", String, "The workflow the resource belongs to", func() {
+		Example("mlflow-sklearn-e2e")
+	})
+	Field(4, "codeset", String, "The codeset of a webhook, as project/name", func() {
+		Example("workspace/mlflow-app-01")
+	})
+	Field(5, "error", String, "Why the resource could not be fixed", func() {
+		Example("the workflow listener is not available")
+	})
+
+	Required("action", "resource", "workflow")
+})
+
+// ReconcileReport describes the outcome of a reconciliation
+var ReconcileReport = Type("ReconcileReport", func() {
+	Field(1, "started", String, "The time when the reconciliation started", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:25Z")
+	})
+	Field(2, "finished", String, "The time when the reconciliation finished", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:27Z")
+	})
+	Field(3, "dryRun", Boolean, "Whether the resources were only reported, and not fixed")
+	Field(4, "fixes", ArrayOf(ReconcileFix), "The resources that drifted")
+	Field(5, "errors", ArrayOf(String), "The problems that prevented some resources from being checked", func() {
+		Example([]string{`workflow "mlflow-sklearn-e2e": codeset workspace/mlflow-app-01: codeset not found`})
+	})
+
+	Required("started", "finished", "dryRun", "fixes")
+})
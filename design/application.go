@@ -16,9 +16,13 @@ var _ = Service("application", func() {
 			Field(2, "workflow", String, "List only Applications generated by given workflow", func() {
 				Example("mlflow-sklearn-e2e")
 			})
+			Field(3, "status", Boolean, "Probe the Applications and include their live status", func() {
+				Default(false)
+			})
+			listOptions(4, "name", "name", "type", "workflow")
 		})
 
-		Result(ArrayOf(Application), "Return all registered Applications matching the query.")
+		listResult(Application, "Return all registered Applications matching the query.")
 
 		Error("NotFound", func() {
 			Description("If the Application is not found, should return 404 Not Found.")
@@ -28,7 +32,9 @@ var _ = Service("application", func() {
 			GET("/applications")
 			Param("type")
 			Param("workflow")
-			Response(StatusOK)
+			Param("status")
+			listOptionsParams()
+			listResponse()
 			Response("NotFound", StatusNotFound)
 		})
 
@@ -36,6 +42,7 @@ var _ = Service("application", func() {
 			// Responses use a "OK" gRPC code.
 			// The result is encoded in the response message (default).
 			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
 			Response("NotFound", CodeNotFound)
 		})
 
@@ -49,6 +56,9 @@ var _ = Service("application", func() {
 		Error("BadRequest", func() {
 			Description("If the Application does not have the required fields, should return 400 Bad Request.")
 		})
+		Error("Conflict", func() {
+			Description("If an Application with the same name was registered by a different workflow, should return 409 Conflict.")
+		})
 
 		Result(Application)
 
@@ -56,10 +66,39 @@ var _ = Service("application", func() {
 			POST("/applications")
 			Response(StatusCreated)
 			Response("BadRequest", StatusBadRequest)
+			Response("Conflict", StatusConflict)
 		})
 		GRPC(func() {
 			Response(CodeOK)
 			Response("BadRequest", CodeInvalidArgument)
+			Response("Conflict", CodeAlreadyExists)
+		})
+	})
+
+	Method("update", func() {
+		Description("Update an Application registered by FuseML.")
+
+		Payload(Application)
+
+		Error("BadRequest", func() {
+			Description("If the Application does not have the required fields, should return 400 Bad Request.")
+		})
+		Error("NotFound", func() {
+			Description("If there is no application with the given name, should return 404 Not Found.")
+		})
+
+		Result(Application)
+
+		HTTP(func() {
+			PUT("/applications/{name}")
+			Response(StatusOK)
+			Response("BadRequest", StatusBadRequest)
+			Response("NotFound", StatusNotFound)
+		})
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+			Response("NotFound", CodeNotFound)
 		})
 	})
 
@@ -96,6 +135,39 @@ var _ = Service("application", func() {
 		})
 	})
 
+	Method("status", func() {
+		Description("Probe an Application registered by FuseML and retrieve its status.")
+
+		Payload(func() {
+			Field(1, "name", String, "Application name", func() {
+				Example("mlflow-seldon-predictor-01")
+			})
+			Required("name")
+		})
+
+		Error("BadRequest", func() {
+			Description("If name is not given, should return 400 Bad Request.")
+		})
+		Error("NotFound", func() {
+			Description("If there is no application with the given name, should return 404 Not Found.")
+		})
+
+		Result(ApplicationStatus)
+
+		HTTP(func() {
+			GET("/applications/{name}/status")
+			Response(StatusOK)
+			Response("BadRequest", StatusBadRequest)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
 	Method("delete", func() {
 		Description("Delete an Application registered by FuseML application store.")
 
@@ -145,6 +217,7 @@ var Application = Type("Application", func() {
 	Field(7, "k8s_namespace", String, "Kubernetes namespace where the resources are located", func() {
 		Example("fuseml-workloads")
 	})
+	Field(8, "status", ApplicationStatus, "The live status of the Application, only set when explicitly requested")
 
 	Required("name", "type", "url", "workflow", "k8s_namespace")
 })
@@ -159,3 +232,33 @@ var KubernetesResource = Type("KubernetesResource", func() {
 	})
 	Required("name", "kind")
 })
+
+// ApplicationStatus describes the result of probing an Application
+var ApplicationStatus = Type("ApplicationStatus", func() {
+	Field(1, "state", String, "The overall state of the Application", func() {
+		Enum("ready", "degraded", "missing")
+		Example("ready")
+	})
+	Field(2, "url_reachable", Boolean, "Whether the Application URL responded to requests")
+	Field(3, "message", String, "Details about the problems encountered while probing the Application", func() {
+		Example("Pod serving-pod-01 not found")
+	})
+	Field(4, "k8s_resources", ArrayOf(KubernetesResourceStatus), "The status of the Kubernetes resources describing the Application")
+	Field(5, "checked", String, "The time when the Application was probed", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:25Z")
+	})
+	Required("state", "url_reachable", "checked")
+})
+
+// KubernetesResourceStatus describes whether a Kubernetes resource exists
+var KubernetesResourceStatus = Type("KubernetesResourceStatus", func() {
+	Field(1, "name", String, "The name of the Kubernetes resource", func() {
+		Example("serving-pod-01")
+	})
+	Field(2, "kind", String, "The kind of Kubernetes resource", func() {
+		Example("Pod")
+	})
+	Field(3, "exists", Boolean, "Whether the resource exists in the Kubernetes cluster")
+	Required("name", "kind", "exists")
+})
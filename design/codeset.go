@@ -21,11 +21,12 @@ var _ = Service("codeset", func() {
 			Field(2, "label", String, "List only Codesets with matching label", func() {
 				Example("mlflow")
 			})
+			listOptions(3, "project", "project", "name")
 		})
 
 		// Result describes the method result.
-		// Here the result is a collection of codeset value.
-		Result(ArrayOf(Codeset), "Return all registered Codesets matching the query.")
+		// Here the result is a page of codeset values.
+		listResult(Codeset, "Return all registered Codesets matching the query.")
 
 		Error("NotFound", func() {
 			Description("If the Codeset is not found, should return 404 Not Found.")
@@ -42,9 +43,10 @@ var _ = Service("codeset", func() {
 			Param("label", String, "List only Codesets with matching label", func() {
 				Example("mlflow")
 			})
+			listOptionsParams()
 			// Responses use a "200 OK" HTTP status.
-			// The result is encoded in the response body (default).
-			Response(StatusOK)
+			// The codesets are encoded in the response body and the next page token in a header.
+			listResponse()
 			Response("NotFound", StatusNotFound)
 		})
 
@@ -53,6 +55,7 @@ var _ = Service("codeset", func() {
 			// Responses use a "OK" gRPC code.
 			// The result is encoded in the response message (default).
 			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
 			Response("NotFound", CodeNotFound)
 		})
 
@@ -175,6 +178,74 @@ var _ = Service("codeset", func() {
 		})
 	})
 
+	Method("listTemplates", func() {
+		Description("Retrieve the templates that can be used to scaffold new Codesets.")
+
+		Payload(func() {
+			Field(1, "runnable", String, "List only templates compatible with the codeset inputs of the given runnable", func() {
+				Example("mlflow-trainer")
+			})
+			Field(2, "type", String, "List only templates generating codesets with the given type of information", func() {
+				Example("code")
+			})
+			Field(3, "function", String, "List only templates generating codesets with the given intended function", func() {
+				Example("model-training")
+			})
+			Field(4, "format", String, "List only templates generating codesets with the given format", func() {
+				Example("MLProject")
+			})
+		})
+
+		Error("NotFound", func() {
+			Description("If the runnable is not found, should return 404 Not Found.")
+		})
+
+		Result(ArrayOf(CodesetTemplate), "Return all templates matching the query.")
+
+		HTTP(func() {
+			GET("/codesettemplates")
+			Param("runnable")
+			Param("type")
+			Param("function")
+			Param("format")
+			Response(StatusOK)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
+	Method("getTemplate", func() {
+		Description("Retrieve a Codeset template, including the contents of its files.")
+
+		Payload(func() {
+			Field(1, "name", String, "Template name", func() {
+				Example("mlflow")
+			})
+			Required("name")
+		})
+
+		Error("NotFound", func() {
+			Description("If there is no template with the given name, should return 404 Not Found.")
+		})
+
+		Result(CodesetTemplate)
+
+		HTTP(func() {
+			GET("/codesettemplates/{name}")
+			Response(StatusOK)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
 })
 
 // Codeset describes the Codeset
@@ -198,3 +269,22 @@ var Codeset = Type("Codeset", func() {
 	})
 	Required("name", "project")
 })
+
+// CodesetTemplate describes a template used to scaffold new Codesets
+var CodesetTemplate = Type("CodesetTemplate", func() {
+	Field(1, "name", String, "The name of the template", func() {
+		Example("mlflow")
+	})
+	Field(2, "description", String, "Template description", func() {
+		Example("MLflow project with a conda environment and a training entrypoint")
+		Default("")
+	})
+	Field(3, "codeset", CodesetArgumentDesc, "Attributes describing the contents of the codesets generated from this template")
+	Field(4, "files", MapOf(String, String), "Template files, indexed by their path relative to the codeset root directory", func() {
+		Example(map[string]string{
+			"MLproject":  "name: {{ .Name }}\n",
+			"conda.yaml": "name: {{ .Name }}\n",
+		})
+	})
+	Required("name", "codeset")
+})
@@ -1,6 +1,68 @@
 package design
 
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
 const (
 	identifierPattern         = `^[A-Za-z0-9_][A-Za-z0-9-_]*$`
 	optionalIdentifierPattern = `^([A-Za-z0-9_][A-Za-z0-9-_]*)*$`
 )
+
+// listOptions adds the paging, sorting and field selection fields to the payload of a list method, starting
+// at the given field tag. Results can be ordered by any of the sort fields, in ascending order or, when
+// prefixed with '-', in descending order.
+func listOptions(tag int, defaultSort string, sortFields ...string) {
+	Field(tag, "limit", Int, "Maximum number of results to return. All results are returned if not set.", func() {
+		Minimum(1)
+		Example(50)
+	})
+	Field(tag+1, "continue", String, "Token returned with the previous page of results, used to retrieve the next page", func() {
+		Example("eyJzb3J0IjoibmFtZSIsImFmdGVyIjpbInM6bWxmbG93Il19")
+	})
+	sortValues := []interface{}{}
+	for _, f := range sortFields {
+		sortValues = append(sortValues, f, "-"+f)
+	}
+	Field(tag+2, "sort", String, "Field used to order the results, prefixed with '-' for descending order. Defaults to '"+
+		defaultSort+"'.", func() {
+		Enum(sortValues...)
+		Example(sortFields[0])
+	})
+	Field(tag+3, "fields", ArrayOf(String), "Fields to return for each result, in addition to the required ones. "+
+		"All fields are returned if not set.", func() {
+		Example([]string{sortFields[0]})
+	})
+}
+
+// listOptionsParams maps the paging, sorting and field selection fields of a list method payload to query
+// string parameters
+func listOptionsParams() {
+	Param("limit")
+	Param("continue")
+	Param("sort")
+	Param("fields")
+}
+
+// listResult describes the result of a list method as a page of items of the given type
+func listResult(item interface{}, description string) {
+	Result(func() {
+		Field(1, "items", ArrayOf(item), description)
+		Field(2, "continue", String, "Token used to retrieve the next page of results. Empty for the last page.")
+		Required("items")
+	})
+
+	Error("BadRequest", func() {
+		Description("If the paging, sorting or field selection parameters are not valid, should return 400 Bad Request.")
+	})
+}
+
+// listResponse maps the result of a list method to a HTTP response that carries the items in the body and
+// the token used to retrieve the next page in the X-Continue header
+func listResponse() {
+	Response(StatusOK, func() {
+		Header("continue:X-Continue")
+		Body("items")
+	})
+	Response("BadRequest", StatusBadRequest)
+}
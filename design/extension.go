@@ -72,15 +72,17 @@ var _ = Service("extension", func() {
 
 		Payload(ExtensionQuery, "Extension query parameters")
 
-		Result(ArrayOf(Extension), "Return all registered extensions.")
+		listResult(Extension, "Return all registered extensions.")
 
 		HTTP(func() {
 			GET("/extensions")
-			Response(StatusOK)
+			listOptionsParams()
+			listResponse()
 		})
 
 		GRPC(func() {
 			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
 		})
 	})
 
@@ -655,6 +657,128 @@ var _ = Service("extension", func() {
 		})
 	})
 
+	Method("listUsage", func() {
+		Description("List the workflows and workflow steps that are bound to an extension, extension service or set of credentials")
+
+		Payload(func() {
+			Field(1, "extension_id", String, "Extension identifier", func() {
+				Pattern(identifierPattern)
+				MaxLength(100)
+				Example("kserve-001")
+			})
+			Field(2, "service_id", String, "List only the bindings to this extension service", func() {
+				Pattern(identifierPattern)
+				MaxLength(100)
+				Example("s3")
+			})
+			Field(3, "credentials_id", String, "List only the bindings to this set of credentials", func() {
+				Pattern(identifierPattern)
+				MaxLength(100)
+				Example("cred-user-12bb")
+			})
+			Field(4, "all", Boolean, "Also list the bindings that were released", func() {
+				Default(false)
+			})
+			Required("extension_id")
+		})
+
+		Result(ArrayOf(ExtensionUsage), "Return the bindings between workflows and the extension.")
+
+		HTTP(func() {
+			GET("/extensions/{extension_id}/usage")
+			Param("service_id")
+			Param("credentials_id")
+			Param("all")
+			Response(StatusOK)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+		})
+	})
+
+	Method("importExtensions", func() {
+		Description(`Import extensions, with their services, endpoints and credentials, into the registry. Elements that
+are not yet registered are added, those already registered are updated and registered elements missing from the
+imported extensions are left untouched.`)
+
+		Payload(func() {
+			Field(1, "extensions", ArrayOf(Extension), "Extensions to import")
+			Field(2, "dry_run", Boolean, "Only report the changes that the import would make, without applying them", func() {
+				Default(false)
+			})
+			Field(3, "exclude_credentials", Boolean, "Ignore the credentials of the imported extension services", func() {
+				Default(false)
+			})
+			Required("extensions")
+		})
+
+		Error("BadRequest", func() {
+			Description("If an imported extension does not have the required fields, should return 400 Bad Request.")
+		})
+
+		Result(ArrayOf(ExtensionChange), "Return the changes made to the registry.")
+
+		HTTP(func() {
+			POST("/extensions/import")
+			Body("extensions")
+			Param("dry_run")
+			Param("exclude_credentials")
+			Response(StatusOK)
+			Response("BadRequest", StatusBadRequest)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+		})
+	})
+
+	Method("exportExtensions", func() {
+		Description("Export all registered extensions, with their services, endpoints and, optionally, credentials.")
+
+		Payload(func() {
+			Field(1, "exclude_credentials", Boolean, "Leave the credentials out of the exported extensions", func() {
+				Default(false)
+			})
+		})
+
+		Result(ArrayOf(Extension), "Return all registered extensions.")
+
+		HTTP(func() {
+			GET("/extensions/export")
+			Param("exclude_credentials")
+			Response(StatusOK)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+		})
+	})
+
+	Method("listResourceSchemas", func() {
+		Description(`List the configuration schemas of the known extension service resource types. Services, endpoints
+and credentials registered for these resource types must include the configuration entries marked as required.`)
+
+		Payload(func() {
+			Field(1, "resource", String, "List only the schema of this resource type", func() {
+				Example("s3")
+			})
+		})
+
+		Result(ArrayOf(ExtensionResourceSchema), "Return the configuration schemas.")
+
+		HTTP(func() {
+			GET("/extensions/schemas")
+			Param("resource")
+			Response(StatusOK)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+		})
+	})
+
 })
 
 // Extension descriptor
@@ -855,8 +979,38 @@ this endpoint), expressed as set of key-value entries`, func() {
 
 // Extension endpoint status descriptor
 var ExtensionEndpointStatus = Type("ExtensionEndpointStatus", func() {
-	// empty for now, but can be extended to include future operational status attrs
-	// e.g. whether the endpoint is reachable or not, or number of consumers
+	tag := 1
+	Field(tag, "registered", String, "The time when the endpoint was registered", func() {
+		Format(FormatDateTime)
+		Default(time.Now().Format(time.RFC3339))
+		Example(time.Now().Format(time.RFC3339))
+	})
+	tag++
+	Field(tag, "updated", String, "The time when the endpoint was last updated", func() {
+		Format(FormatDateTime)
+		Default(time.Now().Format(time.RFC3339))
+		Example(time.Now().Format(time.RFC3339))
+	})
+	tag++
+	Field(tag, "health", String, "Whether the endpoint responded to the last health check", func() {
+		Enum("unknown", "healthy", "unhealthy")
+		Default("unknown")
+		Example("healthy")
+	})
+	tag++
+	Field(tag, "message", String, "Details about the last failed health check", func() {
+		Example("dial tcp 10.120.130.140:9000: connect: connection refused")
+	})
+	tag++
+	Field(tag, "last_checked", String, "The time when the endpoint was last checked", func() {
+		Format(FormatDateTime)
+		Example(time.Now().Format(time.RFC3339))
+	})
+	tag++
+	Field(tag, "last_seen", String, "The time when the endpoint last responded to a health check", func() {
+		Format(FormatDateTime)
+		Example(time.Now().Format(time.RFC3339))
+	})
 })
 
 // Extension credentials descriptor
@@ -996,4 +1150,111 @@ categories of AI/ML services (e.g. model store, feature store, distributed train
 			Example("serving-platform")
 		})
 	tag++
+	listOptions(tag, "id", "id", "product", "zone", "created", "updated")
+})
+
+// Extension usage record
+var ExtensionUsage = Type("ExtensionUsage", func() {
+	tag := 1
+	Field(tag, "workflow", String, "Name of the workflow", func() {
+		Example("mlflow-sklearn-e2e")
+	})
+	tag++
+	Field(tag, "step", String, "Name of the workflow step", func() {
+		Example("trainer")
+	})
+	tag++
+	Field(tag, "requirement", String, "Name of the step extension requirement", func() {
+		Example("mlflow-tracking")
+	})
+	tag++
+	Field(tag, "extension_id", String, "Extension bound to the workflow step", func() {
+		Example("mlflow-0001")
+	})
+	tag++
+	Field(tag, "service_id", String, "Extension service bound to the workflow step", func() {
+		Example("mlflow-tracking")
+	})
+	tag++
+	Field(tag, "endpoint_url", String, "Extension endpoint bound to the workflow step", func() {
+		Example("http://mlflow")
+	})
+	tag++
+	Field(tag, "credentials_id", String, "Set of credentials bound to the workflow step", func() {
+		Example("default")
+	})
+	tag++
+	Field(tag, "bound", String, "The time when the workflow step was bound to the extension", func() {
+		Format(FormatDateTime)
+		Example(time.Now().Format(time.RFC3339))
+	})
+	tag++
+	Field(tag, "released", String, "The time when the binding was released", func() {
+		Format(FormatDateTime)
+		Example(time.Now().Format(time.RFC3339))
+	})
+	Required("workflow", "step", "requirement", "extension_id", "service_id", "endpoint_url", "bound")
+})
+
+// ExtensionChange describes a change made to the extension registry when importing extensions
+var ExtensionChange = Type("ExtensionChange", func() {
+	tag := 1
+	Field(tag, "kind", String, "Kind of registry element that is changed", func() {
+		Enum("extension", "service", "endpoint", "credentials")
+		Example("service")
+	})
+	tag++
+	Field(tag, "element", String, "Path of the changed element", func() {
+		Example("mlflow-0001/mlflow-tracking")
+	})
+	tag++
+	Field(tag, "action", String, "Action performed on the element", func() {
+		Enum("create", "update")
+		Example("update")
+	})
+	tag++
+	Field(tag, "fields", ArrayOf(String), "Names of the attributes changed for updated elements", func() {
+		Example([]string{"description", "configuration"})
+	})
+	Required("kind", "element", "action")
+})
+
+// ExtensionConfigurationKey describes a configuration entry declared by a resource schema
+var ExtensionConfigurationKey = Type("ExtensionConfigurationKey", func() {
+	tag := 1
+	Field(tag, "name", String, "Configuration entry key", func() {
+		Example("AWS_ACCESS_KEY_ID")
+	})
+	tag++
+	Field(tag, "description", String, "Description of the configuration entry", func() {
+		Example("Access key ID")
+	})
+	tag++
+	Field(tag, "required", Boolean, "Whether the configuration entry must be present", func() {
+		Default(false)
+	})
+	Required("name")
+})
+
+// ExtensionResourceSchema declares the configuration entries expected for a known service resource type
+var ExtensionResourceSchema = Type("ExtensionResourceSchema", func() {
+	tag := 1
+	Field(tag, "resource", String, "Service resource type", func() {
+		Example("s3")
+	})
+	tag++
+	Field(tag, "description", String, "Description of the resource type", func() {
+		Example("S3 compatible object storage")
+	})
+	tag++
+	Field(tag, "category", String, "Category that services of this resource type usually belong to", func() {
+		Example("object-storage")
+	})
+	tag++
+	Field(tag, "service_configuration", ArrayOf(ExtensionConfigurationKey), "Configuration entries for services")
+	tag++
+	Field(tag, "endpoint_configuration", ArrayOf(ExtensionConfigurationKey), "Configuration entries for service endpoints")
+	tag++
+	Field(tag, "credentials_configuration", ArrayOf(ExtensionConfigurationKey), "Configuration entries for service credentials")
+	Required("resource")
 })
@@ -0,0 +1,234 @@
+package design
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var _ = Service("notification", func() {
+	Description("The notification service manages the targets notified when workflow runs change state.")
+
+	Method("list", func() {
+		Description("Retrieve information about the notification targets registered in FuseML.")
+
+		Payload(func() {
+			Field(1, "workflow", String, "List only the notification targets scoped to the given workflow", func() {
+				Example("mlflow-sklearn-e2e")
+			})
+			Field(2, "project", String, "List only the notification targets scoped to the given project", func() {
+				Example("mlflow-project-01")
+			})
+			listOptions(3, "name", "name", "type", "created")
+		})
+
+		listResult(NotificationTarget, "Return all registered notification targets matching the query.")
+
+		HTTP(func() {
+			GET("/notifications")
+			Param("workflow")
+			Param("project")
+			listOptionsParams()
+			listResponse()
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+		})
+	})
+
+	Method("register", func() {
+		Description("Register a notification target with FuseML.")
+
+		Payload(NotificationTarget)
+
+		Error("BadRequest", func() {
+			Description("If the notification target is not valid, should return 400 Bad Request.")
+		})
+		Error("Conflict", func() {
+			Description("If a notification target with the same name already exists, should return 409 Conflict.")
+		})
+
+		Result(NotificationTarget)
+
+		HTTP(func() {
+			POST("/notifications")
+			Response(StatusCreated)
+			Response("BadRequest", StatusBadRequest)
+			Response("Conflict", StatusConflict)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+			Response("Conflict", CodeAlreadyExists)
+		})
+	})
+
+	Method("get", func() {
+		Description("Retrieve a notification target registered with FuseML.")
+
+		Payload(func() {
+			Field(1, "name", String, "Notification target name", func() {
+				Example("nightly-failures")
+			})
+			Required("name")
+		})
+
+		Error("NotFound", func() {
+			Description("If there is no notification target with the given name, should return 404 Not Found.")
+		})
+
+		Result(NotificationTarget)
+
+		HTTP(func() {
+			GET("/notifications/{name}")
+			Response(StatusOK)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
+	Method("delete", func() {
+		Description("Delete a notification target and its delivery log.")
+
+		Payload(func() {
+			Field(1, "name", String, "Notification target name", func() {
+				Example("nightly-failures")
+			})
+			Required("name")
+		})
+
+		Error("NotFound", func() {
+			Description("If there is no notification target with the given name, should return 404 Not Found.")
+		})
+
+		HTTP(func() {
+			DELETE("/notifications/{name}")
+			Response(StatusNoContent)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
+	Method("deliveries", func() {
+		Description("Retrieve the log of notifications delivered to a notification target.")
+
+		Payload(func() {
+			Field(1, "name", String, "Notification target name", func() {
+				Example("nightly-failures")
+			})
+			listOptions(2, "-created", "created", "status")
+			Required("name")
+		})
+
+		listResult(NotificationDelivery, "Return the notifications delivered to the target, most recent first.")
+
+		Error("NotFound", func() {
+			Description("If there is no notification target with the given name, should return 404 Not Found.")
+		})
+
+		HTTP(func() {
+			GET("/notifications/{name}/deliveries")
+			listOptionsParams()
+			listResponse()
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+})
+
+// NotificationTarget describes a destination for workflow run notifications
+var NotificationTarget = Type("NotificationTarget", func() {
+	Field(1, "name", String, "The name of the notification target", func() {
+		Pattern(identifierPattern)
+		Example("nightly-failures")
+	})
+	Field(2, "description", String, "Notification target description", func() {
+		Example("Notify the team when the nightly retrain fails")
+	})
+	Field(3, "type", String, "How notifications are delivered to the target", func() {
+		Enum("webhook", "slack", "email")
+		Example("slack")
+	})
+	Field(4, "workflow", String, "Only notify about the runs of this workflow", func() {
+		Example("mlflow-sklearn-e2e")
+	})
+	Field(5, "project", String, "Only notify about the runs triggered by the codesets of this project", func() {
+		Example("mlflow-project-01")
+	})
+	Field(6, "statuses", ArrayOf(String), "Only notify about the runs that reach one of these statuses. "+
+		"All status changes are notified if not set.", func() {
+		Example([]string{"Failed"})
+	})
+	Field(7, "url", String, "URL where webhook and slack notifications are POSTed", func() {
+		Example("https://hooks.slack.com/services/T0000/B0000/XXXX")
+	})
+	Field(8, "secret", String, "Secret used to sign webhook notifications with HMAC-SHA256. It is never returned.", func() {
+		Example("s3cr3t")
+	})
+	Field(9, "recipients", ArrayOf(String), "Recipients of email notifications", func() {
+		Example([]string{"ml-team@example.org"})
+	})
+	Field(10, "template", String, "Template used to format the notification message, using the Go text/template "+
+		"syntax. The available fields are Target, Workflow, Run, Status, Codeset, Project, URL, StartTime and CompletionTime.", func() {
+		Example("Workflow {{.Workflow}} run {{.Run}} {{.Status}}: {{.URL}}")
+	})
+	Field(11, "created", String, "The time when the notification target was registered", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:25Z")
+	})
+
+	Required("name", "type")
+})
+
+// NotificationDelivery describes a notification delivered to a target
+var NotificationDelivery = Type("NotificationDelivery", func() {
+	Field(1, "id", UInt64, "Delivery ID, also sent with webhook notifications", func() {
+		Example(42)
+	})
+	Field(2, "target", String, "The name of the notification target", func() {
+		Example("nightly-failures")
+	})
+	Field(3, "workflow", String, "The workflow the run belongs to", func() {
+		Example("mlflow-sklearn-e2e")
+	})
+	Field(4, "run", String, "The workflow run that changed state", func() {
+		Example("fuseml-mlflow-project-01-mlflow-app-01-kxfn8")
+	})
+	Field(5, "runStatus", String, "The status reached by the workflow run", func() {
+		Example("Failed")
+	})
+	Field(6, "status", String, "The outcome of the delivery", func() {
+		Enum("pending", "succeeded", "failed")
+		Example("succeeded")
+	})
+	Field(7, "attempts", Int, "The number of delivery attempts", func() {
+		Example(1)
+	})
+	Field(8, "error", String, "The error encountered by the last failed attempt", func() {
+		Example("notification target responded with \"503 Service Unavailable\"")
+	})
+	Field(9, "created", String, "The time when the notification was generated", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:25Z")
+	})
+	Field(10, "updated", String, "The time of the last delivery attempt", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:26Z")
+	})
+
+	Required("id", "target", "workflow", "run", "runStatus", "status", "attempts", "created", "updated")
+})
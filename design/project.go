@@ -10,15 +10,21 @@ var _ = Service("project", func() {
 	Method("list", func() {
 		Description("Retrieve information about FuseML Projects.")
 
-		Result(ArrayOf(Project), "Return all Projects.")
+		Payload(func() {
+			listOptions(1, "name", "name")
+		})
+
+		listResult(Project, "Return all Projects.")
 
 		HTTP(func() {
 			GET("/projects")
-			Response(StatusOK)
+			listOptionsParams()
+			listResponse()
 		})
 
 		GRPC(func() {
 			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
 		})
 
 	})
@@ -96,27 +102,205 @@ var _ = Service("project", func() {
 	})
 
 	Method("delete", func() {
-		Description("Delete a FuseML Project.")
+		Description("Delete a FuseML Project. A Project with codesets is only deleted when cascade is set.")
 
 		Payload(func() {
 			Field(1, "name", String, "Project name", func() {
 				Example("mlflow-project-01")
 			})
+			Field(2, "cascade", Boolean, "Also delete the codesets of the Project, including their git repositories", func() {
+				Default(false)
+			})
 			Required("name")
 		})
 
 		Error("BadRequest", func() {
 			Description("If name is not given, should return 400 Bad Request.")
 		})
+		Error("NotFound", func() {
+			Description("If there is no project with the given name, should return 404 Not Found.")
+		})
+		Error("Conflict", func() {
+			Description("If the project still has codesets and cascade is not set, should return 409 Conflict.")
+		})
 
 		HTTP(func() {
 			DELETE("/projects/{name}")
+			Param("cascade")
 			Response(StatusNoContent)
 			Response("BadRequest", StatusBadRequest)
+			Response("NotFound", StatusNotFound)
+			Response("Conflict", StatusConflict)
+		})
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+			Response("NotFound", CodeNotFound)
+			Response("Conflict", CodeFailedPrecondition)
+		})
+	})
+
+	Method("summary", func() {
+		Description("Retrieve a summary of the resources that belong to a FuseML Project. These are also the resources removed when the Project is deleted.")
+
+		Payload(func() {
+			Field(1, "name", String, "Project name", func() {
+				Example("mlflow-project-01")
+			})
+			Required("name")
+		})
+
+		Error("NotFound", func() {
+			Description("If there is no project with the given name, should return 404 Not Found.")
+		})
+
+		Result(ProjectSummary)
+
+		HTTP(func() {
+			GET("/projects/{name}/summary")
+			Response(StatusOK)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
+	Method("listMembers", func() {
+		Description("Retrieve the members of a FuseML Project.")
+
+		Payload(func() {
+			Field(1, "name", String, "Project name", func() {
+				Example("mlflow-project-01")
+			})
+			Required("name")
+		})
+
+		Error("NotFound", func() {
+			Description("If there is no project with the given name, should return 404 Not Found.")
+		})
+
+		Result(ArrayOf(User), "Return all Project members.")
+
+		HTTP(func() {
+			GET("/projects/{name}/members")
+			Response(StatusOK)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
+	Method("addMember", func() {
+		Description("Add a user to a FuseML Project. A dedicated git account is created for the user if it does not exist.")
+
+		Payload(func() {
+			Field(1, "name", String, "Project name", func() {
+				Example("mlflow-project-01")
+			})
+			Field(2, "user", String, "The name of the user", func() {
+				Example("alice")
+				Pattern(`^[A-Za-z0-9_][A-Za-z0-9-_.]*$`)
+			})
+			Field(3, "email", String, "The email of the user", func() {
+				Example("alice@fuseml.org")
+				Format(FormatEmail)
+			})
+			Required("name", "user")
+		})
+
+		Error("BadRequest", func() {
+			Description("If the member does not have the required fields, should return 400 Bad Request.")
+		})
+		Error("NotFound", func() {
+			Description("If there is no project with the given name, should return 404 Not Found.")
+		})
+		Error("Conflict", func() {
+			Description("If the user is already a member of the project, should return 409 Conflict.")
+		})
+
+		Result(UserCredentials)
+
+		HTTP(func() {
+			POST("/projects/{name}/members")
+			Param("user")
+			Param("email")
+			Response(StatusCreated)
+			Response("BadRequest", StatusBadRequest)
+			Response("NotFound", StatusNotFound)
+			Response("Conflict", StatusConflict)
 		})
+
 		GRPC(func() {
 			Response(CodeOK)
 			Response("BadRequest", CodeInvalidArgument)
+			Response("NotFound", CodeNotFound)
+			Response("Conflict", CodeAlreadyExists)
+		})
+	})
+
+	Method("removeMember", func() {
+		Description("Remove a user from a FuseML Project.")
+
+		Payload(func() {
+			Field(1, "name", String, "Project name", func() {
+				Example("mlflow-project-01")
+			})
+			Field(2, "user", String, "The name of the user", func() {
+				Example("alice")
+			})
+			Required("name", "user")
+		})
+
+		Error("NotFound", func() {
+			Description("If the project does not exist or the user is not a member, should return 404 Not Found.")
+		})
+
+		HTTP(func() {
+			DELETE("/projects/{name}/members/{user}")
+			Response(StatusNoContent)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
+	Method("rotateCredentials", func() {
+		Description("Replace the git credentials of a Project member with newly generated ones.")
+
+		Payload(func() {
+			Field(1, "name", String, "Project name", func() {
+				Example("mlflow-project-01")
+			})
+			Field(2, "user", String, "The name of the user", func() {
+				Example("fuseml-mlflow-project-01")
+			})
+			Required("name", "user")
+		})
+
+		Error("NotFound", func() {
+			Description("If the project does not exist or the user is not a member, should return 404 Not Found.")
+		})
+
+		Result(UserCredentials)
+
+		HTTP(func() {
+			POST("/projects/{name}/members/{user}/credentials")
+			Response(StatusOK)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("NotFound", CodeNotFound)
 		})
 	})
 })
@@ -145,3 +329,51 @@ var User = Type("User", func() {
 	})
 	Required("name", "email")
 })
+
+// UserCredentials describes the git credentials issued to a project member
+var UserCredentials = Type("UserCredentials", func() {
+	Field(1, "username", String, "User name used to access the Project repositories", func() {
+		Example("alice")
+	})
+	Field(2, "password", String, "Password used to access the Project repositories, only returned when new credentials are issued", func() {
+		Example("Qm9zY2hpU2VjcmV0")
+	})
+	Required("username")
+})
+
+// ProjectSummary describes the resources that belong to a project
+var ProjectSummary = Type("ProjectSummary", func() {
+	Field(1, "project", Project, "The Project")
+	Field(2, "codesets", ArrayOf(ProjectCodesetSummary), "Codesets that belong to the Project")
+	Field(3, "applications", ArrayOf(Application), "Applications created by workflows assigned only to the Project codesets")
+	Required("project")
+})
+
+// ProjectCodesetSummary describes a codeset and the workflow resources associated with it
+var ProjectCodesetSummary = Type("ProjectCodesetSummary", func() {
+	Field(1, "name", String, "The name of the Codeset", func() {
+		Example("mlflow-app-01")
+	})
+	Field(2, "url", String, "Full URL to the Codeset", func() {
+		Example("http://my-gitea.server/project/repository.git")
+	})
+	Field(3, "workflows", ArrayOf(String), "Workflows assigned to the Codeset", func() {
+		Example([]string{"mlflow-sklearn-e2e"})
+	})
+	Field(4, "runs", ArrayOf(ProjectWorkflowRun), "Most recent workflow runs for the Codeset")
+	Required("name")
+})
+
+// ProjectWorkflowRun describes a workflow run in a project summary
+var ProjectWorkflowRun = Type("ProjectWorkflowRun", func() {
+	Field(1, "name", String, "Name of the run")
+	Field(2, "workflow", String, "The Workflow that was run")
+	Field(3, "startTime", String, "The time when the workflow run started", func() {
+		Format(FormatDateTime)
+		Example("2021-04-09T06:17:25Z")
+	})
+	Field(4, "status", String, "The current status of the workflow run", func() {
+		Example("Succeeded")
+	})
+	Required("name", "workflow", "startTime", "status")
+})
@@ -32,11 +32,12 @@ var _ = Service("runnable", func() {
 						"function": "predict|train",
 					})
 				})
+			listOptions(4, "id", "id", "kind", "created")
 			Required()
 		})
 
-		// Result is a collection of runnables
-		Result(ArrayOf(Runnable), "Return all registered runnables matching the query.")
+		// Result is a page of runnables
+		listResult(Runnable, "Return all registered runnables matching the query.")
 
 		Error("NotFound", func() {
 			Description("If the runnable is not found, should return 404 Not Found.")
@@ -50,9 +51,10 @@ var _ = Service("runnable", func() {
 			Param("id")
 			Param("kind")
 			Param("labels")
+			listOptionsParams()
 			// Responses use a "200 OK" HTTP status.
-			// The result is encoded in the response body (default).
-			Response(StatusOK)
+			// The runnables are encoded in the response body and the next page token in a header.
+			listResponse()
 			Response("NotFound", StatusNotFound)
 		})
 
@@ -61,6 +63,7 @@ var _ = Service("runnable", func() {
 			// Responses use a "OK" gRPC code.
 			// The result is encoded in the response message (default).
 			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
 			Response("NotFound", CodeNotFound)
 		})
 
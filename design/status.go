@@ -0,0 +1,90 @@
+package design
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var _ = Service("status", func() {
+	Description("The status service reports the health of the FuseML server and of the services it depends on.")
+
+	Method("get", func() {
+		Description("Retrieve the server configuration and the connectivity and version of its dependencies.")
+
+		Result(ServerStatus)
+
+		HTTP(func() {
+			GET("/status")
+			Response(StatusOK)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+		})
+	})
+
+	Method("live", func() {
+		Description("Liveness probe: succeeds as long as the server is able to handle requests.")
+
+		HTTP(func() {
+			GET("/healthz")
+			Response(StatusOK)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+		})
+	})
+
+	Method("ready", func() {
+		Description("Readiness probe: succeeds if all the services the server depends on are available.")
+
+		Error("Unavailable", func() {
+			Description("If any of the services the server depends on is not available, should return 503 Service Unavailable.")
+		})
+
+		HTTP(func() {
+			GET("/readyz")
+			Response(StatusOK)
+			Response("Unavailable", StatusServiceUnavailable)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("Unavailable", CodeUnavailable)
+		})
+	})
+})
+
+// ServerStatus describes the configuration of the server and the status of its dependencies
+var ServerStatus = Type("ServerStatus", func() {
+	Field(1, "version", VersionInfo, "The server version information")
+	Field(2, "namespace", String, "The kubernetes namespace where FuseML creates its resources", func() {
+		Example("fuseml-workloads")
+	})
+	Field(3, "dashboardUrl", String, "The URL of the Tekton dashboard used to follow the workflow runs", func() {
+		Example("http://tekton.172.18.0.2.nip.io")
+	})
+	Field(4, "ready", Boolean, "Whether all the services the server depends on are available")
+	Field(5, "dependencies", ArrayOf(DependencyStatus), "The status of the services the server depends on")
+
+	Required("version", "namespace", "ready", "dependencies")
+})
+
+// DependencyStatus describes the connectivity to a service the server depends on
+var DependencyStatus = Type("DependencyStatus", func() {
+	Field(1, "name", String, "The name of the dependency", func() {
+		Example("gitea")
+	})
+	Field(2, "endpoint", String, "The address used to reach the dependency", func() {
+		Example("http://gitea.172.18.0.2.nip.io")
+	})
+	Field(3, "version", String, "The version reported by the dependency", func() {
+		Example("1.14.2")
+	})
+	Field(4, "available", Boolean, "Whether the dependency can be used by the server")
+	Field(5, "error", String, "Why the dependency cannot be used", func() {
+		Example("dial tcp: connection refused")
+	})
+
+	Required("name", "available")
+})
@@ -0,0 +1,76 @@
+package design
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var _ = Service("watch", func() {
+	Description("The watch service streams change notifications for FuseML resources.")
+
+	Method("watch", func() {
+		Description("Watch workflow runs, workflow assignments, codesets and extensions for changes. Every event carries " +
+			"a resource version. Clients that reconnect can pass the resource version of the last event they received " +
+			"to resume watching from where they left off. A bookmark event, which only carries the current resource " +
+			"version, is sent when the watch starts and periodically while there are no changes. When several " +
+			"fuseml-core replicas serve the API, the assignment, codeset and extension changes are only streamed " +
+			"by the replica that made them, and resource versions can only be resumed from on the replica that " +
+			"issued them: resuming on another one returns Gone.")
+
+		Payload(func() {
+			Field(1, "kinds", ArrayOf(String, func() {
+				Enum("workflowrun", "assignment", "codeset", "extension")
+			}), "Kinds of resources to watch. All kinds are watched if not set.", func() {
+				Example([]string{"workflowrun", "assignment"})
+			})
+			Field(2, "resourceVersion", String, "Resource version of the last event received. Only the events that followed it are streamed.", func() {
+				Example("kq3mtr0d4u8o.42")
+			})
+		})
+
+		StreamingResult(WatchEvent)
+
+		Error("BadRequest", func() {
+			Description("If the resource kinds or the resource version are not valid, should return 400 Bad Request.")
+		})
+		Error("Gone", func() {
+			Description("If the events that followed the resource version are no longer available, should return 410 Gone. " +
+				"Clients should list the resources again and start a new watch.")
+		})
+
+		HTTP(func() {
+			GET("/watch")
+			Param("kinds")
+			Param("resourceVersion")
+			Response(StatusOK)
+			Response("BadRequest", StatusBadRequest)
+			Response("Gone", StatusGone)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+			Response("Gone", CodeOutOfRange)
+		})
+	})
+})
+
+// WatchEvent describes a change made to a FuseML resource
+var WatchEvent = Type("WatchEvent", func() {
+	Field(1, "type", String, "The change made to the resource", func() {
+		Enum("added", "modified", "deleted", "bookmark")
+		Example("modified")
+	})
+	Field(2, "kind", String, "The kind of resource that changed. Not set for bookmarks.", func() {
+		Enum("workflowrun", "assignment", "codeset", "extension")
+		Example("workflowrun")
+	})
+	Field(3, "resourceVersion", String, "Position of the event in the stream of changes, used to resume watching", func() {
+		Example("kq3mtr0d4u8o.42")
+	})
+	Field(4, "workflowRun", WorkflowRun, "The workflow run that changed, for workflowrun events")
+	Field(5, "assignment", WorkflowAssignment, "The workflow assignment that changed, for assignment events")
+	Field(6, "codeset", Codeset, "The codeset that changed, for codeset events")
+	Field(7, "extension", Extension, "The extension that changed, for extension events")
+
+	Required("type", "resourceVersion")
+})
@@ -13,20 +13,23 @@ var _ = Service("workflow", func() {
 			Field(1, "name", String, "List workflows with the specified name", func() {
 				Example("workflowA")
 			})
+			listOptions(2, "name", "name", "created")
 		})
 
-		Result(ArrayOf(Workflow), "Return all workflows matching the query.")
+		listResult(Workflow, "Return all workflows matching the query.")
 
 		HTTP(func() {
 			GET("/workflows")
 			Param("name", String, "List workflows with the specified name", func() {
 				Example("workflowA")
 			})
-			Response(StatusOK)
+			listOptionsParams()
+			listResponse()
 		})
 
 		GRPC(func() {
 			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
 		})
 
 	})
@@ -89,6 +92,39 @@ var _ = Service("workflow", func() {
 		})
 	})
 
+	Method("refresh", func() {
+		Description("Resolve the extension references of a Workflow again and update it with the current extension endpoints and credentials.")
+
+		Payload(func() {
+			Field(1, "name", String, "Workflow name", func() {
+				Example("mlflow-sklearn-e2e")
+			})
+			Required("name")
+		})
+
+		Error("BadRequest", func() {
+			Description("If name is not given or the extension references cannot be resolved, should return 400 Bad Request.")
+		})
+		Error("NotFound", func() {
+			Description("If there is no workflow with the given name, should return 404 Not Found.")
+		})
+
+		Result(Workflow)
+
+		HTTP(func() {
+			POST("/workflows/{name}/refresh")
+			Response(StatusOK)
+			Response("BadRequest", StatusBadRequest)
+			Response("NotFound", StatusNotFound)
+		})
+
+		GRPC(func() {
+			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
+			Response("NotFound", CodeNotFound)
+		})
+	})
+
 	Method("delete", func() {
 		Description("Delete a Workflow and its assignments.")
 
@@ -234,13 +270,14 @@ var _ = Service("workflow", func() {
 				Example("Succeeded")
 
 			})
+			listOptions(5, "-started", "started", "completed", "name", "workflow", "status")
 		})
 
 		Error("NotFound", func() {
 			Description("If there is no workflow with the given name, should return 404 Not Found.")
 		})
 
-		Result(ArrayOf(WorkflowRun), "Return all runs of a workflow.")
+		listResult(WorkflowRun, "Return all runs of a workflow.")
 
 		HTTP(func() {
 			GET("/workflows/runs")
@@ -248,12 +285,14 @@ var _ = Service("workflow", func() {
 			Param("codesetProject")
 			Param("codesetName")
 			Param("status")
-			Response(StatusOK)
+			listOptionsParams()
+			listResponse()
 			Response("NotFound", StatusNotFound)
 		})
 
 		GRPC(func() {
 			Response(CodeOK)
+			Response("BadRequest", CodeInvalidArgument)
 			Response("NotFound", CodeNotFound)
 		})
 
@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	adminsvc "github.com/fuseml/fuseml-core/gen/admin"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// backupOptions holds the options for 'admin backup' sub command
+type backupOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	Output string
+}
+
+func newBackupOptions(o *common.GlobalOptions) *backupOptions {
+	return &backupOptions{global: o}
+}
+
+// newSubCmdAdminBackup creates and returns the cobra command for the `admin backup` CLI command
+func newSubCmdAdminBackup(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newBackupOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   `backup {-o|--output FILE}`,
+		Short: "Back up the FuseML server state.",
+		Long: `Save a snapshot of the workflows, assignments, extensions, applications and notification targets stored by the
+FuseML server to a file, or to the standard output if FILE is '-'. Increase the timeout for large snapshots.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "file where the snapshot is saved, '-' for the standard output")
+	cmd.MarkFlagRequired("output")
+	return cmd
+}
+
+func (o *backupOptions) run() (err error) {
+	response, err := o.AdminClient.Backup()(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("could not back up the server state: %w", err)
+	}
+	body := response.(*adminsvc.BackupResponseData).Body
+	defer body.Close()
+
+	if o.Output == "-" {
+		_, err = io.Copy(os.Stdout, body)
+		return err
+	}
+
+	f, err := os.OpenFile(o.Output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		// do not leave an incomplete snapshot behind
+		if err != nil {
+			os.Remove(o.Output)
+		}
+	}()
+
+	size, err := io.Copy(f, body)
+	if err != nil {
+		return fmt.Errorf("could not save the server state: %w", err)
+	}
+
+	fmt.Printf("FuseML server state saved to %s (%d bytes)\n", o.Output, size)
+	return nil
+}
@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdAdmin creates and returns the cobra command that acts as a root for all other admin CLI sub-commands
+func NewCmdAdmin(c *common.GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Server administration",
+		Long:  `Back up and restore the state of the FuseML server, and reconcile the workflow resources with it`,
+	}
+
+	cmd.AddCommand(newSubCmdAdminBackup(c))
+	cmd.AddCommand(newSubCmdAdminRestore(c))
+	cmd.AddCommand(newSubCmdAdminReconcile(c))
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	adminsvc "github.com/fuseml/fuseml-core/gen/admin"
+	adminc "github.com/fuseml/fuseml-core/gen/http/admin/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+// reconcileOptions holds the options for 'admin reconcile' sub command
+type reconcileOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	format *common.FormattingOptions
+	DryRun bool
+	Last   bool
+}
+
+func newReconcileOptions(o *common.GlobalOptions) *reconcileOptions {
+	res := &reconcileOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"Action", "Resource", "Workflow", "Codeset", "Error"},
+		nil,
+		common.OutputFormatters{},
+	)
+	return res
+}
+
+// newSubCmdAdminReconcile creates and returns the cobra command for the `admin reconcile` CLI command
+func newSubCmdAdminReconcile(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newReconcileOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   `reconcile [--dry-run|--last]`,
+		Short: "Reconcile the workflow resources.",
+		Long: `Compare the stored workflows and codeset assignments with the Tekton pipelines and listeners and the codeset
+webhooks, re-create the missing resources and delete the orphaned ones. Use --dry-run to only report the resources that
+drifted, or --last to display the report of the last reconciliation run by the server.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "only report the resources that drifted")
+	cmd.Flags().BoolVar(&o.Last, "last", false, "display the report of the last reconciliation")
+	o.format.AddMultiValueFormattingFlags(cmd)
+	return cmd
+}
+
+func (o *reconcileOptions) run() error {
+	if o.DryRun && o.Last {
+		return fmt.Errorf("--dry-run and --last cannot be used together")
+	}
+
+	var response interface{}
+	var err error
+	if o.Last {
+		response, err = o.AdminClient.GetReconcileReport()(context.Background(), nil)
+	} else {
+		var payload *adminsvc.ReconcilePayload
+		payload, err = adminc.BuildReconcilePayload(o.DryRun)
+		if err != nil {
+			return err
+		}
+		response, err = o.AdminClient.Reconcile()(context.Background(), payload)
+	}
+	if err != nil {
+		return fmt.Errorf("could not reconcile the workflow resources: %w", err)
+	}
+	res := response.(*adminsvc.ReconcileReport)
+
+	switch o.format.Format {
+	case common.FormatTable, common.FormatCSV:
+		fmt.Printf("Started:  %s\n", res.Started)
+		fmt.Printf("Finished: %s\n", res.Finished)
+		fmt.Printf("Dry run:  %t\n\n", res.DryRun)
+		if len(res.Fixes) == 0 {
+			fmt.Println("No workflow resources drifted")
+		} else {
+			o.format.FormatValue(os.Stdout, res.Fixes)
+		}
+	default:
+		o.format.FormatValue(os.Stdout, res)
+	}
+
+	if len(res.Errors) > 0 {
+		for _, e := range res.Errors {
+			fmt.Fprintf(os.Stderr, "  %s\n", e)
+		}
+		return fmt.Errorf("%d workflow resources could not be checked", len(res.Errors))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	adminsvc "github.com/fuseml/fuseml-core/gen/admin"
+	adminc "github.com/fuseml/fuseml-core/gen/http/admin/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// restoreOptions holds the options for 'admin restore' sub command
+type restoreOptions struct {
+	client.Clients
+	global   *common.GlobalOptions
+	File     string
+	Recreate bool
+}
+
+func newRestoreOptions(o *common.GlobalOptions) *restoreOptions {
+	return &restoreOptions{global: o}
+}
+
+// newSubCmdAdminRestore creates and returns the cobra command for the `admin restore` CLI command
+func newSubCmdAdminRestore(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newRestoreOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   `restore {-f|--file FILE} [--recreate]`,
+		Short: "Restore the FuseML server state.",
+		Long: `Restore a snapshot saved with 'admin backup' into a FuseML server that does not store any data yet.
+Use --recreate to also re-create the Tekton pipelines and listeners of the restored workflows and the webhooks of
+their codeset assignments, when the snapshot is restored into a rebuilt cluster.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "file holding the snapshot")
+	cmd.Flags().BoolVar(&o.Recreate, "recreate", false, "re-create the Tekton resources and the codeset webhooks")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func (o *restoreOptions) run() error {
+	f, err := os.Open(o.File)
+	if err != nil {
+		return err
+	}
+
+	payload, err := adminc.BuildRestorePayload(o.Recreate)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	// the request body is closed by the HTTP client
+	response, err := o.AdminClient.Restore()(context.Background(), &adminsvc.RestoreRequestData{Payload: payload, Body: f})
+	if err != nil {
+		return fmt.Errorf("could not restore the server state: %w", err)
+	}
+	res := response.(*adminsvc.RestoreResult)
+
+	fmt.Printf("Restored %d workflows and %d codeset assignments from the snapshot of FuseML %s taken at %s\n",
+		res.Workflows, res.Assignments, util.DerefString(res.ServerVersion, "(unknown version)"), util.DerefString(res.Created))
+	if len(res.Errors) > 0 {
+		for _, e := range res.Errors {
+			fmt.Fprintf(os.Stderr, "  %s\n", e)
+		}
+		return fmt.Errorf("%d restored resources could not be re-created", len(res.Errors))
+	}
+	if res.Recreated {
+		fmt.Println("Tekton resources and codeset webhooks re-created")
+	}
+	return nil
+}
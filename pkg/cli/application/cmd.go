@@ -15,6 +15,8 @@ func NewCmdApplication(c *common.GlobalOptions) *cobra.Command {
 
 	cmd.AddCommand(newSubCmdApplicationList(c))
 	cmd.AddCommand(newSubCmdApplicationGet(c))
+	cmd.AddCommand(newSubCmdApplicationStatus(c))
+	cmd.AddCommand(newSubCmdApplicationUpdate(c))
 	cmd.AddCommand(newSubCmdApplicationDelete(c))
 
 	return cmd
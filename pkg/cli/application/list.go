@@ -4,9 +4,10 @@ import (
 	"context"
 	"os"
 
-	applicationc "github.com/fuseml/fuseml-core/gen/http/application/client"
+	"github.com/fuseml/fuseml-core/gen/application"
 	"github.com/fuseml/fuseml-core/pkg/cli/client"
 	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 )
@@ -18,12 +19,13 @@ type listOptions struct {
 	format   *common.FormattingOptions
 	Type     string
 	Workflow string
+	Status   bool
 }
 
 func newListOptions(o *common.GlobalOptions) (res *listOptions) {
 	res = &listOptions{global: o}
 	res.format = common.NewFormattingOptions(
-		[]string{"Name", "Type", "Description", "URL", "Workflow"},
+		[]string{"Name", "Type", "Description", "URL", "Workflow", "Status:Status.State"},
 		[]table.SortBy{{Name: "Name", Mode: table.Asc}, {Name: "Type", Mode: table.Asc}},
 		common.OutputFormatters{},
 	)
@@ -37,7 +39,7 @@ func newSubCmdApplicationList(gOpt *common.GlobalOptions) *cobra.Command {
 	o := newListOptions(gOpt)
 
 	cmd := &cobra.Command{
-		Use:   "list [-t|--type TYPE] [-w|--workflow WORKFLOW]",
+		Use:   "list [-t|--type TYPE] [-w|--workflow WORKFLOW] [--status=false]",
 		Short: "List applications.",
 		Long:  `Retrieve information about applications registered in FuseML`,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -50,7 +52,9 @@ func newSubCmdApplicationList(gOpt *common.GlobalOptions) *cobra.Command {
 
 	cmd.Flags().StringVarP(&o.Type, "type", "t", "", "list only applications of given type")
 	cmd.Flags().StringVarP(&o.Workflow, "workflow", "w", "", "list only applications generated by given workflow")
+	cmd.Flags().BoolVar(&o.Status, "status", true, "probe the applications and show their live status")
 	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "name", "type", "workflow")
 
 	return cmd
 }
@@ -60,17 +64,16 @@ func (o *listOptions) validate() error {
 }
 
 func (o *listOptions) run() error {
-	request, err := applicationc.BuildListPayload(o.Type, o.Workflow)
-	if err != nil {
-		return err
-	}
-
-	response, err := o.ApplicationClient.List()(context.Background(), request)
-	if err != nil {
-		return err
-	}
+	request := &application.ListPayload{Type: util.RefString(o.Type), Workflow: util.RefString(o.Workflow), Status: o.Status,
+		Limit: util.RefInt(o.format.PageSize), Sort: util.RefString(o.format.Sort)}
 
-	o.format.FormatValue(os.Stdout, response)
-
-	return nil
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		request.Continue = util.RefString(cont)
+		response, err := o.ApplicationClient.List()(context.Background(), request)
+		if err != nil {
+			return nil, "", err
+		}
+		res := response.(*application.ListResult)
+		return res.Items, util.DerefString(res.Continue), nil
+	})
 }
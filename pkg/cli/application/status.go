@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"os"
+
+	applicationc "github.com/fuseml/fuseml-core/gen/http/application/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// statusOptions holds the options for 'application status' sub command
+type statusOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	format *common.FormattingOptions
+	Name   string
+}
+
+func newStatusOptions(o *common.GlobalOptions) *statusOptions {
+	res := &statusOptions{global: o}
+	res.format = common.NewSingleValueFormattingOptions()
+	return res
+}
+
+// newSubCmdApplicationStatus creates and returns the cobra command for the `application status` CLI command
+func newSubCmdApplicationStatus(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newStatusOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   `status {-n|--name NAME}`,
+		Short: "Show the status of an application.",
+		Long:  `Probe a FuseML application URL and kubernetes resources and show whether it is ready, degraded or missing`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "application name")
+	o.format.AddSingleValueFormattingFlags(cmd, common.FormatYAML)
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func (o *statusOptions) validate() error {
+	return nil
+}
+
+func (o *statusOptions) run() error {
+	request, err := applicationc.BuildStatusPayload(o.Name)
+	if err != nil {
+		return err
+	}
+
+	response, err := o.ApplicationClient.Status()(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	o.format.FormatValue(os.Stdout, response)
+
+	return nil
+}
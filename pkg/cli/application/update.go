@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"os"
+
+	"github.com/fuseml/fuseml-core/gen/application"
+	applicationc "github.com/fuseml/fuseml-core/gen/http/application/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// updateOptions holds the options for 'application update' sub command
+type updateOptions struct {
+	client.Clients
+	global      *common.GlobalOptions
+	format      *common.FormattingOptions
+	Name        string
+	Type        string
+	Description string
+	URL         string
+}
+
+func newUpdateOptions(o *common.GlobalOptions) *updateOptions {
+	res := &updateOptions{global: o}
+	res.format = common.NewSingleValueFormattingOptions()
+	return res
+}
+
+// newSubCmdApplicationUpdate creates and returns the cobra command for the `application update` CLI command
+func newSubCmdApplicationUpdate(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newUpdateOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   `update {-n|--name NAME} [-t|--type TYPE] [-d|--description DESCRIPTION] [-u|--url URL]`,
+		Short: "Update an application.",
+		Long:  `Update the type, description or URL of a FuseML application`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run(cmd))
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "application name")
+	cmd.Flags().StringVarP(&o.Type, "type", "t", "", "application type")
+	cmd.Flags().StringVarP(&o.Description, "description", "d", "", "application description")
+	cmd.Flags().StringVarP(&o.URL, "url", "u", "", "the public URL for accessing the application")
+	o.format.AddSingleValueFormattingFlags(cmd, common.FormatYAML)
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func (o *updateOptions) validate() error {
+	return nil
+}
+
+func (o *updateOptions) run(cmd *cobra.Command) error {
+	request, err := applicationc.BuildGetPayload(o.Name)
+	if err != nil {
+		return err
+	}
+
+	response, err := o.ApplicationClient.Get()(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	// only update the attributes explicitly set through command line flags
+	app := response.(*application.Application)
+	if cmd.Flags().Changed("type") {
+		app.Type = o.Type
+	}
+	if cmd.Flags().Changed("description") {
+		app.Description = &o.Description
+	}
+	if cmd.Flags().Changed("url") {
+		app.URL = o.URL
+	}
+
+	response, err = o.ApplicationClient.Update()(context.Background(), app)
+	if err != nil {
+		return err
+	}
+
+	o.format.FormatValue(os.Stdout, response)
+
+	return nil
+}
@@ -8,22 +8,28 @@ import (
 	"strings"
 	"time"
 
+	adminc "github.com/fuseml/fuseml-core/gen/http/admin/client"
 	applicationc "github.com/fuseml/fuseml-core/gen/http/application/client"
 	codesetc "github.com/fuseml/fuseml-core/gen/http/codeset/client"
+	notificationc "github.com/fuseml/fuseml-core/gen/http/notification/client"
 	runnablec "github.com/fuseml/fuseml-core/gen/http/runnable/client"
+	statusc "github.com/fuseml/fuseml-core/gen/http/status/client"
 	yaml "github.com/goccy/go-yaml"
 	goahttp "goa.design/goa/v3/http"
 )
 
 // Clients holds a list of clients for all FuseML endpoints
 type Clients struct {
-	CodesetClient     *codesetc.Client
-	ApplicationClient *applicationc.Client
-	WorkflowClient    *WorkflowClient
-	ProjectClient     *ProjectClient
-	RunnableClient    *runnablec.Client
-	VersionClient     *VersionClient
-	ExtensionClient   *ExtensionClient
+	CodesetClient      *codesetc.Client
+	ApplicationClient  *applicationc.Client
+	WorkflowClient     *WorkflowClient
+	ProjectClient      *ProjectClient
+	RunnableClient     *runnablec.Client
+	VersionClient      *VersionClient
+	ExtensionClient    *ExtensionClient
+	NotificationClient *notificationc.Client
+	StatusClient       *statusc.Client
+	AdminClient        *adminc.Client
 }
 
 // InitializeClients initializes a list of fuseml clients based on global configuration parameters
@@ -59,6 +65,9 @@ func (c *Clients) InitializeClients(URL string, timeout int, verbose bool) error
 	c.VersionClient = NewVersionClient(scheme, host, doer, encoder, decoder, verbose)
 	c.WorkflowClient = NewWorkflowClient(scheme, host, doer, encoder, decoder, verbose)
 	c.ExtensionClient = NewExtensionClient(scheme, host, doer, encoder, decoder, verbose)
+	c.NotificationClient = notificationc.NewClient(scheme, host, doer, encoder, decoder, verbose)
+	c.StatusClient = statusc.NewClient(scheme, host, doer, encoder, decoder, verbose)
+	c.AdminClient = adminc.NewClient(scheme, host, doer, encoder, decoder, verbose)
 
 	return nil
 }
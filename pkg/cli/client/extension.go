@@ -1,15 +1,20 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
+	"github.com/goccy/go-yaml"
 	goahttp "goa.design/goa/v3/http"
 
 	"github.com/fuseml/fuseml-core/gen/extension"
 	extensionc "github.com/fuseml/fuseml-core/gen/http/extension/client"
 	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
 // ExtensionClient holds a client for the extension HTTP REST service
@@ -67,15 +72,16 @@ func (ec *ExtensionClient) GetExtension(extensionID string) (*extension.Extensio
 	return response.(*extension.Extension), nil
 }
 
-// ListExtension - list Extensions.
-func (ec *ExtensionClient) ListExtension(query *extension.ExtensionQuery) ([]*extension.Extension, error) {
+// ListExtension - list a page of Extensions matching the query. Returns the continue token for the next page.
+func (ec *ExtensionClient) ListExtension(query *extension.ExtensionQuery) ([]*extension.Extension, string, error) {
 
-	wfs, err := ec.c.ListExtensions()(context.Background(), query)
+	response, err := ec.c.ListExtensions()(context.Background(), query)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return wfs.([]*extension.Extension), nil
+	res := response.(*extension.ListExtensionsResult)
+	return res.Items, util.DerefString(res.Continue), nil
 }
 
 // DeleteExtension - delete an Extension.
@@ -263,3 +269,133 @@ func (ec *ExtensionClient) ListCredentials(extensionID, serviceID string) (res [
 
 	return response.([]*extension.ExtensionCredentials), nil
 }
+
+// ListUsage - list the workflows bound to an extension, extension service or set of credentials.
+func (ec *ExtensionClient) ListUsage(query *extension.ListUsagePayload) (res []*extension.ExtensionUsage, err error) {
+
+	response, err := ec.c.ListUsage()(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.([]*extension.ExtensionUsage), nil
+}
+
+// ReadExtensionsFromFile - read extensions from a multi-document YAML or JSON file. Each document holds
+// either a single extension descriptor or a list of extension descriptors.
+func (ec *ExtensionClient) ReadExtensionsFromFile(filepath string) (res []*extension.Extension, err error) {
+
+	var extDescriptors string
+	err = common.LoadFileIntoVar(filepath, &extDescriptors)
+	if err != nil {
+		return nil, err
+	}
+
+	res = []*extension.Extension{}
+	dec := yaml.NewDecoder(strings.NewReader(extDescriptors))
+	for {
+		var doc interface{}
+		err = dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			continue
+		}
+
+		extDescriptor, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		if _, isList := doc.([]interface{}); isList {
+			request, err := extensionc.BuildImportExtensionsPayload(string(extDescriptor), false, false)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, request.Extensions...)
+		} else {
+			request, err := extensionc.BuildRegisterExtensionPayload(string(extDescriptor))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, request)
+		}
+	}
+
+	return res, nil
+}
+
+// MarshalExtensions - encode extensions as a multi-document YAML that can be read back with ReadExtensionsFromFile.
+// The status of the extensions and their services, endpoints and credentials is left out.
+func (ec *ExtensionClient) MarshalExtensions(exts []*extension.Extension) ([]byte, error) {
+	for _, ext := range exts {
+		ext.Status = nil
+		for _, svc := range ext.Services {
+			svc.Status = nil
+			for _, ep := range svc.Endpoints {
+				ep.Status = nil
+			}
+			for _, creds := range svc.Credentials {
+				creds.Status = nil
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	for _, extDescriptor := range extensionc.NewExtensionRequestBodyRequestBody(&extension.ImportExtensionsPayload{Extensions: exts}) {
+		doc, err := yaml.Marshal(extDescriptor)
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString("---\n")
+		out.Write(doc)
+	}
+
+	return out.Bytes(), nil
+}
+
+// ImportExtensions - import extensions into the registry.
+func (ec *ExtensionClient) ImportExtensions(exts []*extension.Extension, dryRun, excludeCredentials bool) (res []*extension.ExtensionChange, err error) {
+	request := &extension.ImportExtensionsPayload{
+		Extensions:         exts,
+		DryRun:             dryRun,
+		ExcludeCredentials: excludeCredentials,
+	}
+
+	response, err := ec.c.ImportExtensions()(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.([]*extension.ExtensionChange), nil
+}
+
+// ExportExtensions - export all registered extensions.
+func (ec *ExtensionClient) ExportExtensions(excludeCredentials bool) (res []*extension.Extension, err error) {
+	request := &extension.ExportExtensionsPayload{ExcludeCredentials: excludeCredentials}
+
+	response, err := ec.c.ExportExtensions()(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.([]*extension.Extension), nil
+}
+
+// ListResourceSchemas - list the configuration schemas of the known extension service resource types.
+func (ec *ExtensionClient) ListResourceSchemas(resource string) (res []*extension.ExtensionResourceSchema, err error) {
+	request := &extension.ListResourceSchemasPayload{}
+	if resource != "" {
+		request.Resource = &resource
+	}
+
+	response, err := ec.c.ListResourceSchemas()(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.([]*extension.ExtensionResourceSchema), nil
+}
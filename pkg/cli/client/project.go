@@ -8,6 +8,7 @@ import (
 
 	projectc "github.com/fuseml/fuseml-core/gen/http/project/client"
 	"github.com/fuseml/fuseml-core/gen/project"
+	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
 // ProjectClient holds a client for Project
@@ -38,8 +39,8 @@ func (pc *ProjectClient) Create(name, desc string) (*project.Project, error) {
 }
 
 // Delete a Project and its assignments.
-func (pc *ProjectClient) Delete(name string) (err error) {
-	request, err := projectc.BuildDeletePayload(name)
+func (pc *ProjectClient) Delete(name string, cascade bool) (err error) {
+	request, err := projectc.BuildDeletePayload(name, cascade)
 	if err != nil {
 		return
 	}
@@ -63,11 +64,88 @@ func (pc *ProjectClient) Get(name string) (*project.Project, error) {
 	return response.(*project.Project), nil
 }
 
-// List Projects.
-func (pc *ProjectClient) List() ([]*project.Project, error) {
-	response, err := pc.c.List()(context.Background(), nil)
+// List Projects. Returns a page of at most limit projects, starting at the position identified by the
+// continue token, and the continue token for the next page.
+func (pc *ProjectClient) List(limit int, cont, sort string) ([]*project.Project, string, error) {
+	request := &project.ListPayload{Limit: util.RefInt(limit), Continue: util.RefString(cont), Sort: util.RefString(sort)}
+	response, err := pc.c.List()(context.Background(), request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res := response.(*project.ListResult)
+	return res.Items, util.DerefString(res.Continue), nil
+}
+
+// Summary retrieves a summary of the resources that belong to a Project.
+func (pc *ProjectClient) Summary(name string) (*project.ProjectSummary, error) {
+	request, err := projectc.BuildSummaryPayload(name)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := pc.c.Summary()(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*project.ProjectSummary), nil
+}
+
+// ListMembers lists the members of a Project.
+func (pc *ProjectClient) ListMembers(name string) ([]*project.User, error) {
+	request, err := projectc.BuildListMembersPayload(name)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := pc.c.ListMembers()(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	return response.([]*project.User), nil
+}
+
+// AddMember adds a user to a Project.
+func (pc *ProjectClient) AddMember(name, user, email string) (*project.UserCredentials, error) {
+	// the email is optional, so the payload is not built using the generated
+	// helper, which validates the email format even when it is empty
+	request := &project.AddMemberPayload{
+		Name:  name,
+		User:  user,
+		Email: util.RefString(email),
+	}
+
+	response, err := pc.c.AddMember()(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*project.UserCredentials), nil
+}
+
+// RemoveMember removes a user from a Project.
+func (pc *ProjectClient) RemoveMember(name, user string) (err error) {
+	request, err := projectc.BuildRemoveMemberPayload(name, user)
+	if err != nil {
+		return
+	}
+
+	_, err = pc.c.RemoveMember()(context.Background(), request)
+	return
+}
+
+// RotateCredentials issues new credentials for a Project member.
+func (pc *ProjectClient) RotateCredentials(name, user string) (*project.UserCredentials, error) {
+	request, err := projectc.BuildRotateCredentialsPayload(name, user)
 	if err != nil {
 		return nil, err
 	}
-	return response.([]*project.Project), nil
+
+	response, err := pc.c.RotateCredentials()(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.(*project.UserCredentials), nil
 }
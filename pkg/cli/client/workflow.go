@@ -3,13 +3,12 @@ package client
 import (
 	"context"
 	"net/http"
-	"sort"
-	"time"
 
 	goahttp "goa.design/goa/v3/http"
 
 	workflowc "github.com/fuseml/fuseml-core/gen/http/workflow/client"
 	"github.com/fuseml/fuseml-core/gen/workflow"
+	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
 // WorkflowClient holds a client for Workflow
@@ -76,19 +75,33 @@ func (wc *WorkflowClient) Get(name string) (*workflow.Workflow, error) {
 	return response.(*workflow.Workflow), nil
 }
 
-// List Workflows.
-func (wc *WorkflowClient) List(name string) ([]*workflow.Workflow, error) {
-	request, err := workflowc.BuildListPayload(name)
+// Refresh resolves the extension references of a Workflow again.
+func (wc *WorkflowClient) Refresh(name string) (*workflow.Workflow, error) {
+	request, err := workflowc.BuildRefreshPayload(name)
 	if err != nil {
 		return nil, err
 	}
 
-	wfs, err := wc.c.List()(context.Background(), request)
+	response, err := wc.c.Refresh()(context.Background(), request)
 	if err != nil {
 		return nil, err
 	}
 
-	return wfs.([]*workflow.Workflow), nil
+	return response.(*workflow.Workflow), nil
+}
+
+// List Workflows. Returns a page of at most limit workflows, starting at the position identified by the
+// continue token, and the continue token for the next page.
+func (wc *WorkflowClient) List(name string, limit int, cont, sort string) ([]*workflow.Workflow, string, error) {
+	request := &workflow.ListPayload{Name: util.RefString(name), Limit: util.RefInt(limit),
+		Continue: util.RefString(cont), Sort: util.RefString(sort)}
+	response, err := wc.c.List()(context.Background(), request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res := response.(*workflow.ListResult)
+	return res.Items, util.DerefString(res.Continue), nil
 }
 
 // ListAssignments lists Workflow assignments.
@@ -106,22 +119,19 @@ func (wc *WorkflowClient) ListAssignments(name string) ([]*workflow.WorkflowAssi
 	return response.([]*workflow.WorkflowAssignment), nil
 }
 
-// ListRuns lists Workflow runs.
-func (wc *WorkflowClient) ListRuns(name, codesetProject, codesetName, status string) ([]*workflow.WorkflowRun, error) {
-	request, err := workflowc.BuildListRunsPayload(name, codesetProject, codesetName, status)
+// ListRuns lists Workflow runs. Returns a page of at most limit workflow runs, starting at the position
+// identified by the continue token, and the continue token for the next page.
+func (wc *WorkflowClient) ListRuns(name, codesetProject, codesetName, status string, limit int, cont, sort string) ([]*workflow.WorkflowRun, string, error) {
+	request := &workflow.ListRunsPayload{Name: util.RefString(name), CodesetProject: util.RefString(codesetProject),
+		CodesetName: util.RefString(codesetName), Status: util.RefString(status), Limit: util.RefInt(limit),
+		Continue: util.RefString(cont), Sort: util.RefString(sort)}
+	response, err := wc.c.ListRuns()(context.Background(), request)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	res, err := wc.c.ListRuns()(context.Background(), request)
-	if err != nil {
-		return nil, err
-	}
-
-	wrs := res.([]*workflow.WorkflowRun)
-	sortWorkflowRunsByStartTime(wrs)
-
-	return wrs, nil
+	res := response.(*workflow.ListRunsResult)
+	return res.Items, util.DerefString(res.Continue), nil
 }
 
 // Unassign removes an assignment between a workflow and a codeset.
@@ -134,24 +144,3 @@ func (wc *WorkflowClient) Unassign(name, codesetProject, codesetName string) (er
 	_, err = wc.c.Unassign()(context.Background(), request)
 	return
 }
-
-func sortWorkflowRunsByStartTime(wrs []*workflow.WorkflowRun) {
-	sort.Sort(workflowRunsByStartTime(wrs))
-}
-
-type workflowRunsByStartTime []*workflow.WorkflowRun
-
-func (wrs workflowRunsByStartTime) Len() int      { return len(wrs) }
-func (wrs workflowRunsByStartTime) Swap(i, j int) { wrs[i], wrs[j] = wrs[j], wrs[i] }
-func (wrs workflowRunsByStartTime) Less(i, j int) bool {
-	if wrs[j].StartTime == "" {
-		return false
-	}
-	if wrs[i].StartTime == "" {
-		return true
-	}
-	layout := time.RFC3339
-	stj, _ := time.Parse(layout, wrs[j].StartTime)
-	sti, _ := time.Parse(layout, wrs[i].StartTime)
-	return stj.Before(sti)
-}
@@ -5,12 +5,15 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/fuseml/fuseml-core/pkg/cli/admin"
 	"github.com/fuseml/fuseml-core/pkg/cli/application"
 	"github.com/fuseml/fuseml-core/pkg/cli/codeset"
 	"github.com/fuseml/fuseml-core/pkg/cli/common"
 	"github.com/fuseml/fuseml-core/pkg/cli/extension"
+	"github.com/fuseml/fuseml-core/pkg/cli/notification"
 	"github.com/fuseml/fuseml-core/pkg/cli/project"
 	"github.com/fuseml/fuseml-core/pkg/cli/runnable"
+	"github.com/fuseml/fuseml-core/pkg/cli/status"
 	"github.com/fuseml/fuseml-core/pkg/cli/version"
 	"github.com/fuseml/fuseml-core/pkg/cli/workflow"
 	"github.com/spf13/cobra"
@@ -52,6 +55,9 @@ func NewCmdRoot() *cobra.Command {
 	cmd.AddCommand(workflow.NewCmdWorkflow(o))
 	cmd.AddCommand(application.NewCmdApplication(o))
 	cmd.AddCommand(extension.NewCmdExtension(o))
+	cmd.AddCommand(notification.NewCmdNotification(o))
+	cmd.AddCommand(status.NewCmdStatus(o))
+	cmd.AddCommand(admin.NewCmdAdmin(o))
 
 	return cmd
 }
@@ -18,6 +18,8 @@ func NewCmdCodeset(c *common.GlobalOptions) *cobra.Command {
 	cmd.AddCommand(NewSubCmdCodesetList(c))
 	cmd.AddCommand(NewSubCmdCodesetDelete(c))
 	cmd.AddCommand(NewSubCmdCodesetSet(c))
+	cmd.AddCommand(NewSubCmdCodesetInit(c))
+	cmd.AddCommand(NewSubCmdCodesetTemplates(c))
 
 	return cmd
 }
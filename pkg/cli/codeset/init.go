@@ -0,0 +1,152 @@
+package codeset
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/gen/codeset"
+	codesetc "github.com/fuseml/fuseml-core/gen/http/codeset/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+// InitOptions holds the options for 'codeset init' sub command
+type InitOptions struct {
+	client.Clients
+	global    *common.GlobalOptions
+	Template  string
+	Location  string
+	Overwrite bool
+}
+
+// templateValues holds the values that can be referenced by codeset template files
+type templateValues struct {
+	// The codeset name, derived from the name of the target directory
+	Name string
+}
+
+// NewInitOptions creates a CodesetInitOptions struct
+func NewInitOptions(o *common.GlobalOptions) *InitOptions {
+	return &InitOptions{global: o}
+}
+
+// NewSubCmdCodesetInit creates and returns the cobra command for the `codeset init` CLI command
+func NewSubCmdCodesetInit(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := NewInitOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use: `init {-t|--template TEMPLATE} [--overwrite] LOCATION
+
+LOCATION is the path to the local directory where the codeset is created`,
+		Short: "Scaffold codesets.",
+		Long: `Create a local codeset directory from one of the templates served by FuseML.
+Use 'codeset templates' to find the templates compatible with a runnable.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			o.Location = cmd.Flags().Arg(0)
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args:                  cobra.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+	}
+
+	cmd.Flags().StringVarP(&o.Template, "template", "t", "", "the name of the template used to scaffold the codeset")
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false, "overwrite existing files in the target directory")
+	cmd.MarkFlagRequired("template")
+	return cmd
+}
+
+func (o *InitOptions) validate() error {
+	return nil
+}
+
+func (o *InitOptions) run() error {
+	request, err := codesetc.BuildGetTemplatePayload(o.Template)
+	if err != nil {
+		return err
+	}
+
+	response, err := o.CodesetClient.GetTemplate()(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	tmpl := response.(*codeset.CodesetTemplate)
+
+	location, err := filepath.Abs(o.Location)
+	if err != nil {
+		return err
+	}
+	values := templateValues{Name: filepath.Base(location)}
+
+	// resolve all the paths before creating any file, so that a template with a bad path leaves nothing behind
+	targets := map[string]string{}
+	for path := range tmpl.Files {
+		target, err := templateFilePath(location, path)
+		if err != nil {
+			return errors.Wrapf(err, "template %s", tmpl.Name)
+		}
+		targets[path] = target
+	}
+
+	if !o.Overwrite {
+		for path, target := range targets {
+			if _, err := os.Stat(target); err == nil {
+				return fmt.Errorf("file %s already exists, use --overwrite to replace it", filepath.Join(o.Location, path))
+			}
+		}
+	}
+
+	for path, content := range tmpl.Files {
+		if err := writeTemplateFile(targets[path], content, values); err != nil {
+			return errors.Wrapf(err, "failed to create %s", path)
+		}
+		fmt.Printf("Created %s\n", filepath.Join(o.Location, path))
+	}
+
+	fmt.Printf("Codeset %s successfully initialized from template %s\n", values.Name, tmpl.Name)
+	fmt.Printf("Use 'codeset register --name %s --project PROJECT %s' to register it with FuseML\n", values.Name, o.Location)
+
+	return nil
+}
+
+// templateFilePath returns the path where a template file is created in the codeset location. Template file
+// paths must be relative and must not point outside of the location.
+func templateFilePath(location, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("file path %s is not relative", path)
+	}
+	target := filepath.Join(location, path)
+	if rel, err := filepath.Rel(location, target); err != nil || rel == "." || rel == ".." ||
+		strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file path %s points outside of the codeset directory", path)
+	}
+	return target, nil
+}
+
+func writeTemplateFile(path, content string, values templateValues) error {
+	t, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, values); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
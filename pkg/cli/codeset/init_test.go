@@ -0,0 +1,38 @@
+package codeset
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateFilePath(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "mycodeset")
+
+	for path, want := range map[string]string{
+		"MLproject":            filepath.Join(location, "MLproject"),
+		"src/train.py":         filepath.Join(location, "src", "train.py"),
+		"src/../conda.yaml":    filepath.Join(location, "conda.yaml"),
+		"./data/..data/a.csv":  filepath.Join(location, "data", "..data", "a.csv"),
+		"..hidden/config.yaml": filepath.Join(location, "..hidden", "config.yaml"),
+	} {
+		got, err := templateFilePath(location, path)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", path, err)
+		} else if got != want {
+			t.Errorf("Unexpected path for %s: got %s want %s", path, got, want)
+		}
+	}
+
+	for _, path := range []string{
+		"/etc/passwd",
+		"../outside.py",
+		"src/../../outside.py",
+		"..",
+		".",
+		"",
+	} {
+		if got, err := templateFilePath(location, path); err == nil {
+			t.Errorf("Expected an error for %s, got %s", path, got)
+		}
+	}
+}
@@ -7,9 +7,9 @@ import (
 	"strings"
 
 	codeset "github.com/fuseml/fuseml-core/gen/codeset"
-	codesetc "github.com/fuseml/fuseml-core/gen/http/codeset/client"
 	"github.com/fuseml/fuseml-core/pkg/cli/client"
 	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 )
@@ -65,6 +65,7 @@ func NewSubCmdCodesetList(gOpt *common.GlobalOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&o.Label, "label", "l", "", "filter codesets by label")
 	cmd.Flags().BoolVar(&o.All, "all", false, "show all codesets; ignores 'label' and 'project' options (default: false)")
 	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "project", "name")
 
 	return cmd
 }
@@ -78,15 +79,8 @@ func (o *ListOptions) run() error {
 		o.Project = ""
 		o.Label = ""
 	}
-	request, err := codesetc.BuildListPayload(o.Project, o.Label)
-	if err != nil {
-		return err
-	}
-
-	response, err := o.CodesetClient.List()(context.Background(), request)
-	if err != nil {
-		return err
-	}
+	request := &codeset.ListPayload{Project: util.RefString(o.Project), Label: util.RefString(o.Label),
+		Limit: util.RefInt(o.format.PageSize), Sort: util.RefString(o.format.Sort)}
 
 	if o.Project == "" && o.Label == "" {
 		fmt.Println("Listing all Codesets:")
@@ -97,7 +91,13 @@ func (o *ListOptions) run() error {
 	} else {
 		fmt.Printf("Listing Codesets for project %s and with label %s:\n", o.Project, o.Label)
 	}
-	o.format.FormatValue(os.Stdout, response)
-
-	return nil
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		request.Continue = util.RefString(cont)
+		response, err := o.CodesetClient.List()(context.Background(), request)
+		if err != nil {
+			return nil, "", err
+		}
+		res := response.(*codeset.ListResult)
+		return res.Items, util.DerefString(res.Continue), nil
+	})
 }
@@ -43,7 +43,13 @@ func NewSubCmdCodesetRegister(gOpt *common.GlobalOptions) *cobra.Command {
 
 LOCATION can be path to local directory or URL of a git repository`,
 		Short: "Register codesets.",
-		Long:  `Register a codeset with FuseML.`,
+		Long: `Register a codeset with FuseML.
+
+The code is pushed with the git credentials returned when a new project user is created. Otherwise the
+--user and --password flags are used, falling back to the FUSEML_PROJECT_USER and FUSEML_PROJECT_PASSWORD
+environment variables and to the credentials saved in the config file. Project git users created by older
+FuseML versions share a default password: run 'fuseml project member rotate-credentials' to replace it,
+or 'fuseml project member add' to get personal credentials.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			o.Location = cmd.Flags().Arg(0)
 			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
@@ -70,6 +76,20 @@ LOCATION can be path to local directory or URL of a git repository`,
 	return cmd
 }
 
+// credential returns the value of a credential flag or, if it is not set, of the first of the viper keys
+// that is set, or nil if none is
+func credential(flag string, keys ...string) *string {
+	if flag != "" {
+		return &flag
+	}
+	for _, key := range keys {
+		if value := viper.GetString(key); value != "" {
+			return &value
+		}
+	}
+	return nil
+}
+
 func (o *RegisterOptions) validate() error {
 	return nil
 }
@@ -88,14 +108,15 @@ func (o *RegisterOptions) run() error {
 	result := response.(*codeset.RegisterResult)
 	codeset := result.Codeset
 
-	// priority have username/password from the registering (when the new user was created)
+	// priority have username/password from the registering (when the new user was created), then the
+	// flags, then the environment variables and the credentials saved in the config file
 	password := result.Password
 	username := result.Username
-	if username == nil && o.User != "" {
-		username = &o.User
+	if username == nil {
+		username = credential(o.User, "user", "Username")
 	}
-	if password == nil && o.Password != "" {
-		password = &o.Password
+	if password == nil {
+		password = credential(o.Password, "password")
 	}
 
 	err = gitc.Push(o.Project, o.Name, o.Location, *codeset.URL, username, password, o.global.Verbose)
@@ -0,0 +1,36 @@
+package codeset
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestCredential(t *testing.T) {
+	defer viper.Reset()
+	viper.BindEnv("password", "FUSEML_PROJECT_PASSWORD")
+
+	if got := credential("", "user", "Username"); got != nil {
+		t.Errorf("got %q want nil", *got)
+	}
+
+	// the saved credentials are used when neither the flag nor the environment variable is set
+	viper.Set("Username", "fuseml-prj")
+	viper.Set("Password", "saved")
+	if got := credential("", "user", "Username"); got == nil || *got != "fuseml-prj" {
+		t.Errorf("got %v want the saved username", got)
+	}
+	if got := credential("", "password"); got == nil || *got != "saved" {
+		t.Errorf("got %v want the saved password", got)
+	}
+
+	t.Setenv("FUSEML_PROJECT_PASSWORD", "env")
+	viper.Reset()
+	viper.BindEnv("password", "FUSEML_PROJECT_PASSWORD")
+	if got := credential("", "password"); got == nil || *got != "env" {
+		t.Errorf("got %v want the environment variable", got)
+	}
+	if got := credential("flag", "password"); got == nil || *got != "flag" {
+		t.Errorf("got %v want the flag", got)
+	}
+}
@@ -0,0 +1,103 @@
+package codeset
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	codeset "github.com/fuseml/fuseml-core/gen/codeset"
+	codesetc "github.com/fuseml/fuseml-core/gen/http/codeset/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+// TemplatesOptions holds the options for 'codeset templates' sub command
+type TemplatesOptions struct {
+	client.Clients
+	global   *common.GlobalOptions
+	format   *common.FormattingOptions
+	Runnable string
+	Type     string
+	Function string
+	Format   string
+}
+
+// custom formatting handler used to format the codeset attributes of a template
+func formatTemplateCodeset(object interface{}, column string, field interface{}) string {
+	if t, ok := object.(*codeset.CodesetTemplate); ok && t.Codeset != nil {
+		switch column {
+		case "Type":
+			return strings.Join(t.Codeset.Type, "\n")
+		case "Function":
+			return strings.Join(t.Codeset.Function, "\n")
+		case "Format":
+			return strings.Join(t.Codeset.Format, "\n")
+		}
+	}
+	return ""
+}
+
+// NewTemplatesOptions initializes a TemplatesOptions struct
+func NewTemplatesOptions(o *common.GlobalOptions) (res *TemplatesOptions) {
+	res = &TemplatesOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"Name", "Description", "Type", "Function", "Format"},
+		[]table.SortBy{{Name: "Name", Mode: table.Asc}},
+		common.OutputFormatters{
+			"Type":     formatTemplateCodeset,
+			"Function": formatTemplateCodeset,
+			"Format":   formatTemplateCodeset,
+		},
+	)
+
+	return
+}
+
+// NewSubCmdCodesetTemplates creates and returns the cobra command for the `codeset templates` CLI command
+func NewSubCmdCodesetTemplates(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := NewTemplatesOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   "templates [-r|--runnable RUNNABLE] [--type TYPE] [--function FUNCTION] [--codeset-format FORMAT]",
+		Short: "List codeset templates.",
+		Long:  `Retrieve information about the templates that can be used to scaffold codesets`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Runnable, "runnable", "r", "", "list only templates compatible with the codeset inputs of a runnable")
+	cmd.Flags().StringVar(&o.Type, "type", "", "list only templates generating codesets with the given type of information")
+	cmd.Flags().StringVar(&o.Function, "function", "", "list only templates generating codesets with the given intended function")
+	cmd.Flags().StringVar(&o.Format, "codeset-format", "", "list only templates generating codesets with the given format")
+	o.format.AddMultiValueFormattingFlags(cmd)
+
+	return cmd
+}
+
+func (o *TemplatesOptions) validate() error {
+	return nil
+}
+
+func (o *TemplatesOptions) run() error {
+	request, err := codesetc.BuildListTemplatesPayload(o.Runnable, o.Type, o.Function, o.Format)
+	if err != nil {
+		return err
+	}
+
+	response, err := o.CodesetClient.ListTemplates()(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	o.format.FormatValue(os.Stdout, response)
+
+	return nil
+}
@@ -67,8 +67,19 @@ type FormattingOptions struct {
 	SortBy []table.SortBy
 	// Custom formatting functions
 	Formatters OutputFormatters
+	// Maximum number of results retrieved from the server with each request. Zero means all results are
+	// retrieved with a single request.
+	PageSize int
+	// Field used by the server to order the results, prefixed with '-' for descending order.
+	// When set, the results are displayed in the order in which they are returned by the server.
+	Sort string
 }
 
+// PageFetcher is a handler used to retrieve a page of results from the server, starting at the position
+// identified by the continue token. It returns the results, as a slice, and the continue token for the
+// next page, which is empty when there are no more results.
+type PageFetcher func(cont string) (items interface{}, next string, err error)
+
 // NewFormattingOptions initializes formatting options for a cobra command. It accepts the following arguments:
 //  - fields: list of field specifiers controlling how information is converted from structured data into tabular format
 //  (see FormattingOptions/Fields).
@@ -150,6 +161,48 @@ func (o *FormattingOptions) AddSingleValueFormattingFlags(cmd *cobra.Command, de
 	o.addFormattingFlags(cmd, defaultFormat)
 }
 
+// AddPagingFlags adds command line flags that control how list results are retrieved from the server
+// to a cobra command. The sortFields are the fields accepted by the server to order the results.
+func (o *FormattingOptions) AddPagingFlags(cmd *cobra.Command, sortFields ...string) {
+	cmd.Flags().IntVar(&o.PageSize, "page-size", 0,
+		"maximum number of results retrieved from the server with each request (default: all results at once)")
+	cmd.Flags().StringVar(&o.Sort, "sort", "",
+		fmt.Sprintf(`order the results on the server by one of the following fields: %s.
+Prefix the field with '-' for descending order.`, strings.Join(sortFields, ", ")))
+
+	cmd.Use = fmt.Sprintf("%s [--page-size SIZE] [--sort [-]FIELD]", cmd.Use)
+}
+
+// FormatPages retrieves all pages of results using the fetch handler, following the continue
+// tokens returned by the server, and formats the results according to the configured formatting
+// options.
+func (o *FormattingOptions) FormatPages(out io.Writer, fetch PageFetcher) error {
+	var all reflect.Value
+	cont := ""
+	for {
+		items, next, err := fetch(cont)
+		if err != nil {
+			return err
+		}
+		page := reflect.ValueOf(items)
+		if !all.IsValid() {
+			all = reflect.MakeSlice(page.Type(), 0, page.Len())
+		}
+		all = reflect.AppendSlice(all, page)
+		if next == "" {
+			break
+		}
+		cont = next
+	}
+
+	if o.Sort != "" {
+		// keep the order in which the results were returned by the server
+		o.SortBy = nil
+	}
+	o.FormatValue(out, all.Interface())
+	return nil
+}
+
 // Recursive function that extracts a subfield from a generic hierarchical structure.
 // This really a simple and far less powerful alternative to JSONPath and YAML path.
 func getFieldValue(valueMap map[string]interface{}, fields []string) interface{} {
@@ -25,6 +25,10 @@ func NewCmdExtension(c *common.GlobalOptions) *cobra.Command {
 	cmd.AddCommand(newSubCmdExtensionList(c))
 	cmd.AddCommand(newSubCmdExtensionDelete(c))
 	cmd.AddCommand(newSubCmdExtensionUpdate(c))
+	cmd.AddCommand(newSubCmdExtensionUsage(c))
+	cmd.AddCommand(newSubCmdExtensionImport(c))
+	cmd.AddCommand(newSubCmdExtensionExport(c))
+	cmd.AddCommand(newSubCmdExtensionSchemas(c))
 
 	return cmd
 }
@@ -0,0 +1,74 @@
+package extension
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type extensionExportOptions struct {
+	client.Clients
+	global             *common.GlobalOptions
+	toFile             string
+	excludeCredentials bool
+}
+
+func newExtensionExportOptions(o *common.GlobalOptions) *extensionExportOptions {
+	return &extensionExportOptions{global: o}
+}
+
+func newSubCmdExtensionExport(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newExtensionExportOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "export [-o|--output EXTENSIONS_FILE] [--exclude-credentials]",
+		Short: "Exports FuseML extensions",
+		Long: `Exports all registered extensions, with their services, endpoints and credentials, as a multi-document YAML
+
+The exported extensions can be imported into another FuseML server with 'fuseml extension import'.
+Credentials hold sensitive information, use '--exclude-credentials' to leave them out.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.toFile, "output", "o", "", "write the extension descriptors to a file instead of the standard output")
+	cmd.Flags().BoolVar(&o.excludeCredentials, "exclude-credentials", false, "leave the credentials out of the exported extensions")
+
+	return cmd
+}
+
+func (o *extensionExportOptions) validate() error {
+	return nil
+}
+
+func (o *extensionExportOptions) run() error {
+	exts, err := o.ExtensionClient.ExportExtensions(o.excludeCredentials)
+	if err != nil {
+		return err
+	}
+
+	content, err := o.ExtensionClient.MarshalExtensions(exts)
+	if err != nil {
+		return err
+	}
+
+	if o.toFile == "" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+	err = ioutil.WriteFile(o.toFile, content, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot write file %s: %w", o.toFile, err)
+	}
+	fmt.Printf("%d extensions exported to %s\n", len(exts), o.toFile)
+
+	return nil
+}
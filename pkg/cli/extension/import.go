@@ -0,0 +1,92 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type extensionImportOptions struct {
+	client.Clients
+	global             *common.GlobalOptions
+	format             *common.FormattingOptions
+	fromFile           string
+	dryRun             bool
+	excludeCredentials bool
+}
+
+func newExtensionImportOptions(o *common.GlobalOptions) (res *extensionImportOptions) {
+	res = &extensionImportOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"Element", "Kind", "Action", "Fields"},
+		[]table.SortBy{{Name: "Element", Mode: table.Asc}},
+		nil,
+	)
+	return
+}
+
+func newSubCmdExtensionImport(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newExtensionImportOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "import {-f|--file EXTENSIONS_FILE} [--dry-run] [--exclude-credentials]",
+		Short: "Imports FuseML extensions",
+		Long: `Imports extensions, with their services, endpoints and credentials, from a multi-document YAML file
+
+Extensions, services, endpoints and credentials that are not yet registered are added
+and those that are already registered are updated. Registered elements that are missing
+from the file are left untouched. Use '--dry-run' to only list the changes that the
+import would make. For example, to copy the extensions registered with one FuseML
+server to another:
+
+  fuseml extension export -o extensions.yaml
+  fuseml extension import -f extensions.yaml --dry-run --url http://fuseml-core.example.com
+  fuseml extension import -f extensions.yaml --url http://fuseml-core.example.com
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.fromFile, "file", "f", "", "read the extension descriptors from a multi-document YAML or JSON file")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "only list the changes, without applying them")
+	cmd.Flags().BoolVar(&o.excludeCredentials, "exclude-credentials", false, "do not import the credentials found in the file")
+	o.format.AddMultiValueFormattingFlags(cmd)
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func (o *extensionImportOptions) validate() error {
+	return nil
+}
+
+func (o *extensionImportOptions) run() error {
+	exts, err := o.ExtensionClient.ReadExtensionsFromFile(o.fromFile)
+	if err != nil {
+		return err
+	}
+
+	changes, err := o.ExtensionClient.ImportExtensions(exts, o.dryRun, o.excludeCredentials)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("The extension registry is up to date")
+		return nil
+	}
+	if o.dryRun {
+		fmt.Println("The import would make the following changes:")
+	}
+	o.format.FormatValue(os.Stdout, changes)
+
+	return nil
+}
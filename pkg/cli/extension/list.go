@@ -53,7 +53,11 @@ func formatEndpoints(object interface{}, column string, field interface{}) (form
 			}
 			formated += fmt.Sprintf("[ %s ]\n", util.DerefString(svc.ID))
 			for _, ep := range svc.Endpoints {
-				formated += fmt.Sprintf("%s: %s\n", util.DerefString(ep.Type, "external"), util.DerefString(ep.URL))
+				health := "unknown"
+				if ep.Status != nil && ep.Status.Health != "" {
+					health = ep.Status.Health
+				}
+				formated += fmt.Sprintf("%s: %s (%s)\n", util.DerefString(ep.Type, "external"), util.DerefString(ep.URL), health)
 			}
 			formated += "\n"
 		}
@@ -118,6 +122,7 @@ installations of the same product registered with the same or different FuseML s
 		`match only extensions providing one of the well-known categories of AI/ML services
 (e.g. model store, feature store, distributed training, serving)`)
 	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "id", "product", "zone", "created", "updated")
 
 	return cmd
 }
@@ -127,12 +132,10 @@ func (o *extensionListOptions) validate() error {
 }
 
 func (o *extensionListOptions) run() error {
-	exts, err := o.ExtensionClient.ListExtension(&o.query)
-	if err != nil {
-		return err
-	}
-
-	o.format.FormatValue(os.Stdout, exts)
-
-	return nil
+	o.query.Limit = util.RefInt(o.format.PageSize)
+	o.query.Sort = util.RefString(o.format.Sort)
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		o.query.Continue = util.RefString(cont)
+		return o.ExtensionClient.ListExtension(&o.query)
+	})
 }
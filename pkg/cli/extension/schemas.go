@@ -0,0 +1,87 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/gen/extension"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type extensionSchemasOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	format *common.FormattingOptions
+}
+
+func newExtensionSchemasOptions(o *common.GlobalOptions) (res *extensionSchemasOptions) {
+	res = &extensionSchemasOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"Resource", "Category", "Service:ServiceConfiguration", "Endpoint:EndpointConfiguration",
+			"Credentials:CredentialsConfiguration"},
+		[]table.SortBy{{Name: "Resource", Mode: table.Asc}},
+		common.OutputFormatters{"Service": formatConfigurationKeys, "Endpoint": formatConfigurationKeys,
+			"Credentials": formatConfigurationKeys},
+	)
+	return
+}
+
+func formatConfigurationKeys(object interface{}, column string, field interface{}) (formated string) {
+	if schema, ok := object.(*extension.ExtensionResourceSchema); ok {
+		keys := schema.ServiceConfiguration
+		switch column {
+		case "Endpoint":
+			keys = schema.EndpointConfiguration
+		case "Credentials":
+			keys = schema.CredentialsConfiguration
+		}
+		for _, key := range keys {
+			if key.Required {
+				formated += fmt.Sprintf("%s (required)\n", key.Name)
+			} else {
+				formated += fmt.Sprintf("%s\n", key.Name)
+			}
+		}
+	}
+	return
+}
+
+func newSubCmdExtensionSchemas(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newExtensionSchemasOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "schemas [RESOURCE]",
+		Short: "Lists the configuration schemas of known service resource types",
+		Long: `Display the configuration entries expected for extension services of known resource types (e.g. s3, mlflow),
+as well as for their endpoints and credentials. Entries marked as required must be present when services,
+endpoints or credentials of these resource types are registered.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run(cmd.Flags().Arg(0)))
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	o.format.AddMultiValueFormattingFlags(cmd)
+
+	return cmd
+}
+
+func (o *extensionSchemasOptions) validate() error {
+	return nil
+}
+
+func (o *extensionSchemasOptions) run(resource string) error {
+	schemas, err := o.ExtensionClient.ListResourceSchemas(resource)
+	if err != nil {
+		return err
+	}
+
+	o.format.FormatValue(os.Stdout, schemas)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package extension
+
+import (
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/gen/extension"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type extensionUsageOptions struct {
+	client.Clients
+	global        *common.GlobalOptions
+	format        *common.FormattingOptions
+	serviceID     string
+	credentialsID string
+	all           bool
+}
+
+func newExtensionUsageOptions(o *common.GlobalOptions) (res *extensionUsageOptions) {
+	res = &extensionUsageOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"Workflow", "Step", "Requirement", "Service:ServiceID", "Endpoint:EndpointURL", "Credentials:CredentialsID", "Bound", "Released"},
+		[]table.SortBy{{Name: "Workflow", Mode: table.Asc}, {Name: "Step", Mode: table.Asc}},
+		nil,
+	)
+	return
+}
+
+func newSubCmdExtensionUsage(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newExtensionUsageOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "usage {EXTENSION_ID} [--service-id SERVICE_ID] [--credentials-id CREDENTIALS_ID] [--all]",
+		Short: "Lists the workflows that use an extension",
+		Long: `Display the workflows and workflow steps bound to an extension, extension service or set of credentials.
+Extensions, services and credentials cannot be deleted while workflows are bound to them.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run(cmd.Flags().Arg(0)))
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVar(&o.serviceID, "service-id", "", "list only the workflows bound to this extension service")
+	cmd.Flags().StringVar(&o.credentialsID, "credentials-id", "", "list only the workflows bound to this set of credentials")
+	cmd.Flags().BoolVarP(&o.all, "all", "a", false, "also list released bindings, e.g. of deleted workflows")
+	o.format.AddMultiValueFormattingFlags(cmd)
+
+	return cmd
+}
+
+func (o *extensionUsageOptions) validate() error {
+	return nil
+}
+
+func (o *extensionUsageOptions) run(extensionID string) error {
+	query := &extension.ListUsagePayload{ExtensionID: extensionID, All: o.all}
+	if o.serviceID != "" {
+		query.ServiceID = &o.serviceID
+	}
+	if o.credentialsID != "" {
+		query.CredentialsID = &o.credentialsID
+	}
+
+	usage, err := o.ExtensionClient.ListUsage(query)
+	if err != nil {
+		return err
+	}
+
+	o.format.FormatValue(os.Stdout, usage)
+
+	return nil
+}
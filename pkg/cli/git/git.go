@@ -48,8 +48,13 @@ func fetchRemoteRepository(path, target string) error {
 }
 
 // Push the code from local dir to remote repo
-// If username or password is not provided, use default values
+// If username is not provided, the project git user is used. The password is required.
 func Push(org, name, location, gitURL string, uname, pass *string, debug bool) error {
+	if pass == nil {
+		return errors.New("no password available for the project git user, provide one with --password or " +
+			"FUSEML_PROJECT_PASSWORD, or rotate the credentials with 'fuseml project member rotate-credentials'")
+	}
+
 	log.Printf("Pushing the code to the git repository...")
 
 	tmpDir, err := ioutil.TempDir("", "codeset-source")
@@ -79,16 +84,11 @@ func Push(org, name, location, gitURL string, uname, pass *string, debug bool) e
 		return errors.Wrap(err, "Failed to parse git url")
 	}
 	username := config.DefaultUserName(org)
-	password := config.DefaultUserPassword
-
 	if uname != nil {
 		username = *uname
 	}
-	if pass != nil {
-		password = *pass
-	}
 
-	u.User = url.UserPassword(username, password)
+	u.User = url.UserPassword(username, *pass)
 
 	// Clone new repository so we can push new content
 	cloneDir, err := ioutil.TempDir("", "codeset-clone")
@@ -0,0 +1,23 @@
+package notification
+
+import (
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdNotification creates and returns the cobra command that acts as a root for all other notification CLI sub-commands
+func NewCmdNotification(c *common.GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notification",
+		Short: "Notification management",
+		Long:  `Manage the targets notified when workflow runs change state`,
+	}
+
+	cmd.AddCommand(newSubCmdNotificationCreate(c))
+	cmd.AddCommand(newSubCmdNotificationList(c))
+	cmd.AddCommand(newSubCmdNotificationGet(c))
+	cmd.AddCommand(newSubCmdNotificationDelete(c))
+	cmd.AddCommand(newSubCmdNotificationDeliveries(c))
+
+	return cmd
+}
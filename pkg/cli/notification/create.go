@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fuseml/fuseml-core/gen/notification"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// createOptions holds the options for 'notification create' sub command
+type createOptions struct {
+	client.Clients
+	global      *common.GlobalOptions
+	Name        string
+	Description string
+	Type        string
+	Workflow    string
+	Project     string
+	Statuses    []string
+	URL         string
+	Secret      string
+	Recipients  []string
+	Template    string
+}
+
+func newCreateOptions(o *common.GlobalOptions) *createOptions {
+	return &createOptions{global: o}
+}
+
+// newSubCmdNotificationCreate creates and returns the cobra command for the `notification create` CLI command
+func newSubCmdNotificationCreate(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newCreateOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use: `create {-n|--name NAME} {-t|--type webhook|slack|email} [--url URL] [--secret SECRET] [--recipient EMAIL]... ` +
+			`[-w|--workflow WORKFLOW] [-p|--project PROJECT] [-s|--status STATUS]... [--template TEMPLATE] [-d|--desc DESCRIPTION]`,
+		Short: "Create a notification target.",
+		Long: `Register a target that is notified when workflow runs change state
+
+Notifications can be POSTed to a webhook, as JSON documents signed with the
+HMAC-SHA256 of the secret, posted to a Slack incoming webhook, or emailed to
+a list of recipients. For example, to notify a Slack channel when a run of
+the 'nightly' workflow fails:
+
+  fuseml notification create -n nightly-failures -t slack -w nightly -s Failed --url https://hooks.slack.com/services/T0000/B0000/XXXX
+
+The notification message can be customized with a Go text/template, using the
+Target, Workflow, Run, Status, Codeset, Project, URL, StartTime and
+CompletionTime fields. For example:
+
+  fuseml notification create -n team -t email --recipient ml-team@example.org --template '{{.Workflow}}: {{.Status}}'
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "notification target name")
+	cmd.Flags().StringVarP(&o.Description, "desc", "d", "", "notification target description")
+	cmd.Flags().StringVarP(&o.Type, "type", "t", "", "how notifications are delivered: webhook, slack or email")
+	cmd.Flags().StringVarP(&o.Workflow, "workflow", "w", "", "only notify about the runs of this workflow")
+	cmd.Flags().StringVarP(&o.Project, "project", "p", "", "only notify about the runs triggered by the codesets of this project")
+	cmd.Flags().StringSliceVarP(&o.Statuses, "status", "s", []string{}, "only notify about the runs that reach this status. One or more may be supplied")
+	cmd.Flags().StringVar(&o.URL, "url", "", "URL where webhook and slack notifications are POSTed")
+	cmd.Flags().StringVar(&o.Secret, "secret", "", "secret used to sign webhook notifications")
+	cmd.Flags().StringSliceVar(&o.Recipients, "recipient", []string{}, "recipient of email notifications. One or more may be supplied")
+	cmd.Flags().StringVar(&o.Template, "template", "", "template used to format the notification message")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("type")
+	return cmd
+}
+
+func (o *createOptions) validate() error {
+	return nil
+}
+
+func (o *createOptions) run() error {
+	request := &notification.NotificationTarget{
+		Name:        o.Name,
+		Description: util.RefString(o.Description),
+		Type:        o.Type,
+		Workflow:    util.RefString(o.Workflow),
+		Project:     util.RefString(o.Project),
+		Statuses:    o.Statuses,
+		URL:         util.RefString(o.URL),
+		Secret:      util.RefString(o.Secret),
+		Recipients:  o.Recipients,
+		Template:    util.RefString(o.Template),
+	}
+
+	_, err := o.NotificationClient.Register()(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Notification target %s successfully created\n", o.Name)
+
+	return nil
+}
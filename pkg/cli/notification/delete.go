@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	notificationc "github.com/fuseml/fuseml-core/gen/http/notification/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// deleteOptions holds the options for 'notification delete' sub command
+type deleteOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	Name   string
+}
+
+func newDeleteOptions(o *common.GlobalOptions) *deleteOptions {
+	return &deleteOptions{global: o}
+}
+
+// newSubCmdNotificationDelete creates and returns the cobra command for the `notification delete` CLI command
+func newSubCmdNotificationDelete(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newDeleteOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   `delete {-n|--name NAME}`,
+		Short: "Delete a notification target.",
+		Long:  `Delete a notification target and its delivery log`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "notification target name")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func (o *deleteOptions) validate() error {
+	return nil
+}
+
+func (o *deleteOptions) run() error {
+	request, err := notificationc.BuildDeletePayload(o.Name)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.NotificationClient.Delete()(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Notification target %s successfully deleted\n", o.Name)
+
+	return nil
+}
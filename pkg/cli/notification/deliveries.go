@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"context"
+	"os"
+
+	"github.com/fuseml/fuseml-core/gen/notification"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// deliveriesOptions holds the options for 'notification deliveries' sub command
+type deliveriesOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	format *common.FormattingOptions
+	Name   string
+}
+
+func newDeliveriesOptions(o *common.GlobalOptions) (res *deliveriesOptions) {
+	res = &deliveriesOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"ID", "Workflow", "Run", "Run Status:RunStatus", "Status", "Attempts", "Error", "Created"},
+		[]table.SortBy{{Name: "ID", Mode: table.DscNumeric}},
+		common.OutputFormatters{},
+	)
+
+	return
+}
+
+// newSubCmdNotificationDeliveries creates and returns the cobra command for the `notification deliveries` CLI command
+func newSubCmdNotificationDeliveries(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newDeliveriesOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   "deliveries {-n|--name NAME}",
+		Short: "List the notifications delivered to a target.",
+		Long:  `Show the log of notifications delivered to a notification target, including the failed deliveries`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "notification target name")
+	cmd.MarkFlagRequired("name")
+	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "created", "status")
+
+	return cmd
+}
+
+func (o *deliveriesOptions) validate() error {
+	return nil
+}
+
+func (o *deliveriesOptions) run() error {
+	request := &notification.DeliveriesPayload{Name: o.Name, Limit: util.RefInt(o.format.PageSize), Sort: util.RefString(o.format.Sort)}
+
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		request.Continue = util.RefString(cont)
+		response, err := o.NotificationClient.Deliveries()(context.Background(), request)
+		if err != nil {
+			return nil, "", err
+		}
+		res := response.(*notification.DeliveriesResult)
+		return res.Items, util.DerefString(res.Continue), nil
+	})
+}
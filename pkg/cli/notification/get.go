@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"os"
+
+	notificationc "github.com/fuseml/fuseml-core/gen/http/notification/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// getOptions holds the options for 'notification get' sub command
+type getOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	format *common.FormattingOptions
+	Name   string
+}
+
+func newGetOptions(o *common.GlobalOptions) *getOptions {
+	res := &getOptions{global: o}
+	res.format = common.NewSingleValueFormattingOptions()
+	return res
+}
+
+// newSubCmdNotificationGet creates and returns the cobra command for the `notification get` CLI command
+func newSubCmdNotificationGet(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newGetOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   `get {-n|--name NAME}`,
+		Short: "Get a notification target.",
+		Long:  `Show details about a notification target`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "notification target name")
+	o.format.AddSingleValueFormattingFlags(cmd, common.FormatYAML)
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func (o *getOptions) validate() error {
+	return nil
+}
+
+func (o *getOptions) run() error {
+	request, err := notificationc.BuildGetPayload(o.Name)
+	if err != nil {
+		return err
+	}
+
+	response, err := o.NotificationClient.Get()(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	o.format.FormatValue(os.Stdout, response)
+
+	return nil
+}
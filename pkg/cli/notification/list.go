@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"os"
+
+	"github.com/fuseml/fuseml-core/gen/notification"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// listOptions holds the options for 'notification list' sub command
+type listOptions struct {
+	client.Clients
+	global   *common.GlobalOptions
+	format   *common.FormattingOptions
+	Workflow string
+	Project  string
+}
+
+func newListOptions(o *common.GlobalOptions) (res *listOptions) {
+	res = &listOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"Name", "Type", "Workflow", "Project", "Statuses", "URL", "Recipients"},
+		[]table.SortBy{{Name: "Name", Mode: table.Asc}},
+		common.OutputFormatters{},
+	)
+
+	return
+}
+
+// newSubCmdNotificationList creates and returns the cobra command for the `notification list` CLI command
+func newSubCmdNotificationList(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newListOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   "list [-w|--workflow WORKFLOW] [-p|--project PROJECT]",
+		Short: "List notification targets.",
+		Long:  `Retrieve information about the notification targets registered in FuseML`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Workflow, "workflow", "w", "", "list only the notification targets scoped to given workflow")
+	cmd.Flags().StringVarP(&o.Project, "project", "p", "", "list only the notification targets scoped to given project")
+	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "name", "type", "created")
+
+	return cmd
+}
+
+func (o *listOptions) validate() error {
+	return nil
+}
+
+func (o *listOptions) run() error {
+	request := &notification.ListPayload{Workflow: util.RefString(o.Workflow), Project: util.RefString(o.Project),
+		Limit: util.RefInt(o.format.PageSize), Sort: util.RefString(o.format.Sort)}
+
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		request.Continue = util.RefString(cont)
+		response, err := o.NotificationClient.List()(context.Background(), request)
+		if err != nil {
+			return nil, "", err
+		}
+		res := response.(*notification.ListResult)
+		return res.Items, util.DerefString(res.Continue), nil
+	})
+}
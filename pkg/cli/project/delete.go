@@ -2,7 +2,10 @@ package project
 
 import (
 	"fmt"
+	"io"
+	"os"
 
+	"github.com/fuseml/fuseml-core/gen/project"
 	"github.com/fuseml/fuseml-core/pkg/cli/client"
 	"github.com/fuseml/fuseml-core/pkg/cli/common"
 	"github.com/spf13/cobra"
@@ -11,13 +14,18 @@ import (
 // DeleteOptions holds the options for 'project delete' sub command
 type DeleteOptions struct {
 	client.Clients
-	global *common.GlobalOptions
-	Name   string
+	global  *common.GlobalOptions
+	format  *common.FormattingOptions
+	Name    string
+	DryRun  bool
+	Cascade bool
 }
 
 // NewDeleteOptions creates a ProjectDeleteOptions struct
 func NewDeleteOptions(o *common.GlobalOptions) *DeleteOptions {
-	return &DeleteOptions{global: o}
+	res := &DeleteOptions{global: o}
+	res.format = common.NewSingleValueFormattingOptions()
+	return res
 }
 
 // NewSubCmdProjectDelete creates and returns the cobra command for the `project delete` CLI command
@@ -26,9 +34,11 @@ func NewSubCmdProjectDelete(gOpt *common.GlobalOptions) *cobra.Command {
 	o := NewDeleteOptions(gOpt)
 
 	cmd := &cobra.Command{
-		Use:   `delete {-n|--name NAME}`,
+		Use:   `delete {-n|--name NAME} [--cascade] [--dry-run]`,
 		Short: "Delete projects.",
-		Long:  `Delete a project from FuseML`,
+		Long: `Delete a project from FuseML. A project that still has codesets is only deleted with --cascade,
+together with its codesets, their git repositories, workflow assignments and the applications created
+by workflows assigned only to the project codesets`,
 		Run: func(cmd *cobra.Command, args []string) {
 			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
 			common.CheckErr(o.validate())
@@ -38,6 +48,9 @@ func NewSubCmdProjectDelete(gOpt *common.GlobalOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "project name")
+	cmd.Flags().BoolVar(&o.Cascade, "cascade", false, "also delete the project codesets, including their git repositories")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "only show the resources that would be deleted, or why the deletion would be refused")
+	o.format.AddSingleValueFormattingFlags(cmd, common.FormatYAML)
 	cmd.MarkFlagRequired("name")
 	return cmd
 }
@@ -47,7 +60,15 @@ func (o *DeleteOptions) validate() error {
 }
 
 func (o *DeleteOptions) run() error {
-	err := o.ProjectClient.Delete(o.Name)
+	if o.DryRun {
+		summary, err := o.ProjectClient.Summary(o.Name)
+		if err != nil {
+			return err
+		}
+		return o.showDeletion(os.Stdout, summary)
+	}
+
+	err := o.ProjectClient.Delete(o.Name, o.Cascade)
 	if err != nil {
 		return err
 	}
@@ -56,3 +77,19 @@ func (o *DeleteOptions) run() error {
 
 	return nil
 }
+
+// showDeletion shows the resources that deleting the project would remove. Like the deletion itself, it
+// fails if the project still has codesets and cascade is not set.
+func (o *DeleteOptions) showDeletion(out io.Writer, summary *project.ProjectSummary) error {
+	if len(summary.Codesets) > 0 && !o.Cascade {
+		return fmt.Errorf("deleting project %s would be refused: it still has %d codesets, delete them first "+
+			"or use --cascade", o.Name, len(summary.Codesets))
+	}
+	if len(summary.Codesets) == 0 {
+		fmt.Fprintf(out, "Deleting project %s would only remove the project:\n", o.Name)
+	} else {
+		fmt.Fprintf(out, "Deleting project %s would remove the following resources:\n", o.Name)
+	}
+	o.format.FormatValue(out, summary)
+	return nil
+}
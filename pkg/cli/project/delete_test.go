@@ -0,0 +1,58 @@
+package project
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fuseml/fuseml-core/gen/project"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+func TestShowDeletion(t *testing.T) {
+	codesetURL := "http://gitea/prj/cs1.git"
+	withCodesets := &project.ProjectSummary{
+		Project:  &project.Project{Name: "prj"},
+		Codesets: []*project.ProjectCodesetSummary{{Name: "cs1", URL: &codesetURL}},
+	}
+	empty := &project.ProjectSummary{Project: &project.Project{Name: "prj"}}
+
+	tests := []struct {
+		name    string
+		summary *project.ProjectSummary
+		cascade bool
+		want    string
+		wantErr string
+	}{
+		{"refused without cascade", withCodesets, false, "", "would be refused"},
+		{"cascade", withCodesets, true, "would remove the following resources", ""},
+		{"empty", empty, false, "would only remove the project", ""},
+		{"empty with cascade", empty, true, "would only remove the project", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &DeleteOptions{Name: "prj", Cascade: tt.cascade, format: common.NewSingleValueFormattingOptions()}
+			o.format.Format = common.FormatYAML
+			var out bytes.Buffer
+			err := o.showDeletion(&out, tt.summary)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v want %q", err, tt.wantErr)
+				}
+				if out.Len() != 0 {
+					t.Errorf("Unexpected output for a refused deletion: %s", out.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("got output %q want %q", out.String(), tt.want)
+			}
+			if len(tt.summary.Codesets) > 0 && !strings.Contains(out.String(), "cs1") {
+				t.Errorf("Codesets missing from the output: %s", out.String())
+			}
+		})
+	}
+}
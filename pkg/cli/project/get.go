@@ -11,9 +11,10 @@ import (
 // GetOptions holds the options for 'project get' sub command
 type GetOptions struct {
 	client.Clients
-	global *common.GlobalOptions
-	format *common.FormattingOptions
-	Name   string
+	global  *common.GlobalOptions
+	format  *common.FormattingOptions
+	Name    string
+	Summary bool
 }
 
 // NewGetOptions creates a ProjectGetOptions struct
@@ -29,7 +30,7 @@ func NewSubCmdProjectGet(gOpt *common.GlobalOptions) *cobra.Command {
 	o := NewGetOptions(gOpt)
 
 	cmd := &cobra.Command{
-		Use:   `get {-n|--name NAME}`,
+		Use:   `get {-n|--name NAME} [--summary]`,
 		Short: "Get projects.",
 		Long:  `Show details about a FuseML project`,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -41,6 +42,7 @@ func NewSubCmdProjectGet(gOpt *common.GlobalOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&o.Name, "name", "n", "", "project name")
+	cmd.Flags().BoolVar(&o.Summary, "summary", false, "show the codesets, assigned workflows, recent runs and applications that belong to the project")
 	o.format.AddSingleValueFormattingFlags(cmd, common.FormatYAML)
 	cmd.MarkFlagRequired("name")
 	return cmd
@@ -51,6 +53,15 @@ func (o *GetOptions) validate() error {
 }
 
 func (o *GetOptions) run() error {
+	if o.Summary {
+		summary, err := o.ProjectClient.Summary(o.Name)
+		if err != nil {
+			return err
+		}
+		o.format.FormatValue(os.Stdout, summary)
+		return nil
+	}
+
 	project, err := o.ProjectClient.Get(o.Name)
 	if err != nil {
 		return err
@@ -61,6 +61,7 @@ func NewSubCmdProjectList(gOpt *common.GlobalOptions) *cobra.Command {
 		Args: cobra.ExactArgs(0),
 	}
 	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "name")
 
 	return cmd
 }
@@ -70,12 +71,7 @@ func (o *ListOptions) validate() error {
 }
 
 func (o *ListOptions) run() error {
-	projects, err := o.ProjectClient.List()
-	if err != nil {
-		return err
-	}
-
-	o.format.FormatValue(os.Stdout, projects)
-
-	return nil
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		return o.ProjectClient.List(o.format.PageSize, cont, o.format.Sort)
+	})
 }
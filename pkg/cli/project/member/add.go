@@ -0,0 +1,60 @@
+package member
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type memberAddOptions struct {
+	client.Clients
+	global  *common.GlobalOptions
+	Project string
+	Email   string
+}
+
+func newMemberAddOptions(o *common.GlobalOptions) *memberAddOptions {
+	return &memberAddOptions{global: o}
+}
+
+func newSubCmdMemberAdd(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newMemberAddOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "add {-p|--project PROJECT} [--email EMAIL] {USER}",
+		Short: "Adds a member to a project",
+		Long: `Add a user to a FuseML project. A dedicated git account is created for the user if it does not exist,
+in which case the generated password is displayed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run(cmd.Flags().Arg(0)))
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVarP(&o.Project, "project", "p", "", "project name (filled by CurrentProject config value if present)")
+	cmd.Flags().StringVar(&o.Email, "email", "", "the email of the user")
+	cmd.MarkFlagRequired("project")
+	return cmd
+}
+
+func (o *memberAddOptions) validate() error {
+	return nil
+}
+
+func (o *memberAddOptions) run(user string) error {
+	credentials, err := o.ProjectClient.AddMember(o.Project, user, o.Email)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("User %s successfully added to project %s\n", credentials.Username, o.Project)
+	if credentials.Password != nil {
+		fmt.Printf("A new git account was created for %s with password: %s\n", credentials.Username, *credentials.Password)
+	}
+
+	return nil
+}
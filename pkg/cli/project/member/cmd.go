@@ -0,0 +1,23 @@
+package member
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+// NewSubCmdProjectMember creates and returns the cobra command that acts as a root for all other project member CLI sub-commands
+func NewSubCmdProjectMember(c *common.GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "member",
+		Short: "Project member management",
+		Long:  `Perform operations on project members and their git credentials`,
+	}
+
+	cmd.AddCommand(newSubCmdMemberAdd(c))
+	cmd.AddCommand(newSubCmdMemberRemove(c))
+	cmd.AddCommand(newSubCmdMemberList(c))
+	cmd.AddCommand(newSubCmdMemberRotate(c))
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+package member
+
+import (
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type memberListOptions struct {
+	client.Clients
+	global  *common.GlobalOptions
+	format  *common.FormattingOptions
+	Project string
+}
+
+func newMemberListOptions(o *common.GlobalOptions) (res *memberListOptions) {
+	res = &memberListOptions{global: o}
+	res.format = common.NewFormattingOptions(
+		[]string{"Name", "Email"},
+		[]table.SortBy{{Name: "Name", Mode: table.Asc}},
+		nil,
+	)
+	return
+}
+
+func newSubCmdMemberList(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newMemberListOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "list {-p|--project PROJECT}",
+		Short: "Lists the members of a project",
+		Long:  `Retrieve information about the users that are members of a FuseML project.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.Project, "project", "p", "", "project name (filled by CurrentProject config value if present)")
+	o.format.AddMultiValueFormattingFlags(cmd)
+	cmd.MarkFlagRequired("project")
+	return cmd
+}
+
+func (o *memberListOptions) validate() error {
+	return nil
+}
+
+func (o *memberListOptions) run() error {
+	members, err := o.ProjectClient.ListMembers(o.Project)
+	if err != nil {
+		return err
+	}
+
+	o.format.FormatValue(os.Stdout, members)
+
+	return nil
+}
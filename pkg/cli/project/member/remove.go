@@ -0,0 +1,55 @@
+package member
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type memberRemoveOptions struct {
+	client.Clients
+	global  *common.GlobalOptions
+	Project string
+}
+
+func newMemberRemoveOptions(o *common.GlobalOptions) *memberRemoveOptions {
+	return &memberRemoveOptions{global: o}
+}
+
+func newSubCmdMemberRemove(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newMemberRemoveOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "remove {-p|--project PROJECT} {USER}",
+		Short: "Removes a member from a project",
+		Long: `Remove a user from a FuseML project. The git account of the user is kept, only the project
+membership is removed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run(cmd.Flags().Arg(0)))
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVarP(&o.Project, "project", "p", "", "project name (filled by CurrentProject config value if present)")
+	cmd.MarkFlagRequired("project")
+	return cmd
+}
+
+func (o *memberRemoveOptions) validate() error {
+	return nil
+}
+
+func (o *memberRemoveOptions) run(user string) error {
+	err := o.ProjectClient.RemoveMember(o.Project, user)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("User %s successfully removed from project %s\n", user, o.Project)
+
+	return nil
+}
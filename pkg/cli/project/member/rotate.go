@@ -0,0 +1,69 @@
+package member
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type memberRotateOptions struct {
+	client.Clients
+	global  *common.GlobalOptions
+	Project string
+}
+
+func newMemberRotateOptions(o *common.GlobalOptions) *memberRotateOptions {
+	return &memberRotateOptions{global: o}
+}
+
+func newSubCmdMemberRotate(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newMemberRotateOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "rotate-credentials {-p|--project PROJECT} {USER}",
+		Short: "Rotates the git credentials of a project member",
+		Long: `Replace the git password of a project member with a newly generated one.
+If the user is the one saved in the CLI configuration file, the saved password is also updated.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run(cmd.Flags().Arg(0)))
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVarP(&o.Project, "project", "p", "", "project name (filled by CurrentProject config value if present)")
+	cmd.MarkFlagRequired("project")
+	return cmd
+}
+
+func (o *memberRotateOptions) validate() error {
+	return nil
+}
+
+func (o *memberRotateOptions) run(user string) error {
+	credentials, err := o.ProjectClient.RotateCredentials(o.Project, user)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Credentials for user %s successfully rotated\n", credentials.Username)
+	if credentials.Password == nil {
+		return nil
+	}
+	fmt.Printf("New password: %s\n", *credentials.Password)
+
+	if viper.GetString("Username") == credentials.Username {
+		fmt.Println("Saving new password into config file as current password.")
+		viper.Set("Password", *credentials.Password)
+		if err := common.WriteConfigFile(); err != nil {
+			return errors.Wrap(err, "Error writing config file")
+		}
+	}
+
+	return nil
+}
@@ -6,9 +6,10 @@ import (
 	"os"
 	"strings"
 
-	runnablec "github.com/fuseml/fuseml-core/gen/http/runnable/client"
+	"github.com/fuseml/fuseml-core/gen/runnable"
 	"github.com/fuseml/fuseml-core/pkg/cli/client"
 	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 )
@@ -68,6 +69,7 @@ func NewSubCmdRunnableList(gOpt *common.GlobalOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&o.Kind, "kind", "k", "", "kind value or regular expression used to filter runnables")
 	cmd.Flags().StringSliceVar(&o.Labels.Packed, "label", []string{}, "label value or regular expression used to filter runnables. One or more may be supplied.")
 	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "id", "kind", "created")
 
 	return cmd
 }
@@ -77,17 +79,16 @@ func (o *ListOptions) validate() error {
 }
 
 func (o *ListOptions) run() error {
-	request, err := runnablec.BuildListPayload(o.ID, o.Kind, o.Labels.Unpacked)
-	if err != nil {
-		return err
-	}
-
-	response, err := o.RunnableClient.List()(context.Background(), request)
-	if err != nil {
-		return err
-	}
+	request := &runnable.ListPayload{ID: util.RefString(o.ID), Kind: util.RefString(o.Kind), Labels: o.Labels.Unpacked,
+		Limit: util.RefInt(o.format.PageSize), Sort: util.RefString(o.format.Sort)}
 
-	o.format.FormatValue(os.Stdout, response)
-
-	return nil
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		request.Continue = util.RefString(cont)
+		response, err := o.RunnableClient.List()(context.Background(), request)
+		if err != nil {
+			return nil, "", err
+		}
+		res := response.(*runnable.ListResult)
+		return res.Items, util.DerefString(res.Continue), nil
+	})
 }
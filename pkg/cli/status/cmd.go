@@ -0,0 +1,84 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	statusc "github.com/fuseml/fuseml-core/gen/status"
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+	"github.com/fuseml/fuseml-core/pkg/util"
+)
+
+// statusOptions holds the options for the 'status' sub command
+type statusOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	format *common.FormattingOptions
+}
+
+// newStatusOptions initializes a statusOptions struct
+func newStatusOptions(gOpt *common.GlobalOptions) *statusOptions {
+	res := &statusOptions{global: gOpt}
+	res.format = common.NewFormattingOptions(
+		[]string{"Name", "Available", "Version", "Endpoint", "Error"},
+		nil,
+		common.OutputFormatters{},
+	)
+	return res
+}
+
+// NewCmdStatus creates and returns the cobra command for the `status` CLI command
+func NewCmdStatus(gOpt *common.GlobalOptions) *cobra.Command {
+
+	o := newStatusOptions(gOpt)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "display server status",
+		Long: `Display the configuration of the FuseML server and the connectivity and version of the services it depends on.
+Exits with an error if any of those services is not available.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	o.format.AddMultiValueFormattingFlags(cmd)
+	return cmd
+}
+
+func (o *statusOptions) run() error {
+	response, err := o.StatusClient.Get()(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("could not retrieve server status: %s", err.Error())
+	}
+	res := response.(*statusc.ServerStatus)
+
+	switch o.format.Format {
+	case common.FormatTable, common.FormatCSV:
+		fmt.Printf("Version:   %s\n", util.DerefString(res.Version.Version))
+		fmt.Printf("Namespace: %s\n", res.Namespace)
+		fmt.Printf("Dashboard: %s\n", util.DerefString(res.DashboardURL))
+		fmt.Printf("Ready:     %t\n\n", res.Ready)
+		o.format.FormatValue(os.Stdout, res.Dependencies)
+	default:
+		o.format.FormatValue(os.Stdout, res)
+	}
+
+	if !res.Ready {
+		unavailable := []string{}
+		for _, d := range res.Dependencies {
+			if !d.Available {
+				unavailable = append(unavailable, d.Name)
+			}
+		}
+		return fmt.Errorf("FuseML server is not ready, unavailable dependencies: %s", strings.Join(unavailable, ", "))
+	}
+	return nil
+}
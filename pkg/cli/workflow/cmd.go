@@ -21,6 +21,7 @@ func NewCmdWorkflow(c *common.GlobalOptions) *cobra.Command {
 	cmd.AddCommand(newSubCmdListAssignments(c))
 	cmd.AddCommand(newSubCmdListRuns(c))
 	cmd.AddCommand(newSubCmdUnassign(c))
+	cmd.AddCommand(newSubCmdRefresh(c))
 	cmd.AddCommand(newSubCmdDelete(c))
 
 	return cmd
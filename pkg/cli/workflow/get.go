@@ -110,7 +110,7 @@ func (o *getOptions) run() error {
 	}
 
 	if o.format.Format == common.FormatText {
-		wfRuns, err := o.WorkflowClient.ListRuns(o.name, "", "", "")
+		wfRuns, _, err := o.WorkflowClient.ListRuns(o.name, "", "", "", 0, "", "")
 		if err != nil {
 			return err
 		}
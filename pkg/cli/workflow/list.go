@@ -73,6 +73,7 @@ func newSubCmdList(gOpt *common.GlobalOptions) *cobra.Command {
 
 	cmd.Flags().StringVarP(&o.name, "name", "n", "", "filter workflows by name")
 	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "name", "created")
 
 	return cmd
 }
@@ -82,12 +83,7 @@ func (o *listOptions) validate() error {
 }
 
 func (o *listOptions) run() error {
-	wfs, err := o.WorkflowClient.List(o.name)
-	if err != nil {
-		return err
-	}
-
-	o.format.FormatValue(os.Stdout, wfs)
-
-	return nil
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		return o.WorkflowClient.List(o.name, o.format.PageSize, cont, o.format.Sort)
+	})
 }
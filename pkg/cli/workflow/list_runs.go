@@ -93,6 +93,7 @@ func newSubCmdListRuns(gOpt *common.GlobalOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&o.codesetName, "codeset-name", "c", "", "filter workflow runs by the codeset name")
 	cmd.Flags().StringVarP(&o.status, "status", "s", "", "filter workflow runs by the workflow run status")
 	o.format.AddMultiValueFormattingFlags(cmd)
+	o.format.AddPagingFlags(cmd, "started", "completed", "name", "workflow", "status")
 
 	return cmd
 }
@@ -102,12 +103,8 @@ func (o *listRunsOptions) validate() error {
 }
 
 func (o *listRunsOptions) run() error {
-	wfRuns, err := o.WorkflowClient.ListRuns(o.name, o.codesetProject, o.codesetName, o.status)
-	if err != nil {
-		return err
-	}
-
-	o.format.FormatValue(os.Stdout, wfRuns)
-
-	return nil
+	return o.format.FormatPages(os.Stdout, func(cont string) (interface{}, string, error) {
+		return o.WorkflowClient.ListRuns(o.name, o.codesetProject, o.codesetName, o.status,
+			o.format.PageSize, cont, o.format.Sort)
+	})
 }
@@ -0,0 +1,57 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fuseml/fuseml-core/pkg/cli/client"
+	"github.com/fuseml/fuseml-core/pkg/cli/common"
+)
+
+type refreshOptions struct {
+	client.Clients
+	global *common.GlobalOptions
+	name   string
+}
+
+func newRefreshOptions(o *common.GlobalOptions) (res *refreshOptions) {
+	return &refreshOptions{global: o}
+}
+
+func newSubCmdRefresh(gOpt *common.GlobalOptions) *cobra.Command {
+	o := newRefreshOptions(gOpt)
+	cmd := &cobra.Command{
+		Use:   "refresh {-n|--name NAME}",
+		Short: "Refreshes a workflow",
+		Long: `Resolve the extensions required by the workflow steps again and update the workflow with the current
+extension endpoints and credentials. Workflows are refreshed automatically when the extensions they use are
+updated, this command covers the cases when that is not enough, e.g. when a better extension was registered.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			common.CheckErr(o.InitializeClients(gOpt.URL, gOpt.Timeout, gOpt.Verbose))
+			common.CheckErr(o.validate())
+			common.CheckErr(o.run())
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cmd.Flags().StringVarP(&o.name, "name", "n", "", "name of the workflow to be refreshed")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func (o *refreshOptions) validate() error {
+	return nil
+}
+
+func (o *refreshOptions) run() error {
+	_, err := o.WorkflowClient.Refresh(o.name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Workflow %s successfully refreshed\n", o.name)
+
+	return nil
+}
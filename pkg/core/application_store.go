@@ -23,9 +23,10 @@ func (as *ApplicationStore) Find(ctx context.Context, name string) *domain.Appli
 	return as.items[name]
 }
 
-// GetAll returns all applications of a given type.
+// GetAll returns the page of applications of a given type selected by the list options.
 // If type is not specified, return all applications.
-func (as *ApplicationStore) GetAll(ctx context.Context, applicationType *string, applicationWorkflow *string) ([]*domain.Application, error) {
+func (as *ApplicationStore) GetAll(ctx context.Context, applicationType *string, applicationWorkflow *string,
+	opts *domain.ListOptions) ([]*domain.Application, string, error) {
 	result := make([]*domain.Application, 0, len(as.items))
 	for _, app := range as.items {
 		if applicationWorkflow != nil && app.Workflow != *applicationWorkflow {
@@ -36,12 +37,27 @@ func (as *ApplicationStore) GetAll(ctx context.Context, applicationType *string,
 		}
 		result = append(result, app)
 	}
-	return result, nil
+	next, err := domain.Paginate(&result, opts, domain.ApplicationSortFields, domain.ApplicationDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, next, nil
 }
 
 // Add adds a new application, based on the Application structure provided as argument
 func (as *ApplicationStore) Add(ctx context.Context, a *domain.Application) (*domain.Application, error) {
-	// TODO What if such application already exist? Should we thrown an error, or silently replace it?
+	if _, exists := as.items[a.Name]; exists {
+		return nil, domain.ErrApplicationExists
+	}
+	as.items[a.Name] = a
+	return a, nil
+}
+
+// Update replaces an existing application
+func (as *ApplicationStore) Update(ctx context.Context, a *domain.Application) (*domain.Application, error) {
+	if _, exists := as.items[a.Name]; !exists {
+		return nil, domain.ErrApplicationNotFound
+	}
 	as.items[a.Name] = a
 	return a, nil
 }
@@ -8,26 +8,20 @@ import (
 	"github.com/fuseml/fuseml-core/pkg/domain"
 )
 
-type codesetID struct {
-	name    string
-	project string
-}
-
 // GitCodesetStore describes a structure that accesses codeset store implemented in git
 type GitCodesetStore struct {
-	gitAdmin    domain.GitAdminClient
-	subscribers map[codesetID][]domain.CodesetSubscriber
+	gitAdmin domain.GitAdminClient
+	eventBus domain.EventBus
 }
 
 // NewGitCodesetStore returns codeset store instance
-func NewGitCodesetStore(gitAdmin domain.GitAdminClient) *GitCodesetStore {
-	subscribers := make(map[codesetID][]domain.CodesetSubscriber)
-	return &GitCodesetStore{gitAdmin, subscribers}
+func NewGitCodesetStore(gitAdmin domain.GitAdminClient, eventBus domain.EventBus) *GitCodesetStore {
+	return &GitCodesetStore{gitAdmin, eventBus}
 }
 
 // Find returns a codeset identified by project and name
 func (cs *GitCodesetStore) Find(ctx context.Context, project, name string) (*domain.Codeset, error) {
-	result, err := cs.gitAdmin.GetRepository(project, name)
+	result, err := cs.gitAdmin.GetRepository(ctx, project, name)
 	if err != nil {
 		return nil, errors.Wrap(err, "Fetching Codeset failed")
 	}
@@ -40,82 +34,63 @@ func (cs *GitCodesetStore) Delete(ctx context.Context, project, name string) err
 	if err != nil {
 		return nil
 	}
-	// notify codeset subscribers about a codeset being deleted
-	for _, subscriber := range cs.subscribers[codesetID{name, project}] {
-		subscriber.OnDeletingCodeset(ctx, codeset)
-	}
-	err = cs.gitAdmin.DeleteRepository(project, name)
+	// notify subscribers about a codeset being deleted, while it can still be accessed
+	cs.eventBus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.CodesetResource, Object: codeset})
+	err = cs.gitAdmin.DeleteRepository(ctx, project, name)
 	// TODO should we delete the project+user too? If it does not contain any repos?
 	if err != nil {
 		return errors.Wrap(err, "Deleting Codeset failed")
 	}
-	// upon a codeset deletion all subscribers associated to that codeset also needs to be removed
-	cs.deleteSubscribers(codeset)
 	return nil
 }
 
-// GetAll returns all codesets matching given project and label
-func (cs *GitCodesetStore) GetAll(ctx context.Context, project, label *string) ([]*domain.Codeset, error) {
-	result, err := cs.gitAdmin.GetRepositories(project, label)
+// GetAll returns the page of codesets matching given project and label selected by the list options
+func (cs *GitCodesetStore) GetAll(ctx context.Context, project, label *string, opts *domain.ListOptions) ([]*domain.Codeset, string, error) {
+	result, next, err := cs.gitAdmin.GetRepositories(ctx, project, label, opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "Fetching Codesets failed")
+		return nil, "", errors.Wrap(err, "Fetching Codesets failed")
 	}
-	return result, nil
+	return result, next, nil
 }
 
 // CreateWebhook adds a new webhook to a codeset
 func (cs *GitCodesetStore) CreateWebhook(ctx context.Context, c *domain.Codeset, listenerURL string) (*int64, error) {
-	hookID, err := cs.gitAdmin.CreateRepoWebhook(c.Project, c.Name, &listenerURL)
+	hookID, err := cs.gitAdmin.CreateRepoWebhook(ctx, c.Project, c.Name, &listenerURL)
 	if err != nil {
 		return nil, errors.Wrap(err, "Creating webhook failed")
 	}
 	return hookID, nil
 }
 
+// GetWebhooks returns the webhooks of a codeset
+func (cs *GitCodesetStore) GetWebhooks(ctx context.Context, c *domain.Codeset) ([]*domain.Webhook, error) {
+	webhooks, err := cs.gitAdmin.GetRepoWebhooks(ctx, c.Project, c.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "Fetching webhooks failed")
+	}
+	return webhooks, nil
+}
+
 // DeleteWebhook deletes a webhook from a codeset
 func (cs *GitCodesetStore) DeleteWebhook(ctx context.Context, c *domain.Codeset, hookID *int64) error {
-	err := cs.gitAdmin.DeleteRepoWebhook(c.Project, c.Name, hookID)
+	err := cs.gitAdmin.DeleteRepoWebhook(ctx, c.Project, c.Name, hookID)
 	if err != nil {
 		return errors.Wrap(err, "Deleting webhook failed")
 	}
 	return nil
 }
 
-// Add creates new codeset
+// Add creates new codeset, or updates the labels of an existing one
 func (cs *GitCodesetStore) Add(ctx context.Context, c *domain.Codeset) (*domain.Codeset, *string, *string, error) {
-	username, password, err := cs.gitAdmin.PrepareRepository(c, nil)
+	eventType := domain.EventCreated
+	if _, err := cs.gitAdmin.GetRepository(ctx, c.Project, c.Name); err == nil {
+		eventType = domain.EventUpdated
+	}
+	username, password, err := cs.gitAdmin.PrepareRepository(ctx, c, nil)
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "Preparing Repository failed")
 	}
 	// Code itself needs to be pushed from client, here we could do some additional registration
+	cs.eventBus.Publish(ctx, &domain.Event{Type: eventType, Kind: domain.CodesetResource, Object: c})
 	return c, username, password, nil
 }
-
-// Subscribe adds a subscriber interested on operations performed on a specific codeset
-func (cs *GitCodesetStore) Subscribe(ctx context.Context, subscriber domain.CodesetSubscriber, codeset *domain.Codeset) error {
-	if _, err := cs.Find(ctx, codeset.Project, codeset.Name); err != nil {
-		return err
-	}
-	cs.subscribers[codesetID{codeset.Name, codeset.Project}] = append(cs.subscribers[codesetID{codeset.Name, codeset.Project}], subscriber)
-	return nil
-}
-
-// Unsubscribe deletes a specific codeset subscriber
-func (cs *GitCodesetStore) Unsubscribe(ctx context.Context, subscriber domain.CodesetSubscriber, codeset *domain.Codeset) error {
-	cs.subscribers[codesetID{codeset.Name, codeset.Project}] = removeSubscriber(cs.subscribers[codesetID{codeset.Name, codeset.Project}], subscriber)
-	return nil
-}
-
-func (cs *GitCodesetStore) deleteSubscribers(codeset *domain.Codeset) {
-	delete(cs.subscribers, codesetID{codeset.Name, codeset.Project})
-}
-
-func removeSubscriber(subscribers []domain.CodesetSubscriber, subscriber domain.CodesetSubscriber) []domain.CodesetSubscriber {
-	for i, s := range subscribers {
-		if s == subscriber {
-			subscribers[len(subscribers)-1], subscribers[i] = subscribers[i], subscribers[len(subscribers)-1]
-			return subscribers[:len(subscribers)-1]
-		}
-	}
-	return subscribers
-}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// builtinCodesetTemplates lists the codeset templates shipped with FuseML. The files for each template
+// are loaded from the templates/<name> directory.
+var builtinCodesetTemplates = []domain.CodesetTemplate{
+	{
+		Name:        "mlflow",
+		Description: "MLflow project with a conda environment, a Dockerfile and a scikit-learn training entrypoint",
+		Codeset: domain.RunnableCodesetArtifact{
+			Type:     []string{"code", "configuration"},
+			Function: []string{"model-definition", "model-training"},
+			Format:   []string{"MLProject", "conda", "Dockerfile"},
+			Requirements: map[string]string{
+				"mlflow":       ">=1.15",
+				"scikit-learn": "0.24.2",
+			},
+		},
+	},
+}
+
+// CodesetTemplateStore describes an in-memory store for codeset templates
+type CodesetTemplateStore struct {
+	items map[string]*domain.CodesetTemplate
+}
+
+// NewCodesetTemplateStore returns a codeset template store populated with the built-in templates
+func NewCodesetTemplateStore() (*CodesetTemplateStore, error) {
+	store := &CodesetTemplateStore{
+		items: make(map[string]*domain.CodesetTemplate),
+	}
+	for i := range builtinCodesetTemplates {
+		t := builtinCodesetTemplates[i]
+		files, err := loadTemplateFiles(path.Join("templates", t.Name))
+		if err != nil {
+			return nil, err
+		}
+		t.Files = files
+		store.items[t.Name] = &t
+	}
+	return store, nil
+}
+
+// Find returns the codeset template with the given name
+func (s *CodesetTemplateStore) Find(ctx context.Context, name string) (*domain.CodesetTemplate, error) {
+	if t, ok := s.items[name]; ok {
+		return t, nil
+	}
+	return nil, domain.ErrCodesetTemplateNotFound
+}
+
+// GetAll returns all codeset templates matching the codeset artifact descriptor, sorted by name
+func (s *CodesetTemplateStore) GetAll(ctx context.Context, codeset *domain.RunnableCodesetArtifact) ([]*domain.CodesetTemplate, error) {
+	result := make([]*domain.CodesetTemplate, 0, len(s.items))
+	for _, t := range s.items {
+		if t.Matches(codeset) {
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func loadTemplateFiles(root string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := fs.WalkDir(templateFS, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := templateFS.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[p[len(root)+1:]] = string(content)
+		return nil
+	})
+	return files, err
+}
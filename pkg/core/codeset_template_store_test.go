@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func TestCodesetTemplateStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewCodesetTemplateStore()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("find", func(t *testing.T) {
+		tmpl, err := store.Find(ctx, "mlflow")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, f := range []string{"MLproject", "conda.yaml", "Dockerfile", "train.py"} {
+			if _, ok := tmpl.Files[f]; !ok {
+				t.Errorf("Template file %s missing", f)
+			}
+		}
+
+		_, err = store.Find(ctx, "missing")
+		if err != domain.ErrCodesetTemplateNotFound {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("get all", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			codeset *domain.RunnableCodesetArtifact
+			want    int
+		}{
+			{"no query", nil, len(builtinCodesetTemplates)},
+			{"matching format", &domain.RunnableCodesetArtifact{Format: []string{"mlproject"}}, 1},
+			{"matching function", &domain.RunnableCodesetArtifact{Type: []string{"code"}, Function: []string{"model-training", "model-prediction"}}, 1},
+			{"unmatched function", &domain.RunnableCodesetArtifact{Function: []string{"data-labeling"}}, 0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := store.GetAll(ctx, tt.codeset)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if len(got) != tt.want {
+					t.Errorf("Expected %d templates, got %d", tt.want, len(got))
+				}
+			})
+		}
+	})
+}
@@ -8,8 +8,6 @@ const (
 var (
 	// DefaultUserNamePrefix is the default prefix for user names created for each project
 	DefaultUserNamePrefix = "fuseml"
-	// DefaultUserPassword is the default password used when creating new per-project users
-	DefaultUserPassword = "changeme"
 	// DefaultUserEmailDomain is the default domain for user email
 	DefaultUserEmailDomain = "@fuseml.org"
 
@@ -0,0 +1,310 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/fuseml/fuseml-core/pkg/logging"
+)
+
+// EnvPrefix prefixes the environment variables overriding the server configuration. The variable name is
+// built from the configuration key, in upper case, with dots and dashes replaced by underscores (e.g.
+// FUSEML_TEKTON_WORKSPACE_SIZE overrides tekton.workspace.size).
+const EnvPrefix = "FUSEML"
+
+// legacyEnv maps the configuration keys that were configured through environment variables before the
+// configuration file was introduced to those variables, which are still used to override them.
+var legacyEnv = map[string]string{
+	"gitea.url":                   "GITEA_URL",
+	"gitea.admin-username":        "GITEA_ADMIN_USERNAME",
+	"gitea.admin-password":        "GITEA_ADMIN_PASSWORD",
+	"tekton.dashboard-url":        "TEKTON_DASHBOARD_URL",
+	"notifications.smtp-username": "FUSEML_SMTP_USERNAME",
+	"notifications.smtp-password": "FUSEML_SMTP_PASSWORD",
+}
+
+// Server is the configuration of the FuseML core server
+type Server struct {
+	// Namespace is the kubernetes namespace where FuseML workloads are created.
+	Namespace      string         `mapstructure:"namespace"`
+	Storage        Storage        `mapstructure:"storage"`
+	Gitea          Gitea          `mapstructure:"gitea"`
+	Tekton         Tekton         `mapstructure:"tekton"`
+	Workflows      Workflows      `mapstructure:"workflows"`
+	Log            Log            `mapstructure:"log"`
+	Tracing        Tracing        `mapstructure:"tracing"`
+	Extensions     Extensions     `mapstructure:"extensions"`
+	Notifications  Notifications  `mapstructure:"notifications"`
+	LeaderElection LeaderElection `mapstructure:"leader-election"`
+}
+
+// Storage backends that can hold the workflows, extensions, applications and runnables
+const (
+	StorageBadger   = "badger"
+	StoragePostgres = "postgres"
+)
+
+// Storage configures where FuseML keeps its data
+type Storage struct {
+	// Dir is the directory holding the badger database, which keeps the data that is not held by the
+	// storage backend.
+	Dir string `mapstructure:"dir"`
+	// Backend is the storage backend holding the workflows, extensions, applications and runnables.
+	Backend string `mapstructure:"backend"`
+	// DatabaseURL is the connection URL of the PostgreSQL database used by the postgres backend.
+	DatabaseURL string `mapstructure:"database-url"`
+}
+
+// Gitea configures the connection to the gitea server hosting the codesets
+type Gitea struct {
+	URL           string `mapstructure:"url"`
+	AdminUsername string `mapstructure:"admin-username"`
+	AdminPassword string `mapstructure:"admin-password"`
+}
+
+// Tekton configures how workflows are run by Tekton
+type Tekton struct {
+	// DashboardURL is the URL of the Tekton dashboard used to follow the workflow runs.
+	DashboardURL string `mapstructure:"dashboard-url"`
+	// Namespace is the kubernetes namespace where Tekton is installed.
+	Namespace string `mapstructure:"namespace"`
+	// ServiceAccount is the service account the workflow runs are executed with.
+	ServiceAccount string `mapstructure:"service-account"`
+	// TriggersServiceAccount is the service account of the event listeners that start the workflow runs.
+	TriggersServiceAccount string    `mapstructure:"triggers-service-account"`
+	Workspace              Workspace `mapstructure:"workspace"`
+	// Registries rewrites the images used by the workflow steps, for the registries that are known under a
+	// different address by the kubernetes nodes.
+	Registries []RegistryMapping `mapstructure:"registries"`
+}
+
+// Workspace configures the volumes holding the codesets of the workflow runs
+type Workspace struct {
+	Size string `mapstructure:"size"`
+	// StorageClass is the storage class of the volumes. The default storage class is used if empty.
+	StorageClass string `mapstructure:"storage-class"`
+	AccessMode   string `mapstructure:"access-mode"`
+}
+
+// RegistryMapping replaces the From registry prefix with To in the workflow step images
+type RegistryMapping struct {
+	From string `mapstructure:"from"`
+	To   string `mapstructure:"to"`
+}
+
+// Workflows configures the workflow manager
+type Workflows struct {
+	// ListenerTimeout is the time that FuseML waits for a workflow listener to become available.
+	ListenerTimeout time.Duration `mapstructure:"listener-timeout"`
+	// ReconcileInterval is the interval between the reconciliations of the workflow resources with the state
+	// stored for the workflows. Zero disables the periodic reconciliation, which still runs at startup.
+	ReconcileInterval time.Duration `mapstructure:"reconcile-interval"`
+}
+
+// Log configures the server logs
+type Log struct {
+	Level  string `mapstructure:"level"`
+	Format string `mapstructure:"format"`
+}
+
+// Tracing configures the export of traces
+type Tracing struct {
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample-ratio"`
+}
+
+// Extensions configures the health checking and discovery of extensions
+type Extensions struct {
+	// HealthInterval is the interval between extension endpoint health checks. Zero disables health checking.
+	HealthInterval time.Duration `mapstructure:"health-interval"`
+	Discovery      bool          `mapstructure:"discovery"`
+	// DiscoveryNamespace is the namespace watched for extensions. All namespaces are watched if empty.
+	DiscoveryNamespace string `mapstructure:"discovery-namespace"`
+}
+
+// Notifications configures the delivery of email notifications
+type Notifications struct {
+	SMTPServer   string `mapstructure:"smtp-server"`
+	SMTPFrom     string `mapstructure:"smtp-from"`
+	SMTPUsername string `mapstructure:"smtp-username"`
+	SMTPPassword string `mapstructure:"smtp-password"`
+}
+
+// LeaderElection configures the election of the server running the background work that must only be done
+// once when several servers share the storage backend
+type LeaderElection struct {
+	Enabled bool `mapstructure:"enabled"`
+	// LeaseName and LeaseNamespace identify the kubernetes Lease held by the leader.
+	LeaseName      string `mapstructure:"lease-name"`
+	LeaseNamespace string `mapstructure:"lease-namespace"`
+	// Identity identifies the server in the Lease. The host name, which is the pod name, is used if empty.
+	Identity string `mapstructure:"identity"`
+	// LeaseDuration is the time that the other servers wait before taking over the Lease of a leader that
+	// stopped renewing it.
+	LeaseDuration time.Duration `mapstructure:"lease-duration"`
+	// RenewDeadline is the time that the leader keeps trying to renew the Lease before giving up leadership.
+	RenewDeadline time.Duration `mapstructure:"renew-deadline"`
+	// RetryPeriod is the interval between attempts to acquire or renew the Lease.
+	RetryPeriod time.Duration `mapstructure:"retry-period"`
+}
+
+// Default returns the default server configuration. Gitea and the Tekton dashboard have no default address.
+func Default() *Server {
+	return &Server{
+		Namespace: FuseMLNamespace,
+		Storage:   Storage{Dir: "./data", Backend: StorageBadger},
+		Tekton: Tekton{
+			Namespace:              "tekton-pipelines",
+			ServiceAccount:         "fuseml-workloads",
+			TriggersServiceAccount: "tekton-triggers",
+			Workspace:              Workspace{Size: "2Gi", AccessMode: "ReadWriteOnce"},
+			// the kubernetes nodes are unable to resolve the local FuseML registry
+			Registries: []RegistryMapping{{From: "registry.fuseml-registry", To: "127.0.0.1:30500"}},
+		},
+		Workflows:     Workflows{ListenerTimeout: time.Minute, ReconcileInterval: 10 * time.Minute},
+		Log:           Log{Level: "info", Format: logging.FormatText},
+		Tracing:       Tracing{SampleRatio: 1},
+		Extensions:    Extensions{HealthInterval: time.Minute},
+		Notifications: Notifications{SMTPFrom: "fuseml@localhost"},
+		LeaderElection: LeaderElection{
+			LeaseName:      "fuseml-core",
+			LeaseNamespace: "fuseml-core",
+			LeaseDuration:  15 * time.Second,
+			RenewDeadline:  10 * time.Second,
+			RetryPeriod:    2 * time.Second,
+		},
+	}
+}
+
+// Load returns the server configuration, starting from the default configuration and applying, in order,
+// the configuration file, if a path is given, the environment variables and the overrides, indexed by
+// configuration key (e.g. log.level). The configuration is validated before being returned.
+func Load(path string, overrides map[string]interface{}) (*Server, error) {
+	cfg := Default()
+
+	v := viper.New()
+	setDefaults(v, "", reflect.ValueOf(cfg).Elem())
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+	for key, env := range legacyEnv {
+		if err := v.BindEnv(key, env); err != nil {
+			return nil, err
+		}
+	}
+
+	if path != "" {
+		v.SetConfigFile(path)
+		v.SetConfigType("yaml")
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading configuration file %q: %w", path, err)
+		}
+	}
+	for key, value := range overrides {
+		v.Set(key, value)
+	}
+
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("error decoding configuration: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// setDefaults registers the values of the configuration fields as defaults, under their dot-separated keys,
+// which also makes them known to viper, so that they can be overridden by environment variables
+func setDefaults(v *viper.Viper, prefix string, value reflect.Value) {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := prefix + t.Field(i).Tag.Get("mapstructure")
+		if f := value.Field(i); f.Kind() == reflect.Struct {
+			setDefaults(v, key+".", f)
+		} else {
+			v.SetDefault(key, f.Interface())
+		}
+	}
+}
+
+// Validate checks that the configuration is complete and consistent, and returns an error describing all
+// the problems found otherwise.
+func (s *Server) Validate() error {
+	problems := []string{}
+	check := func(ok bool, format string, a ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, a...))
+		}
+	}
+	checkURL := func(key, value string) {
+		u, err := url.Parse(value)
+		check(value != "", "%s is required", key)
+		check(value == "" || (err == nil && u.Scheme != "" && u.Host != ""), "%s %q is not a valid URL", key, value)
+	}
+
+	check(len(validation.IsDNS1123Label(s.Namespace)) == 0, "namespace %q is not a valid namespace name", s.Namespace)
+	check(s.Storage.Dir != "", "storage.dir is required")
+	switch s.Storage.Backend {
+	case StorageBadger:
+	case StoragePostgres:
+		check(s.Storage.DatabaseURL != "", "storage.database-url is required by the %s backend", StoragePostgres)
+	default:
+		check(false, "storage.backend %q is not valid (valid values: %s, %s)", s.Storage.Backend, StorageBadger, StoragePostgres)
+	}
+
+	checkURL("gitea.url", s.Gitea.URL)
+	check(s.Gitea.AdminUsername != "", "gitea.admin-username is required")
+	check(s.Gitea.AdminPassword != "", "gitea.admin-password is required")
+
+	checkURL("tekton.dashboard-url", s.Tekton.DashboardURL)
+	check(len(validation.IsDNS1123Label(s.Tekton.Namespace)) == 0, "tekton.namespace %q is not a valid namespace name",
+		s.Tekton.Namespace)
+	check(s.Tekton.ServiceAccount != "", "tekton.service-account is required")
+	check(s.Tekton.TriggersServiceAccount != "", "tekton.triggers-service-account is required")
+	_, err := resource.ParseQuantity(s.Tekton.Workspace.Size)
+	check(err == nil, "tekton.workspace.size %q is not a valid quantity", s.Tekton.Workspace.Size)
+	switch s.Tekton.Workspace.AccessMode {
+	case "ReadWriteOnce", "ReadOnlyMany", "ReadWriteMany":
+	default:
+		check(false, "tekton.workspace.access-mode %q is not valid (valid values: ReadWriteOnce, ReadOnlyMany, ReadWriteMany)",
+			s.Tekton.Workspace.AccessMode)
+	}
+	for i, r := range s.Tekton.Registries {
+		check(r.From != "" && r.To != "", "tekton.registries[%d] requires both from and to", i)
+	}
+
+	check(s.Workflows.ListenerTimeout > 0, "workflows.listener-timeout must be positive")
+	check(s.Workflows.ReconcileInterval >= 0, "workflows.reconcile-interval must not be negative")
+
+	_, err = logging.New(io.Discard, &logging.Config{Level: s.Log.Level, Format: s.Log.Format})
+	check(err == nil, "log: %v", err)
+	check(s.Tracing.SampleRatio >= 0 && s.Tracing.SampleRatio <= 1, "tracing.sample-ratio must be between 0 and 1")
+	check(s.Extensions.HealthInterval >= 0, "extensions.health-interval must not be negative")
+
+	if le := s.LeaderElection; le.Enabled {
+		// the servers taking part in the election share the data, which the badger database cannot do
+		check(s.Storage.Backend == StoragePostgres, "leader-election requires the %s storage backend", StoragePostgres)
+		check(len(validation.IsDNS1123Subdomain(le.LeaseName)) == 0, "leader-election.lease-name %q is not a valid name",
+			le.LeaseName)
+		check(len(validation.IsDNS1123Label(le.LeaseNamespace)) == 0,
+			"leader-election.lease-namespace %q is not a valid namespace name", le.LeaseNamespace)
+		check(le.RetryPeriod > 0, "leader-election.retry-period must be positive")
+		// the retry period is jittered by up to 20%
+		check(le.RenewDeadline > le.RetryPeriod*6/5, "leader-election.renew-deadline must be longer than 1.2 times the retry period")
+		check(le.LeaseDuration > le.RenewDeadline, "leader-election.lease-duration must be longer than the renew deadline")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
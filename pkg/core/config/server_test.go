@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfig = `
+namespace: fuseml-test
+gitea:
+  url: http://gitea.test
+  admin-username: admin
+  admin-password: password
+tekton:
+  dashboard-url: http://tekton.test
+  workspace:
+    size: 5Gi
+    storage-class: fast
+  registries:
+  - from: registry.test
+    to: 127.0.0.1:5000
+log:
+  level: debug
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write configuration file: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		cfg, err := Load(writeConfig(t, testConfig), nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Namespace != "fuseml-test" || cfg.Gitea.URL != "http://gitea.test" || cfg.Log.Level != "debug" {
+			t.Errorf("Unexpected configuration: %+v", cfg)
+		}
+		if cfg.Tekton.Workspace.Size != "5Gi" || cfg.Tekton.Workspace.StorageClass != "fast" ||
+			cfg.Tekton.Workspace.AccessMode != "ReadWriteOnce" {
+			t.Errorf("Unexpected workspace configuration: %+v", cfg.Tekton.Workspace)
+		}
+		if len(cfg.Tekton.Registries) != 1 || cfg.Tekton.Registries[0].To != "127.0.0.1:5000" {
+			t.Errorf("Unexpected registries: %+v", cfg.Tekton.Registries)
+		}
+		if cfg.Workflows.ListenerTimeout != time.Minute || cfg.Storage.Dir != "./data" {
+			t.Errorf("Defaults not applied: %+v", cfg)
+		}
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("FUSEML_TEKTON_WORKSPACE_SIZE", "10Gi")
+		t.Setenv("FUSEML_WORKFLOWS_LISTENER_TIMEOUT", "30s")
+		t.Setenv("GITEA_URL", "http://gitea.env")
+		cfg, err := Load(writeConfig(t, testConfig), nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Tekton.Workspace.Size != "10Gi" || cfg.Workflows.ListenerTimeout != 30*time.Second ||
+			cfg.Gitea.URL != "http://gitea.env" {
+			t.Errorf("Environment overrides not applied: %+v", cfg)
+		}
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		t.Setenv("FUSEML_NAMESPACE", "fuseml-env")
+		cfg, err := Load(writeConfig(t, testConfig), map[string]interface{}{"namespace": "fuseml-flag", "log.format": "json"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.Namespace != "fuseml-flag" || cfg.Log.Format != "json" {
+			t.Errorf("Overrides not applied: %+v", cfg)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), nil); err == nil {
+			t.Errorf("Expected an error for a missing configuration file")
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Server {
+		cfg := Default()
+		cfg.Gitea = Gitea{URL: "http://gitea.test", AdminUsername: "admin", AdminPassword: "password"}
+		cfg.Tekton.DashboardURL = "http://tekton.test"
+		return cfg
+	}
+
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("Unexpected error validating the default configuration: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Server)
+		want   []string
+	}{
+		{"defaults", func(s *Server) { *s = *Default() }, []string{"gitea.url is required", "tekton.dashboard-url is required"}},
+		{"namespace", func(s *Server) { s.Namespace = "FuseML" }, []string{`namespace "FuseML"`}},
+		{"url", func(s *Server) { s.Gitea.URL = "gitea" }, []string{`gitea.url "gitea" is not a valid URL`}},
+		{"workspace", func(s *Server) {
+			s.Tekton.Workspace = Workspace{Size: "big", AccessMode: "ReadWrite"}
+		}, []string{"tekton.workspace.size", "tekton.workspace.access-mode"}},
+		{"registries", func(s *Server) {
+			s.Tekton.Registries = append(s.Tekton.Registries, RegistryMapping{From: "registry.test"})
+		}, []string{"tekton.registries[1]"}},
+		{"storage backend", func(s *Server) { s.Storage.Backend = "mysql" }, []string{`storage.backend "mysql"`}},
+		{"database url", func(s *Server) { s.Storage.Backend = StoragePostgres }, []string{"storage.database-url is required"}},
+		{"leader election backend", func(s *Server) { s.LeaderElection.Enabled = true }, []string{"leader-election requires"}},
+		{"leader election", func(s *Server) {
+			s.Storage = Storage{Dir: "./data", Backend: StoragePostgres, DatabaseURL: "postgres://postgres.test/fuseml"}
+			s.LeaderElection = LeaderElection{Enabled: true, LeaseName: "FuseML", LeaseNamespace: "fuseml-core",
+				LeaseDuration: time.Second, RenewDeadline: time.Second, RetryPeriod: time.Second}
+		}, []string{"leader-election.lease-name", "leader-election.renew-deadline", "leader-election.lease-duration"}},
+		{"timeout", func(s *Server) {
+			s.Workflows = Workflows{ListenerTimeout: 0, ReconcileInterval: -time.Minute}
+		}, []string{"workflows.listener-timeout", "workflows.reconcile-interval"}},
+		{"log", func(s *Server) { s.Log.Level = "verbose" }, []string{"log:"}},
+		{"tracing", func(s *Server) { s.Tracing.SampleRatio = 2 }, []string{"tracing.sample-ratio"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if err == nil {
+				t.Fatalf("Expected a validation error")
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("Error %q does not mention %q", err, w)
+				}
+			}
+		})
+	}
+}
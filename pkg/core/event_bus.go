@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+type eventSubscription struct {
+	subscriber domain.EventSubscriber
+	kinds      map[domain.ResourceKind]bool
+}
+
+func (s *eventSubscription) matches(event *domain.Event) bool {
+	return len(s.kinds) == 0 || s.kinds[event.Kind]
+}
+
+// EventBus is an in-memory, synchronous event bus. Subscribers are called in the order in
+// which they subscribed, from the goroutine that publishes the event. Events do not leave the
+// server: when several replicas share the storage, each one only sees the changes made through it.
+type EventBus struct {
+	sync.RWMutex
+	subscriptions []*eventSubscription
+}
+
+// NewEventBus returns an event bus instance
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe adds a subscriber interested in the events published for the given resource kinds.
+// Subscribing an existing subscriber replaces the resource kinds it is subscribed to.
+func (b *EventBus) Subscribe(subscriber domain.EventSubscriber, kinds ...domain.ResourceKind) {
+	b.Lock()
+	defer b.Unlock()
+
+	subscription := &eventSubscription{subscriber, make(map[domain.ResourceKind]bool)}
+	for _, k := range kinds {
+		subscription.kinds[k] = true
+	}
+	for i, s := range b.subscriptions {
+		if s.subscriber == subscriber {
+			b.subscriptions[i] = subscription
+			return
+		}
+	}
+	b.subscriptions = append(b.subscriptions, subscription)
+}
+
+// Unsubscribe removes a subscriber
+func (b *EventBus) Unsubscribe(subscriber domain.EventSubscriber) {
+	b.Lock()
+	defer b.Unlock()
+
+	for i, s := range b.subscriptions {
+		if s.subscriber == subscriber {
+			b.subscriptions = append(b.subscriptions[:i], b.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish delivers an event to the interested subscribers and waits for all of them to process it
+func (b *EventBus) Publish(ctx context.Context, event *domain.Event) {
+	// subscribers may publish events or (un)subscribe themselves while handling an event,
+	// so they are called without holding the lock
+	b.RLock()
+	subscriptions := make([]*eventSubscription, len(b.subscriptions))
+	copy(subscriptions, b.subscriptions)
+	b.RUnlock()
+
+	for _, s := range subscriptions {
+		if s.matches(event) {
+			s.subscriber.OnEvent(ctx, event)
+		}
+	}
+}
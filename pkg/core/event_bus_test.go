@@ -0,0 +1,48 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+type recordingSubscriber struct {
+	events []*domain.Event
+}
+
+func (s *recordingSubscriber) OnEvent(ctx context.Context, event *domain.Event) {
+	s.events = append(s.events, event)
+}
+
+func TestEventBus(t *testing.T) {
+	ctx := context.Background()
+	bus := NewEventBus()
+	codesets := &recordingSubscriber{}
+	all := &recordingSubscriber{}
+	bus.Subscribe(codesets, domain.CodesetResource)
+	bus.Subscribe(all)
+
+	bus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.CodesetResource, Object: &domain.Codeset{Name: "cs"}})
+	bus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.ProjectResource, Object: &domain.Project{Name: "prj"}})
+
+	if len(codesets.events) != 1 || codesets.events[0].Kind != domain.CodesetResource {
+		t.Errorf("Expected a single codeset event, got %v", codesets.events)
+	}
+	if len(all.events) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(all.events))
+	}
+
+	// re-subscribing replaces the resource kinds
+	bus.Subscribe(codesets, domain.ProjectResource)
+	bus.Unsubscribe(all)
+	bus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.ProjectResource, Object: &domain.Project{Name: "prj"}})
+	bus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.CodesetResource, Object: &domain.Codeset{Name: "cs"}})
+
+	if len(codesets.events) != 2 || codesets.events[1].Kind != domain.ProjectResource {
+		t.Errorf("Expected a project event after re-subscribing, got %v", codesets.events)
+	}
+	if len(all.events) != 2 {
+		t.Errorf("Expected no events after unsubscribing, got %d", len(all.events))
+	}
+}
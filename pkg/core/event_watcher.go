@@ -0,0 +1,196 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+const (
+	// watchHistorySize is the number of recent events kept in memory, used to resume watches
+	watchHistorySize = 1000
+	// watchBufferSize is the number of events that can be queued for a watch before it is closed
+	// for falling behind
+	watchBufferSize = 100
+)
+
+// watchableResources are the resource kinds that can be watched
+var watchableResources = []domain.ResourceKind{
+	domain.WorkflowRunResource,
+	domain.WorkflowAssignmentResource,
+	domain.CodesetResource,
+	domain.ExtensionResource,
+}
+
+type eventWatch struct {
+	kinds  map[domain.ResourceKind]bool
+	events chan *domain.WatchEvent
+}
+
+// EventWatcher turns the events published on the event bus into a stream of watch events, numbered
+// with resource versions, and delivers them to the watching clients. The most recent events are kept
+// in memory, so that clients can resume watching after reconnecting. The watcher is fed by the
+// server's own event bus: with several replicas, only the workflow runs, which every replica tracks
+// in tekton, are streamed by all of them, the other changes only by the replica that made them.
+type EventWatcher struct {
+	sync.Mutex
+	// epoch identifies this server instance. Resource versions issued by a previous instance cannot
+	// be resumed from.
+	epoch   string
+	version uint64
+	history []*domain.WatchEvent
+	watches map[*eventWatch]bool
+}
+
+// NewEventWatcher returns an event watcher subscribed to the events of all watchable resources
+func NewEventWatcher(eventBus domain.EventBus) *EventWatcher {
+	w := &EventWatcher{
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		watches: make(map[*eventWatch]bool),
+	}
+	eventBus.Subscribe(w, watchableResources...)
+	return w
+}
+
+// OnEvent records an event and delivers it to the matching watches. Watches that fall behind are closed
+// instead of blocking the publisher.
+func (w *EventWatcher) OnEvent(ctx context.Context, event *domain.Event) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.version++
+	watchEvent := &domain.WatchEvent{
+		Type:            watchEventType(event.Type),
+		Kind:            event.Kind,
+		ResourceVersion: w.resourceVersion(w.version),
+		Object:          event.Object,
+	}
+
+	w.history = append(w.history, watchEvent)
+	if len(w.history) >= 2*watchHistorySize {
+		w.history = append([]*domain.WatchEvent{}, w.history[len(w.history)-watchHistorySize:]...)
+	}
+
+	for watch := range w.watches {
+		if !watch.kinds[event.Kind] {
+			continue
+		}
+		select {
+		case watch.events <- watchEvent:
+		default:
+			w.closeWatch(watch)
+		}
+	}
+}
+
+// Watch returns a channel that receives the events published for the given resource kinds, starting
+// after the given resource version, until the context is cancelled
+func (w *EventWatcher) Watch(ctx context.Context, kinds []domain.ResourceKind, resourceVersion string) (<-chan *domain.WatchEvent, error) {
+	watch := &eventWatch{kinds: make(map[domain.ResourceKind]bool)}
+	if len(kinds) == 0 {
+		kinds = watchableResources
+	}
+	for _, k := range kinds {
+		if !isWatchable(k) {
+			return nil, fmt.Errorf("%w: cannot watch resources of kind '%s'", domain.ErrInvalidWatch, k)
+		}
+		watch.kinds[k] = true
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	replay, err := w.eventsAfter(resourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	pending := []*domain.WatchEvent{}
+	for _, e := range replay {
+		if watch.kinds[e.Kind] {
+			pending = append(pending, e)
+		}
+	}
+
+	// the bookmark reports the resource version the watch starts from
+	pending = append(pending, &domain.WatchEvent{Type: domain.WatchBookmark, ResourceVersion: w.resourceVersion(w.version)})
+	watch.events = make(chan *domain.WatchEvent, len(pending)+watchBufferSize)
+	for _, e := range pending {
+		watch.events <- e
+	}
+	w.watches[watch] = true
+
+	go func() {
+		<-ctx.Done()
+		w.Lock()
+		defer w.Unlock()
+		w.closeWatch(watch)
+	}()
+	return watch.events, nil
+}
+
+// eventsAfter returns the recorded events that followed the given resource version
+func (w *EventWatcher) eventsAfter(resourceVersion string) ([]*domain.WatchEvent, error) {
+	if resourceVersion == "" {
+		return nil, nil
+	}
+
+	invalid := fmt.Errorf("%w: unknown resource version '%s'", domain.ErrInvalidWatch, resourceVersion)
+	parts := strings.SplitN(resourceVersion, ".", 2)
+	if len(parts) != 2 {
+		return nil, invalid
+	}
+	version, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	if parts[0] != w.epoch {
+		// issued before the server was restarted
+		return nil, domain.ErrWatchExpired
+	}
+	if version > w.version {
+		return nil, invalid
+	}
+
+	// events are numbered consecutively, so the history holds the versions that follow the oldest one
+	oldest := w.version - uint64(len(w.history))
+	if version < oldest {
+		return nil, domain.ErrWatchExpired
+	}
+	return w.history[version-oldest:], nil
+}
+
+func (w *EventWatcher) resourceVersion(version uint64) string {
+	return fmt.Sprintf("%s.%d", w.epoch, version)
+}
+
+// closeWatch removes a watch and closes its channel. Must be called with the lock held.
+func (w *EventWatcher) closeWatch(watch *eventWatch) {
+	if w.watches[watch] {
+		delete(w.watches, watch)
+		close(watch.events)
+	}
+}
+
+func isWatchable(kind domain.ResourceKind) bool {
+	for _, k := range watchableResources {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func watchEventType(eventType domain.EventType) domain.WatchEventType {
+	switch eventType {
+	case domain.EventCreated:
+		return domain.WatchAdded
+	case domain.EventDeleting, domain.EventDeleted:
+		return domain.WatchDeleted
+	}
+	return domain.WatchModified
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// receive reads the events available on a watch channel, up to and including the next bookmark
+func receive(t *testing.T, events <-chan *domain.WatchEvent) (res []*domain.WatchEvent, bookmark *domain.WatchEvent) {
+	t.Helper()
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatalf("Watch channel closed unexpectedly")
+			}
+			if e.Type == domain.WatchBookmark {
+				return res, e
+			}
+			res = append(res, e)
+		default:
+			t.Fatalf("Expected a bookmark, got %d events", len(res))
+		}
+	}
+}
+
+func TestEventWatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("watch", func(t *testing.T) {
+		bus := NewEventBus()
+		watcher := NewEventWatcher(bus)
+		watchCtx, cancel := context.WithCancel(ctx)
+
+		events, err := watcher.Watch(watchCtx, []domain.ResourceKind{domain.CodesetResource}, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		_, bookmark := receive(t, events)
+
+		bus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.CodesetResource, Object: &domain.Codeset{Name: "cs"}})
+		bus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.ExtensionResource, Object: &domain.Extension{ID: "ext"}})
+		bus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.CodesetResource, Object: &domain.Codeset{Name: "cs"}})
+
+		got := []*domain.WatchEvent{<-events, <-events}
+		if got[0].Type != domain.WatchAdded || got[1].Type != domain.WatchDeleted {
+			t.Errorf("Unexpected event types: %s, %s", got[0].Type, got[1].Type)
+		}
+		if got[0].ResourceVersion == bookmark.ResourceVersion || got[0].ResourceVersion == got[1].ResourceVersion {
+			t.Errorf("Expected distinct resource versions, got %s, %s, %s",
+				bookmark.ResourceVersion, got[0].ResourceVersion, got[1].ResourceVersion)
+		}
+
+		cancel()
+		if _, ok := <-events; ok {
+			t.Errorf("Expected the watch channel to be closed")
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		bus := NewEventBus()
+		watcher := NewEventWatcher(bus)
+
+		events, err := watcher.Watch(ctx, nil, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		_, bookmark := receive(t, events)
+		for _, name := range []string{"a", "b", "c"} {
+			bus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.WorkflowRunResource, Object: &domain.WorkflowRun{Name: name}})
+		}
+		first := <-events
+
+		// resuming from the first event replays the events that followed it
+		resumed, err := watcher.Watch(ctx, nil, first.ResourceVersion)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		got, _ := receive(t, resumed)
+		if len(got) != 2 || got[0].Object.(*domain.WorkflowRun).Name != "b" || got[1].Object.(*domain.WorkflowRun).Name != "c" {
+			t.Errorf("Unexpected replayed events: %v", got)
+		}
+
+		// resuming from the initial bookmark replays all events
+		resumed, err = watcher.Watch(ctx, nil, bookmark.ResourceVersion)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		got, _ = receive(t, resumed)
+		if len(got) != 3 {
+			t.Errorf("Expected 3 replayed events, got %d", len(got))
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		bus := NewEventBus()
+		watcher := NewEventWatcher(bus)
+
+		events, _ := watcher.Watch(context.Background(), []domain.ResourceKind{domain.ExtensionResource}, "")
+		_, bookmark := receive(t, events)
+		for i := 0; i < 2*watchHistorySize; i++ {
+			bus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.CodesetResource, Object: &domain.Codeset{}})
+		}
+
+		_, err := watcher.Watch(ctx, nil, bookmark.ResourceVersion)
+		if !errors.Is(err, domain.ErrWatchExpired) {
+			t.Errorf("Expected %q, got %v", domain.ErrWatchExpired, err)
+		}
+
+		// resource versions issued by another server instance cannot be resumed from
+		_, err = watcher.Watch(ctx, nil, "previous.1")
+		if !errors.Is(err, domain.ErrWatchExpired) {
+			t.Errorf("Expected %q, got %v", domain.ErrWatchExpired, err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		watcher := NewEventWatcher(NewEventBus())
+
+		for _, rv := range []string{"1", watcher.epoch + ".x", watcher.epoch + ".10"} {
+			_, err := watcher.Watch(ctx, nil, rv)
+			if !errors.Is(err, domain.ErrInvalidWatch) {
+				t.Errorf("Expected %q for resource version %q, got %v", domain.ErrInvalidWatch, rv, err)
+			}
+		}
+		_, err := watcher.Watch(ctx, []domain.ResourceKind{domain.ProjectResource}, "")
+		if !errors.Is(err, domain.ErrInvalidWatch) {
+			t.Errorf("Expected %q, got %v", domain.ErrInvalidWatch, err)
+		}
+	})
+
+	t.Run("slow client", func(t *testing.T) {
+		bus := NewEventBus()
+		watcher := NewEventWatcher(bus)
+
+		events, _ := watcher.Watch(ctx, nil, "")
+		for i := 0; i <= watchBufferSize; i++ {
+			bus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.CodesetResource, Object: &domain.Codeset{}})
+		}
+
+		// the buffered events are still delivered before the channel is closed
+		count := 0
+		for range events {
+			count++
+		}
+		if count != watchBufferSize+1 {
+			t.Errorf("Expected %d events, including the bookmark, got %d", watchBufferSize+1, count)
+		}
+	})
+}
@@ -41,8 +41,22 @@ func (store *ExtensionStore) GetExtension(ctx context.Context, extensionID strin
 	return extension, nil
 }
 
-// ListExtensions retrieves all stored extensions.
-func (store *ExtensionStore) ListExtensions(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.Extension) {
+// ListExtensions retrieves the page of stored extensions matching the query selected by the list options.
+func (store *ExtensionStore) ListExtensions(ctx context.Context, query *domain.ExtensionQuery,
+	opts *domain.ListOptions) ([]*domain.Extension, string, error) {
+	result, err := store.listExtensions(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+	next, err := domain.Paginate(&result, opts, domain.ExtensionSortFields, domain.ExtensionDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, next, nil
+}
+
+// listExtensions retrieves all stored extensions matching the query.
+func (store *ExtensionStore) listExtensions(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.Extension, err error) {
 	result = make([]*domain.Extension, 0, len(store.items))
 
 	if query != nil {
@@ -54,7 +68,7 @@ func (store *ExtensionStore) ListExtensions(ctx context.Context, query *domain.E
 					result = append(result, matchingExtension)
 				}
 			}
-			return
+			return result, nil
 		}
 
 		for _, extension := range store.items {
@@ -82,10 +96,12 @@ func (store *ExtensionStore) UpdateExtension(ctx context.Context, newExtension *
 	newExtension.Updated = time.Now()
 
 	for _, newExtService := range newExtension.ListServices() {
-		_, err := extension.GetService(newExtService.ID)
+		oldExtService, err := extension.GetService(newExtService.ID)
 		if err != nil {
 			// If the service is new, set the creation time
 			newExtService.SetCreated(newExtension.Updated)
+		} else {
+			newExtService.InheritEndpointStatus(oldExtService)
 		}
 	}
 
@@ -184,6 +200,20 @@ func (store *ExtensionStore) UpdateExtensionServiceEndpoint(ctx context.Context,
 	return extension.UpdateServiceEndpoint(serviceID, endpoint)
 }
 
+// UpdateExtensionServiceEndpointStatus updates the operational status of an endpoint belonging to an extension service.
+func (store *ExtensionStore) UpdateExtensionServiceEndpointStatus(ctx context.Context, extensionID, serviceID, endpointURL string, status domain.ExtensionServiceEndpointStatus) error {
+	extension, err := store.GetExtension(ctx, extensionID)
+	if err != nil {
+		return err
+	}
+	endpoint, err := extension.GetServiceEndpoint(serviceID, endpointURL)
+	if err != nil {
+		return err
+	}
+	endpoint.Status = status
+	return nil
+}
+
 // DeleteExtensionServiceEndpoint deletes an extension endpoint from an extension service.
 func (store *ExtensionStore) DeleteExtensionServiceEndpoint(ctx context.Context, extensionID, serviceID, endpointID string) error {
 	extension, err := store.GetExtension(ctx, extensionID)
@@ -243,7 +273,11 @@ func (store *ExtensionStore) DeleteExtensionServiceCredentials(ctx context.Conte
 func (store *ExtensionStore) GetExtensionAccessDescriptors(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.ExtensionAccessDescriptor, err error) {
 	result = make([]*domain.ExtensionAccessDescriptor, 0)
 
-	for _, extension := range store.ListExtensions(ctx, query) {
+	extensions, err := store.listExtensions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, extension := range extensions {
 		result = append(result, extension.GetAccessDescriptors()...)
 	}
 	return result, nil
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// ExtensionUsageStore describes in memory store for extension usage records
+type ExtensionUsageStore struct {
+	items []*domain.ExtensionUsage
+}
+
+// NewExtensionUsageStore returns an in-memory extension usage store instance
+func NewExtensionUsageStore() *ExtensionUsageStore {
+	return &ExtensionUsageStore{}
+}
+
+// AddUsage adds new usage records
+func (store *ExtensionUsageStore) AddUsage(ctx context.Context, usage []*domain.ExtensionUsage) error {
+	store.items = append(store.items, usage...)
+	return nil
+}
+
+// ReleaseUsage marks all active usage records of a workflow as released
+func (store *ExtensionUsageStore) ReleaseUsage(ctx context.Context, workflowName string, released time.Time) error {
+	for _, u := range store.items {
+		if u.Workflow == workflowName && u.Active() {
+			u.Released = released
+		}
+	}
+	return nil
+}
+
+// GetUsage retrieves the usage records that match a filter, ordered by the time when the bindings were made
+func (store *ExtensionUsageStore) GetUsage(ctx context.Context, filter *domain.ExtensionUsageFilter) ([]*domain.ExtensionUsage, error) {
+	result := []*domain.ExtensionUsage{}
+	for _, u := range store.items {
+		if filter.Matches(u) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
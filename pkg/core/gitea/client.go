@@ -1,15 +1,20 @@
 package gitea
 
 import (
-	"log"
-	"math/rand"
-	"os"
+	"context"
+	"crypto/rand"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sort"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/pkg/errors"
 
 	config "github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/metrics"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
@@ -19,6 +24,7 @@ type Client interface {
 	CreateOrg(gitea.CreateOrgOption) (*gitea.Organization, *gitea.Response, error)
 	GetUserInfo(string) (*gitea.User, *gitea.Response, error)
 	AdminCreateUser(gitea.CreateUserOption) (*gitea.User, *gitea.Response, error)
+	AdminEditUser(string, gitea.EditUserOption) (*gitea.Response, error)
 	AdminDeleteUser(string) (*gitea.Response, error)
 	ListOrgTeams(string, gitea.ListTeamsOptions) ([]*gitea.Team, *gitea.Response, error)
 	AddTeamMember(int64, string) (*gitea.Response, error)
@@ -36,6 +42,7 @@ type Client interface {
 	DeleteRepo(string, string) (*gitea.Response, error)
 	DeleteOrg(string) (*gitea.Response, error)
 	DeleteOrgMembership(org, user string) (*gitea.Response, error)
+	ServerVersion() (string, *gitea.Response, error)
 }
 
 // AdminClient is the struct holding information about gitea client
@@ -43,17 +50,21 @@ type Client interface {
 type AdminClient struct {
 	giteaClient Client
 	url         string
-	logger      *log.Logger
+	logger      *slog.Logger
 }
 
 const (
-	errGITEAURLMissing           = giteaErr("Value for gitea URL (GITEA_URL) was not provided.")
-	errGITEAADMINUSERNAMEMissing = giteaErr("Value for gitea admin user name (GITEA_ADMIN_USERNAME) was not provided.")
-	errGITEAADMINPASSWORDMissing = giteaErr("Value for gitea admin user password (GITEA_ADMIN_PASSWORD) was not provided.")
+	errGITEAURLMissing           = giteaErr("Value for gitea URL (gitea.url) was not provided.")
+	errGITEAADMINUSERNAMEMissing = giteaErr("Value for gitea admin user name (gitea.admin-username) was not provided.")
+	errGITEAADMINPASSWORDMissing = giteaErr("Value for gitea admin user password (gitea.admin-password) was not provided.")
 	errRepoNotFound              = giteaErr("Repository by that name not found")
 	errProjectNotEmpty           = giteaErr("Project has still codesets assigned. Delete them first")
+	errOwnersTeamNotFound        = giteaErr("Project does not have an Owners team")
 )
 
+// giteaPageSize is the number of items requested per page when listing gitea resources
+const giteaPageSize = 50
+
 type giteaErr string
 
 func (e giteaErr) Error() string {
@@ -63,35 +74,30 @@ func (e giteaErr) Error() string {
 var lettersForPassword = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 var generatedPasswordLength = 16
 
-// For now, turn off the password generation
-// But we need to fine a way how user can alter this value (env variable, config file, client param...)
-var generateUserPassword = false
-
 // NewAdminClient creates a new gitea client and performs authentication
-// from the credentials provided as env variables
-func NewAdminClient(logger *log.Logger) (*AdminClient, error) {
+// with the configured admin credentials
+func NewAdminClient(logger *slog.Logger, cfg *config.Gitea) (*AdminClient, error) {
 
-	url, exists := os.LookupEnv("GITEA_URL")
-	if !exists {
+	url, username, password := cfg.URL, cfg.AdminUsername, cfg.AdminPassword
+	if url == "" {
 		return nil, errGITEAURLMissing
 	}
-	username, exists := os.LookupEnv("GITEA_ADMIN_USERNAME")
-	if !exists {
+	if username == "" {
 		return nil, errGITEAADMINUSERNAMEMissing
 	}
-	password, exists := os.LookupEnv("GITEA_ADMIN_PASSWORD")
-	if !exists {
+	if password == "" {
 		return nil, errGITEAADMINPASSWORDMissing
 	}
 
-	client, err := gitea.NewClient(url)
+	httpClient := &http.Client{Transport: metrics.RoundTripper("gitea", nil)}
+	client, err := gitea.NewClient(url, gitea.SetHTTPClient(httpClient))
 	if err != nil {
 		return nil, errors.Wrap(err, "gitea client failed")
 	}
 
 	client.SetBasicAuth(username, password)
 
-	logger.Printf("Using GITEA from: %s", url)
+	logger.Info("Using Gitea", "url", url)
 
 	return &AdminClient{
 		giteaClient: client,
@@ -104,13 +110,16 @@ func generateUserName(org string) string {
 	return config.DefaultUserName(org)
 }
 
-func getUserPassword() string {
-	if !generateUserPassword {
-		return config.DefaultUserPassword
-	}
+// generatePassword returns a random password, used for every account created by FuseML and when rotating credentials
+func generatePassword() string {
 	p := make([]rune, generatedPasswordLength)
+	max := big.NewInt(int64(len(lettersForPassword)))
 	for i := range p {
-		p[i] = lettersForPassword[rand.Intn(len(lettersForPassword))]
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(err)
+		}
+		p[i] = lettersForPassword[n.Int64()]
 	}
 	return string(p)
 }
@@ -120,10 +129,42 @@ func (gac *AdminClient) GetGiteaURL() (string, error) {
 	return gac.url, nil
 }
 
+// CheckDependencies checks that the gitea server can be reached with the admin credentials and reports
+// its version. The gitea client does not accept a context, so the check is abandoned when the context is
+// done, but the request is left to complete in the background.
+func (gac *AdminClient) CheckDependencies(ctx context.Context) []*domain.DependencyStatus {
+	_, span := tracing.Start(ctx, "gitea.CheckDependencies")
+	defer span.End()
+
+	status := &domain.DependencyStatus{Name: "gitea", Endpoint: gac.url}
+	type result struct {
+		version string
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		version, _, err := gac.giteaClient.ServerVersion()
+		done <- result{version, err}
+	}()
+	select {
+	case r := <-done:
+		status.Version = r.version
+		if r.err != nil {
+			status.Error = r.err.Error()
+		}
+	case <-ctx.Done():
+		status.Error = ctx.Err().Error()
+	}
+	return []*domain.DependencyStatus{status}
+}
+
 // CreateProject creates a Project (= implemented as Organization in git).
 // If ignoreExisting argument is true, the call will not fail when a project with same name already exists.
-func (gac *AdminClient) CreateProject(name, desc string, ignoreExisting bool) (*domain.Project, error) {
-	gac.logger.Printf("Creating project %s....", name)
+func (gac *AdminClient) CreateProject(ctx context.Context, name, desc string, ignoreExisting bool) (_ *domain.Project, err error) {
+	_, span := tracing.Start(ctx, "gitea.CreateProject")
+	defer tracing.End(span, &err)
+
+	gac.logger.InfoContext(ctx, "Creating project", "project", name)
 
 	_, resp, err := gac.giteaClient.GetOrg(name)
 	if resp == nil && err != nil {
@@ -131,7 +172,7 @@ func (gac *AdminClient) CreateProject(name, desc string, ignoreExisting bool) (*
 	}
 
 	if resp != nil && resp.StatusCode == 200 {
-		gac.logger.Printf("Project already exists.")
+		gac.logger.InfoContext(ctx, "Project already exists", "project", name)
 		if ignoreExisting {
 			return nil, nil
 		}
@@ -154,26 +195,28 @@ func (gac *AdminClient) CreateProject(name, desc string, ignoreExisting bool) (*
 }
 
 // CreateOrg creates an Org in gitea. Does not return an error if it already exists
-func (gac *AdminClient) createOrganizationIfNotPresent(org string) error {
-
-	_, err := gac.CreateProject(org, "", true)
+func (gac *AdminClient) createOrganizationIfNotPresent(ctx context.Context, org string) error {
+	_, err := gac.CreateProject(ctx, org, "", true)
 	return err
 }
 
 // CreateUser creates user assigned to current project
-func (gac *AdminClient) CreateUser(org string) (*string, *string, error) {
+func (gac *AdminClient) CreateUser(ctx context.Context, org string) (_ *string, _ *string, err error) {
+	_, span := tracing.Start(ctx, "gitea.CreateUser")
+	defer tracing.End(span, &err)
+
 	username := generateUserName(org)
-	password := getUserPassword()
+	password := generatePassword()
 	user, resp, err := gac.giteaClient.GetUserInfo(username)
 	if resp == nil && err != nil {
 		return nil, nil, errors.Wrap(err, "Failed to make get user request")
 	}
 	if user != nil && user.ID != 0 {
-		gac.logger.Println("User already exists")
+		gac.logger.InfoContext(ctx, "User already exists", "user", username)
 		return nil, nil, nil
 	}
 
-	gac.logger.Printf("Creating user '%s'", username)
+	gac.logger.InfoContext(ctx, "Creating user", "user", username)
 	_, _, err = gac.giteaClient.AdminCreateUser(gitea.CreateUserOption{
 		Username:           username,
 		Email:              config.DefaultUserEmail(org),
@@ -207,19 +250,22 @@ func (gac *AdminClient) CreateUser(org string) (*string, *string, error) {
 }
 
 // CreateRepo creates a git repository with given name under given org
-func (gac *AdminClient) CreateRepo(c *domain.Codeset) error {
+func (gac *AdminClient) CreateRepo(ctx context.Context, c *domain.Codeset) (err error) {
+	_, span := tracing.Start(ctx, "gitea.CreateRepo")
+	defer tracing.End(span, &err)
+
 	repo, resp, err := gac.giteaClient.GetRepo(c.Project, c.Name)
 	if resp == nil && err != nil {
 		return errors.Wrap(err, "Failed to make get repo request")
 	}
 
 	if resp != nil && resp.StatusCode == 200 {
-		gac.logger.Printf("Repository '%s' already exists under '%s'", c.Name, c.Project)
+		gac.logger.InfoContext(ctx, "Repository already exists", "project", c.Project, "repository", c.Name)
 		c.URL = repo.CloneURL
 		return nil
 	}
 
-	gac.logger.Printf("Creating repository '%s' under '%s'...", c.Name, c.Project)
+	gac.logger.InfoContext(ctx, "Creating repository", "project", c.Project, "repository", c.Name)
 	repo, _, err = gac.giteaClient.CreateOrgRepo(c.Project, gitea.CreateRepoOption{
 		Name:          c.Name,
 		AutoInit:      true,
@@ -237,7 +283,10 @@ func (gac *AdminClient) CreateRepo(c *domain.Codeset) error {
 }
 
 // AddRepoTopics adds topics to given repository
-func (gac *AdminClient) AddRepoTopics(org, name string, labels []string) error {
+func (gac *AdminClient) AddRepoTopics(ctx context.Context, org, name string, labels []string) (err error) {
+	_, span := tracing.Start(ctx, "gitea.AddRepoTopics")
+	defer tracing.End(span, &err)
+
 	for _, label := range labels {
 		_, err := gac.giteaClient.AddRepoTopic(org, name, label)
 		if err != nil {
@@ -248,12 +297,15 @@ func (gac *AdminClient) AddRepoTopics(org, name string, labels []string) error {
 }
 
 // CreateRepoWebhook creates webhook for given repository and wire it to the listenerURL
-func (gac *AdminClient) CreateRepoWebhook(org, name string, listenerURL *string) (*int64, error) {
+func (gac *AdminClient) CreateRepoWebhook(ctx context.Context, org, name string, listenerURL *string) (_ *int64, err error) {
+	_, span := tracing.Start(ctx, "gitea.CreateRepoWebhook")
+	defer tracing.End(span, &err)
+
 	if listenerURL == nil {
-		gac.logger.Printf("Webhook listener URL not provided, skipping creation")
+		gac.logger.InfoContext(ctx, "Webhook listener URL not provided, skipping creation", "project", org, "repository", name)
 		return nil, nil
 	}
-	hooks, _, err := gac.giteaClient.ListRepoHooks(org, name, gitea.ListHooksOptions{})
+	hooks, err := gac.listRepoHooks(org, name)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to list webhooks")
 	}
@@ -261,13 +313,13 @@ func (gac *AdminClient) CreateRepoWebhook(org, name string, listenerURL *string)
 	for _, hook := range hooks {
 		url := hook.Config["url"]
 		if url == *listenerURL {
-			gac.logger.Printf("Webhook for '%s' already exists", name)
+			gac.logger.InfoContext(ctx, "Webhook already exists", "project", org, "repository", name)
 			return &hook.ID, nil
 		}
 	}
 
-	gac.logger.Printf("Creating Webhook for '%s' under '%s'...", name, org)
-	hook, _, _ := gac.giteaClient.CreateRepoHook(org, name, gitea.CreateHookOption{
+	gac.logger.InfoContext(ctx, "Creating webhook", "project", org, "repository", name)
+	hook, _, err := gac.giteaClient.CreateRepoHook(org, name, gitea.CreateHookOption{
 		Active:       true,
 		BranchFilter: "*",
 		Config: map[string]string{
@@ -278,17 +330,39 @@ func (gac *AdminClient) CreateRepoWebhook(org, name string, listenerURL *string)
 		},
 		Type: "gitea",
 	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create webhook")
+	}
 
 	return &hook.ID, nil
 }
 
+// GetRepoWebhooks returns the webhooks of given repository
+func (gac *AdminClient) GetRepoWebhooks(ctx context.Context, org, name string) (_ []*domain.Webhook, err error) {
+	_, span := tracing.Start(ctx, "gitea.GetRepoWebhooks")
+	defer tracing.End(span, &err)
+
+	hooks, err := gac.listRepoHooks(org, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list webhooks")
+	}
+	webhooks := make([]*domain.Webhook, 0, len(hooks))
+	for _, hook := range hooks {
+		webhooks = append(webhooks, &domain.Webhook{ID: hook.ID, URL: hook.Config["url"], Created: hook.Created})
+	}
+	return webhooks, nil
+}
+
 // DeleteRepoWebhook deletes a webhook for given repository
-func (gac *AdminClient) DeleteRepoWebhook(org, name string, hookID *int64) error {
-	gac.logger.Printf("Deleting Webhook for %q under %q...", name, org)
+func (gac *AdminClient) DeleteRepoWebhook(ctx context.Context, org, name string, hookID *int64) (err error) {
+	_, span := tracing.Start(ctx, "gitea.DeleteRepoWebhook")
+	defer tracing.End(span, &err)
+
+	gac.logger.InfoContext(ctx, "Deleting webhook", "project", org, "repository", name)
 	resp, err := gac.giteaClient.DeleteRepoHook(org, name, *hookID)
 	if err != nil {
 		if resp.StatusCode == 404 {
-			gac.logger.Printf("Webhook not found, skipping deletion")
+			gac.logger.InfoContext(ctx, "Webhook not found, skipping deletion", "project", org, "repository", name)
 			return nil
 		}
 		return errors.Wrap(err, "Failed to delete webhook")
@@ -297,91 +371,158 @@ func (gac *AdminClient) DeleteRepoWebhook(org, name string, hookID *int64) error
 }
 
 // PrepareRepository prepares the org, repository, and creates a user
-func (gac *AdminClient) PrepareRepository(code *domain.Codeset, listenerURL *string) (*string, *string, error) {
+func (gac *AdminClient) PrepareRepository(ctx context.Context, code *domain.Codeset, listenerURL *string) (_ *string, _ *string, err error) {
+	ctx, span := tracing.Start(ctx, "gitea.PrepareRepository")
+	defer tracing.End(span, &err)
 
-	err := gac.createOrganizationIfNotPresent(code.Project)
+	err = gac.createOrganizationIfNotPresent(ctx, code.Project)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Create org failed")
 	}
 
-	user, pass, err := gac.CreateUser(code.Project)
+	user, pass, err := gac.CreateUser(ctx, code.Project)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Create FuseML user failed")
 	}
 
-	err = gac.CreateRepo(code)
+	err = gac.CreateRepo(ctx, code)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Create repo failed")
 	}
 
-	err = gac.AddRepoTopics(code.Project, code.Name, code.Labels)
+	err = gac.AddRepoTopics(ctx, code.Project, code.Name, code.Labels)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Failed to add topics to repository")
 	}
 
-	_, err = gac.CreateRepoWebhook(code.Project, code.Name, listenerURL)
+	_, err = gac.CreateRepoWebhook(ctx, code.Project, code.Name, listenerURL)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Creating webhook failed")
 	}
 	return user, pass, nil
 }
 
-// GetReposForOrg retrieves all repositories for given project, can be filtered by label
-func (gac *AdminClient) GetReposForOrg(org string, label *string) ([]*domain.Codeset, error) {
-	var codesets []*domain.Codeset
-	gac.logger.Printf("Listing repos for org '%s'...", org)
-	repos, _, err := gac.giteaClient.ListOrgRepos(org, gitea.ListOrgReposOptions{})
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to list project repos")
+// listOrgs retrieves all organizations the admin user belongs to, going through all result pages
+func (gac *AdminClient) listOrgs() ([]*gitea.Organization, error) {
+	var result []*gitea.Organization
+	for page := 1; ; page++ {
+		orgs, _, err := gac.giteaClient.ListMyOrgs(gitea.ListOrgsOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: giteaPageSize}})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, orgs...)
+		if len(orgs) < giteaPageSize {
+			return result, nil
+		}
 	}
-	for _, repo := range repos {
-		var labels []string
-		labels, _, err = gac.giteaClient.ListRepoTopics(org, repo.Name, gitea.ListRepoTopicsOptions{})
+}
+
+// listOrgRepos retrieves all repositories of an organization, going through all result pages
+func (gac *AdminClient) listOrgRepos(org string) ([]*gitea.Repository, error) {
+	var result []*gitea.Repository
+	for page := 1; ; page++ {
+		repos, _, err := gac.giteaClient.ListOrgRepos(org, gitea.ListOrgReposOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: giteaPageSize}})
 		if err != nil {
-			return nil, errors.Wrap(err, "Failed to list repo topics")
+			return nil, err
 		}
-		if label != nil && !util.StringInSlice(*label, labels) {
-			continue
+		result = append(result, repos...)
+		if len(repos) < giteaPageSize {
+			return result, nil
 		}
+	}
+}
 
-		codesets = append(codesets, &domain.Codeset{
-			Name:        repo.Name,
-			Project:     org,
-			Labels:      labels,
-			Description: repo.Description,
-			URL:         repo.CloneURL,
-		})
+// listRepoHooks retrieves all webhooks of a repository, going through all result pages
+func (gac *AdminClient) listRepoHooks(org, name string) ([]*gitea.Hook, error) {
+	var result []*gitea.Hook
+	for page := 1; ; page++ {
+		hooks, _, err := gac.giteaClient.ListRepoHooks(org, name, gitea.ListHooksOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: giteaPageSize}})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, hooks...)
+		if len(hooks) < giteaPageSize {
+			return result, nil
+		}
 	}
-	return codesets, nil
 }
 
-// GetRepositories retrieves all repositories, can be filtered by project(org) and label
-func (gac *AdminClient) GetRepositories(org, label *string) ([]*domain.Codeset, error) {
+// GetRepositories retrieves the page of repositories selected by the list options, can be filtered by
+// project(org) and label. Repository topics, which need one API call per repository, are only fetched
+// for the repositories on the requested page.
+func (gac *AdminClient) GetRepositories(ctx context.Context, org, label *string, opts *domain.ListOptions) (_ []*domain.Codeset, _ string, err error) {
+	_, span := tracing.Start(ctx, "gitea.GetRepositories")
+	defer tracing.End(span, &err)
 
-	var allRepos []*domain.Codeset
-	var orgs []*gitea.Organization
+	w, err := opts.Window(domain.CodesetSortFields, domain.CodesetDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
 
+	var orgs []string
 	if org == nil {
-		gac.logger.Printf("Going through all orgs...")
-		var err error
-		orgs, _, err = gac.giteaClient.ListMyOrgs(gitea.ListOrgsOptions{})
+		gac.logger.DebugContext(ctx, "Listing repositories of all projects")
+		allOrgs, err := gac.listOrgs()
 		if err != nil {
-			return nil, errors.Wrap(err, "Failed to list orgs")
+			return nil, "", errors.Wrap(err, "Failed to list orgs")
+		}
+		for _, o := range allOrgs {
+			orgs = append(orgs, o.UserName)
 		}
 	} else {
-		orgs = append(orgs, &gitea.Organization{UserName: *org})
+		orgs = append(orgs, *org)
 	}
 
+	var codesets []*domain.Codeset
 	for _, o := range orgs {
-		repos, _ := gac.GetReposForOrg(o.UserName, label)
-		allRepos = append(allRepos, repos...)
+		gac.logger.DebugContext(ctx, "Listing repositories", "project", o)
+		repos, err := gac.listOrgRepos(o)
+		if err != nil {
+			continue
+		}
+		for _, repo := range repos {
+			codesets = append(codesets, &domain.Codeset{
+				Name:        repo.Name,
+				Project:     o,
+				Description: repo.Description,
+				URL:         repo.CloneURL,
+			})
+		}
+	}
+	w.Sort(&codesets)
+
+	result := []*domain.Codeset{}
+	// start after the last codeset of the previous page
+	i := sort.Search(len(codesets), func(i int) bool { return w.Follows(codesets[i]) })
+	for ; i < len(codesets) && (w.Limit == 0 || len(result) < w.Limit); i++ {
+		codeset := codesets[i]
+		labels, _, err := gac.giteaClient.ListRepoTopics(codeset.Project, codeset.Name, gitea.ListRepoTopicsOptions{})
+		if err != nil {
+			return nil, "", errors.Wrap(err, "Failed to list repo topics")
+		}
+		if label != nil && !util.StringInSlice(*label, labels) {
+			continue
+		}
+		codeset.Labels = labels
+		result = append(result, codeset)
 	}
-	return allRepos, nil
+
+	next := ""
+	if i < len(codesets) {
+		next = w.Next(codesets[i-1], true)
+	}
+	return result, next, nil
 }
 
 // GetRepository retrieves information about the repository
-func (gac *AdminClient) GetRepository(org, name string) (*domain.Codeset, error) {
-	gac.logger.Printf("Get repo %s for org '%s'...", name, org)
+func (gac *AdminClient) GetRepository(ctx context.Context, org, name string) (_ *domain.Codeset, err error) {
+	_, span := tracing.Start(ctx, "gitea.GetRepository")
+	defer tracing.End(span, &err)
+
+	gac.logger.DebugContext(ctx, "Fetching repository", "project", org, "repository", name)
 	repo, _, err := gac.giteaClient.GetRepo(org, name)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to read repository")
@@ -406,13 +547,16 @@ func (gac *AdminClient) GetRepository(org, name string) (*domain.Codeset, error)
 }
 
 // DeleteRepository delete a repository
-func (gac *AdminClient) DeleteRepository(org, name string) error {
-	gac.logger.Printf("Going to delete repo %s for org '%s'...", name, org)
+func (gac *AdminClient) DeleteRepository(ctx context.Context, org, name string) (err error) {
+	_, span := tracing.Start(ctx, "gitea.DeleteRepository")
+	defer tracing.End(span, &err)
+
+	gac.logger.InfoContext(ctx, "Deleting repository", "project", org, "repository", name)
 
 	_, resp, err := gac.giteaClient.GetRepo(org, name)
 
 	if resp.StatusCode == 404 {
-		gac.logger.Printf("Repo does not exist, no need to delete")
+		gac.logger.InfoContext(ctx, "Repository does not exist, no need to delete", "project", org, "repository", name)
 		return nil
 	}
 	if err != nil {
@@ -426,65 +570,224 @@ func (gac *AdminClient) DeleteRepository(org, name string) error {
 	return nil
 }
 
+// return the ID of the Owners team for given organization
+func (gac *AdminClient) getOwnersTeamID(name string) (int64, error) {
+	teams, _, err := gac.giteaClient.ListOrgTeams(name, gitea.ListTeamsOptions{})
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to list org teams")
+	}
+	for _, team := range teams {
+		if team.Name == "Owners" {
+			return team.ID, nil
+		}
+	}
+	return 0, errOwnersTeamNotFound
+}
+
 // return all non-admin users that are Owners for given organization
 func (gac *AdminClient) getProjectOwners(name string) ([]*domain.User, error) {
 
 	var ret []*domain.User
-	teams, _, err := gac.giteaClient.ListOrgTeams(name, gitea.ListTeamsOptions{})
+	teamID, err := gac.getOwnersTeamID(name)
+	if err != nil {
+		if err == errOwnersTeamNotFound {
+			return ret, nil
+		}
+		return nil, err
+	}
+	users, _, err := gac.giteaClient.ListTeamMembers(teamID, gitea.ListTeamMembersOptions{})
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to list org teams")
+		return nil, errors.Wrap(err, "Failed listing members of Owners team")
 	}
-	for _, team := range teams {
-		if team.Name != "Owners" {
+	for _, u := range users {
+		if u.IsAdmin {
 			continue
 		}
-		users, _, err := gac.giteaClient.ListTeamMembers(team.ID, gitea.ListTeamMembersOptions{})
-		if err != nil {
-			return nil, errors.Wrap(err, "Failed listing members of Owners team")
-		}
-		for _, u := range users {
-			if u.IsAdmin {
-				continue
-			}
-			ret = append(ret, &domain.User{
-				Name:  u.UserName,
-				Email: u.Email,
-			})
-		}
-		break // no need to continue after checking Owners
+		ret = append(ret, &domain.User{
+			Name:  u.UserName,
+			Email: u.Email,
+		})
 	}
 	return ret, nil
 }
 
-// GetProjects retrieves all projects (orgs)
-func (gac *AdminClient) GetProjects() ([]*domain.Project, error) {
-	gac.logger.Printf("listing git orgs....")
+// return the project member with the given name, or nil if the user is not a member of the project
+func (gac *AdminClient) getProjectMember(ctx context.Context, org, userName string) (*domain.User, error) {
+	members, err := gac.GetProjectMembers(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if m.Name == userName {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetProjectMembers retrieves the users that are members of a project
+func (gac *AdminClient) GetProjectMembers(ctx context.Context, org string) (_ []*domain.User, err error) {
+	_, span := tracing.Start(ctx, "gitea.GetProjectMembers")
+	defer tracing.End(span, &err)
 
-	orgs, _, err := gac.giteaClient.ListMyOrgs(gitea.ListOrgsOptions{})
+	_, resp, err := gac.giteaClient.GetOrg(org)
+	if resp == nil && err != nil {
+		return nil, errors.Wrap(err, "Failed to make get org request")
+	}
+	if resp == nil || resp.StatusCode != 200 {
+		return nil, domain.ErrProjectNotFound
+	}
+	return gac.getProjectOwners(org)
+}
+
+// AddProjectMember adds a user to a project. A dedicated git account is created for the user
+// if it does not exist already, in which case the generated password is returned.
+func (gac *AdminClient) AddProjectMember(ctx context.Context, org string, user *domain.User) (_ *string, err error) {
+	ctx, span := tracing.Start(ctx, "gitea.AddProjectMember")
+	defer tracing.End(span, &err)
+
+	gac.logger.InfoContext(ctx, "Adding user to project", "project", org, "user", user.Name)
+
+	member, err := gac.getProjectMember(ctx, org, user.Name)
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to list orgs")
+		return nil, err
+	}
+	if member != nil {
+		return nil, domain.ErrProjectMemberExists
 	}
 
-	var ret []*domain.Project
-	for _, o := range orgs {
-		users, err := gac.getProjectOwners(o.UserName)
+	var password *string
+	existing, resp, err := gac.giteaClient.GetUserInfo(user.Name)
+	if resp == nil && err != nil {
+		return nil, errors.Wrap(err, "Failed to make get user request")
+	}
+	if existing == nil || existing.ID == 0 {
+		email := user.Email
+		if email == "" {
+			email = user.Name + config.DefaultUserEmailDomain
+		}
+		p := generatePassword()
+		gac.logger.InfoContext(ctx, "Creating user", "user", user.Name)
+		_, _, err = gac.giteaClient.AdminCreateUser(gitea.CreateUserOption{
+			Username:           user.Name,
+			Email:              email,
+			Password:           p,
+			MustChangePassword: gitea.OptionalBool(false),
+			SendNotify:         false,
+		})
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "Failed to create user")
 		}
-		ret = append(ret, &domain.Project{
+		password = &p
+	}
+
+	teamID, err := gac.getOwnersTeamID(org)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = gac.giteaClient.AddTeamMember(teamID, user.Name); err != nil {
+		return nil, errors.Wrap(err, "Failed adding user to Owners")
+	}
+	return password, nil
+}
+
+// RemoveProjectMember removes a user from a project. Only the project membership is removed, the git
+// account is kept because it may be used outside of FuseML.
+func (gac *AdminClient) RemoveProjectMember(ctx context.Context, org, userName string) (err error) {
+	ctx, span := tracing.Start(ctx, "gitea.RemoveProjectMember")
+	defer tracing.End(span, &err)
+
+	gac.logger.InfoContext(ctx, "Removing user from project", "project", org, "user", userName)
+
+	member, err := gac.getProjectMember(ctx, org, userName)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return domain.ErrProjectMemberNotFound
+	}
+
+	if _, err := gac.giteaClient.DeleteOrgMembership(org, userName); err != nil {
+		return errors.Wrap(err, "Failed to remove user from project")
+	}
+	return nil
+}
+
+// ResetUserPassword replaces the password of a project member with a newly generated one
+func (gac *AdminClient) ResetUserPassword(ctx context.Context, org, userName string) (_ *string, err error) {
+	ctx, span := tracing.Start(ctx, "gitea.ResetUserPassword")
+	defer tracing.End(span, &err)
+
+	gac.logger.InfoContext(ctx, "Rotating user credentials", "project", org, "user", userName)
+
+	member, err := gac.getProjectMember(ctx, org, userName)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, domain.ErrProjectMemberNotFound
+	}
+
+	password := generatePassword()
+	_, err = gac.giteaClient.AdminEditUser(userName, gitea.EditUserOption{
+		LoginName:          userName,
+		Password:           password,
+		MustChangePassword: gitea.OptionalBool(false),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to reset user password")
+	}
+	return &password, nil
+}
+
+// GetProjects retrieves the page of projects (orgs) selected by the list options. Project owners are only
+// fetched for the projects on the requested page.
+func (gac *AdminClient) GetProjects(ctx context.Context, opts *domain.ListOptions) (_ []*domain.Project, _ string, err error) {
+	_, span := tracing.Start(ctx, "gitea.GetProjects")
+	defer tracing.End(span, &err)
+
+	gac.logger.DebugContext(ctx, "Listing projects")
+
+	w, err := opts.Window(domain.ProjectSortFields, domain.ProjectDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	orgs, err := gac.listOrgs()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Failed to list orgs")
+	}
+
+	ret := make([]*domain.Project, len(orgs))
+	for i, o := range orgs {
+		ret[i] = &domain.Project{
 			Name:        o.UserName,
 			Description: o.Description,
-			Users:       users,
-		})
+		}
 	}
-	return ret, nil
+	next := w.Apply(&ret)
+
+	for _, p := range ret {
+		users, err := gac.getProjectOwners(p.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		p.Users = users
+	}
+	return ret, next, nil
 }
 
 // GetProject retrieves a project by its name
-func (gac *AdminClient) GetProject(name string) (*domain.Project, error) {
-	gac.logger.Printf("Fetching git org %s....", name)
+func (gac *AdminClient) GetProject(ctx context.Context, name string) (_ *domain.Project, err error) {
+	_, span := tracing.Start(ctx, "gitea.GetProject")
+	defer tracing.End(span, &err)
 
-	org, _, err := gac.giteaClient.GetOrg(name)
+	gac.logger.DebugContext(ctx, "Fetching project", "project", name)
+
+	org, resp, err := gac.giteaClient.GetOrg(name)
+	if resp != nil && resp.StatusCode == 404 {
+		return nil, domain.ErrProjectNotFound
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to make get org request")
 	}
@@ -501,8 +804,11 @@ func (gac *AdminClient) GetProject(name string) (*domain.Project, error) {
 }
 
 // DeleteProject deletes a project
-func (gac *AdminClient) DeleteProject(org string) error {
-	gac.logger.Printf("Deleting project %s....", org)
+func (gac *AdminClient) DeleteProject(ctx context.Context, org string) (err error) {
+	_, span := tracing.Start(ctx, "gitea.DeleteProject")
+	defer tracing.End(span, &err)
+
+	gac.logger.InfoContext(ctx, "Deleting project", "project", org)
 	// 1. check if they are no repos
 	repos, _, err := gac.giteaClient.ListOrgRepos(org, gitea.ListOrgReposOptions{})
 	if err != nil {
@@ -513,29 +819,28 @@ func (gac *AdminClient) DeleteProject(org string) error {
 		return errProjectNotEmpty
 	}
 
-	// 2. delete all members of the project, if they are not owning any other project
+	// 2. delete the user FuseML created for the project, if it is not owning any other project.
+	// Accounts of the project members are kept, they lose their membership together with the org.
+	userName := generateUserName(org)
 	owners, err := gac.getProjectOwners(org)
 	if err != nil {
 		return errors.Wrap(err, "Failed to list project owners")
 	}
-	usersOrgs := make(map[string]int)
 	for _, owner := range owners {
-		orgsForUser, _, err := gac.giteaClient.ListUserOrgs(owner.Name, gitea.ListOrgsOptions{})
+		if owner.Name != userName {
+			continue
+		}
+		orgsForUser, _, err := gac.giteaClient.ListUserOrgs(userName, gitea.ListOrgsOptions{})
 		if err != nil {
 			return errors.Wrap(err, "Failed to list orgs for user")
 		}
-		for range orgsForUser {
-			usersOrgs[owner.Name]++
-		}
-	}
-	for userName, orgNumber := range usersOrgs {
-		if orgNumber == 1 {
-			gac.logger.Printf("Removing user %s from %s ....", userName, org)
+		if len(orgsForUser) == 1 {
+			gac.logger.InfoContext(ctx, "Removing user from project", "project", org, "user", userName)
 			if _, err := gac.giteaClient.DeleteOrgMembership(org, userName); err != nil {
 				return errors.Wrap(err, "Failed to remove user from project")
 			}
 
-			gac.logger.Printf("Deleting user %s....", userName)
+			gac.logger.InfoContext(ctx, "Deleting user", "user", userName)
 			if _, err := gac.giteaClient.AdminDeleteUser(userName); err != nil {
 				return errors.Wrap(err, "Failed to delete user")
 			}
@@ -1,14 +1,17 @@
 package gitea
 
 import (
-	"io"
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
+	"sort"
 	"testing"
 
 	"code.gitea.io/sdk/gitea"
+	"github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/logging"
 	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
@@ -16,13 +19,18 @@ type TestStore struct {
 	projects       map[string]gitea.Organization
 	projects2repos map[string]map[string]gitea.Repository
 	teams          map[int64][]string
+	users          map[string]gitea.User
+	passwords      map[string]string
+	hooks          map[string][]*gitea.Hook
+	lastHookID     int64
+	unavailable    bool
 }
 
 // Replace all methods that are caled from actual gitea client with the ones operating
 // on local structures instead of git server
 type testGiteaClient struct {
 	testStore *TestStore
-	logger    *log.Logger
+	logger    *slog.Logger
 }
 
 func NewTestStore() *TestStore {
@@ -30,16 +38,15 @@ func NewTestStore() *TestStore {
 		projects:       make(map[string]gitea.Organization),
 		projects2repos: make(map[string]map[string]gitea.Repository),
 		teams:          make(map[int64][]string),
+		users:          make(map[string]gitea.User),
+		passwords:      make(map[string]string),
+		hooks:          make(map[string][]*gitea.Hook),
 	}
 }
 
-// Set a specific logger just for testing
-func testLogger() *log.Logger {
-
-	logger := log.New(os.Stderr, "[test] ", log.Ltime)
-	// suppress the regular output from app
-	logger.SetOutput(io.Discard)
-	return logger
+// Set a specific logger just for testing, suppressing the regular output from app
+func testLogger() *slog.Logger {
+	return logging.Discard()
 }
 
 func newTestGiteaAdminClient(testStore *TestStore) *AdminClient {
@@ -65,13 +72,25 @@ func (tc *testGiteaClient) CreateOrg(opt gitea.CreateOrgOption) (*gitea.Organiza
 	tc.testStore.projects2repos[opt.Name] = make(map[string]gitea.Repository)
 	return &org, nil, nil
 }
-func (tc *testGiteaClient) GetUserInfo(string) (*gitea.User, *gitea.Response, error) {
+func (tc *testGiteaClient) GetUserInfo(user string) (*gitea.User, *gitea.Response, error) {
+	if u, ok := tc.testStore.users[user]; ok {
+		return &u, &gitea.Response{Response: &httpResp200}, nil
+	}
 	return &gitea.User{ID: 0}, nil, nil
 }
-func (tc *testGiteaClient) AdminCreateUser(gitea.CreateUserOption) (*gitea.User, *gitea.Response, error) {
-	return nil, nil, nil
+func (tc *testGiteaClient) AdminCreateUser(opt gitea.CreateUserOption) (*gitea.User, *gitea.Response, error) {
+	u := gitea.User{ID: int64(len(tc.testStore.users) + 1), UserName: opt.Username, Email: opt.Email}
+	tc.testStore.users[opt.Username] = u
+	tc.testStore.passwords[opt.Username] = opt.Password
+	return &u, nil, nil
+}
+func (tc *testGiteaClient) AdminEditUser(user string, opt gitea.EditUserOption) (*gitea.Response, error) {
+	tc.testStore.passwords[user] = opt.Password
+	return &gitea.Response{Response: &httpResp200}, nil
 }
 func (tc *testGiteaClient) AdminDeleteUser(user string) (*gitea.Response, error) {
+	delete(tc.testStore.users, user)
+	delete(tc.testStore.passwords, user)
 	return nil, nil
 }
 func (tc *testGiteaClient) ListOrgTeams(string, gitea.ListTeamsOptions) ([]*gitea.Team, *gitea.Response, error) {
@@ -84,11 +103,22 @@ func (tc *testGiteaClient) AddTeamMember(id int64, username string) (*gitea.Resp
 	return nil, nil
 }
 func (tc *testGiteaClient) DeleteOrgMembership(org, user string) (*gitea.Response, error) {
+	// all orgs share the same Owners team
+	members := make([]string, 0)
+	for _, m := range tc.testStore.teams[42] {
+		if m != user {
+			members = append(members, m)
+		}
+	}
+	tc.testStore.teams[42] = members
 	return &gitea.Response{Response: &httpResp200}, nil
 }
 
 func (tc *testGiteaClient) ListTeamMembers(id int64, opts gitea.ListTeamMembersOptions) ([]*gitea.User, *gitea.Response, error) {
 	users := make([]*gitea.User, 0)
+	for _, m := range tc.testStore.teams[id] {
+		users = append(users, &gitea.User{UserName: m})
+	}
 	return users, &gitea.Response{Response: &httpResp200}, nil
 }
 
@@ -103,39 +133,81 @@ func (tc *testGiteaClient) CreateOrgRepo(org string, repo gitea.CreateRepoOption
 	tc.testStore.projects2repos[org][repo.Name] = r
 	return &r, nil, nil
 }
-func (tc *testGiteaClient) ListRepoHooks(string, string, gitea.ListHooksOptions) ([]*gitea.Hook, *gitea.Response, error) {
-	return nil, nil, nil
+func (tc *testGiteaClient) ListRepoHooks(org, repo string, opt gitea.ListHooksOptions) ([]*gitea.Hook, *gitea.Response, error) {
+	hooks := tc.testStore.hooks[org+"/"+repo]
+	start, end := pageBounds(len(hooks), opt.ListOptions)
+	return hooks[start:end], nil, nil
+}
+
+// pageBounds returns the range of items on a page of results requested from the gitea API
+func pageBounds(count int, opt gitea.ListOptions) (int, int) {
+	// the gitea server returns the first page, with the maximum page size, if no paging options are set
+	if opt.Page < 1 {
+		opt.Page = 1
+	}
+	if opt.PageSize < 1 {
+		opt.PageSize = 50
+	}
+	start := (opt.Page - 1) * opt.PageSize
+	if start > count {
+		start = count
+	}
+	end := start + opt.PageSize
+	if end > count {
+		end = count
+	}
+	return start, end
 }
+
 func (tc *testGiteaClient) ListOrgRepos(org string, opt gitea.ListOrgReposOptions) ([]*gitea.Repository, *gitea.Response, error) {
 	repos := make([]*gitea.Repository, 0)
 	for _, repo := range tc.testStore.projects2repos[org] {
 		r := repo
 		repos = append(repos, &r)
 	}
-	return repos, nil, nil
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+	start, end := pageBounds(len(repos), opt.ListOptions)
+	return repos[start:end], nil, nil
 }
 
 func (tc *testGiteaClient) ListUserOrgs(user string, opt gitea.ListOrgsOptions) ([]*gitea.Organization, *gitea.Response, error) {
+	// all orgs share the same Owners team, so a member of the team belongs to a single org
 	userOrgs := make([]*gitea.Organization, 0)
+	if util.StringInSlice(user, tc.testStore.teams[42]) {
+		userOrgs = append(userOrgs, &gitea.Organization{})
+	}
 	return userOrgs, nil, nil
 }
 
-func (tc *testGiteaClient) CreateRepoHook(string, string, gitea.CreateHookOption) (*gitea.Hook, *gitea.Response, error) {
-	return &gitea.Hook{ID: int64(1)}, nil, nil
-}
-func (tc *testGiteaClient) DeleteRepoHook(string, string, int64) (*gitea.Response, error) {
-	return &gitea.Response{Response: &httpResp200}, nil
+func (tc *testGiteaClient) CreateRepoHook(org, repo string, opt gitea.CreateHookOption) (*gitea.Hook, *gitea.Response, error) {
+	key := org + "/" + repo
+	tc.testStore.lastHookID++
+	hook := &gitea.Hook{ID: tc.testStore.lastHookID, Config: opt.Config}
+	tc.testStore.hooks[key] = append(tc.testStore.hooks[key], hook)
+	return hook, nil, nil
+}
+func (tc *testGiteaClient) DeleteRepoHook(org, repo string, id int64) (*gitea.Response, error) {
+	key := org + "/" + repo
+	for i, hook := range tc.testStore.hooks[key] {
+		if hook.ID == id {
+			tc.testStore.hooks[key] = append(tc.testStore.hooks[key][:i], tc.testStore.hooks[key][i+1:]...)
+			return &gitea.Response{Response: &httpResp200}, nil
+		}
+	}
+	return &gitea.Response{Response: &httpResp404}, fmt.Errorf("hook not found")
 }
 func (tc *testGiteaClient) ListRepoTopics(org, repo string, opt gitea.ListRepoTopicsOptions) ([]string, *gitea.Response, error) {
 	return nil, nil, nil
 }
-func (tc *testGiteaClient) ListMyOrgs(gitea.ListOrgsOptions) ([]*gitea.Organization, *gitea.Response, error) {
+func (tc *testGiteaClient) ListMyOrgs(opt gitea.ListOrgsOptions) ([]*gitea.Organization, *gitea.Response, error) {
 	allOrgs := make([]*gitea.Organization, 0)
 	for _, org := range tc.testStore.projects {
 		o := org
 		allOrgs = append(allOrgs, &o)
 	}
-	return allOrgs, nil, nil
+	sort.Slice(allOrgs, func(i, j int) bool { return allOrgs[i].UserName < allOrgs[j].UserName })
+	start, end := pageBounds(len(allOrgs), opt.ListOptions)
+	return allOrgs[start:end], nil, nil
 }
 
 func (tc *testGiteaClient) DeleteRepo(owner, repo string) (*gitea.Response, error) {
@@ -148,11 +220,19 @@ func (tc *testGiteaClient) DeleteOrg(orgname string) (*gitea.Response, error) {
 	return nil, nil
 }
 
+func (tc *testGiteaClient) ServerVersion() (string, *gitea.Response, error) {
+	if tc.testStore.unavailable {
+		return "", nil, fmt.Errorf("connection refused")
+	}
+	return testVersion, nil, nil
+}
+
 var (
 	project1              = "test-project1"
 	project2              = "test-project2"
 	name                  = "test"
 	testURL               = "http://gitea.example.io"
+	testVersion           = "1.14.2"
 	testListenerStringURL = "tekton-listener"
 	testListenerURL       = &testListenerStringURL
 	httpResp200           = http.Response{StatusCode: 200}
@@ -188,7 +268,7 @@ func TestPrepareRepository(t *testing.T) {
 		t.Errorf("Initial number of teams is not empty")
 	}
 
-	_, _, err := testGiteaAdminClient.PrepareRepository(code, testListenerURL)
+	_, _, err := testGiteaAdminClient.PrepareRepository(context.Background(), code, testListenerURL)
 	if err != nil {
 		t.Errorf("Error preparing repository: %v", err)
 	}
@@ -217,15 +297,15 @@ func TestGetRepository(t *testing.T) {
 	testGiteaAdminClient := newTestGiteaAdminClient(NewTestStore())
 
 	// Reading repo that was not added should throw error
-	_, err := testGiteaAdminClient.GetRepository(project1, name)
+	_, err := testGiteaAdminClient.GetRepository(context.Background(), project1, name)
 
 	assertError(t, err, errRepoNotFound)
 
 	// Prepare new repo
-	testGiteaAdminClient.PrepareRepository(getTestCodeset(), testListenerURL)
+	testGiteaAdminClient.PrepareRepository(context.Background(), getTestCodeset(), testListenerURL)
 
 	// Get the repo now
-	c, err := testGiteaAdminClient.GetRepository(project1, name)
+	c, err := testGiteaAdminClient.GetRepository(context.Background(), project1, name)
 	if err != nil {
 		t.Errorf("Error geting repository that was just created")
 	}
@@ -239,49 +319,84 @@ func TestDeleteRepository(t *testing.T) {
 	testGiteaAdminClient := newTestGiteaAdminClient(NewTestStore())
 
 	// Reading repo that was not added should throw error
-	_, err := testGiteaAdminClient.GetRepository(project1, name)
+	_, err := testGiteaAdminClient.GetRepository(context.Background(), project1, name)
 
 	assertError(t, err, errRepoNotFound)
 
 	// Prepare new repo
-	testGiteaAdminClient.PrepareRepository(getTestCodeset(), testListenerURL)
+	testGiteaAdminClient.PrepareRepository(context.Background(), getTestCodeset(), testListenerURL)
 
 	// Get the repo now
-	_, err = testGiteaAdminClient.GetRepository(project1, name)
+	_, err = testGiteaAdminClient.GetRepository(context.Background(), project1, name)
 	if err != nil {
 		t.Errorf("Error geting repository that was just created")
 	}
 
-	err = testGiteaAdminClient.DeleteRepository(project1, name)
+	err = testGiteaAdminClient.DeleteRepository(context.Background(), project1, name)
 	if err != nil {
 		t.Errorf("Error deleting repository")
 	}
 
-	c, _ := testGiteaAdminClient.GetRepository(project1, name)
+	c, _ := testGiteaAdminClient.GetRepository(context.Background(), project1, name)
 	if c != nil {
 		t.Errorf("Repository still present after deleting")
 	}
 
-	err = testGiteaAdminClient.DeleteRepository(project1, name)
+	err = testGiteaAdminClient.DeleteRepository(context.Background(), project1, name)
 	if err != nil {
 		t.Errorf("Error: deleting non existent repository should not fail")
 	}
 }
 
+func TestRepoWebhooks(t *testing.T) {
+	ctx := context.Background()
+	testGiteaAdminClient := newTestGiteaAdminClient(NewTestStore())
+	testGiteaAdminClient.PrepareRepository(ctx, getTestCodeset(), nil)
+
+	id, err := testGiteaAdminClient.CreateRepoWebhook(ctx, project1, name, testListenerURL)
+	if err != nil {
+		t.Fatalf("Error creating webhook: %v", err)
+	}
+	// creating a webhook for the same listener returns the existing one
+	again, err := testGiteaAdminClient.CreateRepoWebhook(ctx, project1, name, testListenerURL)
+	if err != nil || *again != *id {
+		t.Errorf("Got webhook %d (error %v), want the existing webhook %d", *again, err, *id)
+	}
+	otherURL := "other-listener"
+	other, _ := testGiteaAdminClient.CreateRepoWebhook(ctx, project1, name, &otherURL)
+
+	hooks, err := testGiteaAdminClient.GetRepoWebhooks(ctx, project1, name)
+	if err != nil {
+		t.Fatalf("Error listing webhooks: %v", err)
+	}
+	want := []*domain.Webhook{{ID: *id, URL: testListenerStringURL}, {ID: *other, URL: otherURL}}
+	if len(hooks) != len(want) || *hooks[0] != *want[0] || *hooks[1] != *want[1] {
+		t.Errorf("Unexpected webhooks: %v", hooks)
+	}
+
+	if err := testGiteaAdminClient.DeleteRepoWebhook(ctx, project1, name, id); err != nil {
+		t.Fatalf("Error deleting webhook: %v", err)
+	}
+	hooks, _ = testGiteaAdminClient.GetRepoWebhooks(ctx, project1, name)
+	if len(hooks) != 1 || hooks[0].ID != *other {
+		t.Errorf("Unexpected webhooks after deleting one: %v", hooks)
+	}
+}
+
 func TestGetRepositories(t *testing.T) {
 
 	testGiteaAdminClient := newTestGiteaAdminClient(NewTestStore())
 
-	repos, err := testGiteaAdminClient.GetRepositories(&project1, nil)
+	repos, _, err := testGiteaAdminClient.GetRepositories(context.Background(), &project1, nil, nil)
 	if len(repos) > 0 {
 		t.Errorf("Initial set of repositories is not empty")
 	}
 	if err != nil {
 		t.Errorf("Error reading list of repositories")
 	}
-	testGiteaAdminClient.PrepareRepository(getTestCodeset(), testListenerURL)
+	testGiteaAdminClient.PrepareRepository(context.Background(), getTestCodeset(), testListenerURL)
 
-	repos, _ = testGiteaAdminClient.GetRepositories(&project1, nil)
+	repos, _, _ = testGiteaAdminClient.GetRepositories(context.Background(), &project1, nil, nil)
 	if len(repos) < 1 {
 		t.Errorf("List of repositories is empty after adding")
 	}
@@ -289,28 +404,72 @@ func TestGetRepositories(t *testing.T) {
 	// now add new project+repo and list all repos accross projects
 	codeset2 := getTestCodeset()
 	codeset2.Project = project2
-	testGiteaAdminClient.PrepareRepository(codeset2, testListenerURL)
+	testGiteaAdminClient.PrepareRepository(context.Background(), codeset2, testListenerURL)
 
-	repos, _ = testGiteaAdminClient.GetRepositories(nil, nil)
+	repos, _, _ = testGiteaAdminClient.GetRepositories(context.Background(), nil, nil, nil)
 	if len(repos) != 2 {
 		t.Errorf("There are not 2 repos in total")
 	}
 }
 
+func TestGetRepositoriesPaging(t *testing.T) {
+
+	testGiteaAdminClient := newTestGiteaAdminClient(NewTestStore())
+
+	// more repositories than fit in a single page of gitea results
+	for i := 0; i < giteaPageSize+5; i++ {
+		codeset := getTestCodeset()
+		codeset.Name = fmt.Sprintf("test-%03d", i)
+		testGiteaAdminClient.PrepareRepository(context.Background(), codeset, testListenerURL)
+	}
+
+	var names []string
+	opts := &domain.ListOptions{Limit: 20, Sort: "-name"}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("Too many pages")
+		}
+		repos, next, err := testGiteaAdminClient.GetRepositories(context.Background(), &project1, nil, opts)
+		assertError(t, err, nil)
+		if len(repos) > opts.Limit {
+			t.Errorf("Page has %d repositories, limit is %d", len(repos), opts.Limit)
+		}
+		for _, r := range repos {
+			names = append(names, r.Name)
+		}
+		if next == "" {
+			break
+		}
+		opts.Continue = next
+	}
+
+	if len(names) != giteaPageSize+5 {
+		t.Fatalf("Expected %d repositories, got %d", giteaPageSize+5, len(names))
+	}
+	if names[0] != fmt.Sprintf("test-%03d", giteaPageSize+4) || names[len(names)-1] != "test-000" {
+		t.Errorf("Repositories are not sorted by name in descending order: %v", names)
+	}
+
+	_, _, err := testGiteaAdminClient.GetRepositories(context.Background(), &project1, nil, &domain.ListOptions{Sort: "url"})
+	if _, ok := err.(*domain.ErrInvalidListOptions); !ok {
+		t.Errorf("Expected invalid list options error, got %v", err)
+	}
+}
+
 func TestAddDeleteOrgs(t *testing.T) {
 
 	testGiteaAdminClient := newTestGiteaAdminClient(NewTestStore())
 
-	testGiteaAdminClient.PrepareRepository(getTestCodeset(), testListenerURL)
+	testGiteaAdminClient.PrepareRepository(context.Background(), getTestCodeset(), testListenerURL)
 
-	p1, err := testGiteaAdminClient.GetProject(project1)
+	p1, err := testGiteaAdminClient.GetProject(context.Background(), project1)
 	assertError(t, err, nil)
 
 	if p1.Name != project1 {
 		t.Errorf("wrong name of project: %v, not %s", p1.Name, project1)
 	}
 
-	p2, err := testGiteaAdminClient.CreateProject(project2, "description of "+project2, false)
+	p2, err := testGiteaAdminClient.CreateProject(context.Background(), project2, "description of "+project2, false)
 	assertError(t, err, nil)
 
 	if p2.Name != project2 {
@@ -318,15 +477,15 @@ func TestAddDeleteOrgs(t *testing.T) {
 	}
 
 	// create same project, ignore if it exists
-	_, err = testGiteaAdminClient.CreateProject(project2, "description of "+project2, true)
+	_, err = testGiteaAdminClient.CreateProject(context.Background(), project2, "description of "+project2, true)
 	assertError(t, err, nil)
 
 	// create same project, fail if it exists
-	_, err = testGiteaAdminClient.CreateProject(project2, "description of "+project2, false)
+	_, err = testGiteaAdminClient.CreateProject(context.Background(), project2, "description of "+project2, false)
 	assertError(t, err, domain.ErrProjectExists)
 
 	// list all projects, there should be 2
-	projects, err := testGiteaAdminClient.GetProjects()
+	projects, _, err := testGiteaAdminClient.GetProjects(context.Background(), nil)
 	assertError(t, err, nil)
 
 	if len(projects) != 2 {
@@ -334,15 +493,15 @@ func TestAddDeleteOrgs(t *testing.T) {
 	}
 
 	// project2 is empty, should not be a problem to delete
-	err = testGiteaAdminClient.DeleteProject(project2)
+	err = testGiteaAdminClient.DeleteProject(context.Background(), project2)
 	assertError(t, err, nil)
 
 	// project1 is not empty, error on delete
-	err = testGiteaAdminClient.DeleteProject(project1)
+	err = testGiteaAdminClient.DeleteProject(context.Background(), project1)
 	assertError(t, err, errProjectNotEmpty)
 
 	// list all projects after delete
-	projects, err = testGiteaAdminClient.GetProjects()
+	projects, _, err = testGiteaAdminClient.GetProjects(context.Background(), nil)
 	assertError(t, err, nil)
 
 	if len(projects) != 1 {
@@ -352,8 +511,112 @@ func TestAddDeleteOrgs(t *testing.T) {
 
 func TestNewGiteaAdminClient(t *testing.T) {
 
-	os.Unsetenv("GITEA_URL")
-	_, err := NewAdminClient(testLogger())
+	_, err := NewAdminClient(testLogger(), &config.Gitea{AdminUsername: "admin", AdminPassword: "password"})
 
 	assertError(t, err, errGITEAURLMissing)
 }
+
+func TestCheckDependencies(t *testing.T) {
+
+	testStore := NewTestStore()
+	testGiteaAdminClient := newTestGiteaAdminClient(testStore)
+
+	got := testGiteaAdminClient.CheckDependencies(context.Background())
+	if len(got) != 1 {
+		t.Fatalf("got %d dependencies want 1", len(got))
+	}
+	if got[0].Name != "gitea" || got[0].Endpoint != testURL || got[0].Version != testVersion || !got[0].Available() {
+		t.Errorf("Unexpected status of available gitea: %+v", got[0])
+	}
+
+	testStore.unavailable = true
+	got = testGiteaAdminClient.CheckDependencies(context.Background())
+	if got[0].Error != "connection refused" {
+		t.Errorf("got error %q want %q", got[0].Error, "connection refused")
+	}
+}
+
+func TestProjectMembers(t *testing.T) {
+
+	testStore := NewTestStore()
+	testGiteaAdminClient := newTestGiteaAdminClient(testStore)
+
+	_, err := testGiteaAdminClient.AddProjectMember(context.Background(), project1, &domain.User{Name: "alice"})
+	assertError(t, err, domain.ErrProjectNotFound)
+
+	_, err = testGiteaAdminClient.CreateProject(context.Background(), project1, "", false)
+	assertError(t, err, nil)
+
+	// adding a new member creates a dedicated account
+	password, err := testGiteaAdminClient.AddProjectMember(context.Background(), project1, &domain.User{Name: "alice"})
+	assertError(t, err, nil)
+	if password == nil || *password == "" || *password != testStore.passwords["alice"] {
+		t.Errorf("Unexpected password returned for new member: %v", password)
+	}
+
+	_, err = testGiteaAdminClient.AddProjectMember(context.Background(), project1, &domain.User{Name: "alice"})
+	assertError(t, err, domain.ErrProjectMemberExists)
+
+	members, err := testGiteaAdminClient.GetProjectMembers(context.Background(), project1)
+	assertError(t, err, nil)
+	if len(members) != 1 || members[0].Name != "alice" {
+		t.Errorf("Unexpected project members: %v", members)
+	}
+
+	// rotating credentials replaces the member password
+	newPassword, err := testGiteaAdminClient.ResetUserPassword(context.Background(), project1, "alice")
+	assertError(t, err, nil)
+	if newPassword == nil || *newPassword == *password || *newPassword != testStore.passwords["alice"] {
+		t.Errorf("Password was not rotated: %v", newPassword)
+	}
+
+	_, err = testGiteaAdminClient.ResetUserPassword(context.Background(), project1, "bob")
+	assertError(t, err, domain.ErrProjectMemberNotFound)
+
+	// removing the member keeps the account, it may be used outside of FuseML
+	err = testGiteaAdminClient.RemoveProjectMember(context.Background(), project1, "alice")
+	assertError(t, err, nil)
+	if _, ok := testStore.users["alice"]; !ok {
+		t.Errorf("User account deleted after removing member")
+	}
+	if util.StringInSlice("alice", testStore.teams[42]) {
+		t.Errorf("User still a member after removing member")
+	}
+
+	err = testGiteaAdminClient.RemoveProjectMember(context.Background(), project1, "alice")
+	assertError(t, err, domain.ErrProjectMemberNotFound)
+}
+
+func TestDeleteProjectUsers(t *testing.T) {
+
+	testStore := NewTestStore()
+	testGiteaAdminClient := newTestGiteaAdminClient(testStore)
+
+	_, err := testGiteaAdminClient.CreateProject(context.Background(), project1, "", false)
+	assertError(t, err, nil)
+
+	// the project user created by FuseML gets a generated password
+	user, password, err := testGiteaAdminClient.CreateUser(context.Background(), project1)
+	assertError(t, err, nil)
+	if user == nil || *user != generateUserName(project1) {
+		t.Fatalf("Unexpected project user: %v", user)
+	}
+	if password == nil || len(*password) != generatedPasswordLength || *password != testStore.passwords[*user] {
+		t.Errorf("Unexpected password for project user: %v", password)
+	}
+
+	// an account that existed before it was added to the project
+	testStore.users["bob"] = gitea.User{ID: 100, UserName: "bob"}
+	_, err = testGiteaAdminClient.AddProjectMember(context.Background(), project1, &domain.User{Name: "bob"})
+	assertError(t, err, nil)
+
+	err = testGiteaAdminClient.DeleteProject(context.Background(), project1)
+	assertError(t, err, nil)
+
+	if _, ok := testStore.users[*user]; ok {
+		t.Errorf("Project user still present after deleting project")
+	}
+	if _, ok := testStore.users["bob"]; !ok {
+		t.Errorf("Account of project member deleted with project")
+	}
+}
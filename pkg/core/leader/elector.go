@@ -0,0 +1,158 @@
+// Package leader elects, among the fuseml-core servers sharing the storage backend, the one that runs the
+// background work that must only be done once, such as probing the extension endpoints or delivering the
+// notifications. The leader holds a kubernetes Lease, which it renews for as long as it is running.
+//
+// Leader election does not share the event bus between the servers: the events published for the
+// changes made through a server, and the watches and event subscribers fed by them, stay on that
+// server. Only the workflow runs, which every server watches in tekton, are seen by all of them.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/fuseml/fuseml-core/pkg/core/config"
+)
+
+// Task is background work started by the leader. It must run in the background, until the context is
+// cancelled, and add the goroutines it starts to the wait group.
+type Task func(ctx context.Context, wg *sync.WaitGroup)
+
+// Elector runs tasks while the server is the leader. When leader election is disabled, the server is
+// the only one and always leads.
+type Elector struct {
+	logger *slog.Logger
+	config *config.LeaderElection
+	lock   resourcelock.Interface
+
+	leading atomic.Bool
+
+	mu sync.Mutex
+	// stopped is set when the elector stops, after which no new term can start
+	stopped bool
+	// terms tracks the terms of the leader, until their tasks return
+	terms sync.WaitGroup
+	// term is held by the current term until its tasks return, so that the tasks of a new term do not
+	// start while those of the previous one are still running
+	term sync.Mutex
+}
+
+// NewElector initializes an elector that holds the Lease configured by cfg through the coordination client.
+// The client is not used, and may be nil, when leader election is disabled.
+func NewElector(logger *slog.Logger, cfg *config.LeaderElection, client coordinationv1.LeasesGetter) (*Elector, error) {
+	e := &Elector{logger: logger, config: cfg}
+	if !cfg.Enabled {
+		return e, nil
+	}
+
+	identity := cfg.Identity
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the leader election identity: %w", err)
+		}
+		identity = hostname
+	}
+	e.lock = &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: cfg.LeaseName, Namespace: cfg.LeaseNamespace},
+		Client:     client,
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+	return e, nil
+}
+
+// IsLeader returns true while the server is the leader
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Start runs the tasks in the background, every time the server becomes the leader, until the context is
+// cancelled. The context passed to the tasks is cancelled when the server stops being the leader, and the
+// Lease is released when the context passed to Start is cancelled, for another server to take over.
+func (e *Elector) Start(ctx context.Context, wg *sync.WaitGroup, tasks ...Task) error {
+	if e.lock == nil {
+		e.leading.Store(true)
+		for _, task := range tasks {
+			task(ctx, wg)
+		}
+		return nil
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            e.lock,
+		LeaseDuration:   e.config.LeaseDuration,
+		RenewDeadline:   e.config.RenewDeadline,
+		RetryPeriod:     e.config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            e.config.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) { e.lead(leaderCtx, tasks) },
+			// the tasks are stopped when the context passed to lead is cancelled
+			OnStoppedLeading: func() {},
+			OnNewLeader: func(identity string) {
+				e.logger.Info("Leader elected", "lease", e.config.LeaseName, "leader", identity)
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// the elector returns when the server stops being the leader, and campaigns again, until the
+		// server shuts down
+		for ctx.Err() == nil {
+			elector.Run(ctx)
+		}
+
+		e.mu.Lock()
+		e.stopped = true
+		e.mu.Unlock()
+		e.terms.Wait()
+	}()
+	return nil
+}
+
+// lead runs the tasks until the leader context is cancelled, and waits for them to return. The leader
+// election calls it in the background, so a term won after losing the lease may start before the
+// previous one returns: its tasks are only started once those of the previous term have returned.
+func (e *Elector) lead(ctx context.Context, tasks []Task) {
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return
+	}
+	e.terms.Add(1)
+	e.mu.Unlock()
+	defer e.terms.Done()
+
+	e.term.Lock()
+	defer e.term.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+
+	e.logger.Info("Started leading", "lease", e.config.LeaseName)
+	e.leading.Store(true)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		task(ctx, &wg)
+	}
+
+	<-ctx.Done()
+	e.leading.Store(false)
+	e.logger.Info("Stopped leading", "lease", e.config.LeaseName)
+	wg.Wait()
+}
@@ -0,0 +1,216 @@
+package leader
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+
+	"github.com/fuseml/fuseml-core/pkg/core/config"
+)
+
+// testConfig returns a leader election configuration with short durations, for the tests to run quickly
+func testConfig(identity string) *config.LeaderElection {
+	return &config.LeaderElection{
+		Enabled:        true,
+		LeaseName:      "fuseml-core",
+		LeaseNamespace: "fuseml-core",
+		Identity:       identity,
+		LeaseDuration:  time.Second,
+		RenewDeadline:  500 * time.Millisecond,
+		RetryPeriod:    100 * time.Millisecond,
+	}
+}
+
+// testServer is a server taking part in the election, which records whether its task is running
+type testServer struct {
+	elector *Elector
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex
+	running bool
+	runs    int
+}
+
+func startServer(t *testing.T, client coordinationv1.LeasesGetter, identity string) *testServer {
+	t.Helper()
+
+	elector, err := NewElector(slog.Default(), testConfig(identity), client)
+	if err != nil {
+		t.Fatalf("Failed to create elector: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &testServer{elector: elector, cancel: cancel}
+	task := func(ctx context.Context, wg *sync.WaitGroup) {
+		s.setRunning(true)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			s.setRunning(false)
+		}()
+	}
+	if err := elector.Start(ctx, &s.wg, task); err != nil {
+		t.Fatalf("Failed to start elector: %v", err)
+	}
+	return s
+}
+
+func (s *testServer) setRunning(running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = running
+	if running {
+		s.runs++
+	}
+}
+
+func (s *testServer) isRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// stop shuts the server down and waits for the elector and the tasks to return
+func (s *testServer) stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// waitFor polls a condition until it is true, failing the test if it isn't after a few lease durations
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestElectorDisabled(t *testing.T) {
+	cfg := testConfig("")
+	cfg.Enabled = false
+	elector, err := NewElector(slog.Default(), cfg, nil)
+	if err != nil {
+		t.Fatalf("Failed to create elector: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	started := false
+	err = elector.Start(ctx, &wg, func(ctx context.Context, wg *sync.WaitGroup) { started = true })
+	if err != nil {
+		t.Fatalf("Failed to start elector: %v", err)
+	}
+	if !started || !elector.IsLeader() {
+		t.Errorf("The tasks were not started by the only server")
+	}
+}
+
+func TestElector(t *testing.T) {
+	client := fake.NewSimpleClientset().CoordinationV1()
+
+	first := startServer(t, client, "first")
+	waitFor(t, "the first server to lead", first.isRunning)
+	second := startServer(t, client, "second")
+	defer second.stop()
+
+	// the second server does not run the task while the first one renews the lease
+	time.Sleep(3 * time.Second)
+	if second.isRunning() || second.elector.IsLeader() {
+		t.Fatalf("The task runs on both servers")
+	}
+	lease, err := client.Leases("fuseml-core").Get(context.TODO(), "fuseml-core", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the lease: %v", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != "first" {
+		t.Errorf("Unexpected lease holder: %v", lease.Spec.HolderIdentity)
+	}
+
+	// the first server releases the lease when it shuts down, and the second one takes over
+	first.stop()
+	if first.isRunning() || first.elector.IsLeader() {
+		t.Errorf("The task still runs on the server that was shut down")
+	}
+	waitFor(t, "the second server to lead", second.isRunning)
+	if first.runs != 1 || second.runs != 1 {
+		t.Errorf("Unexpected task runs: first %d, second %d", first.runs, second.runs)
+	}
+}
+
+func TestElectorLeaseRegained(t *testing.T) {
+	client := fake.NewSimpleClientset().CoordinationV1()
+
+	elector, err := NewElector(slog.Default(), testConfig("server"), client)
+	if err != nil {
+		t.Fatalf("Failed to create elector: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	// the task takes a while to stop, and records whether it ever runs twice at once
+	var mu sync.Mutex
+	running, overlapped, runs := 0, false, 0
+	task := func(ctx context.Context, wg *sync.WaitGroup) {
+		mu.Lock()
+		running++
+		runs++
+		overlapped = overlapped || running > 1
+		mu.Unlock()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			time.Sleep(2 * time.Second)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+	}
+	if err := elector.Start(ctx, &wg, task); err != nil {
+		t.Fatalf("Failed to start elector: %v", err)
+	}
+	waitFor(t, "the server to lead", elector.IsLeader)
+
+	// another server takes the lease over, which the server loses, and regains once it expires
+	lease, err := client.Leases("fuseml-core").Get(context.TODO(), "fuseml-core", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the lease: %v", err)
+	}
+	other := "other"
+	now := metav1.NewMicroTime(time.Now())
+	lease.Spec.HolderIdentity = &other
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	if _, err := client.Leases("fuseml-core").Update(context.TODO(), lease, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update the lease: %v", err)
+	}
+	waitFor(t, "the server to stop leading", func() bool { return !elector.IsLeader() })
+	waitFor(t, "the server to lead again", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return runs == 2
+	})
+
+	cancel()
+	wg.Wait()
+	mu.Lock()
+	defer mu.Unlock()
+	if overlapped {
+		t.Errorf("The task of the new term started while the one of the previous term was running")
+	}
+	if running != 0 {
+		t.Errorf("%d tasks still running after the elector stopped", running)
+	}
+}
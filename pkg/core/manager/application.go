@@ -0,0 +1,235 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/kubernetes"
+)
+
+// applicationProbeTimeout is the time that FuseML waits for an application URL to respond
+const applicationProbeTimeout = 5 * time.Second
+
+// kubernetesCluster describes the operations performed on the kubernetes resources of an application
+type kubernetesCluster interface {
+	ResourceExists(ctx context.Context, name, namespace, kind string) (bool, error)
+	DeleteResource(ctx context.Context, name, namespace, kind string) error
+}
+
+// ApplicationManager implements the domain.ApplicationManager interface
+type ApplicationManager struct {
+	logger           *slog.Logger
+	applicationStore domain.ApplicationStore
+	workflowStore    domain.WorkflowStore
+	httpClient       *http.Client
+	// newCluster returns a client for the kubernetes cluster where the application resources are located.
+	// The cluster is only accessed when needed, so FuseML can run without one.
+	newCluster func() (kubernetesCluster, error)
+}
+
+// NewApplicationManager initializes an Application Manager and subscribes it to project
+// events, to remove the applications that belong to deleted projects
+func NewApplicationManager(
+	logger *slog.Logger,
+	applicationStore domain.ApplicationStore,
+	workflowStore domain.WorkflowStore,
+	eventBus domain.EventBus) *ApplicationManager {
+	mgr := &ApplicationManager{
+		logger:           logger,
+		applicationStore: applicationStore,
+		workflowStore:    workflowStore,
+		httpClient:       &http.Client{Timeout: applicationProbeTimeout},
+		newCluster: func() (kubernetesCluster, error) {
+			cluster, err := kubernetes.NewCluster(logger)
+			if err != nil {
+				// a nil *kubernetes.Cluster would not be a nil kubernetesCluster
+				return nil, err
+			}
+			return cluster, nil
+		},
+	}
+	eventBus.Subscribe(mgr, domain.ProjectResource)
+	return mgr
+}
+
+// RegisterApplication registers a new Application, or replaces an existing one. Workflows register their
+// applications every time they run, so an existing Application can only be replaced by the workflow that
+// created it.
+func (mgr *ApplicationManager) RegisterApplication(ctx context.Context, app *domain.Application) (*domain.Application, error) {
+	existing := mgr.applicationStore.Find(ctx, app.Name)
+	if existing == nil {
+		return mgr.applicationStore.Add(ctx, app)
+	}
+	if existing.Workflow != app.Workflow {
+		return nil, domain.ErrApplicationExists
+	}
+	return mgr.applicationStore.Update(ctx, app)
+}
+
+// UpdateApplication updates an existing Application.
+func (mgr *ApplicationManager) UpdateApplication(ctx context.Context, app *domain.Application) (*domain.Application, error) {
+	return mgr.applicationStore.Update(ctx, app)
+}
+
+// GetApplication retrieves an Application.
+func (mgr *ApplicationManager) GetApplication(ctx context.Context, name string) (*domain.Application, error) {
+	app := mgr.applicationStore.Find(ctx, name)
+	if app == nil {
+		return nil, domain.ErrApplicationNotFound
+	}
+	return app, nil
+}
+
+// GetApplications returns the page of Applications, filtered by type and workflow, selected by the list options.
+func (mgr *ApplicationManager) GetApplications(ctx context.Context, appType, workflow *string,
+	opts *domain.ListOptions) ([]*domain.Application, string, error) {
+	return mgr.applicationStore.GetAll(ctx, appType, workflow, opts)
+}
+
+// DeleteApplication deletes an Application and its kubernetes resources.
+func (mgr *ApplicationManager) DeleteApplication(ctx context.Context, name string) error {
+	app, err := mgr.GetApplication(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(app.K8sResources) > 0 {
+		cluster, err := mgr.newCluster()
+		if err != nil {
+			return errors.Wrap(err, "Failed initializing kubernetes cluster")
+		}
+		for _, r := range app.K8sResources {
+			err := cluster.DeleteResource(ctx, r.Name, app.K8sNamespace, r.Kind)
+			if err != nil {
+				return errors.Wrap(err, "Failed deleting kubernetes resource "+r.Name)
+			}
+		}
+	}
+	return mgr.applicationStore.Delete(ctx, name)
+}
+
+// GetApplicationStatus probes the Application URL and kubernetes resources and returns its status.
+func (mgr *ApplicationManager) GetApplicationStatus(ctx context.Context, name string) (*domain.ApplicationStatus, error) {
+	app, err := mgr.GetApplication(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.ApplicationStatus{Checked: time.Now()}
+	problems := []string{}
+
+	status.URLReachable, err = mgr.probeURL(ctx, app.URL)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("URL %q is not reachable: %s", app.URL, err))
+	}
+
+	missing := 0
+	if len(app.K8sResources) > 0 {
+		cluster, clusterErr := mgr.newCluster()
+		if clusterErr != nil {
+			problems = append(problems, fmt.Sprintf("failed initializing kubernetes cluster: %s", clusterErr))
+		}
+		for _, r := range app.K8sResources {
+			rs := &domain.KubernetesResourceStatus{Name: r.Name, Kind: r.Kind}
+			status.Resources = append(status.Resources, rs)
+			if clusterErr != nil {
+				continue
+			}
+			rs.Exists, err = cluster.ResourceExists(ctx, r.Name, app.K8sNamespace, r.Kind)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("failed checking %s %s: %s", r.Kind, r.Name, err))
+			} else if !rs.Exists {
+				missing++
+				problems = append(problems, fmt.Sprintf("%s %s not found", r.Kind, r.Name))
+			}
+		}
+	}
+
+	switch {
+	case len(problems) == 0:
+		status.State = domain.ApplicationReady
+	case !status.URLReachable && (len(app.K8sResources) == 0 || missing == len(app.K8sResources)):
+		status.State = domain.ApplicationMissing
+	default:
+		status.State = domain.ApplicationDegraded
+	}
+	status.Message = strings.Join(problems, "; ")
+	return status, nil
+}
+
+// probeURL checks whether an Application URL responds to requests. Serving endpoints do not necessarily
+// accept GET requests, so any response other than a server error is considered a sign of life.
+func (mgr *ApplicationManager) probeURL(ctx context.Context, url string) (bool, error) {
+	if url == "" {
+		return false, fmt.Errorf("no URL")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := mgr.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return false, fmt.Errorf("server responded with %q", resp.Status)
+	}
+	return true, nil
+}
+
+// GetProjectApplications returns the Applications that belong to a project. Applications only
+// reference the workflow that created them, so an Application is considered to belong to a
+// project when its workflow is assigned exclusively to codesets from that project.
+func (mgr *ApplicationManager) GetProjectApplications(ctx context.Context, project string) ([]*domain.Application, error) {
+	apps, _, err := mgr.applicationStore.GetAll(ctx, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := mgr.workflowStore.GetAllCodesetAssignments(ctx, nil)
+	result := make([]*domain.Application, 0)
+	for _, app := range apps {
+		if workflowOwnedByProject(assignments[app.Workflow], project) {
+			result = append(result, app)
+		}
+	}
+	return result, nil
+}
+
+// OnEvent deletes the Applications that belong to a project that is being deleted
+func (mgr *ApplicationManager) OnEvent(ctx context.Context, event *domain.Event) {
+	project, ok := event.Object.(*domain.Project)
+	if !ok || event.Type != domain.EventDeleting {
+		return
+	}
+	apps, err := mgr.GetProjectApplications(ctx, project.Name)
+	if err != nil {
+		mgr.logger.ErrorContext(ctx, "Failed listing applications for project", "project", project.Name, "error", err)
+		return
+	}
+	for _, app := range apps {
+		if err := mgr.DeleteApplication(ctx, app.Name); err != nil {
+			mgr.logger.ErrorContext(ctx, "Failed deleting application", "application", app.Name, "error", err)
+		}
+	}
+}
+
+// workflowOwnedByProject returns true if the workflow assignments reference only codesets from the project
+func workflowOwnedByProject(assignments []*domain.CodesetAssignment, project string) bool {
+	if len(assignments) == 0 {
+		return false
+	}
+	for _, a := range assignments {
+		if a.Codeset.Project != project {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,203 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+
+	"github.com/fuseml/fuseml-core/pkg/core"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func TestProjectApplications(t *testing.T) {
+	ctx := context.Background()
+	wfStore := core.NewWorkflowStore()
+	appStore := core.NewApplicationStore()
+	bus := core.NewEventBus()
+	mgr := NewApplicationManager(slog.Default(), appStore, wfStore, bus)
+
+	// wf0 is assigned only to prj0 codesets, wf1 to both prj0 and prj1 codesets,
+	// and wf2 is not assigned to any codeset
+	assignments := map[string][]*domain.Codeset{
+		"wf0": {{Name: "cs0", Project: "prj0"}, {Name: "cs1", Project: "prj0"}},
+		"wf1": {{Name: "cs0", Project: "prj0"}, {Name: "cs0", Project: "prj1"}},
+		"wf2": {},
+	}
+	for wf, codesets := range assignments {
+		if _, err := wfStore.AddWorkflow(ctx, &domain.Workflow{Name: wf}); err != nil {
+			t.Fatalf("Failed adding workflow %s: %s", wf, err)
+		}
+		for _, c := range codesets {
+			if _, err := wfStore.AddCodesetAssignment(ctx, wf, c, nil); err != nil {
+				t.Fatalf("Failed assigning workflow %s: %s", wf, err)
+			}
+		}
+		if _, err := mgr.RegisterApplication(ctx, &domain.Application{Name: "app-" + wf, Workflow: wf}); err != nil {
+			t.Fatalf("Failed registering application: %s", err)
+		}
+	}
+
+	appNames := func(apps []*domain.Application) []string {
+		names := []string{}
+		for _, a := range apps {
+			names = append(names, a.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t.Run("get project applications", func(t *testing.T) {
+		apps, err := mgr.GetProjectApplications(ctx, "prj0")
+		assertError(t, err, nil)
+		if d := cmp.Diff([]string{"app-wf0"}, appNames(apps)); d != "" {
+			t.Errorf("Unexpected applications: %s", diff.PrintWantGot(d))
+		}
+
+		apps, err = mgr.GetProjectApplications(ctx, "prj1")
+		assertError(t, err, nil)
+		if d := cmp.Diff([]string{}, appNames(apps)); d != "" {
+			t.Errorf("Unexpected applications: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("delete project", func(t *testing.T) {
+		bus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.ProjectResource, Object: &domain.Project{Name: "prj0"}})
+
+		apps, _, err := mgr.GetApplications(ctx, nil, nil, nil)
+		assertError(t, err, nil)
+		if d := cmp.Diff([]string{"app-wf1", "app-wf2"}, appNames(apps)); d != "" {
+			t.Errorf("Unexpected applications: %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
+// fakeCluster records the kubernetes resources that exist, indexed by name
+type fakeCluster struct {
+	resources map[string]bool
+}
+
+func (c *fakeCluster) ResourceExists(ctx context.Context, name, namespace, kind string) (bool, error) {
+	return c.resources[name], nil
+}
+
+func (c *fakeCluster) DeleteResource(ctx context.Context, name, namespace, kind string) error {
+	delete(c.resources, name)
+	return nil
+}
+
+func TestRegisterApplication(t *testing.T) {
+	ctx := context.Background()
+	mgr := NewApplicationManager(slog.Default(), core.NewApplicationStore(), core.NewWorkflowStore(), core.NewEventBus())
+
+	_, err := mgr.RegisterApplication(ctx, &domain.Application{Name: "app", Workflow: "wf0", Type: "predictor"})
+	assertError(t, err, nil)
+
+	t.Run("same workflow", func(t *testing.T) {
+		want := &domain.Application{Name: "app", Workflow: "wf0", Type: "model"}
+		_, err := mgr.RegisterApplication(ctx, want)
+		assertError(t, err, nil)
+
+		got, err := mgr.GetApplication(ctx, "app")
+		assertError(t, err, nil)
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected application: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("different workflow", func(t *testing.T) {
+		_, err := mgr.RegisterApplication(ctx, &domain.Application{Name: "app", Workflow: "wf1"})
+		assertError(t, err, domain.ErrApplicationExists)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		_, err := mgr.UpdateApplication(ctx, &domain.Application{Name: "app", Workflow: "wf1"})
+		assertError(t, err, nil)
+
+		_, err = mgr.UpdateApplication(ctx, &domain.Application{Name: "unknown"})
+		assertError(t, err, domain.ErrApplicationNotFound)
+	})
+}
+
+func TestGetApplicationStatus(t *testing.T) {
+	ctx := context.Background()
+	mgr := NewApplicationManager(slog.Default(), core.NewApplicationStore(), core.NewWorkflowStore(), core.NewEventBus())
+	cluster := &fakeCluster{}
+	mgr.newCluster = func() (kubernetesCluster, error) { return cluster, nil }
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	resources := []*domain.KubernetesResource{{Name: "pod", Kind: "Pod"}, {Name: "svc", Kind: "Service"}}
+
+	tests := []struct {
+		name      string
+		url       string
+		resources map[string]bool
+		want      domain.ApplicationState
+	}{
+		{"ready", up.URL, map[string]bool{"pod": true, "svc": true}, domain.ApplicationReady},
+		{"resource missing", up.URL, map[string]bool{"pod": true}, domain.ApplicationDegraded},
+		{"url down", down.URL, map[string]bool{"pod": true, "svc": true}, domain.ApplicationDegraded},
+		{"missing", down.URL, map[string]bool{}, domain.ApplicationMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster.resources = tt.resources
+			app := &domain.Application{Name: tt.name, Workflow: "wf", URL: tt.url, K8sResources: resources}
+			_, err := mgr.RegisterApplication(ctx, app)
+			assertError(t, err, nil)
+
+			got, err := mgr.GetApplicationStatus(ctx, tt.name)
+			assertError(t, err, nil)
+			if got.State != tt.want {
+				t.Errorf("got state %q want %q (%s)", got.State, tt.want, got.Message)
+			}
+			if len(got.Resources) != len(resources) {
+				t.Errorf("got %d resource statuses want %d", len(got.Resources), len(resources))
+			}
+		})
+	}
+
+	t.Run("no cluster", func(t *testing.T) {
+		defer func(newCluster func() (kubernetesCluster, error)) { mgr.newCluster = newCluster }(mgr.newCluster)
+		// a failing constructor may return a typed nil cluster along with the error
+		mgr.newCluster = func() (kubernetesCluster, error) {
+			var cluster *fakeCluster
+			return cluster, errors.New("no kubeconfig")
+		}
+		app := &domain.Application{Name: "no-cluster", Workflow: "wf", URL: up.URL, K8sResources: resources}
+		_, err := mgr.RegisterApplication(ctx, app)
+		assertError(t, err, nil)
+
+		got, err := mgr.GetApplicationStatus(ctx, app.Name)
+		assertError(t, err, nil)
+		if got.State != domain.ApplicationDegraded {
+			t.Errorf("got state %q want %q (%s)", got.State, domain.ApplicationDegraded, got.Message)
+		}
+		if !strings.Contains(got.Message, "no kubeconfig") {
+			t.Errorf("got message %q, expected the cluster error", got.Message)
+		}
+		if len(got.Resources) != len(resources) {
+			t.Errorf("got %d resource statuses want %d", len(got.Resources), len(resources))
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := mgr.GetApplicationStatus(ctx, "unknown")
+		assertError(t, err, domain.ErrApplicationNotFound)
+	})
+}
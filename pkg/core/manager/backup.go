@@ -0,0 +1,171 @@
+package manager
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/core/config"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+	"github.com/fuseml/fuseml-core/pkg/version"
+)
+
+const (
+	// backupFormat identifies the FuseML backups
+	backupFormat = "fuseml-backup"
+	// backupFormatVersion is the version of the backup format written by this server. Backups with a newer
+	// format version cannot be restored.
+	backupFormatVersion = 1
+)
+
+// backupHeader is written as a JSON document, on its own line, before the store snapshot
+type backupHeader struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	ServerVersion string    `json:"serverVersion"`
+	Created       time.Time `json:"created"`
+}
+
+// BackupManager implements the domain.BackupManager interface
+type BackupManager struct {
+	logger          *slog.Logger
+	cfg             *config.Workflows
+	backupStore     domain.BackupStore
+	workflowStore   domain.WorkflowStore
+	workflowBackend domain.WorkflowBackend
+	codesetStore    domain.CodesetStore
+}
+
+// NewBackupManager initializes a Backup Manager
+func NewBackupManager(
+	logger *slog.Logger,
+	cfg *config.Workflows,
+	backupStore domain.BackupStore,
+	workflowStore domain.WorkflowStore,
+	workflowBackend domain.WorkflowBackend,
+	codesetStore domain.CodesetStore) *BackupManager {
+	return &BackupManager{logger, cfg, backupStore, workflowStore, workflowBackend, codesetStore}
+}
+
+// Backup returns a stream with the backup header followed by the store snapshot. The snapshot is written
+// as the stream is read, and the stream fails if the snapshot cannot be taken.
+func (mgr *BackupManager) Backup(ctx context.Context) (io.ReadCloser, error) {
+	header := backupHeader{
+		Format:        backupFormat,
+		Version:       backupFormatVersion,
+		ServerVersion: version.GetInfo().Version,
+		Created:       time.Now().UTC(),
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		ctx, span := tracing.Start(ctx, "BackupManager.Backup")
+		var err error
+		defer tracing.End(span, &err)
+
+		mgr.logger.InfoContext(ctx, "Backing up FuseML state")
+		if err = json.NewEncoder(pw).Encode(header); err == nil {
+			err = mgr.backupStore.Backup(ctx, pw)
+		}
+		if err != nil {
+			mgr.logger.ErrorContext(ctx, "Backup failed", "error", err)
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// Restore checks the backup header, loads the store snapshot that follows it and, if requested, re-creates
+// the Tekton resources of the restored workflows and the webhooks of their codeset assignments. Failures to
+// re-create resources are reported in the result, as the restored state is kept.
+func (mgr *BackupManager) Restore(ctx context.Context, r io.Reader, recreate bool) (_ *domain.RestoreResult, err error) {
+	ctx, span := tracing.Start(ctx, "BackupManager.Restore")
+	defer tracing.End(span, &err)
+
+	// the header line is bounded by the size of the reader buffer
+	br := bufio.NewReader(r)
+	line, err := br.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", domain.ErrInvalidBackup, err)
+	}
+	header := backupHeader{}
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", domain.ErrInvalidBackup, err)
+	}
+	if header.Format != backupFormat {
+		return nil, fmt.Errorf("%w: not a FuseML backup", domain.ErrInvalidBackup)
+	}
+	if header.Version < 1 || header.Version > backupFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d (supported: %d)", domain.ErrInvalidBackup,
+			header.Version, backupFormatVersion)
+	}
+
+	mgr.logger.InfoContext(ctx, "Restoring FuseML state", "serverVersion", header.ServerVersion, "created", header.Created)
+	err = mgr.backupStore.Restore(ctx, br)
+	if err != nil {
+		return nil, err
+	}
+
+	workflows, _, err := mgr.workflowStore.GetWorkflows(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.RestoreResult{
+		ServerVersion: header.ServerVersion,
+		Created:       header.Created,
+		Workflows:     len(workflows),
+		Recreated:     recreate,
+	}
+	for _, wf := range workflows {
+		assignments := wf.GetCodesetAssignments(ctx)
+		result.Assignments += len(assignments)
+		if recreate {
+			for _, err := range mgr.recreateWorkflow(ctx, wf, assignments) {
+				result.Errors = append(result.Errors, fmt.Sprintf("workflow %q: %v", wf.Name, err))
+			}
+		}
+	}
+	if len(result.Errors) > 0 {
+		mgr.logger.WarnContext(ctx, "Some restored resources could not be re-created", "errors", result.Errors)
+	}
+	return result, nil
+}
+
+// recreateWorkflow creates the Tekton resources of a restored workflow and the webhooks of its assignments,
+// replacing the webhook IDs of the assignments with those of the new webhooks. It returns the errors met.
+func (mgr *BackupManager) recreateWorkflow(ctx context.Context, wf *domain.Workflow,
+	assignments []*domain.CodesetAssignment) (errs []error) {
+	err := mgr.workflowBackend.CreateWorkflow(ctx, wf)
+	if err != nil && err != domain.ErrWorkflowExists {
+		return []error{err}
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	listener, err := mgr.workflowBackend.CreateWorkflowListener(ctx, wf.Name, mgr.cfg.ListenerTimeout)
+	if err != nil {
+		return []error{err}
+	}
+	for _, a := range assignments {
+		codeset, err := mgr.codesetStore.Find(ctx, a.Codeset.Project, a.Codeset.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("codeset %s/%s: %w", a.Codeset.Project, a.Codeset.Name, err))
+			continue
+		}
+		webhookID, err := mgr.codesetStore.CreateWebhook(ctx, codeset, listener.URL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("codeset %s/%s: %w", a.Codeset.Project, a.Codeset.Name, err))
+			continue
+		}
+		a.WebhookID = webhookID
+		if err := mgr.workflowStore.UpdateCodesetAssignmentWebhook(ctx, wf.Name, a.Codeset, webhookID); err != nil {
+			errs = append(errs, fmt.Errorf("codeset %s/%s: %w", a.Codeset.Project, a.Codeset.Name, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,160 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/fuseml/fuseml-core/pkg/core/config"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// fakeSnapshotPrefix starts the snapshots written by fakeBackupStore, which must be restored from the same
+// position in the backup stream
+const fakeSnapshotPrefix = "snapshot:"
+
+// fakeBackupStore snapshots the workflows of a workflow store as a JSON list
+type fakeBackupStore struct {
+	workflowStore domain.WorkflowStore
+}
+
+func (s *fakeBackupStore) Backup(ctx context.Context, w io.Writer) error {
+	workflows, _, err := s.workflowStore.GetWorkflows(ctx, nil, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, fakeSnapshotPrefix); err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(workflows)
+}
+
+func (s *fakeBackupStore) Restore(ctx context.Context, r io.Reader) error {
+	if workflows, _, _ := s.workflowStore.GetWorkflows(ctx, nil, nil); len(workflows) > 0 {
+		return domain.ErrStoreNotEmpty
+	}
+	prefix := make([]byte, len(fakeSnapshotPrefix))
+	if _, err := io.ReadFull(r, prefix); err != nil || string(prefix) != fakeSnapshotPrefix {
+		return fmt.Errorf("%w: snapshot prefix not found", domain.ErrInvalidBackup)
+	}
+	workflows := []*domain.Workflow{}
+	if err := json.NewDecoder(r).Decode(&workflows); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidBackup, err)
+	}
+	for _, wf := range workflows {
+		if _, err := s.workflowStore.AddWorkflow(ctx, wf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newFakeBackupManager returns a backup manager for the stores of a new fake workflow manager
+func newFakeBackupManager(t *testing.T) *BackupManager {
+	t.Helper()
+
+	newFakeWorkflowManager(t)
+	return NewBackupManager(slog.Default(), &config.Default().Workflows, &fakeBackupStore{workflowStore},
+		workflowStore, workflowBackend, codesetStore)
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+
+	// back up a workflow assigned to a codeset and one that is not assigned
+	wfm := newFakeWorkflowManager(t)
+	for _, name := range []string{"wf0", "wf1"} {
+		_, err := wfm.CreateWorkflow(ctx, &domain.Workflow{Name: name})
+		assertError(t, err, nil)
+	}
+	_, _, err := wfm.AssignToCodeset(ctx, "wf0", "csproject0", "cs0")
+	assertError(t, err, nil)
+	mgr := NewBackupManager(slog.Default(), &config.Default().Workflows, &fakeBackupStore{workflowStore},
+		workflowStore, workflowBackend, codesetStore)
+
+	stream, err := mgr.Backup(ctx)
+	assertError(t, err, nil)
+	backup, err := ioutil.ReadAll(stream)
+	assertError(t, err, nil)
+	stream.Close()
+
+	header := backupHeader{}
+	if err := json.NewDecoder(bytes.NewReader(backup)).Decode(&header); err != nil {
+		t.Fatalf("Failed to decode backup header: %v", err)
+	}
+	if header.Format != backupFormat || header.Version != backupFormatVersion || header.Created.IsZero() {
+		t.Errorf("Unexpected backup header: %+v", header)
+	}
+
+	t.Run("restore", func(t *testing.T) {
+		mgr := newFakeBackupManager(t)
+
+		got, err := mgr.Restore(ctx, bytes.NewReader(backup), false)
+		assertError(t, err, nil)
+		if got.Workflows != 2 || got.Assignments != 1 || got.Recreated || !got.Created.Equal(header.Created) {
+			t.Errorf("Unexpected restore result: %+v", got)
+		}
+		if _, err := workflowBackend.GetWorkflowListener(ctx, "wf0"); err == nil {
+			t.Errorf("Workflow listener created without recreate")
+		}
+
+		_, err = mgr.Restore(ctx, bytes.NewReader(backup), false)
+		assertError(t, err, domain.ErrStoreNotEmpty)
+	})
+
+	t.Run("recreate", func(t *testing.T) {
+		mgr := newFakeBackupManager(t)
+
+		got, err := mgr.Restore(ctx, bytes.NewReader(backup), true)
+		assertError(t, err, nil)
+		if got.Workflows != 2 || got.Assignments != 1 || !got.Recreated || len(got.Errors) != 0 {
+			t.Errorf("Unexpected restore result: %+v", got)
+		}
+
+		listener, err := workflowBackend.GetWorkflowListener(ctx, "wf0")
+		assertError(t, err, nil)
+		codeset, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+		assignment, err := workflowStore.GetCodesetAssignment(ctx, "wf0", codeset)
+		assertError(t, err, nil)
+		if url := codesetStore.store[codesetID{"cs0", "csproject0"}].webhooks[*assignment.WebhookID]; url != listener.URL {
+			t.Errorf("Webhook of the restored assignment points to %q, want %q", url, listener.URL)
+		}
+		if err := workflowBackend.CreateWorkflow(ctx, &domain.Workflow{Name: "wf1"}); err != domain.ErrWorkflowExists {
+			t.Errorf("Workflow not re-created in the backend")
+		}
+	})
+
+	t.Run("recreate missing codeset", func(t *testing.T) {
+		mgr := newFakeBackupManager(t)
+		assertError(t, codesetStore.Delete(ctx, "csproject0", "cs0"), nil)
+
+		got, err := mgr.Restore(ctx, bytes.NewReader(backup), true)
+		assertError(t, err, nil)
+		if len(got.Errors) != 1 || !strings.Contains(got.Errors[0], `workflow "wf0": codeset csproject0/cs0`) {
+			t.Errorf("Unexpected restore errors: %v", got.Errors)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, b := range []string{
+			"not a backup",
+			fmt.Sprintf(`{"format":%q,"version":%d}`, backupFormat, backupFormatVersion),
+			`{"format":"other-backup","version":1}` + "\n",
+			fmt.Sprintf(`{"format":%q,"version":%d}`+"\n", backupFormat, backupFormatVersion+1),
+			fmt.Sprintf(`{"format":%q,"version":%d}`+"\nnot a snapshot", backupFormat, backupFormatVersion),
+		} {
+			mgr := newFakeBackupManager(t)
+			_, err := mgr.Restore(ctx, strings.NewReader(b), false)
+			if !errors.Is(err, domain.ErrInvalidBackup) {
+				t.Errorf("Restoring %q: got error %v, want %v", b, err, domain.ErrInvalidBackup)
+			}
+		}
+	})
+}
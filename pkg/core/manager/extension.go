@@ -3,120 +3,363 @@ package manager
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 )
 
 // ExtensionRegistry implements the domain.ExtensionRegistry interface
 type ExtensionRegistry struct {
 	extensionStore domain.ExtensionStore
+	usageStore     domain.ExtensionUsageStore
+	eventBus       domain.EventBus
 }
 
-// NewExtensionRegistry initializes an extension registry
-func NewExtensionRegistry(extensionStore domain.ExtensionStore) *ExtensionRegistry {
-	return &ExtensionRegistry{extensionStore}
+// NewExtensionRegistry initializes an extension registry. Changes made to the registered extensions are
+// published on the event bus, for the subsystems that depend on extensions to keep up with them.
+// Services of known resource types are validated against the schemas of their resource types.
+func NewExtensionRegistry(extensionStore domain.ExtensionStore, usageStore domain.ExtensionUsageStore,
+	eventBus domain.EventBus) *ExtensionRegistry {
+	return &ExtensionRegistry{extensionStore, usageStore, eventBus}
 }
 
 // RegisterExtension - register a new extension, with all participating services, endpoints and credentials
-func (registry *ExtensionRegistry) RegisterExtension(ctx context.Context, extension *domain.Extension) (*domain.Extension, error) {
-	return registry.extensionStore.AddExtension(ctx, extension)
+func (registry *ExtensionRegistry) RegisterExtension(ctx context.Context, extension *domain.Extension) (_ *domain.Extension, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.RegisterExtension")
+	defer tracing.End(span, &err)
+
+	err = validateExtension(extension)
+	if err != nil {
+		return nil, err
+	}
+	extension, err = registry.extensionStore.AddExtension(ctx, extension)
+	if err != nil {
+		return nil, err
+	}
+	registry.eventBus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.ExtensionResource, Object: extension})
+	return extension, nil
 }
 
 // AddService - add a service to an existing extension
-func (registry *ExtensionRegistry) AddService(ctx context.Context, extensionID string, service *domain.ExtensionService) (*domain.ExtensionService, error) {
-	return registry.extensionStore.AddExtensionService(ctx, extensionID, service)
+func (registry *ExtensionRegistry) AddService(ctx context.Context, extensionID string, service *domain.ExtensionService) (_ *domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.AddService")
+	defer tracing.End(span, &err)
+
+	err = validateService(extensionID, service)
+	if err != nil {
+		return nil, err
+	}
+	service, err = registry.extensionStore.AddExtensionService(ctx, extensionID, service)
+	if err != nil {
+		return nil, err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return service, nil
 }
 
 // AddEndpoint - add an endpoint to an existing extension service
 func (registry *ExtensionRegistry) AddEndpoint(ctx context.Context, extensionID string, serviceID string,
-	endpoint *domain.ExtensionServiceEndpoint) (*domain.ExtensionServiceEndpoint, error) {
+	endpoint *domain.ExtensionServiceEndpoint) (_ *domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.AddEndpoint")
+	defer tracing.End(span, &err)
+
 	if endpoint.URL == "" {
 		return nil, domain.NewErrMissingField("endpoint", "URL")
 	}
-	return registry.extensionStore.AddExtensionServiceEndpoint(ctx, extensionID, serviceID, endpoint)
+	err = registry.validateEndpoint(ctx, extensionID, serviceID, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err = registry.extensionStore.AddExtensionServiceEndpoint(ctx, extensionID, serviceID, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return endpoint, nil
 }
 
 // AddCredentials - add a set of credentials to an existing extension service
 func (registry *ExtensionRegistry) AddCredentials(ctx context.Context, extensionID string, serviceID string,
-	credentials *domain.ExtensionServiceCredentials) (*domain.ExtensionServiceCredentials, error) {
-	return registry.extensionStore.AddExtensionServiceCredentials(ctx, extensionID, serviceID, credentials)
+	credentials *domain.ExtensionServiceCredentials) (_ *domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.AddCredentials")
+	defer tracing.End(span, &err)
+
+	err = registry.validateCredentials(ctx, extensionID, serviceID, credentials)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err = registry.extensionStore.AddExtensionServiceCredentials(ctx, extensionID, serviceID, credentials)
+	if err != nil {
+		return nil, err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return credentials, nil
 }
 
-// ListExtensions - list all registered extensions that match the supplied query parameters
-func (registry *ExtensionRegistry) ListExtensions(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.Extension, err error) {
-	return registry.extensionStore.ListExtensions(ctx, query), nil
+// ListExtensions - list the page of registered extensions that match the supplied query parameters, selected by
+// the list options
+func (registry *ExtensionRegistry) ListExtensions(ctx context.Context, query *domain.ExtensionQuery,
+	opts *domain.ListOptions) (result []*domain.Extension, next string, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.ListExtensions")
+	defer tracing.End(span, &err)
+
+	return registry.extensionStore.ListExtensions(ctx, query, opts)
 }
 
 // GetExtension - retrieve an extension by ID and, optionally, its entire service/endpoint/credentials subtree
-func (registry *ExtensionRegistry) GetExtension(ctx context.Context, extensionID string) (*domain.Extension, error) {
+func (registry *ExtensionRegistry) GetExtension(ctx context.Context, extensionID string) (_ *domain.Extension, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.GetExtension")
+	defer tracing.End(span, &err)
+
 	return registry.extensionStore.GetExtension(ctx, extensionID)
 }
 
 // GetService - retrieve an extension service by ID and, optionally, its entire endpoint/credentials subtree
-func (registry *ExtensionRegistry) GetService(ctx context.Context, extensionID, serviceID string) (*domain.ExtensionService, error) {
+func (registry *ExtensionRegistry) GetService(ctx context.Context, extensionID, serviceID string) (_ *domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.GetService")
+	defer tracing.End(span, &err)
+
 	return registry.extensionStore.GetExtensionService(ctx, extensionID, serviceID)
 }
 
 // GetEndpoint - retrieve an extension endpoint by ID
-func (registry *ExtensionRegistry) GetEndpoint(ctx context.Context, extensionID, serviceID, endpointURL string) (*domain.ExtensionServiceEndpoint, error) {
+func (registry *ExtensionRegistry) GetEndpoint(ctx context.Context, extensionID, serviceID, endpointURL string) (_ *domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.GetEndpoint")
+	defer tracing.End(span, &err)
+
 	return registry.extensionStore.GetExtensionServiceEndpoint(ctx, extensionID, serviceID, endpointURL)
 }
 
 // GetCredentials - retrieve a set of extension credentials by ID
-func (registry *ExtensionRegistry) GetCredentials(ctx context.Context, extensionID, serviceID, credentialsID string) (*domain.ExtensionServiceCredentials, error) {
+func (registry *ExtensionRegistry) GetCredentials(ctx context.Context, extensionID, serviceID, credentialsID string) (_ *domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.GetCredentials")
+	defer tracing.End(span, &err)
+
 	return registry.extensionStore.GetExtensionServiceCredentials(ctx, extensionID, serviceID, credentialsID)
 }
 
 // UpdateExtension - update an extension
-func (registry *ExtensionRegistry) UpdateExtension(ctx context.Context, extension *domain.Extension) error {
+func (registry *ExtensionRegistry) UpdateExtension(ctx context.Context, extension *domain.Extension) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.UpdateExtension")
+	defer tracing.End(span, &err)
+
 	if extension.ID == "" {
 		return domain.NewErrMissingField("extension", "extension ID")
 	}
-	return registry.extensionStore.UpdateExtension(ctx, extension)
+	err = validateExtension(extension)
+	if err != nil {
+		return err
+	}
+	err = registry.extensionStore.UpdateExtension(ctx, extension)
+	if err != nil {
+		return err
+	}
+	registry.publishUpdated(ctx, extension.ID)
+	return nil
 }
 
 // UpdateService - update a service belonging to an extension
-func (registry *ExtensionRegistry) UpdateService(ctx context.Context, extensionID string, service *domain.ExtensionService) error {
+func (registry *ExtensionRegistry) UpdateService(ctx context.Context, extensionID string, service *domain.ExtensionService) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.UpdateService")
+	defer tracing.End(span, &err)
+
 	if service.ID == "" {
 		return domain.NewErrMissingField("service", "service ID")
 	}
-	return registry.extensionStore.UpdateExtensionService(ctx, extensionID, service)
+	err = validateService(extensionID, service)
+	if err != nil {
+		return err
+	}
+	err = registry.extensionStore.UpdateExtensionService(ctx, extensionID, service)
+	if err != nil {
+		return err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return nil
 }
 
 // UpdateEndpoint - update an endpoint belonging to a service
-func (registry *ExtensionRegistry) UpdateEndpoint(ctx context.Context, extensionID string, serviceID string, endpoint *domain.ExtensionServiceEndpoint) error {
+func (registry *ExtensionRegistry) UpdateEndpoint(ctx context.Context, extensionID string, serviceID string, endpoint *domain.ExtensionServiceEndpoint) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.UpdateEndpoint")
+	defer tracing.End(span, &err)
+
 	if endpoint.URL == "" {
 		return domain.NewErrMissingField("endpoint", "URL")
 	}
-	return registry.extensionStore.UpdateExtensionServiceEndpoint(ctx, extensionID, serviceID, endpoint)
+	err = registry.validateEndpoint(ctx, extensionID, serviceID, endpoint)
+	if err != nil {
+		return err
+	}
+	err = registry.extensionStore.UpdateExtensionServiceEndpoint(ctx, extensionID, serviceID, endpoint)
+	if err != nil {
+		return err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return nil
 }
 
 // UpdateCredentials - update a set of credentials belonging to a service
-func (registry *ExtensionRegistry) UpdateCredentials(ctx context.Context, extensionID string, serviceID string, credentials *domain.ExtensionServiceCredentials) error {
+func (registry *ExtensionRegistry) UpdateCredentials(ctx context.Context, extensionID string, serviceID string, credentials *domain.ExtensionServiceCredentials) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.UpdateCredentials")
+	defer tracing.End(span, &err)
+
 	if credentials.ID == "" {
 		return domain.NewErrMissingField("credentials", "credentials ID")
 	}
-	return registry.extensionStore.UpdateExtensionServiceCredentials(ctx, extensionID, serviceID, credentials)
+	err = registry.validateCredentials(ctx, extensionID, serviceID, credentials)
+	if err != nil {
+		return err
+	}
+	err = registry.extensionStore.UpdateExtensionServiceCredentials(ctx, extensionID, serviceID, credentials)
+	if err != nil {
+		return err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return nil
+}
+
+// UpdateEndpointStatus - update the operational status of an endpoint belonging to a service. Status updates
+// don't change how the extension is accessed, so no event is published for them.
+func (registry *ExtensionRegistry) UpdateEndpointStatus(ctx context.Context, extensionID string, serviceID string, endpointURL string,
+	status domain.ExtensionServiceEndpointStatus) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.UpdateEndpointStatus")
+	defer tracing.End(span, &err)
+
+	return registry.extensionStore.UpdateExtensionServiceEndpointStatus(ctx, extensionID, serviceID, endpointURL, status)
 }
 
-// RemoveExtension - remove an extension from the registry
-func (registry *ExtensionRegistry) RemoveExtension(ctx context.Context, extensionID string) error {
+// RemoveExtension - remove an extension from the registry, unless workflows are bound to it
+func (registry *ExtensionRegistry) RemoveExtension(ctx context.Context, extensionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.RemoveExtension")
+	defer tracing.End(span, &err)
+
+	err = registry.checkNotInUse(ctx, extensionID, &domain.ExtensionUsageFilter{ExtensionID: extensionID})
+	if err != nil {
+		return err
+	}
+	extension, err := registry.extensionStore.GetExtension(ctx, extensionID)
+	if err != nil {
+		return err
+	}
+	registry.eventBus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.ExtensionResource, Object: extension})
 	return registry.extensionStore.DeleteExtension(ctx, extensionID)
 }
 
-// RemoveService - remove an extension service from the registry
-func (registry *ExtensionRegistry) RemoveService(ctx context.Context, extensionID, serviceID string) error {
-	return registry.extensionStore.DeleteExtensionService(ctx, extensionID, serviceID)
+// publishUpdated publishes an update event for an extension that was changed, or for an extension to which
+// services, endpoints or credentials were added, changed or removed
+func (registry *ExtensionRegistry) publishUpdated(ctx context.Context, extensionID string) {
+	extension, err := registry.extensionStore.GetExtension(ctx, extensionID)
+	if err != nil {
+		return
+	}
+	registry.eventBus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.ExtensionResource, Object: extension})
+}
+
+// RemoveService - remove an extension service from the registry, unless workflows are bound to it
+func (registry *ExtensionRegistry) RemoveService(ctx context.Context, extensionID, serviceID string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.RemoveService")
+	defer tracing.End(span, &err)
+
+	err = registry.checkNotInUse(ctx, extensionID+"/"+serviceID,
+		&domain.ExtensionUsageFilter{ExtensionID: extensionID, ServiceID: serviceID})
+	if err != nil {
+		return err
+	}
+	err = registry.extensionStore.DeleteExtensionService(ctx, extensionID, serviceID)
+	if err != nil {
+		return err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return nil
 }
 
 // RemoveEndpoint - remove an extension endpoint from the registry
-func (registry *ExtensionRegistry) RemoveEndpoint(ctx context.Context, extensionID, serviceID, endpointID string) error {
-	return registry.extensionStore.DeleteExtensionServiceEndpoint(ctx, extensionID, serviceID, endpointID)
+func (registry *ExtensionRegistry) RemoveEndpoint(ctx context.Context, extensionID, serviceID, endpointID string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.RemoveEndpoint")
+	defer tracing.End(span, &err)
+
+	err = registry.extensionStore.DeleteExtensionServiceEndpoint(ctx, extensionID, serviceID, endpointID)
+	if err != nil {
+		return err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return nil
 }
 
-// RemoveCredentials - remove a set of extension credentials from the registry
-func (registry *ExtensionRegistry) RemoveCredentials(ctx context.Context, extensionID, serviceID, credentialsID string) error {
-	return registry.extensionStore.DeleteExtensionServiceCredentials(ctx, extensionID, serviceID, credentialsID)
+// RemoveCredentials - remove a set of extension credentials from the registry, unless workflows are bound to it
+func (registry *ExtensionRegistry) RemoveCredentials(ctx context.Context, extensionID, serviceID, credentialsID string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.RemoveCredentials")
+	defer tracing.End(span, &err)
+
+	err = registry.checkNotInUse(ctx, extensionID+"/"+serviceID+"/"+credentialsID,
+		&domain.ExtensionUsageFilter{ExtensionID: extensionID, ServiceID: serviceID, CredentialsID: credentialsID})
+	if err != nil {
+		return err
+	}
+	err = registry.extensionStore.DeleteExtensionServiceCredentials(ctx, extensionID, serviceID, credentialsID)
+	if err != nil {
+		return err
+	}
+	registry.publishUpdated(ctx, extensionID)
+	return nil
+}
+
+// checkNotInUse returns an ErrExtensionInUse error if workflows are bound to the registry element
+// selected by the filter
+func (registry *ExtensionRegistry) checkNotInUse(ctx context.Context, element string, filter *domain.ExtensionUsageFilter) error {
+	usage, err := registry.usageStore.GetUsage(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if len(usage) == 0 {
+		return nil
+	}
+
+	workflows := []string{}
+	seen := make(map[string]bool)
+	for _, u := range usage {
+		if !seen[u.Workflow] {
+			seen[u.Workflow] = true
+			workflows = append(workflows, u.Workflow)
+		}
+	}
+	sort.Strings(workflows)
+	return domain.NewErrExtensionInUse(element, workflows)
+}
+
+// RecordUsage - record the bindings between a workflow and extensions, releasing the previous bindings
+// of the workflow
+func (registry *ExtensionRegistry) RecordUsage(ctx context.Context, workflowName string, usage []*domain.ExtensionUsage) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.RecordUsage")
+	defer tracing.End(span, &err)
+
+	now := time.Now()
+	err = registry.usageStore.ReleaseUsage(ctx, workflowName, now)
+	if err != nil {
+		return err
+	}
+	for _, u := range usage {
+		u.Workflow = workflowName
+		u.Bound = now
+	}
+	return registry.usageStore.AddUsage(ctx, usage)
+}
+
+// ReleaseUsage - release all bindings between a workflow and extensions
+func (registry *ExtensionRegistry) ReleaseUsage(ctx context.Context, workflowName string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.ReleaseUsage")
+	defer tracing.End(span, &err)
+
+	return registry.usageStore.ReleaseUsage(ctx, workflowName, time.Now())
+}
+
+// GetUsage - list the bindings between workflows and extensions that match the supplied filter
+func (registry *ExtensionRegistry) GetUsage(ctx context.Context, filter *domain.ExtensionUsageFilter) (_ []*domain.ExtensionUsage, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.GetUsage")
+	defer tracing.End(span, &err)
+
+	return registry.usageStore.GetUsage(ctx, filter)
 }
 
 type queryResults []*domain.ExtensionAccessDescriptor
@@ -147,6 +390,9 @@ func (s byID) Less(i, j int) bool {
 
 // GetExtensionAccessDescriptors - returns access descriptors for extensions that matches the query
 func (registry *ExtensionRegistry) GetExtensionAccessDescriptors(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.ExtensionAccessDescriptor, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.GetExtensionAccessDescriptors")
+	defer tracing.End(span, &err)
+
 	result, err = registry.extensionStore.GetExtensionAccessDescriptors(ctx, query)
 	if err != nil {
 		return nil, err
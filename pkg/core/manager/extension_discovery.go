@@ -0,0 +1,474 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	k8s "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/kubernetes"
+)
+
+// Annotations used to describe the extensions discovered from kubernetes Services, Ingresses and Secrets.
+// Only resources annotated with an extension ID are taken into account. Extension and service attributes
+// may be set on any of the resources that belong to the same extension or service.
+const (
+	// extension attributes
+	annotationExtensionID          = "fuseml.org/extension-id"
+	annotationExtensionProduct     = "fuseml.org/extension-product"
+	annotationExtensionVersion     = "fuseml.org/extension-version"
+	annotationExtensionDescription = "fuseml.org/extension-description"
+	annotationExtensionZone        = "fuseml.org/extension-zone"
+	// prefix for extension configuration entries (e.g. fuseml.org/extension-config-KEY: VALUE)
+	annotationExtensionConfigPrefix = "fuseml.org/extension-config-"
+
+	// service attributes. The service ID defaults to the name of the annotated resource.
+	annotationServiceID           = "fuseml.org/extension-service-id"
+	annotationServiceResource     = "fuseml.org/extension-service-resource"
+	annotationServiceCategory     = "fuseml.org/extension-service-category"
+	annotationServiceDescription  = "fuseml.org/extension-service-description"
+	annotationServiceAuthRequired = "fuseml.org/extension-service-auth-required"
+	// prefix for service configuration entries
+	annotationServiceConfigPrefix = "fuseml.org/extension-service-config-"
+
+	// endpoint attributes, only used with Services and Ingresses. Service endpoints are internal and Ingress
+	// endpoints are external, unless explicitly overridden.
+	annotationEndpointURL    = "fuseml.org/extension-endpoint-url"
+	annotationEndpointType   = "fuseml.org/extension-endpoint-type"
+	annotationEndpointScheme = "fuseml.org/extension-endpoint-scheme"
+	// the name or number of the Service port used to build the endpoint URL. Defaults to the first port.
+	annotationEndpointPort = "fuseml.org/extension-endpoint-port"
+	// prefix for endpoint configuration entries
+	annotationEndpointConfigPrefix = "fuseml.org/extension-endpoint-config-"
+
+	// credentials attributes, only used with Secrets. The credentials ID defaults to the Secret name and
+	// the credentials configuration is populated with the Secret data.
+	annotationCredentialsID       = "fuseml.org/extension-credentials-id"
+	annotationCredentialsScope    = "fuseml.org/extension-credentials-scope"
+	annotationCredentialsDefault  = "fuseml.org/extension-credentials-default"
+	annotationCredentialsProjects = "fuseml.org/extension-credentials-projects"
+	annotationCredentialsUsers    = "fuseml.org/extension-credentials-users"
+)
+
+// ExtensionDiscovery is a controller that watches kubernetes Services, Ingresses and Secrets annotated
+// with extension information and keeps the matching extensions in the extension registry up to date
+type ExtensionDiscovery struct {
+	logger   *slog.Logger
+	registry domain.ExtensionRegistry
+	// newClient returns a client for the kubernetes cluster where the extensions are discovered from.
+	// The cluster is only accessed when discovery is enabled, so FuseML can run without one.
+	newClient func() (k8s.Interface, error)
+	// trigger is used to request a registry update when annotated resources change
+	trigger chan struct{}
+	// applied records the discovered extensions last written to the registry, to skip unnecessary updates
+	applied   map[string]string
+	services  corelisters.ServiceLister
+	ingresses networkinglisters.IngressLister
+	secrets   corelisters.SecretLister
+}
+
+// NewExtensionDiscovery initializes an extension discovery controller
+func NewExtensionDiscovery(logger *slog.Logger, registry domain.ExtensionRegistry) *ExtensionDiscovery {
+	return &ExtensionDiscovery{
+		logger:    logger,
+		registry:  registry,
+		newClient: kubernetes.NewClientset,
+		trigger:   make(chan struct{}, 1),
+		applied:   make(map[string]string),
+	}
+}
+
+// Start runs the discovery controller in the background, watching resources in the given namespace (or
+// in all namespaces, if empty), until the context is cancelled
+func (d *ExtensionDiscovery) Start(ctx context.Context, wg *sync.WaitGroup, namespace string) error {
+	client, err := d.newClient()
+	if err != nil {
+		return err
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0, informers.WithNamespace(namespace))
+	services := factory.Core().V1().Services()
+	ingresses := factory.Networking().V1().Ingresses()
+	secrets := factory.Core().V1().Secrets()
+	d.services = services.Lister()
+	d.ingresses = ingresses.Lister()
+	d.secrets = secrets.Lister()
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			d.enqueue(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// an update may also remove the annotations, so both versions are relevant
+			d.enqueue(oldObj)
+			d.enqueue(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			d.enqueue(obj)
+		},
+	}
+	synced := []cache.InformerSynced{}
+	for _, informer := range []cache.SharedIndexInformer{services.Informer(), ingresses.Informer(), secrets.Informer()} {
+		informer.AddEventHandler(handler)
+		synced = append(synced, informer.HasSynced)
+	}
+	factory.Start(ctx.Done())
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+			return
+		}
+		// the registry is always updated once, to remove the extensions that were discovered previously
+		// from resources that no longer exist
+		d.logger.Info("Extension discovery started")
+		d.sync(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-d.trigger:
+				d.sync(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// enqueue requests a registry update if the object carries extension annotations
+func (d *ExtensionDiscovery) enqueue(obj interface{}) {
+	object, err := meta.Accessor(obj)
+	if err != nil || object.GetAnnotations()[annotationExtensionID] == "" {
+		return
+	}
+	select {
+	case d.trigger <- struct{}{}:
+	default:
+		// an update is already pending
+	}
+}
+
+// sync updates the registry with the extensions discovered from the annotated resources
+func (d *ExtensionDiscovery) sync(ctx context.Context) {
+	discovered, err := d.discover()
+	if err != nil {
+		d.logger.ErrorContext(ctx, "Failed discovering extensions", "error", err)
+		return
+	}
+
+	extensions, _, err := d.registry.ListExtensions(ctx, nil, nil)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "Failed listing extensions", "error", err)
+		return
+	}
+	existing := make(map[string]*domain.Extension)
+	for _, ext := range extensions {
+		existing[ext.ID] = ext
+		if _, ok := discovered[ext.ID]; ok {
+			if !ext.Discovered {
+				d.logger.WarnContext(ctx, "Ignoring discovered extension: an extension with the same ID was registered through the API", "extension", ext.ID)
+				delete(discovered, ext.ID)
+			}
+			continue
+		}
+		if ext.Discovered {
+			d.logger.InfoContext(ctx, "Removing extension: no annotated resources found", "extension", ext.ID)
+			if err := d.registry.RemoveExtension(ctx, ext.ID); err != nil {
+				d.logger.ErrorContext(ctx, "Failed removing extension", "extension", ext.ID, "error", err)
+				continue
+			}
+			delete(d.applied, ext.ID)
+		}
+	}
+
+	for id, ext := range discovered {
+		// the extension is serialized before it is handed to the registry, which sets the timestamps
+		data, err := json.Marshal(ext)
+		if err != nil {
+			d.logger.ErrorContext(ctx, "Failed serializing extension", "extension", id, "error", err)
+			continue
+		}
+
+		if existing[id] == nil {
+			d.logger.InfoContext(ctx, "Registering discovered extension", "extension", id)
+			_, err = d.registry.RegisterExtension(ctx, ext)
+		} else if d.applied[id] != string(data) {
+			d.logger.InfoContext(ctx, "Updating discovered extension", "extension", id)
+			err = d.registry.UpdateExtension(ctx, ext)
+		} else {
+			continue
+		}
+		if err != nil {
+			d.logger.ErrorContext(ctx, "Failed writing discovered extension to the registry", "extension", id, "error", err)
+			continue
+		}
+		d.applied[id] = string(data)
+	}
+}
+
+// discover builds the extensions described by the annotated resources
+func (d *ExtensionDiscovery) discover() (map[string]*domain.Extension, error) {
+	extensions := make(map[string]*domain.Extension)
+
+	services, err := d.services.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	// resources are processed in a stable order, so that conflicting annotations are resolved consistently
+	sort.Slice(services, func(i, j int) bool { return objectKey(services[i]) < objectKey(services[j]) })
+	for _, s := range services {
+		if s.Annotations[annotationExtensionID] == "" {
+			continue
+		}
+		endpointURL, err := serviceEndpointURL(s)
+		if err != nil {
+			d.logger.Warn("Ignoring service", "service", objectKey(s), "error", err)
+			continue
+		}
+		svc := discoveredService(extensions, &s.ObjectMeta)
+		addDiscoveredEndpoint(svc, &s.ObjectMeta, endpointURL, domain.EETInternal)
+	}
+
+	ingresses, err := d.ingresses.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ingresses, func(i, j int) bool { return objectKey(ingresses[i]) < objectKey(ingresses[j]) })
+	for _, i := range ingresses {
+		if i.Annotations[annotationExtensionID] == "" {
+			continue
+		}
+		endpointURL, err := ingressEndpointURL(i)
+		if err != nil {
+			d.logger.Warn("Ignoring ingress", "ingress", objectKey(i), "error", err)
+			continue
+		}
+		svc := discoveredService(extensions, &i.ObjectMeta)
+		addDiscoveredEndpoint(svc, &i.ObjectMeta, endpointURL, domain.EETExternal)
+	}
+
+	secrets, err := d.secrets.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(secrets, func(i, j int) bool { return objectKey(secrets[i]) < objectKey(secrets[j]) })
+	for _, s := range secrets {
+		if s.Annotations[annotationExtensionID] == "" {
+			continue
+		}
+		svc := discoveredService(extensions, &s.ObjectMeta)
+		addDiscoveredCredentials(svc, s)
+	}
+
+	return extensions, nil
+}
+
+func objectKey(object metav1.Object) string {
+	return object.GetNamespace() + "/" + object.GetName()
+}
+
+// discoveredService returns the extension service that a resource belongs to, adding the extension
+// and the service to the list of discovered extensions if they don't exist yet
+func discoveredService(extensions map[string]*domain.Extension, object *metav1.ObjectMeta) *domain.ExtensionService {
+	annotations := object.Annotations
+	extensionID := annotations[annotationExtensionID]
+	ext, ok := extensions[extensionID]
+	if !ok {
+		ext = &domain.Extension{
+			ID:         extensionID,
+			Services:   make(map[string]*domain.ExtensionService),
+			Discovered: true,
+		}
+		extensions[extensionID] = ext
+	}
+	setIfEmpty(&ext.Product, annotations[annotationExtensionProduct])
+	setIfEmpty(&ext.Version, annotations[annotationExtensionVersion])
+	setIfEmpty(&ext.Description, annotations[annotationExtensionDescription])
+	setIfEmpty(&ext.Zone, annotations[annotationExtensionZone])
+	ext.Configuration = mergeConfiguration(ext.Configuration, annotations, annotationExtensionConfigPrefix)
+
+	serviceID := annotations[annotationServiceID]
+	if serviceID == "" {
+		serviceID = object.Name
+	}
+	svc, ok := ext.Services[serviceID]
+	if !ok {
+		svc = &domain.ExtensionService{
+			ID:          serviceID,
+			Endpoints:   make(map[string]*domain.ExtensionServiceEndpoint),
+			Credentials: make(map[string]*domain.ExtensionServiceCredentials),
+		}
+		ext.Services[serviceID] = svc
+	}
+	setIfEmpty(&svc.Resource, annotations[annotationServiceResource])
+	setIfEmpty(&svc.Category, annotations[annotationServiceCategory])
+	setIfEmpty(&svc.Description, annotations[annotationServiceDescription])
+	if authRequired, err := strconv.ParseBool(annotations[annotationServiceAuthRequired]); err == nil && authRequired {
+		svc.AuthRequired = true
+	}
+	svc.Configuration = mergeConfiguration(svc.Configuration, annotations, annotationServiceConfigPrefix)
+	return svc
+}
+
+func addDiscoveredEndpoint(svc *domain.ExtensionService, object *metav1.ObjectMeta, endpointURL string,
+	endpointType domain.ExtensionServiceEndpointType) {
+	annotations := object.Annotations
+	if url := annotations[annotationEndpointURL]; url != "" {
+		endpointURL = url
+	}
+	if _, ok := svc.Endpoints[endpointURL]; ok {
+		return
+	}
+	switch annotations[annotationEndpointType] {
+	case string(domain.EETInternal):
+		endpointType = domain.EETInternal
+	case domain.EETExternal:
+		endpointType = domain.EETExternal
+	}
+	svc.Endpoints[endpointURL] = &domain.ExtensionServiceEndpoint{
+		URL:           endpointURL,
+		Type:          endpointType,
+		Configuration: mergeConfiguration(nil, annotations, annotationEndpointConfigPrefix),
+	}
+}
+
+func addDiscoveredCredentials(svc *domain.ExtensionService, secret *corev1.Secret) {
+	annotations := secret.Annotations
+	credentialsID := annotations[annotationCredentialsID]
+	if credentialsID == "" {
+		credentialsID = secret.Name
+	}
+	if _, ok := svc.Credentials[credentialsID]; ok {
+		return
+	}
+
+	credentials := &domain.ExtensionServiceCredentials{
+		ID:       credentialsID,
+		Scope:    domain.ECSGlobal,
+		Projects: splitList(annotations[annotationCredentialsProjects]),
+		Users:    splitList(annotations[annotationCredentialsUsers]),
+	}
+	if scope := annotations[annotationCredentialsScope]; scope != "" {
+		credentials.Scope = domain.ExtensionServiceCredentialsScope(scope)
+	}
+	if isDefault, err := strconv.ParseBool(annotations[annotationCredentialsDefault]); err == nil {
+		credentials.Default = isDefault
+	}
+	if len(secret.Data) > 0 {
+		credentials.Configuration = make(map[string]string)
+		for k, v := range secret.Data {
+			credentials.Configuration[k] = string(v)
+		}
+	}
+	svc.Credentials[credentialsID] = credentials
+}
+
+// serviceEndpointURL returns the cluster-local URL of a kubernetes Service
+func serviceEndpointURL(service *corev1.Service) (string, error) {
+	if len(service.Spec.Ports) == 0 {
+		return "", fmt.Errorf("service has no ports")
+	}
+	port := service.Spec.Ports[0]
+	if selected := service.Annotations[annotationEndpointPort]; selected != "" {
+		found := false
+		for _, p := range service.Spec.Ports {
+			if p.Name == selected || strconv.Itoa(int(p.Port)) == selected {
+				port, found = p, true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("service has no port %q", selected)
+		}
+	}
+
+	scheme := service.Annotations[annotationEndpointScheme]
+	if scheme == "" {
+		scheme = "http"
+		if port.Port == 443 || port.Name == "https" {
+			scheme = "https"
+		}
+	}
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", service.Name, service.Namespace)
+	if (scheme == "http" && port.Port != 80) || (scheme == "https" && port.Port != 443) {
+		host = fmt.Sprintf("%s:%d", host, port.Port)
+	}
+	return fmt.Sprintf("%s://%s", scheme, host), nil
+}
+
+// ingressEndpointURL returns the URL of the first host exposed by a kubernetes Ingress
+func ingressEndpointURL(ingress *networkingv1.Ingress) (string, error) {
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+		scheme := ingress.Annotations[annotationEndpointScheme]
+		if scheme == "" {
+			scheme = "http"
+			for _, tls := range ingress.Spec.TLS {
+				for _, host := range tls.Hosts {
+					if host == rule.Host {
+						scheme = "https"
+					}
+				}
+			}
+		}
+		return fmt.Sprintf("%s://%s", scheme, rule.Host), nil
+	}
+	return "", fmt.Errorf("ingress has no host rules")
+}
+
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// mergeConfiguration adds the annotations with the given prefix to a configuration, without overwriting
+// existing entries
+func mergeConfiguration(configuration map[string]string, annotations map[string]string, prefix string) map[string]string {
+	for k, v := range annotations {
+		if !strings.HasPrefix(k, prefix) || len(k) == len(prefix) {
+			continue
+		}
+		if configuration == nil {
+			configuration = make(map[string]string)
+		}
+		key := strings.TrimPrefix(k, prefix)
+		if _, ok := configuration[key]; !ok {
+			configuration[key] = v
+		}
+	}
+	return configuration
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
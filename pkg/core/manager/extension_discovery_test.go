@@ -0,0 +1,195 @@
+package manager
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func TestExtensionDiscovery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	mlflowService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mlflow",
+			Namespace: "mlflow",
+			Annotations: map[string]string{
+				annotationExtensionID:                                  "mlflow-0001",
+				annotationExtensionProduct:                             "mlflow",
+				annotationExtensionVersion:                             "1.19.0",
+				annotationServiceID:                                    "mlflow-tracking",
+				annotationServiceResource:                              "mlflow-tracking",
+				annotationServiceCategory:                              "experiment-tracking",
+				annotationEndpointConfigPrefix + "MLFLOW_TRACKING_URI": "http://mlflow.mlflow.svc.cluster.local",
+			},
+		},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+	mlflowIngress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mlflow",
+			Namespace: "mlflow",
+			Annotations: map[string]string{
+				annotationExtensionID: "mlflow-0001",
+				annotationServiceID:   "mlflow-tracking",
+				annotationEndpointConfigPrefix + "MLFLOW_TRACKING_URI": "https://mlflow.172.22.0.2.nip.io",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{Host: "mlflow.172.22.0.2.nip.io"}},
+			TLS:   []networkingv1.IngressTLS{{Hosts: []string{"mlflow.172.22.0.2.nip.io"}}},
+		},
+	}
+	minioService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mlflow-minio",
+			Namespace: "mlflow",
+			Annotations: map[string]string{
+				annotationExtensionID:         "mlflow-0001",
+				annotationServiceID:           "mlflow-store",
+				annotationServiceResource:     "s3",
+				annotationServiceAuthRequired: "true",
+				annotationEndpointPort:        "api",
+			},
+		},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "console", Port: 9001}, {Name: "api", Port: 9000}}},
+	}
+	minioSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mlflow-minio",
+			Namespace: "mlflow",
+			Annotations: map[string]string{
+				annotationExtensionID:   "mlflow-0001",
+				annotationServiceID:     "mlflow-store",
+				annotationCredentialsID: "default",
+			},
+		},
+		Data: map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("key"), "AWS_SECRET_ACCESS_KEY": []byte("secret")},
+	}
+	unannotated := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "mlflow"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+	}
+
+	client := fake.NewSimpleClientset(mlflowService, mlflowIngress, minioService, minioSecret, unannotated)
+	registry := newExtensionRegistry()
+	// an extension registered through the API must not be modified
+	_, err := registry.RegisterExtension(ctx, &domain.Extension{ID: "manual"})
+	assertError(t, err, nil)
+
+	discovery := NewExtensionDiscovery(slog.Default(), registry)
+	discovery.newClient = func() (k8s.Interface, error) { return client, nil }
+	err = discovery.Start(ctx, &wg, "")
+	assertError(t, err, nil)
+
+	// waitFor waits until the discovered extension satisfies a condition
+	waitFor := func(t *testing.T, condition func(ext *domain.Extension) bool) *domain.Extension {
+		t.Helper()
+		var ext *domain.Extension
+		err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			ext, _ = registry.GetExtension(ctx, "mlflow-0001")
+			return condition(ext), nil
+		})
+		if err != nil {
+			t.Fatalf("Timed out waiting for extension discovery: %v", ext)
+		}
+		return ext
+	}
+
+	t.Run("discover", func(t *testing.T) {
+		ext := waitFor(t, func(ext *domain.Extension) bool { return ext != nil })
+		if !ext.Discovered || ext.Product != "mlflow" || ext.Version != "1.19.0" {
+			t.Errorf("Unexpected extension: %+v", ext)
+		}
+
+		tracking, err := ext.GetService("mlflow-tracking")
+		assertError(t, err, nil)
+		urls := []string{}
+		for _, ep := range tracking.ListEndpoints() {
+			urls = append(urls, string(ep.Type)+" "+ep.URL)
+		}
+		sort.Strings(urls)
+		if d := cmp.Diff([]string{"external https://mlflow.172.22.0.2.nip.io", "internal http://mlflow.mlflow.svc.cluster.local"}, urls); d != "" {
+			t.Errorf("Unexpected endpoints: %s", diff.PrintWantGot(d))
+		}
+		ep, err := ext.GetServiceEndpoint("mlflow-tracking", "http://mlflow.mlflow.svc.cluster.local")
+		assertError(t, err, nil)
+		if d := cmp.Diff(map[string]string{"MLFLOW_TRACKING_URI": "http://mlflow.mlflow.svc.cluster.local"}, ep.Configuration); d != "" {
+			t.Errorf("Unexpected endpoint configuration: %s", diff.PrintWantGot(d))
+		}
+
+		store, err := ext.GetService("mlflow-store")
+		assertError(t, err, nil)
+		if store.Resource != "s3" || !store.AuthRequired {
+			t.Errorf("Unexpected service: %+v", store)
+		}
+		_, err = ext.GetServiceEndpoint("mlflow-store", "http://mlflow-minio.mlflow.svc.cluster.local:9000")
+		assertError(t, err, nil)
+		creds, err := ext.GetServiceCredentials("mlflow-store", "default")
+		assertError(t, err, nil)
+		if creds.Scope != domain.ECSGlobal || creds.Configuration["AWS_SECRET_ACCESS_KEY"] != "secret" {
+			t.Errorf("Unexpected credentials: %+v", creds)
+		}
+
+		_, err = ext.GetService("other")
+		if err == nil {
+			t.Errorf("Unannotated service was discovered")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		minioSecret.Annotations[annotationCredentialsScope] = domain.ECSProject
+		minioSecret.Annotations[annotationCredentialsProjects] = "prj0, prj1"
+		_, err := client.CoreV1().Secrets("mlflow").Update(ctx, minioSecret, metav1.UpdateOptions{})
+		assertError(t, err, nil)
+
+		waitFor(t, func(ext *domain.Extension) bool {
+			creds, err := ext.GetServiceCredentials("mlflow-store", "default")
+			return err == nil && creds.Scope == domain.ECSProject
+		})
+		creds, err := registry.GetCredentials(ctx, "mlflow-0001", "mlflow-store", "default")
+		assertError(t, err, nil)
+		if d := cmp.Diff([]string{"prj0", "prj1"}, creds.Projects); d != "" {
+			t.Errorf("Unexpected projects: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		err := client.NetworkingV1().Ingresses("mlflow").Delete(ctx, "mlflow", metav1.DeleteOptions{})
+		assertError(t, err, nil)
+		waitFor(t, func(ext *domain.Extension) bool {
+			_, err := ext.GetServiceEndpoint("mlflow-tracking", "https://mlflow.172.22.0.2.nip.io")
+			return err != nil
+		})
+
+		for _, name := range []string{"mlflow", "mlflow-minio"} {
+			err = client.CoreV1().Services("mlflow").Delete(ctx, name, metav1.DeleteOptions{})
+			assertError(t, err, nil)
+		}
+		err = client.CoreV1().Secrets("mlflow").Delete(ctx, "mlflow-minio", metav1.DeleteOptions{})
+		assertError(t, err, nil)
+		waitFor(t, func(ext *domain.Extension) bool { return ext == nil })
+
+		_, err = registry.GetExtension(ctx, "manual")
+		assertError(t, err, nil)
+	})
+}
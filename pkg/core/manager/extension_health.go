@@ -0,0 +1,182 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// extensionProbeTimeout is the time that FuseML waits for an extension endpoint to respond to a probe
+const extensionProbeTimeout = 5 * time.Second
+
+// endpointProbe checks whether an extension endpoint is reachable and returns an error describing
+// the problem if it isn't
+type endpointProbe func(ctx context.Context, client *http.Client, endpoint *domain.ExtensionServiceEndpoint) error
+
+// resourceProbes are the probes used for services with well-known resource types. Endpoints belonging to
+// services with other resource types are probed with defaultProbe.
+var resourceProbes = map[string]endpointProbe{
+	// S3 services reject anonymous requests, but any response other than a server error
+	// shows that the service is up
+	"s3": httpProbe("", false),
+	// MLflow tracking servers expose a dedicated health check endpoint
+	"mlflow-tracking": httpProbe("/health", true),
+	// the kserve-api endpoint points to the cluster API, which requires a TLS client configuration
+	// that is not part of the registry, so only check that it accepts connections
+	"kserve-api": tcpProbe,
+}
+
+// ExtensionHealthChecker periodically probes the endpoints registered in the extension registry and
+// records their operational status
+type ExtensionHealthChecker struct {
+	logger     *slog.Logger
+	registry   domain.ExtensionRegistry
+	httpClient *http.Client
+	probes     map[string]endpointProbe
+}
+
+// NewExtensionHealthChecker initializes an extension health checker
+func NewExtensionHealthChecker(logger *slog.Logger, registry domain.ExtensionRegistry) *ExtensionHealthChecker {
+	return &ExtensionHealthChecker{
+		logger:     logger,
+		registry:   registry,
+		httpClient: &http.Client{Timeout: extensionProbeTimeout},
+		probes:     resourceProbes,
+	}
+}
+
+// Start runs the health checker in the background, probing all endpoints every interval, until the
+// context is cancelled. A zero interval disables health checking.
+func (hc *ExtensionHealthChecker) Start(ctx context.Context, wg *sync.WaitGroup, interval time.Duration) {
+	if interval <= 0 {
+		hc.logger.Info("Extension health checking disabled")
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			hc.CheckAll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// CheckAll probes all registered extension endpoints and updates their status
+func (hc *ExtensionHealthChecker) CheckAll(ctx context.Context) {
+	extensions, _, err := hc.registry.ListExtensions(ctx, nil, nil)
+	if err != nil {
+		hc.logger.ErrorContext(ctx, "Failed listing extensions for health checking", "error", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, ext := range extensions {
+		for _, svc := range ext.ListServices() {
+			for _, ep := range svc.ListEndpoints() {
+				wg.Add(1)
+				go func(extensionID string, svc *domain.ExtensionService, ep *domain.ExtensionServiceEndpoint) {
+					defer wg.Done()
+					hc.check(ctx, extensionID, svc, ep)
+				}(ext.ID, svc, ep)
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func (hc *ExtensionHealthChecker) check(ctx context.Context, extensionID string, svc *domain.ExtensionService, ep *domain.ExtensionServiceEndpoint) {
+	probe, ok := hc.probes[strings.ToLower(svc.Resource)]
+	if !ok {
+		probe = defaultProbe
+	}
+
+	status := ep.Status
+	status.LastChecked = time.Now()
+	if err := probe(ctx, hc.httpClient, ep); err != nil {
+		status.Health = domain.EEHUnhealthy
+		status.Message = err.Error()
+	} else {
+		status.Health = domain.EEHHealthy
+		status.Message = ""
+		status.LastSeen = status.LastChecked
+	}
+
+	err := hc.registry.UpdateEndpointStatus(ctx, extensionID, svc.ID, ep.URL, status)
+	if err != nil {
+		// the endpoint may have been removed while being probed
+		hc.logger.ErrorContext(ctx, "Failed updating extension endpoint status", "extension", extensionID, "service", svc.ID, "endpoint", ep.URL, "error", err)
+	}
+}
+
+// defaultProbe uses an HTTP check for HTTP(S) endpoints and a TCP check for all other endpoints
+func defaultProbe(ctx context.Context, client *http.Client, endpoint *domain.ExtensionServiceEndpoint) error {
+	u, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "http" || u.Scheme == "https" {
+		return httpProbe("", false)(ctx, client, endpoint)
+	}
+	return tcpProbe(ctx, client, endpoint)
+}
+
+// httpProbe returns a probe that sends a GET request to the given path, relative to the endpoint URL.
+// If strict is set, only successful responses are accepted, otherwise any response other than a
+// server error is accepted.
+func httpProbe(path string, strict bool) endpointProbe {
+	return func(ctx context.Context, client *http.Client, endpoint *domain.ExtensionServiceEndpoint) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint.URL, "/")+path, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError || (strict && resp.StatusCode >= http.StatusBadRequest) {
+			return fmt.Errorf("endpoint responded with %q", resp.Status)
+		}
+		return nil
+	}
+}
+
+// tcpProbe checks that the endpoint host accepts TCP connections
+func tcpProbe(ctx context.Context, client *http.Client, endpoint *domain.ExtensionServiceEndpoint) error {
+	address := endpoint.URL
+	if u, err := url.Parse(endpoint.URL); err == nil && u.Host != "" {
+		address = u.Host
+		if u.Port() == "" {
+			switch u.Scheme {
+			case "https":
+				address = net.JoinHostPort(u.Hostname(), "443")
+			default:
+				address = net.JoinHostPort(u.Hostname(), "80")
+			}
+		}
+	}
+
+	dialer := net.Dialer{Timeout: extensionProbeTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
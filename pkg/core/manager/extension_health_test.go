@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func TestExtensionHealthChecker(t *testing.T) {
+	ctx := context.Background()
+	registry := newExtensionRegistry()
+	checker := NewExtensionHealthChecker(slog.Default(), registry)
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	mlflow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mlflow.Close()
+
+	// a listener that is closed right away provides an address that refuses connections
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	closedAddr := l.Addr().String()
+	l.Close()
+
+	tests := []struct {
+		resource string
+		url      string
+		want     domain.ExtensionServiceEndpointHealth
+	}{
+		{"s3", up.URL, domain.EEHHealthy},
+		{"s3", down.URL, domain.EEHUnhealthy},
+		{"mlflow-tracking", mlflow.URL, domain.EEHHealthy},
+		{"mlflow-tracking", up.URL, domain.EEHUnhealthy},
+		{"kserve-api", up.URL, domain.EEHHealthy},
+		{"kserve-api", "http://" + closedAddr, domain.EEHUnhealthy},
+		{"other", up.URL, domain.EEHHealthy},
+		{"other", "tcp://" + closedAddr, domain.EEHUnhealthy},
+	}
+
+	e := &domain.Extension{ID: "testextension"}
+	for i, tt := range tests {
+		s := &domain.ExtensionService{ID: fmt.Sprintf("svc-%d", i), Resource: tt.resource}
+		s.AddEndpoint(&domain.ExtensionServiceEndpoint{URL: tt.url, Configuration: map[string]string{"MLFLOW_TRACKING_URI": tt.url}})
+		e.AddService(s)
+	}
+	_, err = registry.RegisterExtension(ctx, e)
+	assertError(t, err, nil)
+
+	start := time.Now()
+	checker.CheckAll(ctx)
+
+	for i, tt := range tests {
+		t.Run(tt.resource+" "+tt.url, func(t *testing.T) {
+			ep, err := registry.GetEndpoint(ctx, e.ID, fmt.Sprintf("svc-%d", i), tt.url)
+			assertError(t, err, nil)
+			if ep.Status.Health != tt.want {
+				t.Errorf("got health %q want %q (%s)", ep.Status.Health, tt.want, ep.Status.Message)
+			}
+			if ep.Status.LastChecked.Before(start) {
+				t.Errorf("endpoint was not checked")
+			}
+			if tt.want == domain.EEHHealthy && ep.Status.LastSeen.Before(start) {
+				t.Errorf("last seen time was not updated for healthy endpoint")
+			}
+			if tt.want == domain.EEHUnhealthy && (!ep.Status.LastSeen.IsZero() || ep.Status.Message == "") {
+				t.Errorf("unexpected status for unhealthy endpoint: %+v", ep.Status)
+			}
+		})
+	}
+}
+
+func TestPreferredAccessDescriptor(t *testing.T) {
+	accessDesc := func(url string, epType domain.ExtensionServiceEndpointType, health domain.ExtensionServiceEndpointHealth) *domain.ExtensionAccessDescriptor {
+		return &domain.ExtensionAccessDescriptor{
+			Endpoint: domain.ExtensionServiceEndpoint{URL: url, Type: epType, Status: domain.ExtensionServiceEndpointStatus{Health: health}},
+		}
+	}
+
+	tests := []struct {
+		name string
+		list []*domain.ExtensionAccessDescriptor
+		want string
+	}{
+		{
+			name: "internal preferred",
+			list: []*domain.ExtensionAccessDescriptor{
+				accessDesc("ext", domain.EETExternal, ""),
+				accessDesc("int", domain.EETInternal, ""),
+			},
+			want: "int",
+		},
+		{
+			name: "healthy preferred over internal",
+			list: []*domain.ExtensionAccessDescriptor{
+				accessDesc("int", domain.EETInternal, domain.EEHUnhealthy),
+				accessDesc("ext", domain.EETExternal, domain.EEHHealthy),
+			},
+			want: "ext",
+		},
+		{
+			name: "unknown preferred over unhealthy",
+			list: []*domain.ExtensionAccessDescriptor{
+				accessDesc("int", domain.EETInternal, domain.EEHUnhealthy),
+				accessDesc("ext", domain.EETExternal, domain.EEHUnknown),
+			},
+			want: "ext",
+		},
+		{
+			name: "all unhealthy",
+			list: []*domain.ExtensionAccessDescriptor{
+				accessDesc("ext", domain.EETExternal, domain.EEHUnhealthy),
+				accessDesc("int", domain.EETInternal, domain.EEHUnhealthy),
+			},
+			want: "int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := preferredAccessDescriptor(tt.list)
+			if got.Endpoint.URL != tt.want {
+				t.Errorf("got endpoint %q want %q", got.Endpoint.URL, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// ImportExtensions - import extensions into the registry, adding the extensions, services, endpoints and credentials
+// that are not yet registered and updating those that are. Registered elements missing from the imported extensions
+// are left untouched. All extensions are validated before any change is made to the registry.
+func (registry *ExtensionRegistry) ImportExtensions(ctx context.Context, extensions []*domain.Extension,
+	options *domain.ExtensionImportOptions) (_ []*domain.ExtensionChange, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.ImportExtensions")
+	defer tracing.End(span, &err)
+
+	if options == nil {
+		options = &domain.ExtensionImportOptions{}
+	}
+	for _, extension := range extensions {
+		err := validateImportedExtension(extension)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	changes := []*domain.ExtensionChange{}
+	// the same extension may be imported more than once, in which case the documents are merged in order
+	merged := map[string]*domain.Extension{}
+	registered := map[string]bool{}
+	order := []string{}
+	for _, imported := range extensions {
+		current, planned := merged[imported.ID]
+		if !planned {
+			order = append(order, imported.ID)
+			existing, err := registry.extensionStore.GetExtension(ctx, imported.ID)
+			if err != nil {
+				if _, notFound := err.(*domain.ErrExtensionNotFound); !notFound {
+					return nil, err
+				}
+			} else {
+				current = copyExtension(existing, false)
+				registered[imported.ID] = true
+			}
+		}
+		extension, extChanges := mergeExtension(current, imported, options.ExcludeCredentials, now)
+		merged[imported.ID] = extension
+		changes = append(changes, extChanges...)
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Element < changes[j].Element })
+	if options.DryRun {
+		return changes, nil
+	}
+
+	changed := map[string]bool{}
+	for _, change := range changes {
+		changed[extensionIDFromElement(change.Element)] = true
+	}
+	for _, extensionID := range order {
+		if !changed[extensionID] {
+			continue
+		}
+		var err error
+		if registered[extensionID] {
+			err = registry.UpdateExtension(ctx, merged[extensionID])
+		} else {
+			_, err = registry.RegisterExtension(ctx, merged[extensionID])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// ExportExtensions - export all registered extensions, sorted by ID, in a form that can be imported back into
+// a registry. The operational status of the endpoints is not exported.
+func (registry *ExtensionRegistry) ExportExtensions(ctx context.Context, excludeCredentials bool) (_ []*domain.Extension, err error) {
+	ctx, span := tracing.Start(ctx, "ExtensionRegistry.ExportExtensions")
+	defer tracing.End(span, &err)
+
+	extensions, _, err := registry.extensionStore.ListExtensions(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Extension, 0, len(extensions))
+	for _, extension := range extensions {
+		exported := copyExtension(extension, excludeCredentials)
+		for _, service := range exported.Services {
+			for _, endpoint := range service.Endpoints {
+				endpoint.Status = domain.ExtensionServiceEndpointStatus{}
+			}
+		}
+		result = append(result, exported)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// validateImportedExtension checks that the imported extension and all its elements can be identified, which is
+// required to match them against the registered ones
+func validateImportedExtension(extension *domain.Extension) error {
+	if extension.ID == "" {
+		return domain.NewErrMissingField("extension", "extension ID")
+	}
+	for _, service := range extension.Services {
+		if service.ID == "" {
+			return domain.NewErrMissingField("service", "service ID")
+		}
+		for _, endpoint := range service.Endpoints {
+			if endpoint.URL == "" {
+				return domain.NewErrMissingField("endpoint", "URL")
+			}
+		}
+		for _, credentials := range service.Credentials {
+			if credentials.ID == "" {
+				return domain.NewErrMissingField("credentials", "credentials ID")
+			}
+		}
+	}
+	return nil
+}
+
+// mergeExtension merges an imported extension into a registered one, returning the merged extension and the list
+// of changes made to the registered extension. The registered extension is nil if it doesn't exist yet.
+func mergeExtension(current, imported *domain.Extension, excludeCredentials bool, now time.Time) (*domain.Extension, []*domain.ExtensionChange) {
+	imported = copyExtension(imported, excludeCredentials)
+	if current == nil {
+		imported.Discovered = false
+		imported.Created = now
+		imported.Updated = now
+		changes := []*domain.ExtensionChange{{Kind: domain.EEKExtension, Element: imported.ID, Action: domain.ECACreate}}
+		for _, service := range imported.ListServices() {
+			service.SetCreated(now)
+			changes = append(changes, createdServiceChanges(imported.ID, service)...)
+		}
+		return imported, changes
+	}
+
+	fields := []string{}
+	fields = appendIfChanged(fields, "product", current.Product != imported.Product)
+	fields = appendIfChanged(fields, "version", current.Version != imported.Version)
+	fields = appendIfChanged(fields, "description", current.Description != imported.Description)
+	fields = appendIfChanged(fields, "zone", current.Zone != imported.Zone)
+	fields = appendIfChanged(fields, "configuration", !equalConfiguration(current.Configuration, imported.Configuration))
+	current.Product = imported.Product
+	current.Version = imported.Version
+	current.Description = imported.Description
+	current.Zone = imported.Zone
+	current.Configuration = imported.Configuration
+
+	changes := []*domain.ExtensionChange{}
+	for _, service := range imported.ListServices() {
+		changes = append(changes, mergeService(current, service, now)...)
+	}
+	if len(fields) > 0 || len(changes) > 0 {
+		current.Updated = now
+	}
+	if len(fields) > 0 {
+		changes = append(changes, &domain.ExtensionChange{
+			Kind: domain.EEKExtension, Element: current.ID, Action: domain.ECAUpdate, Fields: fields})
+	}
+	return current, changes
+}
+
+// mergeService merges an imported service into a registered extension
+func mergeService(extension *domain.Extension, imported *domain.ExtensionService, now time.Time) []*domain.ExtensionChange {
+	service, err := extension.GetService(imported.ID)
+	if err != nil {
+		imported.SetCreated(now)
+		extension.Services[imported.ID] = imported
+		return createdServiceChanges(extension.ID, imported)
+	}
+
+	element := extension.ID + "/" + service.ID
+	fields := []string{}
+	fields = appendIfChanged(fields, "resource", service.Resource != imported.Resource)
+	fields = appendIfChanged(fields, "category", service.Category != imported.Category)
+	fields = appendIfChanged(fields, "description", service.Description != imported.Description)
+	fields = appendIfChanged(fields, "auth_required", service.AuthRequired != imported.AuthRequired)
+	fields = appendIfChanged(fields, "configuration", !equalConfiguration(service.Configuration, imported.Configuration))
+	service.Resource = imported.Resource
+	service.Category = imported.Category
+	service.Description = imported.Description
+	service.AuthRequired = imported.AuthRequired
+	service.Configuration = imported.Configuration
+	if len(fields) > 0 {
+		service.Updated = now
+	}
+
+	changes := []*domain.ExtensionChange{}
+	for _, endpoint := range imported.ListEndpoints() {
+		change := mergeEndpoint(element, service, endpoint, now)
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	for _, credentials := range imported.ListCredentials() {
+		change := mergeCredentials(element, service, credentials, now)
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	if len(fields) > 0 {
+		changes = append(changes, &domain.ExtensionChange{
+			Kind: domain.EEKService, Element: element, Action: domain.ECAUpdate, Fields: fields})
+	}
+	return changes
+}
+
+// mergeEndpoint merges an imported endpoint into a registered service
+func mergeEndpoint(serviceElement string, service *domain.ExtensionService, imported *domain.ExtensionServiceEndpoint,
+	now time.Time) *domain.ExtensionChange {
+	element := serviceElement + "/" + imported.URL
+	endpoint, err := service.GetEndpoint(imported.URL)
+	if err != nil {
+		imported.Created = now
+		imported.Updated = now
+		service.Endpoints[imported.URL] = imported
+		return &domain.ExtensionChange{Kind: domain.EEKEndpoint, Element: element, Action: domain.ECACreate}
+	}
+
+	fields := []string{}
+	fields = appendIfChanged(fields, "type", endpoint.Type != imported.Type)
+	fields = appendIfChanged(fields, "configuration", !equalConfiguration(endpoint.Configuration, imported.Configuration))
+	if len(fields) == 0 {
+		return nil
+	}
+	endpoint.Type = imported.Type
+	endpoint.Configuration = imported.Configuration
+	endpoint.Updated = now
+	return &domain.ExtensionChange{Kind: domain.EEKEndpoint, Element: element, Action: domain.ECAUpdate, Fields: fields}
+}
+
+// mergeCredentials merges a set of imported credentials into a registered service
+func mergeCredentials(serviceElement string, service *domain.ExtensionService, imported *domain.ExtensionServiceCredentials,
+	now time.Time) *domain.ExtensionChange {
+	element := serviceElement + "/" + imported.ID
+	credentials, err := service.GetCredentials(imported.ID)
+	if err != nil {
+		imported.Created = now
+		imported.Updated = now
+		service.Credentials[imported.ID] = imported
+		return &domain.ExtensionChange{Kind: domain.EEKCredentials, Element: element, Action: domain.ECACreate}
+	}
+
+	fields := []string{}
+	fields = appendIfChanged(fields, "scope", credentials.Scope != imported.Scope)
+	fields = appendIfChanged(fields, "default", credentials.Default != imported.Default)
+	fields = appendIfChanged(fields, "projects", !equalStrings(credentials.Projects, imported.Projects))
+	fields = appendIfChanged(fields, "users", !equalStrings(credentials.Users, imported.Users))
+	fields = appendIfChanged(fields, "configuration", !equalConfiguration(credentials.Configuration, imported.Configuration))
+	if len(fields) == 0 {
+		return nil
+	}
+	credentials.Scope = imported.Scope
+	credentials.Default = imported.Default
+	credentials.Projects = imported.Projects
+	credentials.Users = imported.Users
+	credentials.Configuration = imported.Configuration
+	credentials.Updated = now
+	return &domain.ExtensionChange{Kind: domain.EEKCredentials, Element: element, Action: domain.ECAUpdate, Fields: fields}
+}
+
+// createdServiceChanges returns the changes made by adding a new service to an extension
+func createdServiceChanges(extensionID string, service *domain.ExtensionService) []*domain.ExtensionChange {
+	element := extensionID + "/" + service.ID
+	changes := []*domain.ExtensionChange{{Kind: domain.EEKService, Element: element, Action: domain.ECACreate}}
+	for _, endpoint := range service.ListEndpoints() {
+		changes = append(changes, &domain.ExtensionChange{
+			Kind: domain.EEKEndpoint, Element: element + "/" + endpoint.URL, Action: domain.ECACreate})
+	}
+	for _, credentials := range service.ListCredentials() {
+		changes = append(changes, &domain.ExtensionChange{
+			Kind: domain.EEKCredentials, Element: element + "/" + credentials.ID, Action: domain.ECACreate})
+	}
+	return changes
+}
+
+// copyExtension returns a copy of an extension that can be modified without affecting the original, optionally
+// leaving out the service credentials
+func copyExtension(extension *domain.Extension, excludeCredentials bool) *domain.Extension {
+	result := *extension
+	result.Services = make(map[string]*domain.ExtensionService, len(extension.Services))
+	for id, service := range extension.Services {
+		svc := *service
+		svc.Endpoints = make(map[string]*domain.ExtensionServiceEndpoint, len(service.Endpoints))
+		for url, endpoint := range service.Endpoints {
+			ep := *endpoint
+			svc.Endpoints[url] = &ep
+		}
+		svc.Credentials = make(map[string]*domain.ExtensionServiceCredentials)
+		if !excludeCredentials {
+			for credentialsID, credentials := range service.Credentials {
+				creds := *credentials
+				svc.Credentials[credentialsID] = &creds
+			}
+		}
+		result.Services[id] = &svc
+	}
+	return &result
+}
+
+// extensionIDFromElement returns the ID of the extension that a changed element belongs to
+func extensionIDFromElement(element string) string {
+	return strings.SplitN(element, "/", 2)[0]
+}
+
+func appendIfChanged(fields []string, field string, changed bool) []string {
+	if changed {
+		return append(fields, field)
+	}
+	return fields
+}
+
+// equalConfiguration compares two sets of configuration entries, treating nil and empty sets as equal
+func equalConfiguration(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStrings compares two lists of strings, treating nil and empty lists as equal
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// newImportedExtension returns an extension with a service, an endpoint and a set of credentials
+func newImportedExtension(id, description string) *domain.Extension {
+	e := &domain.Extension{ID: id, Product: "mlflow", Description: description}
+	s := &domain.ExtensionService{ID: "mlflow-store", Resource: "s3"}
+	s.AddEndpoint(&domain.ExtensionServiceEndpoint{URL: "http://minio:9000", Type: domain.EETInternal})
+	s.AddCredentials(&domain.ExtensionServiceCredentials{
+		ID: "default", Scope: domain.ECSGlobal, Configuration: map[string]string{"AWS_ACCESS_KEY_ID": "key", "AWS_SECRET_ACCESS_KEY": "secret"}})
+	e.AddService(s)
+	return e
+}
+
+func assertChanges(t *testing.T, got []*domain.ExtensionChange, want []*domain.ExtensionChange) {
+	t.Helper()
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected changes: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestExtensionImport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new extensions", func(t *testing.T) {
+		registry := newExtensionRegistry()
+
+		changes, err := registry.ImportExtensions(ctx, []*domain.Extension{newImportedExtension("mlflow-0001", "")}, nil)
+		assertError(t, err, nil)
+		assertChanges(t, changes, []*domain.ExtensionChange{
+			{Kind: domain.EEKExtension, Element: "mlflow-0001", Action: domain.ECACreate},
+			{Kind: domain.EEKService, Element: "mlflow-0001/mlflow-store", Action: domain.ECACreate},
+			{Kind: domain.EEKCredentials, Element: "mlflow-0001/mlflow-store/default", Action: domain.ECACreate},
+			{Kind: domain.EEKEndpoint, Element: "mlflow-0001/mlflow-store/http://minio:9000", Action: domain.ECACreate},
+		})
+
+		creds, err := registry.GetCredentials(ctx, "mlflow-0001", "mlflow-store", "default")
+		assertError(t, err, nil)
+		assertStrings(t, creds.Configuration["AWS_SECRET_ACCESS_KEY"], "secret")
+
+		// importing the same extension again doesn't change anything
+		changes, err = registry.ImportExtensions(ctx, []*domain.Extension{newImportedExtension("mlflow-0001", "")}, nil)
+		assertError(t, err, nil)
+		assertChanges(t, changes, []*domain.ExtensionChange{})
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		registry := newExtensionRegistry()
+		_, err := registry.RegisterExtension(ctx, newImportedExtension("mlflow-0001", ""))
+		assertError(t, err, nil)
+		_, err = registry.AddService(ctx, "mlflow-0001", &domain.ExtensionService{ID: "mlflow-tracking"})
+		assertError(t, err, nil)
+
+		imported := newImportedExtension("mlflow-0001", "updated")
+		imported.Services["mlflow-store"].Endpoints["http://minio:9000"].Configuration = map[string]string{"KEY": "value"}
+		imported.Services["mlflow-store"].AddEndpoint(&domain.ExtensionServiceEndpoint{URL: "https://minio.example.com"})
+		wantChanges := []*domain.ExtensionChange{
+			{Kind: domain.EEKExtension, Element: "mlflow-0001", Action: domain.ECAUpdate, Fields: []string{"description"}},
+			{Kind: domain.EEKEndpoint, Element: "mlflow-0001/mlflow-store/http://minio:9000", Action: domain.ECAUpdate,
+				Fields: []string{"configuration"}},
+			{Kind: domain.EEKEndpoint, Element: "mlflow-0001/mlflow-store/https://minio.example.com", Action: domain.ECACreate},
+		}
+
+		changes, err := registry.ImportExtensions(ctx, []*domain.Extension{imported}, &domain.ExtensionImportOptions{DryRun: true})
+		assertError(t, err, nil)
+		assertChanges(t, changes, wantChanges)
+		ext, err := registry.GetExtension(ctx, "mlflow-0001")
+		assertError(t, err, nil)
+		assertStrings(t, ext.Description, "")
+		_, err = registry.GetEndpoint(ctx, "mlflow-0001", "mlflow-store", "https://minio.example.com")
+		if err == nil {
+			t.Errorf("Dry-run import changed the registry")
+		}
+
+		changes, err = registry.ImportExtensions(ctx, []*domain.Extension{imported}, nil)
+		assertError(t, err, nil)
+		assertChanges(t, changes, wantChanges)
+		ext, err = registry.GetExtension(ctx, "mlflow-0001")
+		assertError(t, err, nil)
+		assertStrings(t, ext.Description, "updated")
+		ep, err := registry.GetEndpoint(ctx, "mlflow-0001", "mlflow-store", "http://minio:9000")
+		assertError(t, err, nil)
+		assertStrings(t, ep.Configuration["KEY"], "value")
+		_, err = registry.GetEndpoint(ctx, "mlflow-0001", "mlflow-store", "https://minio.example.com")
+		assertError(t, err, nil)
+		// elements missing from the imported extension are left untouched
+		_, err = registry.GetService(ctx, "mlflow-0001", "mlflow-tracking")
+		assertError(t, err, nil)
+	})
+
+	t.Run("exclude credentials", func(t *testing.T) {
+		registry := newExtensionRegistry()
+		_, err := registry.RegisterExtension(ctx, newImportedExtension("mlflow-0001", ""))
+		assertError(t, err, nil)
+
+		imported := newImportedExtension("mlflow-0001", "")
+		imported.Services["mlflow-store"].Credentials["default"].Configuration["AWS_SECRET_ACCESS_KEY"] = "changed"
+		imported.Services["mlflow-store"].AddCredentials(&domain.ExtensionServiceCredentials{ID: "other"})
+		changes, err := registry.ImportExtensions(ctx, []*domain.Extension{imported, newImportedExtension("mlflow-0002", "")},
+			&domain.ExtensionImportOptions{ExcludeCredentials: true})
+		assertError(t, err, nil)
+		assertChanges(t, changes, []*domain.ExtensionChange{
+			{Kind: domain.EEKExtension, Element: "mlflow-0002", Action: domain.ECACreate},
+			{Kind: domain.EEKService, Element: "mlflow-0002/mlflow-store", Action: domain.ECACreate},
+			{Kind: domain.EEKEndpoint, Element: "mlflow-0002/mlflow-store/http://minio:9000", Action: domain.ECACreate},
+		})
+
+		creds, err := registry.GetCredentials(ctx, "mlflow-0001", "mlflow-store", "default")
+		assertError(t, err, nil)
+		assertStrings(t, creds.Configuration["AWS_SECRET_ACCESS_KEY"], "secret")
+		_, err = registry.GetCredentials(ctx, "mlflow-0002", "mlflow-store", "default")
+		assertErrorType(t, err, domain.NewErrExtensionServiceCredentialsNotFound("mlflow-0002", "mlflow-store", "default"))
+	})
+
+	t.Run("missing ID", func(t *testing.T) {
+		registry := newExtensionRegistry()
+		imported := newImportedExtension("mlflow-0001", "")
+		imported.Services["mlflow-store"].Credentials["default"].ID = ""
+
+		_, err := registry.ImportExtensions(ctx, []*domain.Extension{newImportedExtension("mlflow-0002", ""), imported}, nil)
+		assertErrorType(t, err, domain.NewErrMissingField("credentials", "credentials ID"))
+		// nothing is imported if any of the extensions is invalid
+		_, err = registry.GetExtension(ctx, "mlflow-0002")
+		assertErrorType(t, err, domain.NewErrExtensionNotFound("mlflow-0002"))
+	})
+}
+
+func TestExtensionExport(t *testing.T) {
+	ctx := context.Background()
+	registry := newExtensionRegistry()
+	for _, id := range []string{"mlflow-0002", "mlflow-0001"} {
+		_, err := registry.RegisterExtension(ctx, newImportedExtension(id, ""))
+		assertError(t, err, nil)
+	}
+	err := registry.UpdateEndpointStatus(ctx, "mlflow-0001", "mlflow-store", "http://minio:9000",
+		domain.ExtensionServiceEndpointStatus{Health: domain.EEHHealthy})
+	assertError(t, err, nil)
+
+	t.Run("with credentials", func(t *testing.T) {
+		exts, err := registry.ExportExtensions(ctx, false)
+		assertError(t, err, nil)
+		if len(exts) != 2 || exts[0].ID != "mlflow-0001" || exts[1].ID != "mlflow-0002" {
+			t.Fatalf("Unexpected extensions: %v", exts)
+		}
+		ep, err := exts[0].GetServiceEndpoint("mlflow-store", "http://minio:9000")
+		assertError(t, err, nil)
+		if ep.Status.GetHealth() != domain.EEHUnknown {
+			t.Errorf("Endpoint status was exported: %v", ep.Status)
+		}
+		_, err = exts[0].GetServiceCredentials("mlflow-store", "default")
+		assertError(t, err, nil)
+
+		// the exported extensions can be imported back without changes
+		changes, err := registry.ImportExtensions(ctx, exts, nil)
+		assertError(t, err, nil)
+		assertChanges(t, changes, []*domain.ExtensionChange{})
+	})
+
+	t.Run("exclude credentials", func(t *testing.T) {
+		exts, err := registry.ExportExtensions(ctx, true)
+		assertError(t, err, nil)
+		creds, err := exts[0].ListServiceCredentials("mlflow-store")
+		assertError(t, err, nil)
+		if len(creds) != 0 {
+			t.Errorf("Credentials were exported: %v", creds)
+		}
+
+		// exporting must not affect the registered extensions
+		_, err = registry.GetCredentials(ctx, "mlflow-0001", "mlflow-store", "default")
+		assertError(t, err, nil)
+		ep, err := registry.GetEndpoint(ctx, "mlflow-0001", "mlflow-store", "http://minio:9000")
+		assertError(t, err, nil)
+		if ep.Status.GetHealth() != domain.EEHHealthy {
+			t.Errorf("Unexpected endpoint health: %s", ep.Status.GetHealth())
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// builtinResourceSchemas are the schemas of the service resource types known to FuseML. Services of
+// other resource types are not validated.
+var builtinResourceSchemas = []*domain.ExtensionResourceSchema{
+	{
+		Resource:    "s3",
+		Description: "S3 compatible object storage",
+		Category:    "object-storage",
+		ServiceConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "AWS_DEFAULT_REGION", Description: "Region where the buckets are located"},
+		},
+		EndpointConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "S3_ENDPOINT_URL", Description: "URL of the S3 API, for storage services other than AWS S3"},
+			{Name: "MLFLOW_S3_ENDPOINT_URL", Description: "URL of the S3 API, used by MLflow clients to access artifacts"},
+		},
+		CredentialsConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "AWS_ACCESS_KEY_ID", Description: "Access key ID", Required: true},
+			{Name: "AWS_SECRET_ACCESS_KEY", Description: "Secret access key", Required: true},
+			{Name: "AWS_SESSION_TOKEN", Description: "Session token, for temporary credentials"},
+		},
+	},
+	{
+		Resource:    "mlflow-tracking",
+		Description: "MLflow experiment tracking and model registry",
+		Category:    "experiment-tracking",
+		EndpointConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "MLFLOW_TRACKING_URI", Description: "URL of the MLflow tracking server", Required: true},
+		},
+		CredentialsConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "MLFLOW_TRACKING_USERNAME", Description: "Username for HTTP basic authentication"},
+			{Name: "MLFLOW_TRACKING_PASSWORD", Description: "Password for HTTP basic authentication"},
+			{Name: "MLFLOW_TRACKING_TOKEN", Description: "Token for HTTP bearer authentication"},
+		},
+	},
+	{
+		Resource:    "git",
+		Description: "Git source code repositories",
+		Category:    "source-control",
+		CredentialsConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "GIT_USERNAME", Description: "Username"},
+			{Name: "GIT_PASSWORD", Description: "Password"},
+			{Name: "GIT_TOKEN", Description: "Access token, used instead of the username and password"},
+		},
+	},
+	{
+		Resource:    "kserve-api",
+		Description: "KServe prediction services, managed through the kubernetes API",
+		Category:    "prediction-serving",
+		ServiceConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "NAMESPACE", Description: "Namespace where the inference services are created"},
+		},
+		CredentialsConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "KUBECONFIG", Description: "Kubeconfig for the cluster where KServe is installed"},
+		},
+	},
+	{
+		Resource:    "seldon-core",
+		Description: "Seldon Core prediction services, managed through the kubernetes API",
+		Category:    "prediction-serving",
+		ServiceConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "NAMESPACE", Description: "Namespace where the seldon deployments are created"},
+		},
+		CredentialsConfiguration: []*domain.ExtensionConfigurationKey{
+			{Name: "KUBECONFIG", Description: "Kubeconfig for the cluster where Seldon Core is installed"},
+		},
+	},
+}
+
+// ListResourceSchemas - list the schemas of the known service resource types, or only the schema of the
+// given resource type
+func (registry *ExtensionRegistry) ListResourceSchemas(ctx context.Context, resource string) ([]*domain.ExtensionResourceSchema, error) {
+	result := []*domain.ExtensionResourceSchema{}
+	for _, schema := range builtinResourceSchemas {
+		if resource == "" || schema.Resource == resource {
+			result = append(result, schema)
+		}
+	}
+	return result, nil
+}
+
+// resourceSchema returns the schema of a service resource type, or nil if the resource type is unknown
+func resourceSchema(resource string) *domain.ExtensionResourceSchema {
+	for _, schema := range builtinResourceSchemas {
+		if schema.Resource == resource {
+			return schema
+		}
+	}
+	return nil
+}
+
+// validateExtension checks the services of an extension against the schemas of their resource types
+func validateExtension(extension *domain.Extension) error {
+	for _, service := range extension.ListServices() {
+		err := validateService(extension.ID, service)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateService checks a service, along with its endpoints and credentials, against the schema of its
+// resource type
+func validateService(extensionID string, service *domain.ExtensionService) error {
+	if schema := resourceSchema(service.Resource); schema != nil {
+		return schema.ValidateService(extensionID, service)
+	}
+	return nil
+}
+
+// validateEndpoint checks an endpoint against the schema of the resource type of the service it belongs to
+func (registry *ExtensionRegistry) validateEndpoint(ctx context.Context, extensionID, serviceID string,
+	endpoint *domain.ExtensionServiceEndpoint) error {
+	service, err := registry.extensionStore.GetExtensionService(ctx, extensionID, serviceID)
+	if err != nil {
+		// leave it to the store to report missing services
+		return nil
+	}
+	if schema := resourceSchema(service.Resource); schema != nil {
+		return schema.ValidateEndpoint(extensionID+"/"+serviceID, endpoint)
+	}
+	return nil
+}
+
+// validateCredentials checks a set of credentials against the schema of the resource type of the service
+// it belongs to
+func (registry *ExtensionRegistry) validateCredentials(ctx context.Context, extensionID, serviceID string,
+	credentials *domain.ExtensionServiceCredentials) error {
+	service, err := registry.extensionStore.GetExtensionService(ctx, extensionID, serviceID)
+	if err != nil {
+		// leave it to the store to report missing services
+		return nil
+	}
+	if schema := resourceSchema(service.Resource); schema != nil {
+		return schema.ValidateCredentials(extensionID+"/"+serviceID, credentials)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// extensionDescriptor is the format of the extension descriptors found in the examples
+type extensionDescriptor struct {
+	ID          string `json:"id"`
+	Product     string `json:"product"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Zone        string `json:"zone"`
+	Services    []struct {
+		ID           string `json:"id"`
+		Resource     string `json:"resource"`
+		Category     string `json:"category"`
+		Description  string `json:"description"`
+		AuthRequired bool   `json:"auth_required"`
+		Endpoints    []struct {
+			URL           string            `json:"url"`
+			Type          string            `json:"type"`
+			Configuration map[string]string `json:"configuration"`
+		} `json:"endpoints"`
+		Credentials []struct {
+			ID            string            `json:"id"`
+			Scope         string            `json:"scope"`
+			Configuration map[string]string `json:"configuration"`
+		} `json:"credentials"`
+	} `json:"services"`
+}
+
+// loadExampleExtension reads an extension from the descriptors in doc/examples/extension
+func loadExampleExtension(t *testing.T, name string) *domain.Extension {
+	t.Helper()
+
+	data, err := os.ReadFile("../../../doc/examples/extension/" + name)
+	if err != nil {
+		t.Fatalf("Failed to read example extension: %v", err)
+	}
+	d := extensionDescriptor{}
+	if err := yaml.Unmarshal(data, &d); err != nil {
+		t.Fatalf("Failed to parse example extension: %v", err)
+	}
+
+	e := &domain.Extension{ID: d.ID, Product: d.Product, Version: d.Version, Description: d.Description, Zone: d.Zone}
+	for _, ds := range d.Services {
+		s := &domain.ExtensionService{ID: ds.ID, Resource: ds.Resource, Category: ds.Category,
+			Description: ds.Description, AuthRequired: ds.AuthRequired}
+		for _, de := range ds.Endpoints {
+			s.AddEndpoint(&domain.ExtensionServiceEndpoint{URL: de.URL,
+				Type: domain.ExtensionServiceEndpointType(de.Type), Configuration: de.Configuration})
+		}
+		for _, dc := range ds.Credentials {
+			s.AddCredentials(&domain.ExtensionServiceCredentials{ID: dc.ID,
+				Scope: domain.ExtensionServiceCredentialsScope(dc.Scope), Configuration: dc.Configuration})
+		}
+		e.AddService(s)
+	}
+	return e
+}
+
+func TestExtensionResourceSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("register", func(t *testing.T) {
+		registry := newExtensionRegistry()
+		ext := newImportedExtension("mlflow-0001", "")
+		delete(ext.Services["mlflow-store"].Credentials["default"].Configuration, "AWS_ACCESS_KEY_ID")
+
+		_, err := registry.RegisterExtension(ctx, ext)
+		assertErrorType(t, err, domain.NewErrInvalidConfiguration(domain.EEKCredentials, "mlflow-0001/mlflow-store/default",
+			"s3", []string{"AWS_ACCESS_KEY_ID"}))
+		_, err = registry.GetExtension(ctx, "mlflow-0001")
+		assertErrorType(t, err, domain.NewErrExtensionNotFound("mlflow-0001"))
+	})
+
+	t.Run("examples", func(t *testing.T) {
+		registry := newExtensionRegistry()
+		for _, name := range []string{"register-extension001.yaml", "register-extension002.yaml"} {
+			_, err := registry.RegisterExtension(ctx, loadExampleExtension(t, name))
+			assertError(t, err, nil)
+		}
+
+		// the services of the examples are validated against the schemas of their resource types
+		ext := loadExampleExtension(t, "register-extension001.yaml")
+		ext.ID = "mlflow-0002"
+		delete(ext.Services["mlflow-tracking"].Endpoints["http://mlflow"].Configuration, "MLFLOW_TRACKING_URI")
+		_, err := registry.RegisterExtension(ctx, ext)
+		assertErrorType(t, err, domain.NewErrInvalidConfiguration(domain.EEKEndpoint, "mlflow-0002/mlflow-tracking/http://mlflow",
+			"mlflow-tracking", []string{"MLFLOW_TRACKING_URI"}))
+
+		// the resource types of the example services have a schema
+		for _, resource := range []string{"mlflow-tracking", "s3", "kserve-api"} {
+			schemas, err := registry.ListResourceSchemas(ctx, resource)
+			assertError(t, err, nil)
+			if len(schemas) != 1 {
+				t.Errorf("Unexpected number of schemas for %s: got %d, want 1", resource, len(schemas))
+			}
+		}
+	})
+
+	t.Run("add and update", func(t *testing.T) {
+		registry := newExtensionRegistry()
+		_, err := registry.RegisterExtension(ctx, &domain.Extension{ID: "mlflow-0001"})
+		assertError(t, err, nil)
+		_, err = registry.AddService(ctx, "mlflow-0001", &domain.ExtensionService{ID: "mlflow-tracking", Resource: "mlflow-tracking"})
+		assertError(t, err, nil)
+
+		_, err = registry.AddEndpoint(ctx, "mlflow-0001", "mlflow-tracking", &domain.ExtensionServiceEndpoint{URL: "http://mlflow"})
+		assertErrorType(t, err, domain.NewErrInvalidConfiguration(domain.EEKEndpoint, "mlflow-0001/mlflow-tracking/http://mlflow",
+			"mlflow-tracking", []string{"MLFLOW_TRACKING_URI"}))
+
+		endpoint := &domain.ExtensionServiceEndpoint{URL: "http://mlflow",
+			Configuration: map[string]string{"MLFLOW_TRACKING_URI": "http://mlflow"}}
+		_, err = registry.AddEndpoint(ctx, "mlflow-0001", "mlflow-tracking", endpoint)
+		assertError(t, err, nil)
+
+		err = registry.UpdateEndpoint(ctx, "mlflow-0001", "mlflow-tracking",
+			&domain.ExtensionServiceEndpoint{URL: "http://mlflow", Configuration: map[string]string{}})
+		assertErrorType(t, err, domain.NewErrInvalidConfiguration(domain.EEKEndpoint, "mlflow-0001/mlflow-tracking/http://mlflow",
+			"mlflow-tracking", []string{"MLFLOW_TRACKING_URI"}))
+		ep, err := registry.GetEndpoint(ctx, "mlflow-0001", "mlflow-tracking", "http://mlflow")
+		assertError(t, err, nil)
+		assertStrings(t, ep.Configuration["MLFLOW_TRACKING_URI"], "http://mlflow")
+	})
+
+	t.Run("unknown resource", func(t *testing.T) {
+		registry := newExtensionRegistry()
+		ext := &domain.Extension{ID: "custom-0001"}
+		s := &domain.ExtensionService{ID: "custom", Resource: "custom-api"}
+		s.AddEndpoint(&domain.ExtensionServiceEndpoint{URL: "http://custom"})
+		s.AddCredentials(&domain.ExtensionServiceCredentials{ID: "default"})
+		ext.AddService(s)
+
+		_, err := registry.RegisterExtension(ctx, ext)
+		assertError(t, err, nil)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		registry := newExtensionRegistry()
+
+		schemas, err := registry.ListResourceSchemas(ctx, "")
+		assertError(t, err, nil)
+		if len(schemas) != len(builtinResourceSchemas) {
+			t.Errorf("Unexpected number of schemas: got %d, want %d", len(schemas), len(builtinResourceSchemas))
+		}
+
+		schemas, err = registry.ListResourceSchemas(ctx, "s3")
+		assertError(t, err, nil)
+		if len(schemas) != 1 {
+			t.Fatalf("Unexpected number of schemas: got %d, want 1", len(schemas))
+		}
+		assertStrings(t, schemas[0].Resource, "s3")
+
+		schemas, err = registry.ListResourceSchemas(ctx, "unknown")
+		assertError(t, err, nil)
+		if len(schemas) != 0 {
+			t.Errorf("Unexpected schemas: %v", schemas)
+		}
+	})
+}
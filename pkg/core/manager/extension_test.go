@@ -21,7 +21,7 @@ func assertErrorType(t testing.TB, got, want error) {
 }
 
 func newExtensionRegistry() *ExtensionRegistry {
-	return NewExtensionRegistry(core.NewExtensionStore())
+	return NewExtensionRegistry(core.NewExtensionStore(), core.NewExtensionUsageStore(), core.NewEventBus())
 }
 
 // Test registering an extension
@@ -0,0 +1,370 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+const (
+	// notificationTimeout is the time that FuseML waits for a notification target to accept a notification
+	notificationTimeout = 10 * time.Second
+	// notificationAttempts is the number of times FuseML tries to deliver a notification before giving up
+	notificationAttempts = 3
+	// notificationRetryDelay is the time that FuseML waits before retrying a failed delivery. The delay
+	// doubles after every attempt.
+	notificationRetryDelay = 30 * time.Second
+)
+
+// defaultNotificationTemplate is the template used to format the notification messages for targets
+// that don't have their own template
+const defaultNotificationTemplate = `FuseML workflow {{.Workflow}} run {{.Run}}` +
+	`{{if .Codeset}} (codeset {{.Project}}/{{.Codeset}}){{end}} status: {{.Status}}{{if .URL}} - {{.URL}}{{end}}`
+
+// SMTPConfig describes the SMTP server used to send email notifications
+type SMTPConfig struct {
+	// Address of the SMTP server, as host:port
+	Address string
+	// From is the sender address of the email notifications
+	From string
+	// Username and Password are used to authenticate with the SMTP server, if set
+	Username string
+	Password string
+}
+
+// notificationData holds the workflow run details available to the notification templates
+type notificationData struct {
+	Target         string
+	Workflow       string
+	Run            string
+	Status         string
+	Codeset        string
+	Project        string
+	URL            string
+	StartTime      time.Time
+	CompletionTime time.Time
+}
+
+// NotificationManager implements the domain.NotificationManager interface. It delivers notifications to the
+// registered targets when workflow runs change state.
+type NotificationManager struct {
+	logger     *slog.Logger
+	store      domain.NotificationStore
+	eventBus   domain.EventBus
+	httpClient *http.Client
+	retryDelay time.Duration
+	smtp       *SMTPConfig
+
+	sync.Mutex
+	// notifications are delivered in the background, until the context is cancelled. Only the leader, when
+	// several servers share the storage backend, delivers notifications.
+	ctx context.Context
+	wg  *sync.WaitGroup
+	// runStatus is the last known status of every workflow run, used to detect state changes
+	runStatus map[string]string
+}
+
+// NewNotificationManager initializes a Notification Manager and subscribes it to workflow and project
+// events, to remove the notification targets scoped to deleted workflows and projects, and to workflow run
+// events, to track the state changes of the workflow runs
+func NewNotificationManager(logger *slog.Logger, store domain.NotificationStore, eventBus domain.EventBus) *NotificationManager {
+	mgr := &NotificationManager{
+		logger:     logger,
+		store:      store,
+		eventBus:   eventBus,
+		httpClient: &http.Client{Timeout: notificationTimeout},
+		retryDelay: notificationRetryDelay,
+		runStatus:  map[string]string{},
+	}
+	eventBus.Subscribe(mgr, domain.WorkflowResource, domain.ProjectResource)
+	eventBus.Subscribe(&runNotifier{mgr}, domain.WorkflowRunResource)
+	return mgr
+}
+
+// Start delivers notifications for the workflow run state changes in the background, until the context is
+// cancelled. Email notifications can only be delivered if an SMTP server is configured. The state changes
+// are tracked from the time the manager is created, so starting it again, e.g. when the server becomes the
+// leader, does not notify the state changes that were already observed.
+func (mgr *NotificationManager) Start(ctx context.Context, wg *sync.WaitGroup, smtp *SMTPConfig) {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	mgr.ctx = ctx
+	mgr.wg = wg
+	mgr.smtp = smtp
+}
+
+// delivering returns the context and the wait group of the notification deliveries, and false if the
+// notifications are not being delivered
+func (mgr *NotificationManager) delivering() (context.Context, *sync.WaitGroup, bool) {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	if mgr.ctx == nil || mgr.ctx.Err() != nil {
+		return nil, nil, false
+	}
+	return mgr.ctx, mgr.wg, true
+}
+
+// RegisterTarget registers a new notification target
+func (mgr *NotificationManager) RegisterTarget(ctx context.Context, target *domain.NotificationTarget) (*domain.NotificationTarget, error) {
+	if err := validateNotificationTarget(target); err != nil {
+		return nil, err
+	}
+	target.Created = time.Now()
+	return mgr.store.AddTarget(ctx, target)
+}
+
+// GetTarget retrieves a notification target
+func (mgr *NotificationManager) GetTarget(ctx context.Context, name string) (*domain.NotificationTarget, error) {
+	return mgr.store.GetTarget(ctx, name)
+}
+
+// GetTargets returns the page of notification targets, filtered by workflow and project, selected by the
+// list options
+func (mgr *NotificationManager) GetTargets(ctx context.Context, workflow, project string,
+	opts *domain.ListOptions) ([]*domain.NotificationTarget, string, error) {
+	return mgr.store.GetTargets(ctx, workflow, project, opts)
+}
+
+// DeleteTarget deletes a notification target and its delivery log
+func (mgr *NotificationManager) DeleteTarget(ctx context.Context, name string) error {
+	return mgr.store.DeleteTarget(ctx, name)
+}
+
+// GetDeliveries returns the page of deliveries made to a notification target selected by the list options
+func (mgr *NotificationManager) GetDeliveries(ctx context.Context, target string,
+	opts *domain.ListOptions) ([]*domain.NotificationDelivery, string, error) {
+	if _, err := mgr.store.GetTarget(ctx, target); err != nil {
+		return nil, "", err
+	}
+	return mgr.store.GetDeliveries(ctx, target, opts)
+}
+
+// OnEvent removes the notification targets scoped to workflows and projects that are being deleted
+func (mgr *NotificationManager) OnEvent(ctx context.Context, event *domain.Event) {
+	if event.Type != domain.EventDeleting {
+		return
+	}
+
+	var workflow, project string
+	switch object := event.Object.(type) {
+	case *domain.Workflow:
+		workflow = object.Name
+	case *domain.Project:
+		project = object.Name
+	default:
+		return
+	}
+
+	targets, _, err := mgr.store.GetTargets(ctx, workflow, project, nil)
+	if err != nil {
+		mgr.logger.ErrorContext(ctx, "Failed listing notification targets for deleted resource", "kind", event.Kind, "workflow", workflow, "project", project, "error", err)
+		return
+	}
+	for _, target := range targets {
+		if err := mgr.store.DeleteTarget(ctx, target.Name); err != nil {
+			mgr.logger.ErrorContext(ctx, "Failed deleting notification target", "target", target.Name, "error", err)
+		}
+	}
+}
+
+// runNotifier receives the workflow run events on behalf of the notification manager, which is
+// already subscribed to workflow and project events
+type runNotifier struct {
+	mgr *NotificationManager
+}
+
+// OnEvent notifies the interested targets when a workflow run changes state, if notifications are being
+// delivered
+func (n *runNotifier) OnEvent(ctx context.Context, event *domain.Event) {
+	run, ok := event.Object.(*domain.WorkflowRun)
+	if !ok || !n.mgr.statusChanged(event.Type, run) {
+		return
+	}
+	deliveryCtx, wg, ok := n.mgr.delivering()
+	if !ok {
+		return
+	}
+
+	targets, _, err := n.mgr.store.GetTargets(deliveryCtx, "", "", nil)
+	if err != nil {
+		n.mgr.logger.ErrorContext(ctx, "Failed listing notification targets for workflow run", "run", run.Name, "error", err)
+		return
+	}
+	for _, target := range targets {
+		if target.Matches(run) {
+			n.mgr.deliver(deliveryCtx, wg, target, run)
+		}
+	}
+}
+
+// statusChanged records the status of a workflow run and returns true if it is different from the
+// last known status
+func (mgr *NotificationManager) statusChanged(eventType domain.EventType, run *domain.WorkflowRun) bool {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	if eventType == domain.EventDeleted {
+		delete(mgr.runStatus, run.Name)
+		return false
+	}
+	if status, ok := mgr.runStatus[run.Name]; ok && status == run.Status {
+		return false
+	}
+	mgr.runStatus[run.Name] = run.Status
+	return true
+}
+
+// deliver records a new delivery and sends the notification to the target in the background, retrying
+// failed attempts
+func (mgr *NotificationManager) deliver(ctx context.Context, wg *sync.WaitGroup, target *domain.NotificationTarget,
+	run *domain.WorkflowRun) {
+	now := time.Now()
+	delivery := &domain.NotificationDelivery{
+		Target:    target.Name,
+		Workflow:  run.WorkflowRef,
+		Run:       run.Name,
+		RunStatus: run.Status,
+		Status:    domain.DeliveryPending,
+		Created:   now,
+		Updated:   now,
+	}
+	if err := mgr.store.AddDelivery(ctx, delivery); err != nil {
+		mgr.logger.ErrorContext(ctx, "Failed recording notification delivery", "target", target.Name, "error", err)
+		return
+	}
+
+	data := &notificationData{
+		Target:         target.Name,
+		Workflow:       run.WorkflowRef,
+		Run:            run.Name,
+		Status:         run.Status,
+		Codeset:        run.CodesetName,
+		Project:        run.CodesetProject,
+		URL:            run.URL,
+		StartTime:      run.StartTime,
+		CompletionTime: run.CompletionTime,
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		message, err := renderNotification(target, data)
+		if err == nil {
+			err = mgr.attempt(ctx, target, delivery, data, message)
+		}
+		if ctx.Err() != nil {
+			// the server is shutting down and the store may already be closed, so the delivery stays pending
+			return
+		}
+
+		delivery.Status = domain.DeliverySucceeded
+		delivery.Error = ""
+		if err != nil {
+			delivery.Status = domain.DeliveryFailed
+			delivery.Error = err.Error()
+			mgr.logger.WarnContext(ctx, "Failed delivering notification for workflow run", "run", data.Run, "target", target.Name, "error", err)
+		}
+		delivery.Updated = time.Now()
+		mgr.updateDelivery(ctx, delivery)
+	}()
+}
+
+// attempt delivers a notification, retrying failed attempts with increasing delays. It returns the error
+// of the last attempt.
+func (mgr *NotificationManager) attempt(ctx context.Context, target *domain.NotificationTarget,
+	delivery *domain.NotificationDelivery, data *notificationData, message string) error {
+	delay := mgr.retryDelay
+	for {
+		delivery.Attempts++
+		err := mgr.send(ctx, target, delivery, data, message)
+		if err == nil || delivery.Attempts >= notificationAttempts {
+			return err
+		}
+
+		delivery.Error = err.Error()
+		delivery.Updated = time.Now()
+		mgr.updateDelivery(ctx, delivery)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (mgr *NotificationManager) updateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) {
+	if err := mgr.store.UpdateDelivery(ctx, delivery); err != nil {
+		mgr.logger.ErrorContext(ctx, "Failed updating notification delivery", "delivery", delivery.ID, "error", err)
+	}
+}
+
+// send makes a single attempt to deliver a notification to a target
+func (mgr *NotificationManager) send(ctx context.Context, target *domain.NotificationTarget,
+	delivery *domain.NotificationDelivery, data *notificationData, message string) error {
+	switch target.Type {
+	case domain.WebhookTarget:
+		return mgr.sendWebhook(ctx, target, delivery, data, message)
+	case domain.SlackTarget:
+		return mgr.sendSlack(ctx, target, message)
+	case domain.EmailTarget:
+		return mgr.sendEmail(target, data, message)
+	}
+	return fmt.Errorf("unsupported notification target type %q", target.Type)
+}
+
+// renderNotification formats the notification message using the target template
+func renderNotification(target *domain.NotificationTarget, data *notificationData) (string, error) {
+	text := target.Template
+	if text == "" {
+		text = defaultNotificationTemplate
+	}
+	tmpl, err := template.New(target.Name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func validateNotificationTarget(target *domain.NotificationTarget) error {
+	switch target.Type {
+	case domain.WebhookTarget, domain.SlackTarget:
+		u, err := url.Parse(target.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s notifications require a HTTP(S) URL", domain.ErrInvalidNotificationTarget, target.Type)
+		}
+	case domain.EmailTarget:
+		if len(target.Recipients) == 0 {
+			return fmt.Errorf("%w: email notifications require at least one recipient", domain.ErrInvalidNotificationTarget)
+		}
+		for _, r := range target.Recipients {
+			if _, err := mail.ParseAddress(r); err != nil {
+				return fmt.Errorf("%w: invalid recipient %q: %s", domain.ErrInvalidNotificationTarget, r, err)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unsupported type %q", domain.ErrInvalidNotificationTarget, target.Type)
+	}
+	if target.Template != "" {
+		if _, err := template.New(target.Name).Parse(target.Template); err != nil {
+			return fmt.Errorf("%w: %s", domain.ErrInvalidNotificationTarget, err)
+		}
+	}
+	return nil
+}
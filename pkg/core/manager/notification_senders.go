@@ -0,0 +1,168 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+const (
+	// webhookEventHeader identifies the kind of event a webhook notification is sent for
+	webhookEventHeader = "X-FuseML-Event"
+	// webhookDeliveryHeader carries the ID of the delivery, which is the same for all delivery attempts
+	webhookDeliveryHeader = "X-FuseML-Delivery"
+	// webhookSignatureHeader carries the HMAC-SHA256 signature of the webhook request body, computed with
+	// the target secret, in the "sha256=<hex digest>" format
+	webhookSignatureHeader = "X-FuseML-Signature"
+	// webhookEvent is the event webhook notifications are sent for
+	webhookEvent = "workflowrun.status"
+)
+
+// webhookNotification is the JSON document POSTed to webhook notification targets
+type webhookNotification struct {
+	Event    string             `json:"event"`
+	Delivery uint64             `json:"delivery"`
+	Target   string             `json:"target"`
+	Message  string             `json:"message"`
+	Run      webhookWorkflowRun `json:"run"`
+}
+
+// webhookWorkflowRun describes the workflow run that changed state in webhook notifications
+type webhookWorkflowRun struct {
+	Name           string     `json:"name"`
+	Workflow       string     `json:"workflow"`
+	Status         string     `json:"status"`
+	Codeset        string     `json:"codeset,omitempty"`
+	Project        string     `json:"project,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+}
+
+// sendWebhook POSTs the notification as a JSON document, signed with the target secret
+func (mgr *NotificationManager) sendWebhook(ctx context.Context, target *domain.NotificationTarget,
+	delivery *domain.NotificationDelivery, data *notificationData, message string) error {
+	n := webhookNotification{
+		Event:    webhookEvent,
+		Delivery: delivery.ID,
+		Target:   target.Name,
+		Message:  message,
+		Run: webhookWorkflowRun{
+			Name:     data.Run,
+			Workflow: data.Workflow,
+			Status:   data.Status,
+			Codeset:  data.Codeset,
+			Project:  data.Project,
+			URL:      data.URL,
+		},
+	}
+	if !data.StartTime.IsZero() {
+		n.Run.StartTime = &data.StartTime
+	}
+	if !data.CompletionTime.IsZero() {
+		n.Run.CompletionTime = &data.CompletionTime
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		webhookEventHeader:    webhookEvent,
+		webhookDeliveryHeader: fmt.Sprint(delivery.ID),
+	}
+	if target.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(target.Secret))
+		mac.Write(body)
+		headers[webhookSignatureHeader] = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	return mgr.post(ctx, target.URL, body, headers)
+}
+
+// sendSlack POSTs the notification message to a Slack incoming webhook
+func (mgr *NotificationManager) sendSlack(ctx context.Context, target *domain.NotificationTarget, message string) error {
+	body, err := json.Marshal(map[string]string{"text": message})
+	if err != nil {
+		return err
+	}
+	return mgr.post(ctx, target.URL, body, nil)
+}
+
+// post sends a JSON document to a notification target URL and checks that it was accepted
+func (mgr *NotificationManager) post(ctx context.Context, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := mgr.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body, so the connection can be reused
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification target responded with %q", resp.Status)
+	}
+	return nil
+}
+
+// sendEmail sends the notification message to the target recipients through the configured SMTP server
+func (mgr *NotificationManager) sendEmail(target *domain.NotificationTarget, data *notificationData, message string) error {
+	if mgr.smtp == nil || mgr.smtp.Address == "" {
+		return errors.New("no SMTP server is configured for email notifications")
+	}
+
+	subject := fmt.Sprintf("[FuseML] Workflow %s run %s: %s", data.Workflow, data.Run, data.Status)
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", mgr.smtp.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(target.Recipients, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(message, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	// recipients are validated when the target is registered, and may include display names
+	to := make([]string, 0, len(target.Recipients))
+	for _, r := range target.Recipients {
+		addr, err := mail.ParseAddress(r)
+		if err != nil {
+			return err
+		}
+		to = append(to, addr.Address)
+	}
+
+	var auth smtp.Auth
+	if mgr.smtp.Username != "" {
+		host, _, err := net.SplitHostPort(mgr.smtp.Address)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", mgr.smtp.Username, mgr.smtp.Password, host)
+	}
+	return smtp.SendMail(mgr.smtp.Address, auth, mgr.smtp.From, to, []byte(b.String()))
+}
@@ -0,0 +1,356 @@
+package manager
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/core"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func newNotificationManager(t *testing.T, smtp *SMTPConfig) (*NotificationManager, domain.EventBus, *sync.WaitGroup) {
+	t.Helper()
+
+	bus := core.NewEventBus()
+	mgr := NewNotificationManager(slog.Default(), core.NewNotificationStore(), bus)
+	mgr.retryDelay = time.Millisecond
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	mgr.Start(ctx, &wg, smtp)
+	return mgr, bus, &wg
+}
+
+func publishRun(bus domain.EventBus, eventType domain.EventType, workflow, run, status string) {
+	bus.Publish(context.Background(), &domain.Event{Type: eventType, Kind: domain.WorkflowRunResource, Object: &domain.WorkflowRun{
+		Name: run, WorkflowRef: workflow, CodesetName: "cs", CodesetProject: "prj", Status: status, URL: "http://tekton.test/" + run,
+	}})
+}
+
+func getDeliveries(t *testing.T, mgr *NotificationManager, target string) []*domain.NotificationDelivery {
+	t.Helper()
+
+	deliveries, _, err := mgr.GetDeliveries(context.Background(), target, nil)
+	if err != nil {
+		t.Fatalf("Failed getting deliveries: %s", err)
+	}
+	return deliveries
+}
+
+// fakeSMTPServer accepts SMTP connections on a local port and sends the data of every received message
+// to the returned channel
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed listening: %s", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	messages := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+				reply("220 fake SMTP server")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+					case strings.HasPrefix(cmd, "DATA"):
+						reply("354 end data with <CR><LF>.<CR><LF>")
+						var data strings.Builder
+						for {
+							line, err := r.ReadString('\n')
+							if err != nil {
+								return
+							}
+							if line == ".\r\n" {
+								break
+							}
+							data.WriteString(line)
+						}
+						messages <- data.String()
+						reply("250 OK")
+					case strings.HasPrefix(cmd, "QUIT"):
+						reply("221 bye")
+						return
+					default:
+						reply("250 OK")
+					}
+				}
+			}(conn)
+		}
+	}()
+	return l.Addr().String(), messages
+}
+
+func TestRegisterNotificationTarget(t *testing.T) {
+	mgr, _, _ := newNotificationManager(t, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		target *domain.NotificationTarget
+		valid  bool
+	}{
+		{"webhook", &domain.NotificationTarget{Name: "hook", Type: domain.WebhookTarget, URL: "https://hook.test/fuseml"}, true},
+		{"slack", &domain.NotificationTarget{Name: "slack", Type: domain.SlackTarget, URL: "https://hooks.slack.test/T0/B0/x"}, true},
+		{"email", &domain.NotificationTarget{Name: "email", Type: domain.EmailTarget, Recipients: []string{"Team <team@fuseml.test>"}}, true},
+		{"missing url", &domain.NotificationTarget{Name: "no-url", Type: domain.WebhookTarget}, false},
+		{"invalid url", &domain.NotificationTarget{Name: "bad-url", Type: domain.SlackTarget, URL: "ftp://slack.test"}, false},
+		{"missing recipients", &domain.NotificationTarget{Name: "no-recipients", Type: domain.EmailTarget}, false},
+		{"invalid recipient", &domain.NotificationTarget{Name: "bad-recipient", Type: domain.EmailTarget, Recipients: []string{"team"}}, false},
+		{"invalid template", &domain.NotificationTarget{Name: "bad-template", Type: domain.WebhookTarget, URL: "http://hook.test", Template: "{{.Run"}, false},
+		{"unknown type", &domain.NotificationTarget{Name: "pager", Type: "pager"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mgr.RegisterTarget(ctx, tt.target)
+			if tt.valid && err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			if !tt.valid && !errors.Is(err, domain.ErrInvalidNotificationTarget) {
+				t.Errorf("Expected %q, got %v", domain.ErrInvalidNotificationTarget, err)
+			}
+		})
+	}
+
+	_, err := mgr.RegisterTarget(ctx, &domain.NotificationTarget{Name: "hook", Type: domain.WebhookTarget, URL: "https://hook.test"})
+	assertError(t, err, domain.ErrNotificationTargetExists)
+}
+
+func TestNotificationDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("webhook", func(t *testing.T) {
+		mgr, bus, wg := newNotificationManager(t, nil)
+
+		type request struct {
+			header http.Header
+			body   []byte
+		}
+		requests := make(chan request, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			requests <- request{r.Header, body}
+		}))
+		defer server.Close()
+
+		_, err := mgr.RegisterTarget(ctx, &domain.NotificationTarget{Name: "hook", Type: domain.WebhookTarget,
+			Workflow: "nightly", Statuses: []string{"Failed"}, URL: server.URL, Secret: "s3cr3t"})
+		assertError(t, err, nil)
+
+		publishRun(bus, domain.EventCreated, "nightly", "run-1", "Running")
+		publishRun(bus, domain.EventUpdated, "other", "run-2", "Failed")
+		publishRun(bus, domain.EventUpdated, "nightly", "run-1", "Failed")
+		// repeated updates without a state change are not notified
+		publishRun(bus, domain.EventUpdated, "nightly", "run-1", "Failed")
+		wg.Wait()
+
+		if len(requests) != 1 {
+			t.Fatalf("Expected 1 request, got %d", len(requests))
+		}
+		req := <-requests
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write(req.body)
+		assertStrings(t, req.header.Get(webhookSignatureHeader), "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		assertStrings(t, req.header.Get(webhookEventHeader), webhookEvent)
+
+		var n webhookNotification
+		if err := json.Unmarshal(req.body, &n); err != nil {
+			t.Fatalf("Failed decoding notification: %s", err)
+		}
+		assertStrings(t, n.Run.Name, "run-1")
+		assertStrings(t, n.Run.Status, "Failed")
+		assertStrings(t, n.Message, "FuseML workflow nightly run run-1 (codeset prj/cs) status: Failed - http://tekton.test/run-1")
+
+		deliveries := getDeliveries(t, mgr, "hook")
+		if len(deliveries) != 1 || deliveries[0].Status != domain.DeliverySucceeded || deliveries[0].Attempts != 1 {
+			t.Errorf("Unexpected deliveries: %+v", deliveries)
+		}
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		mgr, bus, wg := newNotificationManager(t, nil)
+
+		messages := make(chan map[string]string, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m := map[string]string{}
+			json.NewDecoder(r.Body).Decode(&m)
+			messages <- m
+		}))
+		defer server.Close()
+
+		_, err := mgr.RegisterTarget(ctx, &domain.NotificationTarget{Name: "slack", Type: domain.SlackTarget, Project: "prj",
+			URL: server.URL, Template: ":rotating_light: {{.Workflow}}/{{.Run}} {{.Status}}"})
+		assertError(t, err, nil)
+
+		publishRun(bus, domain.EventCreated, "nightly", "run-1", "Succeeded")
+		wg.Wait()
+
+		if len(messages) != 1 {
+			t.Fatalf("Expected 1 message, got %d", len(messages))
+		}
+		assertStrings(t, (<-messages)["text"], ":rotating_light: nightly/run-1 Succeeded")
+	})
+
+	t.Run("email", func(t *testing.T) {
+		addr, messages := fakeSMTPServer(t)
+		mgr, bus, wg := newNotificationManager(t, &SMTPConfig{Address: addr, From: "fuseml@fuseml.test"})
+
+		_, err := mgr.RegisterTarget(ctx, &domain.NotificationTarget{Name: "email", Type: domain.EmailTarget,
+			Recipients: []string{"Team <team@fuseml.test>"}})
+		assertError(t, err, nil)
+
+		publishRun(bus, domain.EventCreated, "nightly", "run-1", "Failed")
+		wg.Wait()
+
+		if len(messages) != 1 {
+			t.Fatalf("Expected 1 email, got %d", len(messages))
+		}
+		msg := <-messages
+		for _, want := range []string{"To: Team <team@fuseml.test>", "Subject: [FuseML] Workflow nightly run run-1: Failed",
+			"FuseML workflow nightly run run-1 (codeset prj/cs) status: Failed"} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("Expected the email to contain %q, got:\n%s", want, msg)
+			}
+		}
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		mgr, bus, wg := newNotificationManager(t, nil)
+
+		var mu sync.Mutex
+		failures := map[string]int{"flaky": 1, "down": notificationAttempts}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			target := strings.TrimPrefix(r.URL.Path, "/")
+			if failures[target] > 0 {
+				failures[target]--
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		for _, name := range []string{"flaky", "down"} {
+			_, err := mgr.RegisterTarget(ctx, &domain.NotificationTarget{Name: name, Type: domain.WebhookTarget, URL: server.URL + "/" + name})
+			assertError(t, err, nil)
+		}
+
+		publishRun(bus, domain.EventCreated, "nightly", "run-1", "Failed")
+		wg.Wait()
+
+		flaky := getDeliveries(t, mgr, "flaky")
+		if len(flaky) != 1 || flaky[0].Status != domain.DeliverySucceeded || flaky[0].Attempts != 2 || flaky[0].Error != "" {
+			t.Errorf("Unexpected deliveries: %+v", flaky[0])
+		}
+		down := getDeliveries(t, mgr, "down")
+		if len(down) != 1 || down[0].Status != domain.DeliveryFailed || down[0].Attempts != notificationAttempts ||
+			!strings.Contains(down[0].Error, "503") {
+			t.Errorf("Unexpected deliveries: %+v", down[0])
+		}
+	})
+
+	t.Run("no smtp server", func(t *testing.T) {
+		mgr, bus, wg := newNotificationManager(t, nil)
+
+		_, err := mgr.RegisterTarget(ctx, &domain.NotificationTarget{Name: "email", Type: domain.EmailTarget,
+			Recipients: []string{"team@fuseml.test"}})
+		assertError(t, err, nil)
+
+		publishRun(bus, domain.EventCreated, "nightly", "run-1", "Failed")
+		wg.Wait()
+
+		deliveries := getDeliveries(t, mgr, "email")
+		if len(deliveries) != 1 || deliveries[0].Status != domain.DeliveryFailed {
+			t.Errorf("Unexpected deliveries: %+v", deliveries)
+		}
+	})
+}
+
+func TestNotificationDeliveryLeader(t *testing.T) {
+	ctx := context.Background()
+	bus := core.NewEventBus()
+	mgr := NewNotificationManager(slog.Default(), core.NewNotificationStore(), bus)
+
+	requests := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+	}))
+	defer server.Close()
+	_, err := mgr.RegisterTarget(ctx, &domain.NotificationTarget{Name: "hook", Type: domain.WebhookTarget, URL: server.URL})
+	assertError(t, err, nil)
+
+	// the state changes are tracked, but not notified, until the manager is started by the leader
+	publishRun(bus, domain.EventCreated, "nightly", "run-1", "Running")
+	if len(getDeliveries(t, mgr, "hook")) != 0 {
+		t.Fatalf("Notification delivered before the manager was started")
+	}
+
+	var wg sync.WaitGroup
+	leaderCtx, cancel := context.WithCancel(ctx)
+	mgr.Start(leaderCtx, &wg, nil)
+	publishRun(bus, domain.EventUpdated, "nightly", "run-1", "Running")
+	publishRun(bus, domain.EventUpdated, "nightly", "run-1", "Succeeded")
+	wg.Wait()
+	if len(requests) != 1 {
+		t.Errorf("Expected 1 request, got %d", len(requests))
+	}
+
+	// the notifications stop when the server stops being the leader
+	cancel()
+	publishRun(bus, domain.EventCreated, "nightly", "run-2", "Running")
+	wg.Wait()
+	if got := len(getDeliveries(t, mgr, "hook")); got != 1 {
+		t.Errorf("Expected 1 delivery, got %d", got)
+	}
+}
+
+func TestNotificationTargetCleanup(t *testing.T) {
+	mgr, bus, _ := newNotificationManager(t, nil)
+	ctx := context.Background()
+
+	for _, target := range []*domain.NotificationTarget{
+		{Name: "all", Type: domain.SlackTarget, URL: "http://slack.test"},
+		{Name: "nightly", Type: domain.SlackTarget, Workflow: "nightly", URL: "http://slack.test"},
+		{Name: "team", Type: domain.SlackTarget, Project: "team", URL: "http://slack.test"},
+	} {
+		_, err := mgr.RegisterTarget(ctx, target)
+		assertError(t, err, nil)
+	}
+
+	bus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.WorkflowResource, Object: &domain.Workflow{Name: "nightly"}})
+	bus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.ProjectResource, Object: &domain.Project{Name: "team"}})
+
+	targets, _, err := mgr.GetTargets(ctx, "", "", nil)
+	assertError(t, err, nil)
+	if len(targets) != 1 || targets[0].Name != "all" {
+		t.Errorf("Unexpected targets: %v", targets)
+	}
+}
@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// dependencyCheckTimeout is the time that FuseML waits for the services it depends on to respond to a check
+const dependencyCheckTimeout = 5 * time.Second
+
+// StatusManager reports the configuration of the FuseML server and the status of the services it depends on
+type StatusManager struct {
+	logger       *slog.Logger
+	namespace    string
+	dashboardURL string
+	checkers     []domain.DependencyChecker
+}
+
+// NewStatusManager initializes a status manager that checks the dependencies reported by the given checkers
+func NewStatusManager(logger *slog.Logger, namespace, dashboardURL string, checkers ...domain.DependencyChecker) *StatusManager {
+	return &StatusManager{logger, namespace, dashboardURL, checkers}
+}
+
+// GetStatus checks all dependencies concurrently and returns the server status. The dependencies are
+// listed in the order of the checkers.
+func (sm *StatusManager) GetStatus(ctx context.Context) *domain.ServerStatus {
+	ctx, span := tracing.Start(ctx, "StatusManager.GetStatus")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+	defer cancel()
+
+	results := make([][]*domain.DependencyStatus, len(sm.checkers))
+	var wg sync.WaitGroup
+	for i, checker := range sm.checkers {
+		wg.Add(1)
+		go func(i int, checker domain.DependencyChecker) {
+			defer wg.Done()
+			results[i] = checker.CheckDependencies(ctx)
+		}(i, checker)
+	}
+	wg.Wait()
+
+	status := &domain.ServerStatus{Namespace: sm.namespace, DashboardURL: sm.dashboardURL}
+	for _, deps := range results {
+		for _, d := range deps {
+			if !d.Available() {
+				sm.logger.WarnContext(ctx, "Dependency not available", "dependency", d.Name, "endpoint", d.Endpoint, "error", d.Error)
+			}
+			status.Dependencies = append(status.Dependencies, d)
+		}
+	}
+	return status
+}
@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// fakeChecker reports a fixed list of dependencies
+type fakeChecker []*domain.DependencyStatus
+
+func (c fakeChecker) CheckDependencies(ctx context.Context) []*domain.DependencyStatus {
+	return c
+}
+
+// blockingChecker reports a dependency that does not respond until the context is done
+type blockingChecker string
+
+func (c blockingChecker) CheckDependencies(ctx context.Context) []*domain.DependencyStatus {
+	<-ctx.Done()
+	return []*domain.DependencyStatus{{Name: string(c), Error: ctx.Err().Error()}}
+}
+
+func TestStatusManager(t *testing.T) {
+	store := fakeChecker{{Name: "badger", Endpoint: "./data"}}
+	cluster := fakeChecker{
+		{Name: "kubernetes", Version: "v1.20.7"},
+		{Name: "tekton-pipelines", Error: "resources not available: pipelines"},
+	}
+
+	t.Run("available", func(t *testing.T) {
+		mgr := NewStatusManager(slog.Default(), "fuseml-workloads", "http://tekton.test", store, cluster[:1])
+		got := mgr.GetStatus(context.Background())
+		if got.Namespace != "fuseml-workloads" || got.DashboardURL != "http://tekton.test" {
+			t.Errorf("Unexpected configuration: %+v", got)
+		}
+		if len(got.Dependencies) != 2 || got.Dependencies[0].Name != "badger" || got.Dependencies[1].Name != "kubernetes" {
+			t.Errorf("Unexpected dependencies: %v", got.Dependencies)
+		}
+		if !got.Ready() {
+			t.Errorf("Server not ready with all dependencies available")
+		}
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		mgr := NewStatusManager(slog.Default(), "fuseml-workloads", "", store, cluster)
+		got := mgr.GetStatus(context.Background())
+		if len(got.Dependencies) != 3 || got.Ready() {
+			t.Errorf("Unexpected status: %+v", got)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mgr := NewStatusManager(slog.Default(), "fuseml-workloads", "", store, blockingChecker("gitea"))
+		got := mgr.GetStatus(ctx)
+		if len(got.Dependencies) != 2 || got.Dependencies[1].Error != context.Canceled.Error() || got.Ready() {
+			t.Errorf("Unexpected status: %+v", got)
+		}
+	})
+}
@@ -3,84 +3,186 @@ package manager
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
+	"github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 )
 
-// createWorkflowListenerTimeout is the time (in minutes) that FuseML waits for the workflow listener
-// to be available
-const createWorkflowListenerTimeout = 1
-
 // WorkflowManager implements the domain.WorkflowManager interface
 type WorkflowManager struct {
+	logger            *slog.Logger
+	cfg               *config.Workflows
 	workflowBackend   domain.WorkflowBackend
 	workflowStore     domain.WorkflowStore
 	codesetStore      domain.CodesetStore
 	extensionRegistry domain.ExtensionRegistry
+	eventBus          domain.EventBus
+	metrics           *workflowMetrics
 }
 
-// NewWorkflowManager initializes a Workflow Manager
+// NewWorkflowManager initializes a Workflow Manager and subscribes it to codeset events, to
+// unassign workflows from the codesets that are deleted, and to extension events, to refresh
+// the workflows bound to the extensions that are updated. The manager is also the collector
+// of the workflow metrics.
 // FIXME: instead of CodesetStore, receive a CodesetManager
 func NewWorkflowManager(
+	logger *slog.Logger,
+	cfg *config.Workflows,
 	workflowBackend domain.WorkflowBackend,
 	workflowStore domain.WorkflowStore,
 	codesetStore domain.CodesetStore,
-	extensionRegistry domain.ExtensionRegistry) *WorkflowManager {
-	return &WorkflowManager{workflowBackend, workflowStore, codesetStore, extensionRegistry}
+	extensionRegistry domain.ExtensionRegistry,
+	eventBus domain.EventBus) *WorkflowManager {
+	mgr := &WorkflowManager{logger, cfg, workflowBackend, workflowStore, codesetStore, extensionRegistry, eventBus,
+		newWorkflowMetrics()}
+	eventBus.Subscribe(mgr, domain.CodesetResource, domain.ExtensionResource)
+	return mgr
 }
 
-// GetWorkflows returns a list of Workflows.
-func (mgr *WorkflowManager) GetWorkflows(ctx context.Context, name *string) []*domain.Workflow {
-	return mgr.workflowStore.GetWorkflows(ctx, name)
+// GetWorkflows returns the page of Workflows selected by the list options.
+func (mgr *WorkflowManager) GetWorkflows(ctx context.Context, name *string, opts *domain.ListOptions) (_ []*domain.Workflow, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.GetWorkflows")
+	defer tracing.End(span, &err)
+
+	return mgr.workflowStore.GetWorkflows(ctx, name, opts)
 }
 
-// CreateWorkflow creates a new Workflow.
-func (mgr *WorkflowManager) CreateWorkflow(ctx context.Context, wf *domain.Workflow) (*domain.Workflow, error) {
+// CreateWorkflow creates a new Workflow. If a step fails, the steps already completed are undone, so that
+// no pipeline is left behind for a workflow that was not stored.
+func (mgr *WorkflowManager) CreateWorkflow(ctx context.Context, wf *domain.Workflow) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.CreateWorkflow")
+	defer tracing.End(span, &err)
+
 	wf.Created = time.Now()
-	err := mgr.resolveExtensionReferences(ctx, wf)
+	err = mgr.resolveExtensionReferences(ctx, wf)
 	if err != nil {
 		return nil, err
 	}
+	name := wf.Name
 	err = mgr.workflowBackend.CreateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, err
 	}
-	return mgr.workflowStore.AddWorkflow(ctx, wf)
+	defer mgr.undoIfError(ctx, &err, "creating the workflow pipeline", func(ctx context.Context) error {
+		return mgr.workflowBackend.DeleteWorkflow(ctx, name)
+	})
+	wf, err = mgr.workflowStore.AddWorkflow(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	defer mgr.undoIfError(ctx, &err, "storing the workflow", func(ctx context.Context) error {
+		return mgr.workflowStore.DeleteWorkflow(ctx, name)
+	})
+	err = mgr.extensionRegistry.RecordUsage(ctx, wf.Name, extensionUsage(wf))
+	if err != nil {
+		return nil, err
+	}
+	mgr.eventBus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.WorkflowResource, Object: wf})
+	return wf, nil
 }
 
 // GetWorkflow retrieves a Workflow.
-func (mgr *WorkflowManager) GetWorkflow(ctx context.Context, name string) (*domain.Workflow, error) {
+func (mgr *WorkflowManager) GetWorkflow(ctx context.Context, name string) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.GetWorkflow")
+	defer tracing.End(span, &err)
+
 	return mgr.workflowStore.GetWorkflow(ctx, name)
 }
 
-// DeleteWorkflow deletes a Workflow and its assignments.
-func (mgr *WorkflowManager) DeleteWorkflow(ctx context.Context, name string) error {
+// DeleteWorkflow deletes a Workflow and its assignments. Each assignment is removed on its own, so a failure
+// leaves the workflow stored with the remaining assignments and deleting it again resumes where it stopped.
+// Once all assignments are removed, a failure to delete the workflow restores its pipeline and extension usage.
+func (mgr *WorkflowManager) DeleteWorkflow(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.DeleteWorkflow")
+	defer tracing.End(span, &err)
+
+	wf, wfErr := mgr.workflowStore.GetWorkflow(ctx, name)
+
 	// unassign all assigned codesets, if there's any
 	codesetAssignments := mgr.workflowStore.GetCodesetAssignments(ctx, name)
 	for _, ca := range codesetAssignments {
-		err := mgr.UnassignFromCodeset(ctx, name, ca.Codeset.Project, ca.Codeset.Name)
+		err = mgr.UnassignFromCodeset(ctx, name, ca.Codeset.Project, ca.Codeset.Name)
 		if err != nil {
 			return err
 		}
 	}
 
+	// the workflow no longer depends on the extensions it was bound to
+	err = mgr.extensionRegistry.ReleaseUsage(ctx, name)
+	if err != nil {
+		return err
+	}
+	if wfErr == nil {
+		defer mgr.undoIfError(ctx, &err, "releasing the extension usage", func(ctx context.Context) error {
+			return mgr.extensionRegistry.RecordUsage(ctx, name, extensionUsage(wf))
+		})
+	}
+
 	// delete tekton pipeline
-	err := mgr.workflowBackend.DeleteWorkflow(ctx, name)
+	err = mgr.workflowBackend.DeleteWorkflow(ctx, name)
 	if err != nil {
 		return err
 	}
+	if wfErr == nil {
+		defer mgr.undoIfError(ctx, &err, "deleting the workflow pipeline", func(ctx context.Context) error {
+			return mgr.workflowBackend.CreateWorkflow(ctx, wf)
+		})
+	}
+
+	// the workflow is only announced as deleted once the steps that can be undone succeeded, because the
+	// subscribers clean up after it, e.g. by deleting its notification targets
+	if wfErr == nil {
+		mgr.eventBus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.WorkflowResource, Object: wf})
+	}
 
 	// delete workflow
-	err = mgr.workflowStore.DeleteWorkflow(ctx, name)
+	return mgr.workflowStore.DeleteWorkflow(ctx, name)
+}
+
+// RefreshWorkflow resolves the extension references of a Workflow again, to pick up the changes made to
+// the extensions it depends on, and updates the Workflow accordingly. Only the definition of the Workflow is
+// updated, its codeset assignments may be changed concurrently and are kept as stored.
+func (mgr *WorkflowManager) RefreshWorkflow(ctx context.Context, name string) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.RefreshWorkflow")
+	defer tracing.End(span, &err)
+
+	wf, err := mgr.workflowStore.GetWorkflow(ctx, name)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	err = mgr.resolveExtensionReferences(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	err = mgr.workflowBackend.UpdateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	wf, err = mgr.workflowStore.UpdateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, err
+	}
+	err = mgr.extensionRegistry.RecordUsage(ctx, wf.Name, extensionUsage(wf))
+	if err != nil {
+		return nil, err
+	}
+	mgr.eventBus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.WorkflowResource, Object: wf})
+	return wf, nil
 }
 
-// AssignToCodeset assigns a Workflow to a Codeset.
+// AssignToCodeset assigns a Workflow to a Codeset and runs the workflow for the codeset. The assignment is
+// made in steps: the workflow listener, the codeset webhook, the stored assignment and the first workflow
+// run. If a step fails, the steps already completed are undone. A webhook left behind by a previous attempt,
+// because it could not be undone, is reused instead of creating another one, so failed assignments can be
+// retried.
 func (mgr *WorkflowManager) AssignToCodeset(ctx context.Context, name, codesetProject, codesetName string) (wfListener *domain.WorkflowListener, webhookID *int64, err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.AssignToCodeset")
+	defer tracing.End(span, &err)
+
 	_, err = mgr.workflowStore.GetWorkflow(ctx, name)
 	if err != nil {
 		return nil, nil, err
@@ -91,29 +193,59 @@ func (mgr *WorkflowManager) AssignToCodeset(ctx context.Context, name, codesetPr
 		return nil, nil, err
 	}
 
-	wfListener, err = mgr.workflowBackend.CreateWorkflowListener(ctx, name, createWorkflowListenerTimeout*time.Minute)
+	// the listener is shared by all the assignments of the workflow, it is only deleted when undoing the
+	// first assignment
+	firstAssignment := len(mgr.workflowStore.GetCodesetAssignments(ctx, name)) == 0
+	wfListener, err = mgr.workflowBackend.CreateWorkflowListener(ctx, name, mgr.cfg.ListenerTimeout)
 	if err != nil {
 		return nil, nil, err
 	}
+	if firstAssignment {
+		defer mgr.undoIfError(ctx, &err, "creating the workflow listener", func(ctx context.Context) error {
+			return mgr.workflowBackend.DeleteWorkflowListener(ctx, name)
+		})
+	}
 
-	assignment, err := mgr.workflowStore.GetCodesetAssignment(ctx, name, codeset)
-	if err == nil {
+	if assignment, err := mgr.workflowStore.GetCodesetAssignment(ctx, name, codeset); err == nil {
 		return wfListener, assignment.WebhookID, nil
 	}
 
-	webhookID, err = mgr.codesetStore.CreateWebhook(ctx, codeset, wfListener.URL)
+	webhook, err := mgr.findOrCreateWebhook(ctx, codeset, wfListener.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer mgr.undoIfError(ctx, &err, "creating the codeset webhook", func(ctx context.Context) error {
+		return mgr.codesetStore.DeleteWebhook(ctx, codeset, webhook)
+	})
+
+	_, err = mgr.workflowStore.AddCodesetAssignment(ctx, name, codeset, webhook)
 	if err != nil {
 		return nil, nil, err
 	}
+	defer mgr.undoIfError(ctx, &err, "storing the codeset assignment", func(ctx context.Context) error {
+		_, err := mgr.workflowStore.DeleteCodesetAssignment(ctx, name, codeset)
+		return err
+	})
 
-	mgr.workflowStore.AddCodesetAssignment(ctx, name, codeset, webhookID)
-	mgr.codesetStore.Subscribe(ctx, mgr, codeset)
-	mgr.workflowBackend.CreateWorkflowRun(ctx, name, codeset)
-	return
+	err = mgr.workflowBackend.CreateWorkflowRun(ctx, name, codeset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mgr.eventBus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.WorkflowAssignmentResource,
+		Object: &domain.WorkflowCodesetAssignment{Workflow: name, Codeset: codeset}})
+	return wfListener, webhook, nil
 }
 
-// UnassignFromCodeset unassign a Workflow from a Codeset
+// UnassignFromCodeset unassign a Workflow from a Codeset. The stored assignment is deleted first, followed
+// by the codeset webhook and, for the last assignment of the workflow, the workflow listener. If a step
+// fails, the deleted resources are re-created and the assignment is stored again, referring to the
+// re-created webhook. Deleting resources that are already gone is not an error, so failed unassignments can
+// be retried.
 func (mgr *WorkflowManager) UnassignFromCodeset(ctx context.Context, name, codesetProject, codesetName string) (err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.UnassignFromCodeset")
+	defer tracing.End(span, &err)
+
 	codeset, err := mgr.codesetStore.Find(ctx, codesetProject, codesetName)
 	if err != nil {
 		return err
@@ -123,33 +255,66 @@ func (mgr *WorkflowManager) UnassignFromCodeset(ctx context.Context, name, codes
 	if err != nil {
 		return err
 	}
+	lastAssignment := len(mgr.workflowStore.GetCodesetAssignments(ctx, name)) == 1
 
-	if assignment.WebhookID != nil {
-		err = mgr.codesetStore.DeleteWebhook(ctx, codeset, assignment.WebhookID)
+	mgr.eventBus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.WorkflowAssignmentResource,
+		Object: &domain.WorkflowCodesetAssignment{Workflow: name, Codeset: codeset}})
+	_, err = mgr.workflowStore.DeleteCodesetAssignment(ctx, name, codeset)
+	if err != nil {
+		return err
+	}
+	webhookID := assignment.WebhookID
+	defer mgr.undoIfError(ctx, &err, "deleting the codeset assignment", func(ctx context.Context) error {
+		_, err := mgr.workflowStore.AddCodesetAssignment(ctx, name, codeset, webhookID)
 		if err != nil {
 			return err
 		}
+		mgr.eventBus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.WorkflowAssignmentResource,
+			Object: &domain.WorkflowCodesetAssignment{Workflow: name, Codeset: codeset}})
+		return nil
+	})
+
+	if webhookID != nil {
+		err = mgr.codesetStore.DeleteWebhook(ctx, codeset, webhookID)
+		if err != nil {
+			return err
+		}
+		defer mgr.undoIfError(ctx, &err, "deleting the codeset webhook", func(ctx context.Context) error {
+			listener, err := mgr.workflowBackend.GetWorkflowListener(ctx, name)
+			if err != nil {
+				return err
+			}
+			webhookID, err = mgr.findOrCreateWebhook(ctx, codeset, listener.URL)
+			return err
+		})
 	}
 
-	if len(mgr.workflowStore.GetCodesetAssignments(ctx, name)) == 1 {
+	if lastAssignment {
 		err = mgr.workflowBackend.DeleteWorkflowListener(ctx, name)
 		if err != nil {
 			return err
 		}
+		defer mgr.undoIfError(ctx, &err, "deleting the workflow listener", func(ctx context.Context) error {
+			_, err := mgr.workflowBackend.CreateWorkflowListener(ctx, name, mgr.cfg.ListenerTimeout)
+			return err
+		})
 	}
-
-	mgr.workflowStore.DeleteCodesetAssignment(ctx, name, codeset)
-	mgr.codesetStore.Unsubscribe(ctx, mgr, codeset)
-	return
+	return nil
 }
 
 // GetAllCodesetAssignments lists Workflow assignments.
 func (mgr *WorkflowManager) GetAllCodesetAssignments(ctx context.Context, name *string) map[string][]*domain.CodesetAssignment {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.GetAllCodesetAssignments")
+	defer span.End()
+
 	return mgr.workflowStore.GetAllCodesetAssignments(ctx, name)
 }
 
 // GetAssignmentStatus returns the status of a Workflow assignment.
 func (mgr *WorkflowManager) GetAssignmentStatus(ctx context.Context, name string) *domain.WorkflowAssignmentStatus {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.GetAssignmentStatus")
+	defer span.End()
+
 	status := domain.WorkflowAssignmentStatus{}
 	listener, err := mgr.workflowBackend.GetWorkflowListener(ctx, name)
 	if err != nil {
@@ -161,36 +326,152 @@ func (mgr *WorkflowManager) GetAssignmentStatus(ctx context.Context, name string
 	return &status
 }
 
-// GetWorkflowRuns returns a lists Workflow runs.
-func (mgr *WorkflowManager) GetWorkflowRuns(ctx context.Context, filter *domain.WorkflowRunFilter) ([]*domain.WorkflowRun, error) {
+// GetWorkflowRuns returns the page of Workflow runs selected by the list options. Runs are collected from
+// all the matching workflows, so they are ordered and paged here rather than by the workflow backend.
+// A nil filter matches all the runs.
+func (mgr *WorkflowManager) GetWorkflowRuns(ctx context.Context, filter *domain.WorkflowRunFilter,
+	opts *domain.ListOptions) (_ []*domain.WorkflowRun, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowManager.GetWorkflowRuns")
+	defer tracing.End(span, &err)
+
 	workflowRuns := []*domain.WorkflowRun{}
-	var wfName *string
-	if filter != nil {
-		wfName = filter.WorkflowName
+	if filter == nil {
+		filter = &domain.WorkflowRunFilter{}
+	}
+	workflows, _, err := mgr.workflowStore.GetWorkflows(ctx, filter.WorkflowName, nil)
+	if err != nil {
+		return nil, "", err
 	}
-	workflows := mgr.workflowStore.GetWorkflows(ctx, wfName)
 
 	for _, workflow := range workflows {
 		runs, err := mgr.workflowBackend.GetWorkflowRuns(ctx, workflow, filter)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		workflowRuns = append(workflowRuns, runs...)
 	}
 
-	return workflowRuns, nil
+	next, err := domain.Paginate(&workflowRuns, opts, domain.WorkflowRunSortFields, domain.WorkflowRunDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return workflowRuns, next, nil
+}
+
+// Start watches the workflow runs in the background, until the context is cancelled, and publishes
+// the changes made to them as workflow run events. The runs that already exist are listed once,
+// for the workflow metrics to include them.
+func (mgr *WorkflowManager) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	err := mgr.workflowBackend.WatchWorkflowRuns(ctx, wg, mgr)
+	if err != nil {
+		return err
+	}
+	runs, _, err := mgr.GetWorkflowRuns(ctx, &domain.WorkflowRunFilter{}, nil)
+	if err != nil {
+		mgr.logger.ErrorContext(ctx, "Failed to list the workflow runs for the workflow metrics", "error", err)
+		return nil
+	}
+	for _, run := range runs {
+		mgr.metrics.seedRun(run)
+	}
+	return nil
+}
+
+// OnWorkflowRun publishes a workflow run change observed by the workflow backend
+func (mgr *WorkflowManager) OnWorkflowRun(ctx context.Context, eventType domain.EventType, run *domain.WorkflowRun) {
+	mgr.metrics.observeRun(eventType, run)
+	mgr.eventBus.Publish(ctx, &domain.Event{Type: eventType, Kind: domain.WorkflowRunResource, Object: run})
+}
+
+// OnEvent perform operations on workflows when a codeset is deleted or an extension is updated.
+// The workflows assigned to the codeset are looked up in the workflow store, so assignments made
+// before a restart are also handled.
+func (mgr *WorkflowManager) OnEvent(ctx context.Context, event *domain.Event) {
+	switch event.Kind {
+	case domain.CodesetResource:
+		if codeset, ok := event.Object.(*domain.Codeset); ok && event.Type == domain.EventDeleting {
+			mgr.unassignFromDeletedCodeset(ctx, codeset)
+		}
+	case domain.ExtensionResource:
+		if extension, ok := event.Object.(*domain.Extension); ok && event.Type == domain.EventUpdated {
+			mgr.refreshExtensionWorkflows(ctx, extension)
+		}
+	}
+}
+
+// unassignFromDeletedCodeset unassigns all workflows from a codeset that is being deleted
+func (mgr *WorkflowManager) unassignFromDeletedCodeset(ctx context.Context, codeset *domain.Codeset) {
+	for wfName, assignments := range mgr.workflowStore.GetAllCodesetAssignments(ctx, nil) {
+		for _, a := range assignments {
+			if a.Codeset.Project != codeset.Project || a.Codeset.Name != codeset.Name {
+				continue
+			}
+			if err := mgr.UnassignFromCodeset(ctx, wfName, codeset.Project, codeset.Name); err != nil {
+				mgr.logger.ErrorContext(ctx, "Failed unassigning workflow from deleted codeset", "workflow", wfName,
+					"project", codeset.Project, "codeset", codeset.Name, "error", err)
+			}
+		}
+	}
+}
+
+// refreshExtensionWorkflows refreshes all the workflows currently bound to an updated extension. Failures
+// are only logged, the affected workflows keep using the extension endpoints and credentials they were
+// previously bound to.
+func (mgr *WorkflowManager) refreshExtensionWorkflows(ctx context.Context, extension *domain.Extension) {
+	usage, err := mgr.extensionRegistry.GetUsage(ctx, &domain.ExtensionUsageFilter{ExtensionID: extension.ID})
+	if err != nil {
+		mgr.logger.ErrorContext(ctx, "Failed listing workflows using extension", "extension", extension.ID, "error", err)
+		return
+	}
+	refreshed := map[string]bool{}
+	for _, u := range usage {
+		if refreshed[u.Workflow] {
+			continue
+		}
+		refreshed[u.Workflow] = true
+		if _, err := mgr.RefreshWorkflow(ctx, u.Workflow); err != nil {
+			mgr.logger.ErrorContext(ctx, "Failed refreshing workflow after extension was updated", "workflow", u.Workflow, "extension", extension.ID, "error", err)
+		}
+	}
+}
+
+// findOrCreateWebhook returns the ID of the codeset webhook that notifies a workflow listener, creating the
+// webhook if the codeset does not have one already
+func (mgr *WorkflowManager) findOrCreateWebhook(ctx context.Context, codeset *domain.Codeset, listenerURL string) (*int64, error) {
+	webhooks, err := mgr.codesetStore.GetWebhooks(ctx, codeset)
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		if webhook.URL == listenerURL {
+			mgr.logger.InfoContext(ctx, "Reusing existing codeset webhook", "project", codeset.Project,
+				"codeset", codeset.Name, "webhook", webhook.ID)
+			return &webhook.ID, nil
+		}
+	}
+	return mgr.codesetStore.CreateWebhook(ctx, codeset, listenerURL)
 }
 
-// OnDeletingCodeset perform operations on workflows when a codeset is deleted
-func (mgr *WorkflowManager) OnDeletingCodeset(ctx context.Context, codeset *domain.Codeset) {
-	for _, wf := range mgr.GetWorkflows(ctx, nil) {
-		mgr.UnassignFromCodeset(ctx, wf.Name, codeset.Project, codeset.Name)
+// undoIfError undoes a completed step of a workflow operation when the operation fails. It is deferred right
+// after the step completes, so the completed steps are undone in reverse order. The step is undone even if
+// the operation context is cancelled. Failures to undo a step are only logged, the resources left behind
+// are fixed by the workflow reconciler.
+func (mgr *WorkflowManager) undoIfError(ctx context.Context, err *error, step string, undo func(context.Context) error) {
+	if *err == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	mgr.logger.InfoContext(ctx, "Workflow operation failed, undoing step", "step", step, "error", *err)
+	if undoErr := undo(ctx); undoErr != nil {
+		mgr.logger.ErrorContext(ctx, "Failed undoing step of workflow operation", "step", step, "error", undoErr)
 	}
 }
 
 // Resolve all the extension references in the workflow steps and update them with actual
-// extension endpoints and credentials
+// extension endpoints and credentials. The workflow is only updated if all references can be
+// resolved.
 func (mgr *WorkflowManager) resolveExtensionReferences(ctx context.Context, wf *domain.Workflow) error {
+	resolved := map[*domain.WorkflowStepExtension]*domain.ExtensionAccessDescriptor{}
 	for _, step := range wf.Steps {
 		for _, extReq := range step.Extensions {
 			accessDescList, err := mgr.extensionRegistry.GetExtensionAccessDescriptors(ctx, &domain.ExtensionQuery{
@@ -214,17 +495,62 @@ func (mgr *WorkflowManager) resolveExtensionReferences(ctx context.Context, wf *
 			if len(accessDescList) == 0 {
 				return fmt.Errorf("could not resolve extension requirements for step %q extension %q", step.Name, extReq.Name)
 			}
-			// for now, assume that all internal endpoints are accessible from workflow steps and
-			// prefer internal endpoints if more results are returned
-			extReq.ExtensionAccess = accessDescList[0]
-			for _, accessDesc := range accessDescList {
-				if accessDesc.Endpoint.Type == domain.EETInternal {
-					extReq.ExtensionAccess = accessDesc
-					break
-				}
-			}
+			resolved[extReq] = preferredAccessDescriptor(accessDescList)
 		}
 	}
 
+	for extReq, accessDesc := range resolved {
+		extReq.ExtensionAccess = accessDesc
+	}
 	return nil
 }
+
+// extensionUsage returns the bindings between the workflow steps and the extensions that their
+// extension requirements are resolved to
+func extensionUsage(wf *domain.Workflow) []*domain.ExtensionUsage {
+	usage := []*domain.ExtensionUsage{}
+	for _, step := range wf.Steps {
+		for _, extReq := range step.Extensions {
+			access := extReq.ExtensionAccess
+			if access == nil {
+				continue
+			}
+			u := &domain.ExtensionUsage{
+				Step:        step.Name,
+				Requirement: extReq.Name,
+				ExtensionID: access.Extension.ID,
+				ServiceID:   access.Service.ID,
+				EndpointURL: access.Endpoint.URL,
+			}
+			if access.Credentials != nil {
+				u.CredentialsID = access.Credentials.ID
+			}
+			usage = append(usage, u)
+		}
+	}
+	return usage
+}
+
+// endpointHealthRank orders endpoints by health: healthy endpoints first, followed by those that haven't been
+// probed yet, and unhealthy endpoints last
+var endpointHealthRank = map[domain.ExtensionServiceEndpointHealth]int{
+	domain.EEHHealthy:   0,
+	domain.EEHUnknown:   1,
+	domain.EEHUnhealthy: 2,
+}
+
+// preferredAccessDescriptor selects the access descriptor used by workflow steps out of those returned by
+// an extension registry query. Healthy endpoints are preferred over the rest and, for now, assuming that all
+// internal endpoints are accessible from workflow steps, internal endpoints are preferred over external ones.
+func preferredAccessDescriptor(accessDescList []*domain.ExtensionAccessDescriptor) *domain.ExtensionAccessDescriptor {
+	preferred := accessDescList[0]
+	for _, accessDesc := range accessDescList[1:] {
+		rank := endpointHealthRank[accessDesc.Endpoint.Status.GetHealth()]
+		preferredRank := endpointHealthRank[preferred.Endpoint.Status.GetHealth()]
+		if rank < preferredRank ||
+			(rank == preferredRank && accessDesc.Endpoint.Type == domain.EETInternal && preferred.Endpoint.Type != domain.EETInternal) {
+			preferred = accessDesc
+		}
+	}
+	return preferred
+}
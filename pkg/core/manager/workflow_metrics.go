@@ -0,0 +1,157 @@
+package manager
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/metrics"
+)
+
+var (
+	workflowsDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "", "workflows"),
+		"Number of workflows registered.", nil, nil)
+	workflowAssignmentsDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "workflow", "assignments"),
+		"Number of codesets assigned to each workflow.", []string{"workflow"}, nil)
+	workflowRunsDesc = prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "workflow", "runs"),
+		"Number of workflow runs, by workflow and status.", []string{"workflow", "status"}, nil)
+)
+
+// completedRunStatuses are the statuses of the workflow runs that are no longer running
+var completedRunStatuses = []string{"Succeeded", "Completed", "Failed", "Cancelled"}
+
+// observedRun is the last observed state of a workflow run
+type observedRun struct {
+	workflow string
+	status   string
+}
+
+// workflowMetrics keeps track of the workflow runs observed by the workflow manager, to report the
+// number of runs by status, the runs completed and their duration
+type workflowMetrics struct {
+	sync.Mutex
+	started   time.Time
+	runs      map[string]observedRun
+	completed *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+}
+
+func newWorkflowMetrics() *workflowMetrics {
+	return &workflowMetrics{
+		started: time.Now(),
+		runs:    make(map[string]observedRun),
+		completed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "workflow",
+			Name:      "runs_completed_total",
+			Help:      "Number of workflow runs completed, by workflow and status.",
+		}, []string{"workflow", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "workflow",
+			Name:      "run_duration_seconds",
+			Help:      "Time taken by the workflow runs to complete, by workflow and status.",
+			// from 10 seconds to about 6 hours
+			Buckets: prometheus.ExponentialBuckets(10, 2, 12),
+		}, []string{"workflow", "status"}),
+	}
+}
+
+// runStatusLabel returns the status a workflow run is reported with. The reason of failed runs is left
+// out, to keep the number of reported statuses bounded.
+func runStatusLabel(status string) string {
+	if strings.HasPrefix(status, "Failed") {
+		return "Failed"
+	}
+	return status
+}
+
+func runCompleted(status string) bool {
+	for _, s := range completedRunStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// observeRun records a change made to a workflow run. Runs are counted as completed when they are first
+// observed in a completed state, unless they were already completed when the manager was created.
+func (m *workflowMetrics) observeRun(eventType domain.EventType, run *domain.WorkflowRun) {
+	status := runStatusLabel(run.Status)
+
+	m.Lock()
+	defer m.Unlock()
+	prev, seen := m.runs[run.Name]
+	if eventType == domain.EventDeleted {
+		delete(m.runs, run.Name)
+		return
+	}
+	m.runs[run.Name] = observedRun{run.WorkflowRef, status}
+
+	if !runCompleted(status) || (seen && prev.status == status) {
+		return
+	}
+	if !seen && run.CompletionTime.Before(m.started) {
+		return
+	}
+	m.completed.WithLabelValues(run.WorkflowRef, status).Inc()
+	if !run.StartTime.IsZero() && !run.CompletionTime.IsZero() {
+		m.duration.WithLabelValues(run.WorkflowRef, status).Observe(run.CompletionTime.Sub(run.StartTime).Seconds())
+	}
+}
+
+// seedRun records a workflow run that existed before its changes started being observed
+func (m *workflowMetrics) seedRun(run *domain.WorkflowRun) {
+	m.Lock()
+	defer m.Unlock()
+	if _, seen := m.runs[run.Name]; !seen {
+		m.runs[run.Name] = observedRun{run.WorkflowRef, runStatusLabel(run.Status)}
+	}
+}
+
+func (m *workflowMetrics) collect(ch chan<- prometheus.Metric) {
+	m.Lock()
+	counts := make(map[observedRun]int)
+	for _, run := range m.runs {
+		counts[run]++
+	}
+	m.Unlock()
+
+	for run, count := range counts {
+		ch <- prometheus.MustNewConstMetric(workflowRunsDesc, prometheus.GaugeValue, float64(count), run.workflow, run.status)
+	}
+	m.completed.Collect(ch)
+	m.duration.Collect(ch)
+}
+
+// Describe sends the descriptors of the workflow metrics reported by the manager
+func (mgr *WorkflowManager) Describe(ch chan<- *prometheus.Desc) {
+	ch <- workflowsDesc
+	ch <- workflowAssignmentsDesc
+	ch <- workflowRunsDesc
+	mgr.metrics.completed.Describe(ch)
+	mgr.metrics.duration.Describe(ch)
+}
+
+// Collect sends the workflow metrics reported by the manager. The workflows and their assignments are
+// counted when the metrics are collected, while the workflow runs are tracked as they change.
+func (mgr *WorkflowManager) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	workflows, _, err := mgr.workflowStore.GetWorkflows(ctx, nil, nil)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(workflowsDesc, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(workflowsDesc, prometheus.GaugeValue, float64(len(workflows)))
+		assignments := mgr.workflowStore.GetAllCodesetAssignments(ctx, nil)
+		for _, wf := range workflows {
+			ch <- prometheus.MustNewConstMetric(workflowAssignmentsDesc, prometheus.GaugeValue,
+				float64(len(assignments[wf.Name])), wf.Name)
+		}
+	}
+	mgr.metrics.collect(ch)
+}
@@ -0,0 +1,373 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/core/config"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// orphanGracePeriod is the time, on top of the listener timeout, that the resources no workflow or assignment
+// refers to are kept for. Workflows and assignments are stored after their resources are created, so younger
+// resources may belong to operations that are still in progress.
+const orphanGracePeriod = 5 * time.Minute
+
+const errListenerUnavailable = domain.ReconcileErr("the workflow listener is not available")
+
+// WorkflowReconciler implements the domain.WorkflowReconciler interface
+type WorkflowReconciler struct {
+	logger          *slog.Logger
+	cfg             *config.Workflows
+	workflowStore   domain.WorkflowStore
+	workflowBackend domain.WorkflowBackend
+	codesetStore    domain.CodesetStore
+	gracePeriod     time.Duration
+
+	// running serializes the reconciliations
+	running sync.Mutex
+	// mu guards the report of the last reconciliation
+	mu   sync.Mutex
+	last *domain.ReconcileReport
+}
+
+// NewWorkflowReconciler initializes a Workflow Reconciler
+func NewWorkflowReconciler(
+	logger *slog.Logger,
+	cfg *config.Workflows,
+	workflowStore domain.WorkflowStore,
+	workflowBackend domain.WorkflowBackend,
+	codesetStore domain.CodesetStore) *WorkflowReconciler {
+	return &WorkflowReconciler{
+		logger:          logger,
+		cfg:             cfg,
+		workflowStore:   workflowStore,
+		workflowBackend: workflowBackend,
+		codesetStore:    codesetStore,
+		gracePeriod:     cfg.ListenerTimeout + orphanGracePeriod,
+	}
+}
+
+// Start reconciles the workflows in the background, right away and then every interval, until the context
+// is cancelled. A zero interval disables the periodic reconciliation.
+func (r *WorkflowReconciler) Start(ctx context.Context, wg *sync.WaitGroup, interval time.Duration) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		r.reconcile(ctx)
+		if interval <= 0 {
+			r.logger.Info("Periodic workflow reconciliation disabled")
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.reconcile(ctx)
+			}
+		}
+	}()
+}
+
+// reconcile runs a reconciliation in the background and logs its outcome
+func (r *WorkflowReconciler) reconcile(ctx context.Context) {
+	report, err := r.Reconcile(ctx, false)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.ErrorContext(ctx, "Failed reconciling workflows", "error", err)
+		}
+		return
+	}
+	if len(report.Fixes) > 0 || len(report.Errors) > 0 {
+		r.logger.WarnContext(ctx, "Reconciled workflows", "fixes", len(report.Fixes), "errors", report.Errors)
+	}
+}
+
+// Reconcile compares the workflows and their codeset assignments with the resources held by the workflow
+// backend and the codeset webhooks. It re-creates the missing pipelines, listeners and webhooks, and deletes
+// the ones that no workflow or assignment refers to, once they are older than the grace period. Failures to
+// fix a resource are reported, and do not stop the reconciliation.
+func (r *WorkflowReconciler) Reconcile(ctx context.Context, dryRun bool) (_ *domain.ReconcileReport, err error) {
+	ctx, span := tracing.Start(ctx, "WorkflowReconciler.Reconcile")
+	defer tracing.End(span, &err)
+
+	r.running.Lock()
+	defer r.running.Unlock()
+
+	rc := &reconciliation{
+		WorkflowReconciler: r,
+		report:             &domain.ReconcileReport{Started: time.Now(), DryRun: dryRun},
+		listenerURLs:       map[string]string{},
+		webhooks:           map[string][]*domain.Webhook{},
+		assigned:           map[string]map[int64]bool{},
+	}
+	// the backend resources are listed before the workflows are read, for the resources created along with
+	// workflows stored in the meantime not to be taken for orphans
+	pipelines, err := r.workflowBackend.ListWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	listeners, err := r.workflowBackend.ListWorkflowListeners(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workflows, _, err := r.workflowStore.GetWorkflows(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range listeners {
+		rc.listenerURLs[l.Name] = l.URL
+	}
+	wanted := map[string]bool{}
+	for _, wf := range workflows {
+		rc.reconcileWorkflow(ctx, wf, pipelines)
+		wanted[wf.Name] = len(wf.GetCodesetAssignments(ctx)) > 0
+	}
+	rc.collectWebhooks(ctx)
+	for _, l := range listeners {
+		// the workflow may have been assigned since the workflows were listed
+		if !wanted[l.Name] && rc.expired(l.Created) && len(r.workflowStore.GetCodesetAssignments(ctx, l.Name)) == 0 {
+			rc.fix(ctx, &domain.ReconcileFix{Action: domain.ReconcileDeleted, Resource: domain.ReconcileListener, Workflow: l.Name},
+				func() error { return r.workflowBackend.DeleteWorkflowListener(ctx, l.Name) })
+		}
+	}
+	names := make([]string, 0, len(pipelines))
+	for name := range pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, stored := wanted[name]; stored || !rc.expired(pipelines[name]) {
+			continue
+		}
+		// the workflow may have been stored since the workflows were listed
+		if _, err := r.workflowStore.GetWorkflow(ctx, name); err != domain.ErrWorkflowNotFound {
+			continue
+		}
+		rc.fix(ctx, &domain.ReconcileFix{Action: domain.ReconcileDeleted, Resource: domain.ReconcilePipeline, Workflow: name},
+			func() error { return r.workflowBackend.DeleteWorkflow(ctx, name) })
+	}
+
+	rc.report.Finished = time.Now()
+	if !dryRun {
+		r.mu.Lock()
+		r.last = rc.report
+		r.mu.Unlock()
+	}
+	return rc.report, nil
+}
+
+// LastReport returns the report of the last reconciliation that was not a dry run
+func (r *WorkflowReconciler) LastReport(ctx context.Context) (*domain.ReconcileReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.last == nil {
+		return nil, domain.ErrNoReconcileReport
+	}
+	return r.last, nil
+}
+
+// reconciliation holds the state of a single reconciliation
+type reconciliation struct {
+	*WorkflowReconciler
+	report *domain.ReconcileReport
+	// listenerURLs are the URLs of the workflow listeners, by workflow name
+	listenerURLs map[string]string
+	// webhooks are the webhooks of the codesets, by codeset
+	webhooks map[string][]*domain.Webhook
+	// assigned are the IDs of the webhooks referred to by assignments, by codeset
+	assigned map[string]map[int64]bool
+}
+
+// fix reports a fix and, unless this is a dry run, makes it. It returns true if the fix was made.
+func (rc *reconciliation) fix(ctx context.Context, fix *domain.ReconcileFix, do func() error) bool {
+	rc.report.Fixes = append(rc.report.Fixes, fix)
+	if rc.report.DryRun {
+		rc.logger.InfoContext(ctx, "Found drifted workflow resource", "action", fix.Action, "resource", fix.Resource,
+			"workflow", fix.Workflow, "codeset", fix.Codeset)
+		return false
+	}
+	rc.logger.InfoContext(ctx, "Fixing drifted workflow resource", "action", fix.Action, "resource", fix.Resource,
+		"workflow", fix.Workflow, "codeset", fix.Codeset)
+	if err := do(); err != nil {
+		rc.logger.ErrorContext(ctx, "Failed fixing workflow resource", "resource", fix.Resource, "workflow", fix.Workflow,
+			"codeset", fix.Codeset, "error", err)
+		fix.Error = err.Error()
+		return false
+	}
+	return true
+}
+
+// expired returns true if a resource no workflow or assignment refers to was created before the grace period
+func (rc *reconciliation) expired(created time.Time) bool {
+	return time.Since(created) >= rc.gracePeriod
+}
+
+// reconcileWorkflow re-creates the missing pipeline of a workflow and, if it is assigned to codesets, its
+// listener and the missing webhooks of its assignments.
+func (rc *reconciliation) reconcileWorkflow(ctx context.Context, wf *domain.Workflow, pipelines map[string]time.Time) {
+	if _, ok := pipelines[wf.Name]; !ok {
+		rc.fix(ctx, &domain.ReconcileFix{Action: domain.ReconcileCreated, Resource: domain.ReconcilePipeline, Workflow: wf.Name},
+			func() error {
+				if err := rc.workflowBackend.CreateWorkflow(ctx, wf); err != nil && err != domain.ErrWorkflowExists {
+					return err
+				}
+				return nil
+			})
+	}
+
+	assignments := wf.GetCodesetAssignments(ctx)
+	if len(assignments) == 0 {
+		return
+	}
+	if _, ok := rc.listenerURLs[wf.Name]; !ok {
+		rc.reconcileListener(ctx, wf.Name)
+	}
+	for _, a := range assignments {
+		rc.reconcileWebhook(ctx, wf.Name, a)
+	}
+}
+
+// reconcileListener re-creates the missing listener of a workflow, and records its URL
+func (rc *reconciliation) reconcileListener(ctx context.Context, name string) {
+	// listeners created before they were labelled are not listed by the backend
+	if listener, err := rc.workflowBackend.GetWorkflowListener(ctx, name); err == nil {
+		rc.listenerURLs[name] = listener.URL
+		return
+	}
+	rc.fix(ctx, &domain.ReconcileFix{Action: domain.ReconcileCreated, Resource: domain.ReconcileListener, Workflow: name},
+		func() error {
+			listener, err := rc.workflowBackend.CreateWorkflowListener(ctx, name, rc.cfg.ListenerTimeout)
+			if err != nil {
+				return err
+			}
+			rc.listenerURLs[name] = listener.URL
+			return nil
+		})
+}
+
+// reconcileWebhook re-creates the missing webhook of an assignment, or adopts the webhook of the codeset that
+// notifies the workflow listener. Only the webhook of the stored assignment is updated, so the assignments
+// changed since the workflows were listed are kept.
+func (rc *reconciliation) reconcileWebhook(ctx context.Context, workflow string, a *domain.CodesetAssignment) {
+	key := fmt.Sprintf("%s/%s", a.Codeset.Project, a.Codeset.Name)
+	codeset, err := rc.codesetStore.Find(ctx, a.Codeset.Project, a.Codeset.Name)
+	if err != nil {
+		rc.report.Errors = append(rc.report.Errors, fmt.Sprintf("workflow %q: codeset %s: %v", workflow, key, err))
+		return
+	}
+	hooks, err := rc.getWebhooks(ctx, codeset)
+	if err != nil {
+		rc.report.Errors = append(rc.report.Errors, fmt.Sprintf("workflow %q: codeset %s: %v", workflow, key, err))
+		return
+	}
+
+	url := rc.listenerURLs[workflow]
+	var adopt *domain.Webhook
+	for _, h := range hooks {
+		if a.WebhookID != nil && h.ID == *a.WebhookID {
+			rc.assign(key, h.ID)
+			return
+		}
+		if url != "" && h.URL == url {
+			adopt = h
+		}
+	}
+
+	fix := &domain.ReconcileFix{Action: domain.ReconcileCreated, Resource: domain.ReconcileWebhook, Workflow: workflow, Codeset: key}
+	if adopt != nil {
+		// the webhook was created, but the assignment was not updated
+		rc.assign(key, adopt.ID)
+		fix.Action = domain.ReconcileAdopted
+		rc.fix(ctx, fix, func() error {
+			return rc.workflowStore.UpdateCodesetAssignmentWebhook(ctx, workflow, codeset, &adopt.ID)
+		})
+		return
+	}
+	rc.fix(ctx, fix, func() error {
+		if url == "" {
+			return errListenerUnavailable
+		}
+		id, err := rc.codesetStore.CreateWebhook(ctx, codeset, url)
+		if err != nil {
+			return err
+		}
+		rc.assign(key, *id)
+		if err := rc.workflowStore.UpdateCodesetAssignmentWebhook(ctx, workflow, codeset, id); err != nil {
+			// the webhook is not left behind if the assignment was deleted since the workflows were listed
+			rc.codesetStore.DeleteWebhook(ctx, codeset, id)
+			return err
+		}
+		return nil
+	})
+}
+
+// collectWebhooks deletes the codeset webhooks that notify a workflow listener, but that no assignment refers to
+func (rc *reconciliation) collectWebhooks(ctx context.Context) {
+	workflows := map[string]string{}
+	for name, url := range rc.listenerURLs {
+		if url != "" {
+			workflows[url] = name
+		}
+	}
+	codesets, _, err := rc.codesetStore.GetAll(ctx, nil, nil, nil)
+	if err != nil {
+		rc.report.Errors = append(rc.report.Errors, fmt.Sprintf("listing codesets: %v", err))
+		return
+	}
+	for _, codeset := range codesets {
+		key := fmt.Sprintf("%s/%s", codeset.Project, codeset.Name)
+		hooks, err := rc.getWebhooks(ctx, codeset)
+		if err != nil {
+			rc.report.Errors = append(rc.report.Errors, fmt.Sprintf("codeset %s: %v", key, err))
+			continue
+		}
+		for _, h := range hooks {
+			workflow, ok := workflows[h.URL]
+			if !ok || rc.assigned[key][h.ID] || !rc.expired(h.Created) {
+				continue
+			}
+			// the webhook may have been assigned since the workflows were listed
+			if a, err := rc.workflowStore.GetCodesetAssignment(ctx, workflow, codeset); err == nil &&
+				a.WebhookID != nil && *a.WebhookID == h.ID {
+				continue
+			}
+			id := h.ID
+			rc.fix(ctx, &domain.ReconcileFix{Action: domain.ReconcileDeleted, Resource: domain.ReconcileWebhook, Workflow: workflow, Codeset: key},
+				func() error { return rc.codesetStore.DeleteWebhook(ctx, codeset, &id) })
+		}
+	}
+}
+
+// getWebhooks returns the webhooks of a codeset, which are only listed once per reconciliation
+func (rc *reconciliation) getWebhooks(ctx context.Context, codeset *domain.Codeset) ([]*domain.Webhook, error) {
+	key := fmt.Sprintf("%s/%s", codeset.Project, codeset.Name)
+	if hooks, ok := rc.webhooks[key]; ok {
+		return hooks, nil
+	}
+	hooks, err := rc.codesetStore.GetWebhooks(ctx, codeset)
+	if err != nil {
+		return nil, err
+	}
+	rc.webhooks[key] = hooks
+	return hooks, nil
+}
+
+// assign records that an assignment refers to a webhook of a codeset
+func (rc *reconciliation) assign(key string, id int64) {
+	if rc.assigned[key] == nil {
+		rc.assigned[key] = map[int64]bool{}
+	}
+	rc.assigned[key][id] = true
+}
@@ -0,0 +1,231 @@
+package manager
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/fuseml/fuseml-core/pkg/core/config"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// newFakeWorkflowReconciler returns a reconciler for the stores of a new fake workflow manager, holding:
+// - wf0, assigned to csproject0/cs0, whose listener and webhook are missing
+// - wf1, not assigned, whose pipeline is missing
+// - the pipeline and listener of the orphan workflow, which is not stored, and its webhook on csproject1/cs1
+func newFakeWorkflowReconciler(t *testing.T) *WorkflowReconciler {
+	t.Helper()
+
+	ctx := context.Background()
+	wfm := newFakeWorkflowManager(t)
+	for _, name := range []string{"wf0", "wf1"} {
+		_, err := wfm.CreateWorkflow(ctx, &domain.Workflow{Name: name})
+		assertError(t, err, nil)
+	}
+	_, webhookID, err := wfm.AssignToCodeset(ctx, "wf0", "csproject0", "cs0")
+	assertError(t, err, nil)
+
+	cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+	assertError(t, codesetStore.DeleteWebhook(ctx, cs0, webhookID), nil)
+	assertError(t, workflowBackend.DeleteWorkflowListener(ctx, "wf0"), nil)
+	assertError(t, workflowBackend.DeleteWorkflow(ctx, "wf1"), nil)
+
+	assertError(t, workflowBackend.CreateWorkflow(ctx, &domain.Workflow{Name: "orphan"}), nil)
+	listener, err := workflowBackend.CreateWorkflowListener(ctx, "orphan", 0)
+	assertError(t, err, nil)
+	cs1, _ := codesetStore.Find(ctx, "csproject1", "cs1")
+	_, err = codesetStore.CreateWebhook(ctx, cs1, listener.URL)
+	assertError(t, err, nil)
+
+	return NewWorkflowReconciler(slog.Default(), &config.Default().Workflows, workflowStore, workflowBackend, codesetStore)
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	wantFixes := []*domain.ReconcileFix{
+		{Action: domain.ReconcileCreated, Resource: domain.ReconcileListener, Workflow: "wf0"},
+		{Action: domain.ReconcileCreated, Resource: domain.ReconcileWebhook, Workflow: "wf0", Codeset: "csproject0/cs0"},
+		{Action: domain.ReconcileCreated, Resource: domain.ReconcilePipeline, Workflow: "wf1"},
+		{Action: domain.ReconcileDeleted, Resource: domain.ReconcileWebhook, Workflow: "orphan", Codeset: "csproject1/cs1"},
+		{Action: domain.ReconcileDeleted, Resource: domain.ReconcileListener, Workflow: "orphan"},
+		{Action: domain.ReconcileDeleted, Resource: domain.ReconcilePipeline, Workflow: "orphan"},
+	}
+	sortFixes := cmpopts.SortSlices(func(x, y *domain.ReconcileFix) bool {
+		return x.Workflow+x.Resource < y.Workflow+y.Resource
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		r := newFakeWorkflowReconciler(t)
+		r.gracePeriod = 0
+
+		report, err := r.Reconcile(ctx, true)
+		assertError(t, err, nil)
+		if d := cmp.Diff(wantFixes, report.Fixes, sortFixes); d != "" || !report.DryRun || len(report.Errors) > 0 {
+			t.Errorf("Unexpected report (-want +got): %s %+v", d, report)
+		}
+		if _, err := workflowBackend.GetWorkflowListener(ctx, "wf0"); err == nil {
+			t.Errorf("Workflow listener created by a dry run")
+		}
+		if _, err := r.LastReport(ctx); err != domain.ErrNoReconcileReport {
+			t.Errorf("Dry run recorded as the last report")
+		}
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		r := newFakeWorkflowReconciler(t)
+		r.gracePeriod = 0
+
+		report, err := r.Reconcile(ctx, false)
+		assertError(t, err, nil)
+		if d := cmp.Diff(wantFixes, report.Fixes, sortFixes); d != "" || report.DryRun || len(report.Errors) > 0 {
+			t.Errorf("Unexpected report (-want +got): %s %+v", d, report)
+		}
+
+		listener, err := workflowBackend.GetWorkflowListener(ctx, "wf0")
+		assertError(t, err, nil)
+		cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+		assignment, err := workflowStore.GetCodesetAssignment(ctx, "wf0", cs0)
+		assertError(t, err, nil)
+		if url := codesetStore.store[codesetID{"cs0", "csproject0"}].webhooks[*assignment.WebhookID]; url != listener.URL {
+			t.Errorf("Webhook of the assignment points to %q, want %q", url, listener.URL)
+		}
+		if err := workflowBackend.CreateWorkflow(ctx, &domain.Workflow{Name: "wf1"}); err != domain.ErrWorkflowExists {
+			t.Errorf("Workflow pipeline not re-created")
+		}
+		if pipelines, _ := workflowBackend.ListWorkflows(ctx); len(pipelines) != 2 {
+			t.Errorf("Orphan pipeline not deleted: %v", pipelines)
+		}
+		if hooks := codesetStore.store[codesetID{"cs1", "csproject1"}].webhooks; len(hooks) != 0 {
+			t.Errorf("Orphan webhook not deleted: %v", hooks)
+		}
+
+		last, err := r.LastReport(ctx)
+		if err != nil || last != report {
+			t.Errorf("Got last report %+v (error %v), want %+v", last, err, report)
+		}
+
+		// nothing is left to fix
+		report, err = r.Reconcile(ctx, false)
+		assertError(t, err, nil)
+		if len(report.Fixes) > 0 || len(report.Errors) > 0 {
+			t.Errorf("Unexpected report of a second reconciliation: %+v", report)
+		}
+	})
+
+	t.Run("adopt webhook", func(t *testing.T) {
+		r := newFakeWorkflowReconciler(t)
+		r.gracePeriod = 0
+		// the webhook was created for the assignment, which was not updated
+		listener, err := workflowBackend.CreateWorkflowListener(ctx, "wf0", 0)
+		assertError(t, err, nil)
+		cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+		webhookID, err := codesetStore.CreateWebhook(ctx, cs0, listener.URL)
+		assertError(t, err, nil)
+
+		report, err := r.Reconcile(ctx, false)
+		assertError(t, err, nil)
+		want := &domain.ReconcileFix{Action: domain.ReconcileAdopted, Resource: domain.ReconcileWebhook, Workflow: "wf0",
+			Codeset: "csproject0/cs0"}
+		var got *domain.ReconcileFix
+		for _, fix := range report.Fixes {
+			if fix.Workflow == "wf0" && fix.Resource == domain.ReconcileWebhook {
+				got = fix
+			}
+		}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected fix (-want +got): %s", d)
+		}
+		assignment, _ := workflowStore.GetCodesetAssignment(ctx, "wf0", cs0)
+		if *assignment.WebhookID != *webhookID {
+			t.Errorf("Got assignment webhook %d, want %d", *assignment.WebhookID, *webhookID)
+		}
+	})
+
+	t.Run("grace period", func(t *testing.T) {
+		r := newFakeWorkflowReconciler(t)
+
+		// the orphan pipeline was just created, and its listener and webhook have no creation time
+		report, err := r.Reconcile(ctx, false)
+		assertError(t, err, nil)
+		for _, fix := range report.Fixes {
+			if fix.Resource == domain.ReconcilePipeline && fix.Workflow == "orphan" {
+				t.Errorf("Orphan pipeline deleted within the grace period")
+			}
+		}
+		if len(report.Fixes) != len(wantFixes)-1 {
+			t.Errorf("Unexpected fixes: %+v", report.Fixes)
+		}
+	})
+
+	t.Run("assignment changed during reconciliation", func(t *testing.T) {
+		r := newFakeWorkflowReconciler(t)
+		r.gracePeriod = 0
+		cs2, _ := codesetStore.Find(ctx, "csproject1", "cs2")
+		var webhookID *int64
+		// wf0 is assigned to cs2 right after the reconciler lists the workflows
+		r.workflowStore = &snapshotWorkflowStore{workflowStore, func() {
+			var err error
+			webhookID, err = codesetStore.CreateWebhook(ctx, cs2, "http://wf0.listener.test")
+			assertError(t, err, nil)
+			_, err = workflowStore.AddCodesetAssignment(ctx, "wf0", cs2, webhookID)
+			assertError(t, err, nil)
+		}}
+
+		report, err := r.Reconcile(ctx, false)
+		assertError(t, err, nil)
+		if len(report.Errors) > 0 {
+			t.Errorf("Unexpected errors: %v", report.Errors)
+		}
+		assignment, err := workflowStore.GetCodesetAssignment(ctx, "wf0", cs2)
+		if err != nil || *assignment.WebhookID != *webhookID {
+			t.Errorf("Assignment made during the reconciliation lost: %+v (error %v)", assignment, err)
+		}
+		if _, exists := codesetStore.store[codesetID{"cs2", "csproject1"}].webhooks[*webhookID]; !exists {
+			t.Errorf("Webhook of the assignment made during the reconciliation deleted")
+		}
+		cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+		assignment, _ = workflowStore.GetCodesetAssignment(ctx, "wf0", cs0)
+		if _, exists := codesetStore.store[codesetID{"cs0", "csproject0"}].webhooks[*assignment.WebhookID]; !exists {
+			t.Errorf("Webhook of the assignment to cs0 not updated")
+		}
+	})
+
+	t.Run("missing codeset", func(t *testing.T) {
+		r := newFakeWorkflowReconciler(t)
+		delete(codesetStore.store, codesetID{"cs0", "csproject0"})
+
+		report, err := r.Reconcile(ctx, false)
+		assertError(t, err, nil)
+		if len(report.Errors) != 1 {
+			t.Errorf("Unexpected errors: %v", report.Errors)
+		}
+	})
+}
+
+// snapshotWorkflowStore returns copies of the stored workflows, as the persistent stores do, and calls
+// onList after listing them
+type snapshotWorkflowStore struct {
+	domain.WorkflowStore
+	onList func()
+}
+
+func (s *snapshotWorkflowStore) GetWorkflows(ctx context.Context, name *string, opts *domain.ListOptions) ([]*domain.Workflow, string, error) {
+	workflows, next, err := s.WorkflowStore.GetWorkflows(ctx, name, opts)
+	snapshot := make([]*domain.Workflow, len(workflows))
+	for i, wf := range workflows {
+		c := *wf
+		if wf.AssignedTo != nil {
+			c.AssignedTo = &domain.WorkflowAssignment{}
+			for _, a := range wf.AssignedTo.Codesets {
+				ca := *a
+				c.AssignedTo.Codesets = append(c.AssignedTo.Codesets, &ca)
+			}
+		}
+		snapshot[i] = &c
+	}
+	s.onList()
+	return snapshot, next, err
+}
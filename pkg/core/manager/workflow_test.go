@@ -3,16 +3,20 @@ package manager
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/tektoncd/pipeline/test/diff"
 
 	"github.com/fuseml/fuseml-core/pkg/core"
+	"github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/domain"
 	"github.com/fuseml/fuseml-core/pkg/util"
 )
@@ -36,6 +40,9 @@ var (
 	// extensionRegistry stores extensions
 	extensionRegistry *ExtensionRegistry
 
+	// eventBus dispatches the events published by the stores and managers
+	eventBus domain.EventBus
+
 	// workflowRunStatuses are the possible Status for a WorkflowRun. The status of a WorkflowRun is set
 	// accordingly to its order, cycling between the workflowRunStatuses. E.g. run0: Succeeded, run1: Failed,
 	// run2: Succeeded, ...
@@ -48,6 +55,10 @@ func (e codesetErr) Error() string {
 	return string(e)
 }
 
+// faults holds the errors returned by the methods of the fake workflow manager dependencies, keyed by
+// dependency and method name, e.g. "backend.CreateWorkflowListener"
+type faults map[string]error
+
 func TestCreateWorkflow(t *testing.T) {
 	t.Run("new workflow", func(t *testing.T) {
 		mgr := newFakeWorkflowManager(t)
@@ -60,7 +71,7 @@ func TestCreateWorkflow(t *testing.T) {
 			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
 		}
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		err = workflowBackend.CreateWorkflowRun(context.TODO(), wf.Name, codesets[0])
 		assertError(t, err, nil)
 	})
@@ -74,7 +85,7 @@ func TestCreateWorkflow(t *testing.T) {
 		_, err = mgr.CreateWorkflow(context.Background(), &wf)
 		assertError(t, err, domain.ErrWorkflowExists)
 
-		got := workflowStore.GetWorkflows(context.TODO(), nil)
+		got, _, _ := workflowStore.GetWorkflows(context.TODO(), nil, nil)
 		want := []*domain.Workflow{&wf}
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
@@ -107,7 +118,7 @@ func TestCreateWorkflow(t *testing.T) {
 			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
 		}
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		err = workflowBackend.CreateWorkflowRun(context.TODO(), wf.Name, codesets[0])
 		assertError(t, err, nil)
 	})
@@ -143,7 +154,7 @@ func TestGetWorkflows(t *testing.T) {
 		want := []*domain.Workflow{}
 
 		// no workflows
-		got := mgr.GetWorkflows(context.TODO(), nil)
+		got, _, _ := mgr.GetWorkflows(context.TODO(), nil, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow list: %s", diff.PrintWantGot(d))
 		}
@@ -155,7 +166,7 @@ func TestGetWorkflows(t *testing.T) {
 			want = append(want, wf)
 		}
 
-		got = mgr.GetWorkflows(context.TODO(), nil)
+		got, _, _ = mgr.GetWorkflows(context.TODO(), nil, nil)
 		if d := cmp.Diff(want, got, cmpopts.SortSlices(func(x, y *domain.Workflow) bool { return x.Name < y.Name })); d != "" {
 			t.Errorf("Unexpected Workflow list: %s", diff.PrintWantGot(d))
 		}
@@ -167,7 +178,7 @@ func TestGetWorkflows(t *testing.T) {
 
 		// no workflows
 		wfName := "does-not-exist"
-		got := mgr.GetWorkflows(context.TODO(), &wfName)
+		got, _, _ := mgr.GetWorkflows(context.TODO(), &wfName, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow list: %s", diff.PrintWantGot(d))
 		}
@@ -181,7 +192,7 @@ func TestGetWorkflows(t *testing.T) {
 
 		for i := 0; i < len(want); i++ {
 			name := fmt.Sprintf("wf%d", i)
-			got := mgr.GetWorkflows(context.TODO(), &name)
+			got, _, _ := mgr.GetWorkflows(context.TODO(), &name, nil)
 			if d := cmp.Diff([]*domain.Workflow{want[i]}, got, cmpopts.SortSlices(func(x, y *domain.Workflow) bool { return x.Name < y.Name })); d != "" {
 				t.Errorf("Unexpected Workflow list: %s", diff.PrintWantGot(d))
 			}
@@ -219,7 +230,7 @@ func TestDeleteWorkflow(t *testing.T) {
 		err = mgr.DeleteWorkflow(context.Background(), wf.Name)
 		assertError(t, err, nil)
 
-		got := workflowStore.GetWorkflows(context.TODO(), &wf.Name)
+		got, _, _ := workflowStore.GetWorkflows(context.TODO(), &wf.Name, nil)
 		if d := cmp.Diff([]*domain.Workflow{}, got); d != "" {
 			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
 		}
@@ -235,7 +246,7 @@ func TestDeleteWorkflow(t *testing.T) {
 		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		_, _, got := mgr.AssignToCodeset(context.Background(), wf.Name, codesets[0].Project, codesets[0].Name)
 		assertError(t, got, nil)
 
@@ -250,6 +261,143 @@ func TestDeleteWorkflow(t *testing.T) {
 	})
 }
 
+func TestWorkflowExtensionUsage(t *testing.T) {
+	ctx := context.Background()
+	mgr := newFakeWorkflowManager(t)
+	ext, err := mgr.extensionRegistry.RegisterExtension(ctx, createFakeExtension(t, mgr, "test-"))
+	assertError(t, err, nil)
+	svc := ext.ListServices()[0]
+
+	wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{
+		Name: "wf",
+		Steps: []*domain.WorkflowStep{{
+			Name: "test-step",
+			Extensions: []*domain.WorkflowStepExtension{{
+				Name:        "test-extension",
+				ExtensionID: ext.ID,
+				ServiceID:   svc.ID,
+			}},
+		}},
+	})
+	assertError(t, err, nil)
+
+	t.Run("in use", func(t *testing.T) {
+		usage, err := mgr.extensionRegistry.GetUsage(ctx, &domain.ExtensionUsageFilter{ExtensionID: ext.ID})
+		assertError(t, err, nil)
+		if len(usage) != 1 {
+			t.Fatalf("got %d usage records want 1", len(usage))
+		}
+		access := wf.Steps[0].Extensions[0].ExtensionAccess
+		want := &domain.ExtensionUsage{
+			Workflow:    "wf",
+			Step:        "test-step",
+			Requirement: "test-extension",
+			ExtensionID: ext.ID,
+			ServiceID:   svc.ID,
+			EndpointURL: access.Endpoint.URL,
+			Bound:       usage[0].Bound,
+		}
+		if access.Credentials != nil {
+			want.CredentialsID = access.Credentials.ID
+		}
+		if d := cmp.Diff(want, usage[0]); d != "" {
+			t.Errorf("Unexpected usage: %s", diff.PrintWantGot(d))
+		}
+
+		err = mgr.extensionRegistry.RemoveExtension(ctx, ext.ID)
+		assertErrorType(t, err, domain.NewErrExtensionInUse(ext.ID, []string{"wf"}))
+		err = mgr.extensionRegistry.RemoveService(ctx, ext.ID, svc.ID)
+		assertErrorType(t, err, domain.NewErrExtensionInUse(ext.ID+"/"+svc.ID, []string{"wf"}))
+	})
+
+	t.Run("released", func(t *testing.T) {
+		err := mgr.DeleteWorkflow(ctx, wf.Name)
+		assertError(t, err, nil)
+
+		usage, err := mgr.extensionRegistry.GetUsage(ctx, &domain.ExtensionUsageFilter{ExtensionID: ext.ID})
+		assertError(t, err, nil)
+		if len(usage) != 0 {
+			t.Errorf("got %d active usage records want 0", len(usage))
+		}
+		usage, err = mgr.extensionRegistry.GetUsage(ctx, &domain.ExtensionUsageFilter{ExtensionID: ext.ID, All: true})
+		assertError(t, err, nil)
+		if len(usage) != 1 || usage[0].Active() {
+			t.Errorf("Expected a single released usage record, got %v", usage)
+		}
+
+		err = mgr.extensionRegistry.RemoveExtension(ctx, ext.ID)
+		assertError(t, err, nil)
+	})
+}
+
+func TestRefreshWorkflow(t *testing.T) {
+	ctx := context.Background()
+	mgr := newFakeWorkflowManager(t)
+	ext, err := mgr.extensionRegistry.RegisterExtension(ctx, createFakeExtension(t, mgr, "test-"))
+	assertError(t, err, nil)
+	svc := ext.ListServices()[0]
+
+	wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{
+		Name: "wf",
+		Steps: []*domain.WorkflowStep{{
+			Name: "test-step",
+			Extensions: []*domain.WorkflowStepExtension{{
+				Name:        "test-extension",
+				ExtensionID: ext.ID,
+				ServiceID:   svc.ID,
+			}},
+		}},
+	})
+	assertError(t, err, nil)
+	endpointURL := wf.Steps[0].Extensions[0].ExtensionAccess.Endpoint.URL
+
+	t.Run("on extension updated", func(t *testing.T) {
+		err := mgr.extensionRegistry.UpdateEndpoint(ctx, ext.ID, svc.ID, &domain.ExtensionServiceEndpoint{
+			URL:           endpointURL,
+			Type:          domain.EETInternal,
+			Configuration: map[string]string{"KEY": "updated"},
+		})
+		assertError(t, err, nil)
+
+		got, err := mgr.GetWorkflow(ctx, wf.Name)
+		assertError(t, err, nil)
+		access := got.Steps[0].Extensions[0].ExtensionAccess
+		if d := cmp.Diff(map[string]string{"KEY": "updated"}, access.Endpoint.Configuration); d != "" {
+			t.Errorf("Unexpected endpoint configuration: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("on endpoint removed", func(t *testing.T) {
+		_, err := mgr.extensionRegistry.AddEndpoint(ctx, ext.ID, svc.ID, &domain.ExtensionServiceEndpoint{
+			URL:  "http://replacement",
+			Type: domain.EETInternal,
+		})
+		assertError(t, err, nil)
+		err = mgr.extensionRegistry.RemoveEndpoint(ctx, ext.ID, svc.ID, endpointURL)
+		assertError(t, err, nil)
+
+		got, err := mgr.GetWorkflow(ctx, wf.Name)
+		assertError(t, err, nil)
+		assertStrings(t, got.Steps[0].Extensions[0].ExtensionAccess.Endpoint.URL, "http://replacement")
+		usage, err := mgr.extensionRegistry.GetUsage(ctx, &domain.ExtensionUsageFilter{ExtensionID: ext.ID})
+		assertError(t, err, nil)
+		if len(usage) != 1 || usage[0].EndpointURL != "http://replacement" {
+			t.Errorf("Unexpected usage: %v", usage)
+		}
+	})
+
+	t.Run("manual", func(t *testing.T) {
+		got, err := mgr.RefreshWorkflow(ctx, wf.Name)
+		assertError(t, err, nil)
+		assertStrings(t, got.Name, wf.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := mgr.RefreshWorkflow(ctx, "missing")
+		assertError(t, err, domain.ErrWorkflowNotFound)
+	})
+}
+
 func TestAssignToCodeset(t *testing.T) {
 	t.Run("assign", func(t *testing.T) {
 		mgr := newFakeWorkflowManager(t)
@@ -257,17 +405,11 @@ func TestAssignToCodeset(t *testing.T) {
 		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		codeset := codesets[0]
 		wantListener, webhookID, err := mgr.AssignToCodeset(context.Background(), wf.Name, codeset.Project, codeset.Name)
 		assertError(t, err, nil)
 
-		ignoreUnexported := cmpopts.IgnoreUnexported(WorkflowManager{})
-		gotSubscribers := codesetStore.getSubscribers(context.TODO(), codeset)
-		if d := cmp.Diff([]domain.CodesetSubscriber{mgr}, gotSubscribers, ignoreUnexported); d != "" {
-			t.Errorf("Unexpected codeset subscriber: %s", diff.PrintWantGot(d))
-		}
-
 		got := workflowStore.GetAllCodesetAssignments(context.TODO(), &wf.Name)
 		csAsg := domain.CodesetAssignment{Codeset: codeset, WebhookID: webhookID}
 		want := map[string][]*domain.CodesetAssignment{wf.Name: {&csAsg}}
@@ -281,7 +423,7 @@ func TestAssignToCodeset(t *testing.T) {
 			t.Errorf("Unexpected Listener: %s", diff.PrintWantGot(d))
 		}
 
-		workflowRuns, err := workflowBackend.GetWorkflowRuns(context.TODO(), wf, nil)
+		workflowRuns, err := workflowBackend.GetWorkflowRuns(context.TODO(), wf, &domain.WorkflowRunFilter{})
 		assertError(t, err, nil)
 		gotRuns := len(workflowRuns)
 		wantRuns := 1
@@ -295,7 +437,7 @@ func TestAssignToCodeset(t *testing.T) {
 
 		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 
 		for i := 0; i < 2; i++ {
 			_, _, err := mgr.AssignToCodeset(context.TODO(), wf.Name, codesets[0].Project, codesets[0].Name)
@@ -305,7 +447,7 @@ func TestAssignToCodeset(t *testing.T) {
 		_, err = workflowBackend.GetWorkflowListener(context.TODO(), wf.Name)
 		assertError(t, err, nil)
 
-		workflowRuns, err := workflowBackend.GetWorkflowRuns(context.TODO(), wf, nil)
+		workflowRuns, err := workflowBackend.GetWorkflowRuns(context.TODO(), wf, &domain.WorkflowRunFilter{})
 		assertError(t, err, nil)
 
 		gotRuns := len(workflowRuns)
@@ -319,7 +461,7 @@ func TestAssignToCodeset(t *testing.T) {
 		mgr := newFakeWorkflowManager(t)
 
 		wfName := "unknownWf"
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		_, _, got := mgr.AssignToCodeset(context.Background(), wfName, codesets[0].Project, codesets[0].Name)
 		assertError(t, got, domain.ErrWorkflowNotFound)
 
@@ -361,7 +503,7 @@ func TestUnassignFromCodeset(t *testing.T) {
 		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		var listener *domain.WorkflowListener
 		var webhookID *int64
 		webhooks := map[*domain.Codeset][]*int64{}
@@ -380,10 +522,6 @@ func TestUnassignFromCodeset(t *testing.T) {
 		// delete wf assignment to cs0
 		err = mgr.UnassignFromCodeset(context.Background(), wf.Name, codesets[0].Project, codesets[0].Name)
 		assertError(t, err, nil)
-		gotSubscribers := codesetStore.getSubscribers(context.TODO(), codesets[0])
-		if d := cmp.Diff([]domain.CodesetSubscriber{}, gotSubscribers); d != "" {
-			t.Errorf("Unexpected codeset subscriber: %s", diff.PrintWantGot(d))
-		}
 
 		// should have only one assignment to cs1
 		gotAss := workflowStore.GetAllCodesetAssignments(context.TODO(), &wf.Name)
@@ -420,7 +558,7 @@ func TestUnassignFromCodeset(t *testing.T) {
 		mgr := newFakeWorkflowManager(t)
 
 		wfName := "unknownWf"
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		got := mgr.UnassignFromCodeset(context.Background(), wfName, codesets[0].Project, codesets[0].Name)
 		assertError(t, got, domain.ErrWorkflowNotFound)
 
@@ -464,7 +602,7 @@ func TestUnassignFromCodeset(t *testing.T) {
 		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		got := mgr.UnassignFromCodeset(context.Background(), wf.Name, codesets[0].Project, codesets[0].Name)
 		assertError(t, got, domain.ErrWorkflowNotAssignedToCodeset)
 	})
@@ -475,7 +613,7 @@ func TestUnassignFromCodeset(t *testing.T) {
 		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		codeset := codesets[0]
 		_, _, err = mgr.AssignToCodeset(context.Background(), wf.Name, codeset.Project, codeset.Name)
 		assertError(t, err, nil)
@@ -489,13 +627,39 @@ func TestUnassignFromCodeset(t *testing.T) {
 			t.Errorf("Unexpected Assignment: %s", diff.PrintWantGot(d))
 		}
 	})
+
+	t.Run("on codeset deleting after restart", func(t *testing.T) {
+		mgr := newFakeWorkflowManager(t)
+
+		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
+		assertError(t, err, nil)
+
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
+		for _, c := range codesets[:2] {
+			_, _, err = mgr.AssignToCodeset(context.Background(), wf.Name, c.Project, c.Name)
+			assertError(t, err, nil)
+		}
+
+		// a new workflow manager, sharing only the persisted stores with the previous one,
+		// must still unassign workflows from deleted codesets
+		eventBus = core.NewEventBus()
+		codesetStore.eventBus = eventBus
+		NewWorkflowManager(slog.Default(), &config.Default().Workflows, workflowBackend, workflowStore, codesetStore, extensionRegistry, eventBus)
+
+		codesetStore.Delete(context.TODO(), codesets[0].Project, codesets[0].Name)
+
+		gotAss := workflowStore.GetAllCodesetAssignments(context.TODO(), nil)
+		if len(gotAss[wf.Name]) != 1 || gotAss[wf.Name][0].Codeset != codesets[1] {
+			t.Errorf("Unexpected Assignment: %v", gotAss)
+		}
+	})
 }
 
 func TestGetAllCodesetAssignments(t *testing.T) {
 	t.Run("list", func(t *testing.T) {
 		mgr := newFakeWorkflowManager(t)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		want := make(map[string][]*domain.CodesetAssignment, len(codesets))
 
 		addToWantAssignment := func(wf string, cs *domain.Codeset, webhookID *int64) {
@@ -551,7 +715,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		want := []*domain.WorkflowRun{}
 
 		// filter nil, no runs
-		got, err := mgr.GetWorkflowRuns(context.Background(), nil)
+		got, _, err := mgr.GetWorkflowRuns(context.Background(), nil, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -559,7 +723,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 
 		// with filter, no runs
 		filter := domain.WorkflowRunFilter{}
-		got, err = mgr.GetWorkflowRuns(context.Background(), &filter)
+		got, _, err = mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -568,17 +732,17 @@ func TestGetWorkflowRuns(t *testing.T) {
 		wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		// create 3 runs with (cs0, csproject0, "Succeeded", "Failed", "Succeeded") and list
 		for i := 0; i < 3; i++ {
 			// currently, assigning a workflow to a codeset is the only function that creates a workflow run
 			_, _, err = mgr.AssignToCodeset(context.Background(), wf.Name, codesets[0].Project, codesets[0].Name)
 			assertError(t, err, nil)
 
-			got, err = mgr.GetWorkflowRuns(context.Background(), &filter)
+			got, _, err = mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 			assertError(t, err, nil)
 
-			want, _ = workflowBackend.GetWorkflowRuns(context.TODO(), wf, nil)
+			want, _ = workflowBackend.GetWorkflowRuns(context.TODO(), wf, &domain.WorkflowRunFilter{})
 			if d := cmp.Diff(want, got); d != "" {
 				t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
 			}
@@ -593,7 +757,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// non existing workflow, no runs
 		wfName := "unknownWf"
 		filterNoRunsNoWf := domain.WorkflowRunFilter{WorkflowName: &wfName}
-		got, err := mgr.GetWorkflowRuns(context.Background(), &filterNoRunsNoWf)
+		got, _, err := mgr.GetWorkflowRuns(context.Background(), &filterNoRunsNoWf, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -605,7 +769,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 
 		// existing workflow, no runs
 		filterNoRunsExistingWf := domain.WorkflowRunFilter{WorkflowName: &wf.Name}
-		got, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRunsExistingWf)
+		got, _, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRunsExistingWf, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -615,7 +779,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// wf0 -> 0 runs
 		// wf1 -> 1 run (cs0, csproject0, Succeeded)
 		// wf2 -> 2 runs (cs0, csproject0, Succeeded, Failed)
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		for i := 0; i < len(codesets); i++ {
 			wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: fmt.Sprintf("wf%d", i)})
 			assertError(t, err, nil)
@@ -628,13 +792,13 @@ func TestGetWorkflowRuns(t *testing.T) {
 		}
 
 		// iterate over each workflow listing its runs
-		workflows := workflowStore.GetWorkflows(context.TODO(), nil)
+		workflows, _, _ := workflowStore.GetWorkflows(context.TODO(), nil, nil)
 		for _, wf := range workflows {
 			filter := domain.WorkflowRunFilter{WorkflowName: &wf.Name}
-			got, err = mgr.GetWorkflowRuns(context.Background(), &filter)
+			got, _, err = mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 			assertError(t, err, nil)
 
-			want, _ := workflowBackend.GetWorkflowRuns(context.TODO(), &domain.Workflow{Name: wf.Name}, nil)
+			want, _ := workflowBackend.GetWorkflowRuns(context.TODO(), &domain.Workflow{Name: wf.Name}, &domain.WorkflowRunFilter{})
 			if d := cmp.Diff(want, got); d != "" {
 				t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
 			}
@@ -649,16 +813,16 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// non existing codeset, no runs
 		csName := "unknownCs"
 		filterNoRunsNoCs := domain.WorkflowRunFilter{CodesetName: csName}
-		got, err := mgr.GetWorkflowRuns(context.Background(), &filterNoRunsNoCs)
+		got, _, err := mgr.GetWorkflowRuns(context.Background(), &filterNoRunsNoCs, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
 		}
 
 		// existing codeset, no runs
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		filterNoRuns := domain.WorkflowRunFilter{CodesetName: codesets[0].Name}
-		got, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRuns)
+		got, _, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRuns, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -679,7 +843,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// iterate over each codeset and list runs by codeset name
 		for _, cs := range codesets {
 			filter := domain.WorkflowRunFilter{CodesetName: cs.Name}
-			got, err = mgr.GetWorkflowRuns(context.Background(), &filter)
+			got, _, err = mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 			assertError(t, err, nil)
 
 			want, _ := workflowBackend.GetWorkflowRuns(context.TODO(), wf, &filter)
@@ -691,7 +855,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// iterate over each codeset and list runs by codeset project
 		for _, cs := range codesets {
 			filter := domain.WorkflowRunFilter{CodesetProject: cs.Project}
-			got, err = mgr.GetWorkflowRuns(context.Background(), &filter)
+			got, _, err = mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 			assertError(t, err, nil)
 
 			want, _ := workflowBackend.GetWorkflowRuns(context.TODO(), wf, &filter)
@@ -703,7 +867,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// iterate over each codeset and list runs by codeset name and project
 		for _, cs := range codesets {
 			filter := domain.WorkflowRunFilter{CodesetName: cs.Name, CodesetProject: cs.Project}
-			got, err = mgr.GetWorkflowRuns(context.Background(), &filter)
+			got, _, err = mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 			assertError(t, err, nil)
 
 			want, _ := workflowBackend.GetWorkflowRuns(context.TODO(), wf, &filter)
@@ -720,7 +884,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 
 		// nil status, no runs
 		filterNoRunsNilStatus := domain.WorkflowRunFilter{Status: nil}
-		got, err := mgr.GetWorkflowRuns(context.Background(), &filterNoRunsNilStatus)
+		got, _, err := mgr.GetWorkflowRuns(context.Background(), &filterNoRunsNilStatus, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -728,7 +892,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 
 		// empty status, no runs
 		filterNoRunsEmptyStatus := domain.WorkflowRunFilter{Status: []string{}}
-		got, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRunsEmptyStatus)
+		got, _, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRunsEmptyStatus, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -736,7 +900,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 
 		// with status, no runs
 		filterNoRunsWithStatus := domain.WorkflowRunFilter{Status: []string{"Succeeded"}}
-		got, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRunsWithStatus)
+		got, _, err = mgr.GetWorkflowRuns(context.Background(), &filterNoRunsWithStatus, nil)
 		assertError(t, err, nil)
 		if d := cmp.Diff(want, got); d != "" {
 			t.Errorf("Unexpected Workflow Runs: %s", diff.PrintWantGot(d))
@@ -749,7 +913,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// 1. (cs0, csproject0, Succeeded)
 		// 2. (cs0, csproject0, Failed)
 		// 3. (cs0, csproject0, Succeeded)
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		for i := 0; i < len(codesets); i++ {
 			_, _, err = mgr.AssignToCodeset(context.Background(), wf.Name, codesets[0].Project, codesets[0].Name)
 			assertError(t, err, nil)
@@ -759,7 +923,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		for _, s := range workflowRunStatuses {
 			status := []string{s}
 			filter := domain.WorkflowRunFilter{Status: status}
-			got, err = mgr.GetWorkflowRuns(context.Background(), &filter)
+			got, _, err = mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 			assertError(t, err, nil)
 
 			want, _ := workflowBackend.GetWorkflowRuns(context.TODO(), wf, &filter)
@@ -776,7 +940,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		// wf0 -> 0 runs
 		// wf1 -> 1 run (cs0, project0, Succeeded)
 		// wf2 -> 2 runs (cs1, project1, Succeeded) (cs2, project1, Failed)
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		for i := 0; i < len(codesets); i++ {
 			wf, err := mgr.CreateWorkflow(context.Background(), &domain.Workflow{Name: fmt.Sprintf("wf%d", i)})
 			assertError(t, err, nil)
@@ -792,7 +956,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 		}
 
 		// iterate over all workflows, codesets, status listing runs and filtering for each combination
-		workflows := workflowStore.GetWorkflows(context.TODO(), nil)
+		workflows, _, _ := workflowStore.GetWorkflows(context.TODO(), nil, nil)
 		for _, wf := range workflows {
 			wfName := wf.Name
 			for _, cs := range codesets {
@@ -801,7 +965,7 @@ func TestGetWorkflowRuns(t *testing.T) {
 				for _, status := range workflowRunStatuses {
 					status := []string{status}
 					filter := domain.WorkflowRunFilter{WorkflowName: &wfName, CodesetName: csName, CodesetProject: csProject, Status: status}
-					got, err := mgr.GetWorkflowRuns(context.Background(), &filter)
+					got, _, err := mgr.GetWorkflowRuns(context.Background(), &filter, nil)
 					assertError(t, err, nil)
 
 					want, _ := workflowBackend.GetWorkflowRuns(context.TODO(), &domain.Workflow{Name: wfName}, &filter)
@@ -834,7 +998,7 @@ func TestGetAssignmentStatus(t *testing.T) {
 		wf, err := mgr.CreateWorkflow(context.TODO(), &domain.Workflow{Name: "wf"})
 		assertError(t, err, nil)
 
-		codesets, _ := codesetStore.GetAll(context.TODO(), nil, nil)
+		codesets, _, _ := codesetStore.GetAll(context.TODO(), nil, nil, nil)
 		codeset := codesets[0]
 
 		listener, _, err := mgr.AssignToCodeset(context.TODO(), wf.Name, codeset.Project, codeset.Name)
@@ -848,6 +1012,292 @@ func TestGetAssignmentStatus(t *testing.T) {
 	})
 }
 
+func TestStartWithWorkflows(t *testing.T) {
+	mgr := newFakeWorkflowManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+	assertError(t, err, nil)
+	codesets, _, _ := codesetStore.GetAll(ctx, nil, nil, nil)
+	_, _, err = mgr.AssignToCodeset(ctx, wf.Name, codesets[0].Project, codesets[0].Name)
+	assertError(t, err, nil)
+
+	var wg sync.WaitGroup
+	assertError(t, mgr.Start(ctx, &wg), nil)
+	// the existing run is listed for the workflow metrics
+	want := `
+# HELP fuseml_workflow_runs Number of workflow runs, by workflow and status.
+# TYPE fuseml_workflow_runs gauge
+fuseml_workflow_runs{status="Succeeded",workflow="wf"} 1
+`
+	if err := testutil.CollectAndCompare(mgr, strings.NewReader(want), "fuseml_workflow_runs"); err != nil {
+		t.Errorf("Unexpected workflow metrics: %s", err)
+	}
+}
+
+func TestWorkflowMetrics(t *testing.T) {
+	mgr := newFakeWorkflowManager(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+	assertError(t, err, nil)
+	_, err = mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf2"})
+	assertError(t, err, nil)
+
+	// the run created by the assignment exists before the manager starts watching the runs
+	codesets, _, _ := codesetStore.GetAll(ctx, nil, nil, nil)
+	_, _, err = mgr.AssignToCodeset(ctx, wf.Name, codesets[0].Project, codesets[0].Name)
+	assertError(t, err, nil)
+	var wg sync.WaitGroup
+	assertError(t, mgr.Start(ctx, &wg), nil)
+
+	started := time.Now()
+	run := &domain.WorkflowRun{Name: "wf-run1", WorkflowRef: wf.Name, Status: "Running", StartTime: started}
+	mgr.OnWorkflowRun(ctx, domain.EventCreated, run)
+	run = &domain.WorkflowRun{Name: "wf-run1", WorkflowRef: wf.Name, Status: "Failed (PipelineValidationFailed)",
+		StartTime: started, CompletionTime: started.Add(30 * time.Second)}
+	mgr.OnWorkflowRun(ctx, domain.EventUpdated, run)
+	// updates that do not change the status of completed runs are not counted again
+	mgr.OnWorkflowRun(ctx, domain.EventUpdated, run)
+	// runs that completed before the manager was created are not counted as completed
+	mgr.OnWorkflowRun(ctx, domain.EventUpdated, &domain.WorkflowRun{Name: "wf-old", WorkflowRef: wf.Name,
+		Status: "Succeeded", CompletionTime: started.Add(-time.Hour)})
+	mgr.OnWorkflowRun(ctx, domain.EventDeleted, &domain.WorkflowRun{Name: "wf-run0", WorkflowRef: wf.Name, Status: "Succeeded"})
+
+	want := `
+# HELP fuseml_workflows Number of workflows registered.
+# TYPE fuseml_workflows gauge
+fuseml_workflows 2
+# HELP fuseml_workflow_assignments Number of codesets assigned to each workflow.
+# TYPE fuseml_workflow_assignments gauge
+fuseml_workflow_assignments{workflow="wf"} 1
+fuseml_workflow_assignments{workflow="wf2"} 0
+# HELP fuseml_workflow_runs Number of workflow runs, by workflow and status.
+# TYPE fuseml_workflow_runs gauge
+fuseml_workflow_runs{status="Failed",workflow="wf"} 1
+fuseml_workflow_runs{status="Succeeded",workflow="wf"} 1
+# HELP fuseml_workflow_runs_completed_total Number of workflow runs completed, by workflow and status.
+# TYPE fuseml_workflow_runs_completed_total counter
+fuseml_workflow_runs_completed_total{status="Failed",workflow="wf"} 1
+`
+	err = testutil.CollectAndCompare(mgr, strings.NewReader(want), "fuseml_workflows", "fuseml_workflow_assignments",
+		"fuseml_workflow_runs", "fuseml_workflow_runs_completed_total")
+	if err != nil {
+		t.Errorf("Unexpected workflow metrics: %s", err)
+	}
+	if got := testutil.CollectAndCount(mgr, "fuseml_workflow_run_duration_seconds"); got != 1 {
+		t.Errorf("got %d workflow run duration histograms want 1", got)
+	}
+}
+
+func TestCreateWorkflowFailures(t *testing.T) {
+	ctx := context.Background()
+	errFault := fmt.Errorf("injected failure")
+
+	for _, fault := range []string{"backend.CreateWorkflow", "store.AddWorkflow", "extensions.RecordUsage"} {
+		t.Run(fault, func(t *testing.T) {
+			mgr, fail := newFaultyWorkflowManager(t)
+			fail[fault] = errFault
+
+			_, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+			assertError(t, err, errFault)
+			_, err = workflowStore.GetWorkflow(ctx, "wf")
+			assertError(t, err, domain.ErrWorkflowNotFound)
+			if pipelines, _ := workflowBackend.ListWorkflows(ctx); len(pipelines) != 0 {
+				t.Errorf("Workflow pipeline left behind: %v", pipelines)
+			}
+
+			// retry
+			delete(fail, fault)
+			_, err = mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+			assertError(t, err, nil)
+		})
+	}
+}
+
+func TestAssignToCodesetFailures(t *testing.T) {
+	ctx := context.Background()
+	errFault := fmt.Errorf("injected failure")
+
+	for _, fault := range []string{"backend.CreateWorkflowListener", "codesets.GetWebhooks", "codesets.CreateWebhook",
+		"store.AddCodesetAssignment", "backend.CreateWorkflowRun"} {
+		t.Run(fault, func(t *testing.T) {
+			mgr, fail := newFaultyWorkflowManager(t)
+			wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+			assertError(t, err, nil)
+			cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+			fail[fault] = errFault
+
+			_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+			assertError(t, err, errFault)
+			assertUnassigned(t, wf.Name, cs0, true)
+			if runs, _ := workflowBackend.GetWorkflowRuns(ctx, wf, &domain.WorkflowRunFilter{}); len(runs) != 0 {
+				t.Errorf("Expected no WorkflowRun got %d", len(runs))
+			}
+
+			// retry
+			delete(fail, fault)
+			_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+			assertError(t, err, nil)
+			assertAssigned(t, wf.Name, cs0)
+			if runs, _ := workflowBackend.GetWorkflowRuns(ctx, wf, &domain.WorkflowRunFilter{}); len(runs) != 1 {
+				t.Errorf("Expected 1 WorkflowRun got %d", len(runs))
+			}
+		})
+	}
+
+	t.Run("other assignments", func(t *testing.T) {
+		mgr, fail := newFaultyWorkflowManager(t)
+		wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+		assertError(t, err, nil)
+		cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+		cs1, _ := codesetStore.Find(ctx, "csproject1", "cs1")
+		_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs1.Project, cs1.Name)
+		assertError(t, err, nil)
+		fail["backend.CreateWorkflowRun"] = errFault
+
+		_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+		assertError(t, err, errFault)
+		assertUnassigned(t, wf.Name, cs0, false)
+		// the listener is still used by the assignment to cs1
+		assertAssigned(t, wf.Name, cs1)
+	})
+
+	t.Run("undo failed", func(t *testing.T) {
+		mgr, fail := newFaultyWorkflowManager(t)
+		wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+		assertError(t, err, nil)
+		cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+		fail["store.AddCodesetAssignment"] = errFault
+		fail["codesets.DeleteWebhook"] = errFault
+
+		_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+		assertError(t, err, errFault)
+		if hooks := codesetStore.store[codesetID{cs0.Name, cs0.Project}].webhooks; len(hooks) != 1 {
+			t.Fatalf("Expected the webhook to be left behind, got %v", hooks)
+		}
+
+		// retrying reuses the webhook left behind
+		delete(fail, "store.AddCodesetAssignment")
+		delete(fail, "codesets.DeleteWebhook")
+		_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+		assertError(t, err, nil)
+		assertAssigned(t, wf.Name, cs0)
+	})
+}
+
+func TestUnassignFromCodesetFailures(t *testing.T) {
+	ctx := context.Background()
+	errFault := fmt.Errorf("injected failure")
+
+	for _, fault := range []string{"codesets.DeleteWebhook", "backend.DeleteWorkflowListener", "store.DeleteCodesetAssignment"} {
+		t.Run(fault, func(t *testing.T) {
+			mgr, fail := newFaultyWorkflowManager(t)
+			wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+			assertError(t, err, nil)
+			cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+			_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+			assertError(t, err, nil)
+			fail[fault] = errFault
+
+			err = mgr.UnassignFromCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+			assertError(t, err, errFault)
+			assertAssigned(t, wf.Name, cs0)
+
+			// retry
+			delete(fail, fault)
+			err = mgr.UnassignFromCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+			assertError(t, err, nil)
+			assertUnassigned(t, wf.Name, cs0, true)
+		})
+	}
+
+	t.Run("undo failed", func(t *testing.T) {
+		mgr, fail := newFaultyWorkflowManager(t)
+		wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{Name: "wf"})
+		assertError(t, err, nil)
+		cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+		_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+		assertError(t, err, nil)
+		fail["backend.DeleteWorkflowListener"] = errFault
+		fail["codesets.CreateWebhook"] = errFault
+
+		err = mgr.UnassignFromCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+		assertError(t, err, errFault)
+		if _, err := workflowStore.GetCodesetAssignment(ctx, wf.Name, cs0); err != nil {
+			t.Errorf("Codeset assignment not restored: %v", err)
+		}
+
+		// retrying skips the webhook that is already deleted
+		delete(fail, "backend.DeleteWorkflowListener")
+		delete(fail, "codesets.CreateWebhook")
+		err = mgr.UnassignFromCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+		assertError(t, err, nil)
+		assertUnassigned(t, wf.Name, cs0, true)
+	})
+}
+
+func TestDeleteWorkflowFailures(t *testing.T) {
+	ctx := context.Background()
+	errFault := fmt.Errorf("injected failure")
+
+	for _, fault := range []string{"codesets.DeleteWebhook", "extensions.ReleaseUsage", "backend.DeleteWorkflow",
+		"store.DeleteWorkflow"} {
+		t.Run(fault, func(t *testing.T) {
+			mgr, fail := newFaultyWorkflowManager(t)
+			ext, err := mgr.extensionRegistry.RegisterExtension(ctx, createFakeExtension(t, mgr, "test-"))
+			assertError(t, err, nil)
+			wf, err := mgr.CreateWorkflow(ctx, &domain.Workflow{
+				Name: "wf",
+				Steps: []*domain.WorkflowStep{{
+					Name:       "test-step",
+					Extensions: []*domain.WorkflowStepExtension{{Name: "test-extension", ExtensionID: ext.ID}},
+				}},
+			})
+			assertError(t, err, nil)
+			cs0, _ := codesetStore.Find(ctx, "csproject0", "cs0")
+			_, _, err = mgr.AssignToCodeset(ctx, wf.Name, cs0.Project, cs0.Name)
+			assertError(t, err, nil)
+			notifications := NewNotificationManager(slog.Default(), core.NewNotificationStore(), eventBus)
+			_, err = notifications.RegisterTarget(ctx, &domain.NotificationTarget{Name: "wf-target", Type: domain.SlackTarget,
+				Workflow: wf.Name, URL: "http://slack.test"})
+			assertError(t, err, nil)
+			fail[fault] = errFault
+
+			err = mgr.DeleteWorkflow(ctx, wf.Name)
+			assertError(t, err, errFault)
+			// the workflow is announced as deleted right before it is deleted from the store
+			if targets, _, _ := notifications.GetTargets(ctx, wf.Name, "", nil); len(targets) != 1 && fault != "store.DeleteWorkflow" {
+				t.Errorf("Notification targets of the workflow deleted: %v", targets)
+			}
+			_, err = workflowStore.GetWorkflow(ctx, wf.Name)
+			assertError(t, err, nil)
+			if pipelines, _ := workflowBackend.ListWorkflows(ctx); len(pipelines) != 1 {
+				t.Errorf("Expected the workflow pipeline, got %v", pipelines)
+			}
+			if usage, _ := mgr.extensionRegistry.GetUsage(ctx, &domain.ExtensionUsageFilter{Workflow: wf.Name}); len(usage) != 1 {
+				t.Errorf("got %d active usage records want 1", len(usage))
+			}
+
+			// retry
+			delete(fail, fault)
+			err = mgr.DeleteWorkflow(ctx, wf.Name)
+			assertError(t, err, nil)
+			_, err = workflowStore.GetWorkflow(ctx, wf.Name)
+			assertError(t, err, domain.ErrWorkflowNotFound)
+			if pipelines, _ := workflowBackend.ListWorkflows(ctx); len(pipelines) != 0 {
+				t.Errorf("Workflow pipeline left behind: %v", pipelines)
+			}
+			assertUnassigned(t, wf.Name, cs0, true)
+			if targets, _, _ := notifications.GetTargets(ctx, wf.Name, "", nil); len(targets) != 0 {
+				t.Errorf("Notification targets of the workflow left behind: %v", targets)
+			}
+		})
+	}
+}
+
 func assertError(t testing.TB, got, want error) {
 	t.Helper()
 
@@ -868,9 +1318,10 @@ func newFakeWorkflowManager(t *testing.T) *WorkflowManager {
 	t.Helper()
 
 	workflowStore = core.NewWorkflowStore()
-	workflowBackend = &fakeWorkflowBackend{t, make(map[string]*fakeStorableWorkflow)}
-	codesetStore = &fakeCodesetStore{t, make(map[codesetID]fakeStorableCodeset)}
-	extensionRegistry = NewExtensionRegistry(core.NewExtensionStore())
+	workflowBackend = &fakeWorkflowBackend{t, make(map[string]*fakeStorableWorkflow), faults{}}
+	eventBus = core.NewEventBus()
+	codesetStore = &fakeCodesetStore{t, make(map[codesetID]fakeStorableCodeset), eventBus, faults{}}
+	extensionRegistry = NewExtensionRegistry(core.NewExtensionStore(), core.NewExtensionUsageStore(), eventBus)
 
 	// add codesets to the codeset store for the tests to use it:
 	// 1. name: cs0, project: csproject0
@@ -891,7 +1342,58 @@ func newFakeWorkflowManager(t *testing.T) *WorkflowManager {
 		}
 	}
 
-	return NewWorkflowManager(workflowBackend, workflowStore, codesetStore, extensionRegistry)
+	return NewWorkflowManager(slog.Default(), &config.Default().Workflows, workflowBackend, workflowStore, codesetStore, extensionRegistry, eventBus)
+}
+
+// assertAssigned checks that a workflow is assigned to a codeset through a webhook pointing to the workflow
+// listener
+func assertAssigned(t *testing.T, name string, codeset *domain.Codeset) {
+	t.Helper()
+
+	ctx := context.Background()
+	assignment, err := workflowStore.GetCodesetAssignment(ctx, name, codeset)
+	if err != nil {
+		t.Fatalf("Workflow %q not assigned to codeset %s/%s: %v", name, codeset.Project, codeset.Name, err)
+	}
+	listener, err := workflowBackend.GetWorkflowListener(ctx, name)
+	if err != nil {
+		t.Fatalf("Workflow %q has no listener: %v", name, err)
+	}
+	hooks := codesetStore.store[codesetID{codeset.Name, codeset.Project}].webhooks
+	if url, exists := hooks[*assignment.WebhookID]; !exists || url != listener.URL || len(hooks) != 1 {
+		t.Errorf("Got webhooks %v, want only webhook %d pointing to %q", hooks, *assignment.WebhookID, listener.URL)
+	}
+}
+
+// assertUnassigned checks that a workflow is not assigned to a codeset and that the codeset has no webhooks.
+// With noListener set, it also checks that the workflow has no listener.
+func assertUnassigned(t *testing.T, name string, codeset *domain.Codeset, noListener bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	if _, err := workflowStore.GetCodesetAssignment(ctx, name, codeset); err == nil {
+		t.Errorf("Workflow %q assigned to codeset %s/%s", name, codeset.Project, codeset.Name)
+	}
+	if hooks := codesetStore.store[codesetID{codeset.Name, codeset.Project}].webhooks; len(hooks) != 0 {
+		t.Errorf("Webhooks left behind: %v", hooks)
+	}
+	if _, err := workflowBackend.GetWorkflowListener(ctx, name); noListener && err == nil {
+		t.Errorf("Workflow listener left behind")
+	}
+}
+
+// newFaultyWorkflowManager returns a new fake workflow manager, along with the faults that make the methods
+// of its dependencies fail
+func newFaultyWorkflowManager(t *testing.T) (*WorkflowManager, faults) {
+	t.Helper()
+
+	mgr := newFakeWorkflowManager(t)
+	fail := faults{}
+	workflowBackend.(*fakeWorkflowBackend).fail = fail
+	codesetStore.fail = fail
+	mgr.workflowStore = &faultyWorkflowStore{workflowStore, fail}
+	mgr.extensionRegistry = &faultyExtensionRegistry{mgr.extensionRegistry, fail}
+	return mgr, fail
 }
 
 func createFakeExtension(t *testing.T, wfm *WorkflowManager, prefix string) *domain.Extension {
@@ -938,26 +1440,45 @@ func createFakeExtension(t *testing.T, wfm *WorkflowManager, prefix string) *dom
 type fakeStorableWorkflow struct {
 	listener *domain.WorkflowListener
 	runs     []*domain.WorkflowRun
+	created  time.Time
 }
 
 type fakeWorkflowBackend struct {
 	t         *testing.T
 	workflows map[string]*fakeStorableWorkflow
+	fail      faults
 }
 
 func (b *fakeWorkflowBackend) CreateWorkflow(ctx context.Context, w *domain.Workflow) error {
 	b.t.Helper()
 
+	if err := b.fail["backend.CreateWorkflow"]; err != nil {
+		return err
+	}
+
 	if _, exists := b.workflows[w.Name]; exists {
 		return domain.ErrWorkflowExists
 	}
-	b.workflows[w.Name] = &fakeStorableWorkflow{nil, []*domain.WorkflowRun{}}
+	b.workflows[w.Name] = &fakeStorableWorkflow{nil, []*domain.WorkflowRun{}, time.Now()}
+	return nil
+}
+
+func (b *fakeWorkflowBackend) UpdateWorkflow(ctx context.Context, w *domain.Workflow) error {
+	b.t.Helper()
+
+	if _, exists := b.workflows[w.Name]; !exists {
+		return domain.ErrWorkflowNotFound
+	}
 	return nil
 }
 
 func (b *fakeWorkflowBackend) DeleteWorkflow(ctx context.Context, workflowName string) error {
 	b.t.Helper()
 
+	if err := b.fail["backend.DeleteWorkflow"]; err != nil {
+		return err
+	}
+
 	delete(b.workflows, workflowName)
 	return nil
 }
@@ -965,6 +1486,10 @@ func (b *fakeWorkflowBackend) DeleteWorkflow(ctx context.Context, workflowName s
 func (b *fakeWorkflowBackend) CreateWorkflowRun(ctx context.Context, workflowName string, codeset *domain.Codeset) error {
 	b.t.Helper()
 
+	if err := b.fail["backend.CreateWorkflowRun"]; err != nil {
+		return err
+	}
+
 	if _, exists := b.workflows[workflowName]; !exists {
 		return fmt.Errorf("workflow not found")
 	}
@@ -991,7 +1516,8 @@ func (b *fakeWorkflowBackend) GetWorkflowRuns(ctx context.Context, wf *domain.Wo
 	}
 
 	runs := b.workflows[wf.Name].runs
-	if filter == nil || (filter.CodesetName == "" && filter.CodesetProject == "" && len(filter.Status) == 0) {
+	// like the tekton backend, the filter is expected to be set
+	if filter.CodesetName == "" && filter.CodesetProject == "" && len(filter.Status) == 0 {
 		return runs, nil
 	}
 
@@ -1034,6 +1560,10 @@ func (b *fakeWorkflowBackend) GetWorkflowRuns(ctx context.Context, wf *domain.Wo
 func (b *fakeWorkflowBackend) CreateWorkflowListener(ctx context.Context, workflowName string, timeout time.Duration) (*domain.WorkflowListener, error) {
 	b.t.Helper()
 
+	if err := b.fail["backend.CreateWorkflowListener"]; err != nil {
+		return nil, err
+	}
+
 	listener := b.workflows[workflowName].listener
 	if listener == nil {
 		listener = &domain.WorkflowListener{Name: workflowName, Available: true, URL: fmt.Sprintf("http://%s.listener.test", workflowName),
@@ -1046,6 +1576,10 @@ func (b *fakeWorkflowBackend) CreateWorkflowListener(ctx context.Context, workfl
 func (b *fakeWorkflowBackend) DeleteWorkflowListener(ctx context.Context, workflowName string) error {
 	b.t.Helper()
 
+	if err := b.fail["backend.DeleteWorkflowListener"]; err != nil {
+		return err
+	}
+
 	wf, exists := b.workflows[workflowName]
 	if !exists {
 		return nil
@@ -1066,20 +1600,47 @@ func (b *fakeWorkflowBackend) GetWorkflowListener(ctx context.Context, workflowN
 	return nil, fmt.Errorf("listener not found")
 }
 
+func (b *fakeWorkflowBackend) ListWorkflows(ctx context.Context) (map[string]time.Time, error) {
+	b.t.Helper()
+
+	workflows := map[string]time.Time{}
+	for name, wf := range b.workflows {
+		workflows[name] = wf.created
+	}
+	return workflows, nil
+}
+
+func (b *fakeWorkflowBackend) ListWorkflowListeners(ctx context.Context) ([]*domain.WorkflowListener, error) {
+	b.t.Helper()
+
+	listeners := []*domain.WorkflowListener{}
+	for _, wf := range b.workflows {
+		if wf.listener != nil {
+			listeners = append(listeners, wf.listener)
+		}
+	}
+	return listeners, nil
+}
+
+func (b *fakeWorkflowBackend) WatchWorkflowRuns(ctx context.Context, wg *sync.WaitGroup, handler domain.WorkflowRunHandler) error {
+	return nil
+}
+
 type codesetID struct {
 	name    string
 	project string
 }
 
 type fakeStorableCodeset struct {
-	codeset     *domain.Codeset
-	webhooks    map[int64]string
-	subscribers []domain.CodesetSubscriber
+	codeset  *domain.Codeset
+	webhooks map[int64]string
 }
 
 type fakeCodesetStore struct {
-	t     *testing.T
-	store map[codesetID]fakeStorableCodeset
+	t        *testing.T
+	store    map[codesetID]fakeStorableCodeset
+	eventBus domain.EventBus
+	fail     faults
 }
 
 func (fcs *fakeCodesetStore) Add(ctx context.Context, c *domain.Codeset) (*domain.Codeset, *string, *string, error) {
@@ -1092,14 +1653,36 @@ func (fcs *fakeCodesetStore) Add(ctx context.Context, c *domain.Codeset) (*domai
 func (fcs *fakeCodesetStore) CreateWebhook(ctx context.Context, c *domain.Codeset, url string) (*int64, error) {
 	fcs.t.Helper()
 
+	if err := fcs.fail["codesets.CreateWebhook"]; err != nil {
+		return nil, err
+	}
+
 	id := rand.Int63()
 	fcs.store[codesetID{c.Name, c.Project}].webhooks[id] = url
 	return &id, nil
 }
 
+func (fcs *fakeCodesetStore) GetWebhooks(ctx context.Context, c *domain.Codeset) ([]*domain.Webhook, error) {
+	fcs.t.Helper()
+
+	if err := fcs.fail["codesets.GetWebhooks"]; err != nil {
+		return nil, err
+	}
+
+	webhooks := []*domain.Webhook{}
+	for id, url := range fcs.store[codesetID{c.Name, c.Project}].webhooks {
+		webhooks = append(webhooks, &domain.Webhook{ID: id, URL: url})
+	}
+	return webhooks, nil
+}
+
 func (fcs *fakeCodesetStore) DeleteWebhook(ctx context.Context, c *domain.Codeset, id *int64) error {
 	fcs.t.Helper()
 
+	if err := fcs.fail["codesets.DeleteWebhook"]; err != nil {
+		return err
+	}
+
 	delete(fcs.store[codesetID{c.Name, c.Project}].webhooks, *id)
 	return nil
 }
@@ -1111,9 +1694,7 @@ func (fcs *fakeCodesetStore) Delete(ctx context.Context, project, name string) e
 	if !ok {
 		return nil
 	}
-	for _, subscriber := range sc.subscribers {
-		subscriber.OnDeletingCodeset(ctx, sc.codeset)
-	}
+	fcs.eventBus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.CodesetResource, Object: sc.codeset})
 
 	delete(fcs.store, codesetID{name, project})
 	return nil
@@ -1128,46 +1709,68 @@ func (fcs *fakeCodesetStore) Find(ctx context.Context, project, name string) (*d
 	return nil, errCodesetNotFound
 }
 
-func (fcs *fakeCodesetStore) GetAll(ctx context.Context, project, label *string) (res []*domain.Codeset, err error) {
+func (fcs *fakeCodesetStore) GetAll(ctx context.Context, project, label *string, opts *domain.ListOptions) (res []*domain.Codeset, next string, err error) {
 	fcs.t.Helper()
 
 	for _, c := range fcs.store {
 		res = append(res, c.codeset)
 	}
-	return res, nil
+	next, err = domain.Paginate(&res, opts, domain.CodesetSortFields, domain.CodesetDefaultSort)
+	return
 }
 
-func (fcs *fakeCodesetStore) Subscribe(ctx context.Context, subscriber domain.CodesetSubscriber, codeset *domain.Codeset) error {
-	fcs.t.Helper()
+// faultyWorkflowStore is a workflow store whose write methods can be made to fail
+type faultyWorkflowStore struct {
+	domain.WorkflowStore
+	fail faults
+}
 
-	sc, ok := fcs.store[codesetID{codeset.Name, codeset.Project}]
-	if !ok {
-		return fmt.Errorf("codeset not found")
+func (s *faultyWorkflowStore) AddWorkflow(ctx context.Context, w *domain.Workflow) (*domain.Workflow, error) {
+	if err := s.fail["store.AddWorkflow"]; err != nil {
+		return nil, err
 	}
-	sc.subscribers = append(sc.subscribers, subscriber)
-	fcs.store[codesetID{codeset.Name, codeset.Project}] = sc
-	return nil
+	return s.WorkflowStore.AddWorkflow(ctx, w)
 }
 
-func (fcs *fakeCodesetStore) Unsubscribe(ctx context.Context, subscriber domain.CodesetSubscriber, codeset *domain.Codeset) error {
-	fcs.t.Helper()
+func (s *faultyWorkflowStore) DeleteWorkflow(ctx context.Context, name string) error {
+	if err := s.fail["store.DeleteWorkflow"]; err != nil {
+		return err
+	}
+	return s.WorkflowStore.DeleteWorkflow(ctx, name)
+}
 
-	sc := fcs.store[codesetID{codeset.Name, codeset.Project}]
-	sc.subscribers = removeSubscriber(sc.subscribers, subscriber)
-	fcs.store[codesetID{codeset.Name, codeset.Project}] = sc
-	return nil
+func (s *faultyWorkflowStore) AddCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset,
+	webhook *int64) ([]*domain.CodesetAssignment, error) {
+	if err := s.fail["store.AddCodesetAssignment"]; err != nil {
+		return nil, err
+	}
+	return s.WorkflowStore.AddCodesetAssignment(ctx, workflowName, codeset, webhook)
 }
 
-func (fcs *fakeCodesetStore) getSubscribers(ctx context.Context, c *domain.Codeset) []domain.CodesetSubscriber {
-	return fcs.store[codesetID{c.Name, c.Project}].subscribers
+func (s *faultyWorkflowStore) DeleteCodesetAssignment(ctx context.Context, workflowName string,
+	codeset *domain.Codeset) ([]*domain.CodesetAssignment, error) {
+	if err := s.fail["store.DeleteCodesetAssignment"]; err != nil {
+		return nil, err
+	}
+	return s.WorkflowStore.DeleteCodesetAssignment(ctx, workflowName, codeset)
 }
 
-func removeSubscriber(subscribers []domain.CodesetSubscriber, subscriber domain.CodesetSubscriber) []domain.CodesetSubscriber {
-	for i, s := range subscribers {
-		if s == subscriber {
-			subscribers[len(subscribers)-1], subscribers[i] = subscribers[i], subscribers[len(subscribers)-1]
-			return subscribers[:len(subscribers)-1]
-		}
+// faultyExtensionRegistry is an extension registry whose usage methods can be made to fail
+type faultyExtensionRegistry struct {
+	domain.ExtensionRegistry
+	fail faults
+}
+
+func (r *faultyExtensionRegistry) RecordUsage(ctx context.Context, workflowName string, usage []*domain.ExtensionUsage) error {
+	if err := r.fail["extensions.RecordUsage"]; err != nil {
+		return err
+	}
+	return r.ExtensionRegistry.RecordUsage(ctx, workflowName, usage)
+}
+
+func (r *faultyExtensionRegistry) ReleaseUsage(ctx context.Context, workflowName string) error {
+	if err := r.fail["extensions.ReleaseUsage"]; err != nil {
+		return err
 	}
-	return subscribers
+	return r.ExtensionRegistry.ReleaseUsage(ctx, workflowName)
 }
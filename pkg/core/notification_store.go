@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// NotificationStore describes in memory store for notification targets and their delivery log
+type NotificationStore struct {
+	// deliveries are made in the background, so the store may be accessed concurrently
+	sync.Mutex
+	targets    map[string]*domain.NotificationTarget
+	deliveries []*domain.NotificationDelivery
+	lastID     uint64
+}
+
+// NewNotificationStore returns an in-memory notification store instance
+func NewNotificationStore() *NotificationStore {
+	return &NotificationStore{
+		targets: make(map[string]*domain.NotificationTarget),
+	}
+}
+
+// AddTarget adds a notification target to the store
+func (store *NotificationStore) AddTarget(ctx context.Context, target *domain.NotificationTarget) (*domain.NotificationTarget, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	if _, exists := store.targets[target.Name]; exists {
+		return nil, domain.ErrNotificationTargetExists
+	}
+	store.targets[target.Name] = target
+	return target, nil
+}
+
+// GetTarget retrieves a notification target from the store
+func (store *NotificationStore) GetTarget(ctx context.Context, name string) (*domain.NotificationTarget, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	target, exists := store.targets[name]
+	if !exists {
+		return nil, domain.ErrNotificationTargetNotFound
+	}
+	return target, nil
+}
+
+// GetTargets returns the page of notification targets, filtered by workflow and project, selected by the
+// list options
+func (store *NotificationStore) GetTargets(ctx context.Context, workflow, project string,
+	opts *domain.ListOptions) ([]*domain.NotificationTarget, string, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	result := make([]*domain.NotificationTarget, 0, len(store.targets))
+	for _, target := range store.targets {
+		if (workflow == "" || target.Workflow == workflow) && (project == "" || target.Project == project) {
+			result = append(result, target)
+		}
+	}
+	next, err := domain.Paginate(&result, opts, domain.NotificationSortFields, domain.NotificationDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, next, nil
+}
+
+// DeleteTarget deletes a notification target and its delivery log from the store
+func (store *NotificationStore) DeleteTarget(ctx context.Context, name string) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if _, exists := store.targets[name]; !exists {
+		return domain.ErrNotificationTargetNotFound
+	}
+	delete(store.targets, name)
+
+	deliveries := []*domain.NotificationDelivery{}
+	for _, d := range store.deliveries {
+		if d.Target != name {
+			deliveries = append(deliveries, d)
+		}
+	}
+	store.deliveries = deliveries
+	return nil
+}
+
+// AddDelivery adds a delivery to the delivery log and assigns its ID
+func (store *NotificationStore) AddDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	store.Lock()
+	defer store.Unlock()
+
+	store.lastID++
+	delivery.ID = store.lastID
+	d := *delivery
+	store.deliveries = append(store.deliveries, &d)
+	return nil
+}
+
+// UpdateDelivery replaces a delivery in the delivery log
+func (store *NotificationStore) UpdateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	store.Lock()
+	defer store.Unlock()
+
+	for i, d := range store.deliveries {
+		if d.ID == delivery.ID {
+			updated := *delivery
+			store.deliveries[i] = &updated
+			return nil
+		}
+	}
+	return nil
+}
+
+// GetDeliveries returns the page of deliveries made to a notification target selected by the list options
+func (store *NotificationStore) GetDeliveries(ctx context.Context, target string,
+	opts *domain.ListOptions) ([]*domain.NotificationDelivery, string, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	result := []*domain.NotificationDelivery{}
+	for _, d := range store.deliveries {
+		if d.Target == target {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	if opts == nil {
+		// the delivery log is always returned in the default order, most recent deliveries first
+		opts = &domain.ListOptions{}
+	}
+	next, err := domain.Paginate(&result, opts, domain.NotificationDeliverySortFields, domain.NotificationDeliveryDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, next, nil
+}
@@ -10,19 +10,23 @@ import (
 
 // GitProjectStore describes a structure that accesses project store implemented in git
 type GitProjectStore struct {
-	gitAdmin domain.GitAdminClient
+	gitAdmin     domain.GitAdminClient
+	codesetStore domain.CodesetStore
+	eventBus     domain.EventBus
 }
 
 // NewGitProjectStore returns project store instance
-func NewGitProjectStore(gitAdmin domain.GitAdminClient) *GitProjectStore {
+func NewGitProjectStore(gitAdmin domain.GitAdminClient, codesetStore domain.CodesetStore, eventBus domain.EventBus) *GitProjectStore {
 	return &GitProjectStore{
-		gitAdmin: gitAdmin,
+		gitAdmin:     gitAdmin,
+		codesetStore: codesetStore,
+		eventBus:     eventBus,
 	}
 }
 
 // Find returns a project identified by project and name
 func (cs *GitProjectStore) Find(ctx context.Context, project string) (*domain.Project, error) {
-	result, err := cs.gitAdmin.GetProject(project)
+	result, err := cs.gitAdmin.GetProject(ctx, project)
 	if err != nil {
 		return nil, errors.Wrap(err, "Fetching Project failed")
 	}
@@ -31,27 +35,98 @@ func (cs *GitProjectStore) Find(ctx context.Context, project string) (*domain.Pr
 
 // Create creates a new project
 func (cs *GitProjectStore) Create(ctx context.Context, name, desc string) (*domain.Project, error) {
-	result, err := cs.gitAdmin.CreateProject(name, desc, false)
+	result, err := cs.gitAdmin.CreateProject(ctx, name, desc, false)
 	if err != nil {
 		return nil, errors.Wrap(err, "Creating Project failed")
 	}
+	cs.eventBus.Publish(ctx, &domain.Event{Type: domain.EventCreated, Kind: domain.ProjectResource, Object: result})
 	return result, nil
 }
 
-// GetAll returns all projects matching given project and label
-func (cs *GitProjectStore) GetAll(ctx context.Context) ([]*domain.Project, error) {
-	result, err := cs.gitAdmin.GetProjects()
+// GetAll returns the page of projects selected by the list options
+func (cs *GitProjectStore) GetAll(ctx context.Context, opts *domain.ListOptions) ([]*domain.Project, string, error) {
+	result, next, err := cs.gitAdmin.GetProjects(ctx, opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "Fetching Projects failed")
+		return nil, "", errors.Wrap(err, "Fetching Projects failed")
 	}
-	return result, nil
+	return result, next, nil
 }
 
-// Delete removes a project identified by project and name
-func (cs *GitProjectStore) Delete(ctx context.Context, project string) error {
-	err := cs.gitAdmin.DeleteProject(project)
+// Delete removes a project identified by project and name. A project that has codesets is only deleted
+// with cascade, together with all its codesets and their git repositories.
+// A deleting event is published before anything is deleted, so subscribers can clean up the
+// resources they manage for the project, followed by a deleting event for each codeset.
+func (cs *GitProjectStore) Delete(ctx context.Context, project string, cascade bool) error {
+	p, err := cs.Find(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	codesets, _, err := cs.codesetStore.GetAll(ctx, &project, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "Deleting Project failed")
+	}
+	if len(codesets) > 0 && !cascade {
+		return domain.ErrProjectNotEmpty
+	}
+
+	cs.eventBus.Publish(ctx, &domain.Event{Type: domain.EventDeleting, Kind: domain.ProjectResource, Object: p})
+	for _, c := range codesets {
+		if err := cs.codesetStore.Delete(ctx, project, c.Name); err != nil {
+			return errors.Wrap(err, "Deleting Project failed")
+		}
+	}
+
+	err = cs.gitAdmin.DeleteProject(ctx, project)
 	if err != nil {
 		return errors.Wrap(err, "Deleting Project failed")
 	}
 	return nil
 }
+
+// ListMembers returns the members of a project
+func (cs *GitProjectStore) ListMembers(ctx context.Context, project string) ([]*domain.User, error) {
+	result, err := cs.gitAdmin.GetProjectMembers(ctx, project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Fetching Project members failed")
+	}
+	return result, nil
+}
+
+// AddMember adds a user to a project
+func (cs *GitProjectStore) AddMember(ctx context.Context, project string, user *domain.User) (*domain.UserCredentials, error) {
+	password, err := cs.gitAdmin.AddProjectMember(ctx, project, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "Adding Project member failed")
+	}
+	cs.publishUpdated(ctx, project)
+	return &domain.UserCredentials{Username: user.Name, Password: password}, nil
+}
+
+// RemoveMember removes a user from a project
+func (cs *GitProjectStore) RemoveMember(ctx context.Context, project, userName string) error {
+	err := cs.gitAdmin.RemoveProjectMember(ctx, project, userName)
+	if err != nil {
+		return errors.Wrap(err, "Removing Project member failed")
+	}
+	cs.publishUpdated(ctx, project)
+	return nil
+}
+
+// RotateCredentials issues a new password for a project member
+func (cs *GitProjectStore) RotateCredentials(ctx context.Context, project, userName string) (*domain.UserCredentials, error) {
+	password, err := cs.gitAdmin.ResetUserPassword(ctx, project, userName)
+	if err != nil {
+		return nil, errors.Wrap(err, "Rotating credentials failed")
+	}
+	return &domain.UserCredentials{Username: userName, Password: password}, nil
+}
+
+// publishUpdated notifies subscribers about changes made to a project
+func (cs *GitProjectStore) publishUpdated(ctx context.Context, project string) {
+	p, err := cs.Find(ctx, project)
+	if err != nil {
+		return
+	}
+	cs.eventBus.Publish(ctx, &domain.Event{Type: domain.EventUpdated, Kind: domain.ProjectResource, Object: p})
+}
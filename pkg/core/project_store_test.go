@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// testGitAdmin keeps the projects and their repositories in memory
+type testGitAdmin struct {
+	domain.GitAdminClient
+	projects map[string][]string
+}
+
+func (ga *testGitAdmin) GetProject(ctx context.Context, org string) (*domain.Project, error) {
+	if _, ok := ga.projects[org]; !ok {
+		return nil, domain.ErrProjectNotFound
+	}
+	return &domain.Project{Name: org}, nil
+}
+
+func (ga *testGitAdmin) DeleteProject(ctx context.Context, org string) error {
+	delete(ga.projects, org)
+	return nil
+}
+
+// testCodesetStore lists and deletes the repositories kept by testGitAdmin
+type testCodesetStore struct {
+	domain.CodesetStore
+	gitAdmin *testGitAdmin
+}
+
+func (cs *testCodesetStore) GetAll(ctx context.Context, project, label *string, opts *domain.ListOptions) ([]*domain.Codeset, string, error) {
+	result := []*domain.Codeset{}
+	for _, name := range cs.gitAdmin.projects[*project] {
+		result = append(result, &domain.Codeset{Project: *project, Name: name})
+	}
+	return result, "", nil
+}
+
+func (cs *testCodesetStore) Delete(ctx context.Context, project, name string) error {
+	repos := []string{}
+	for _, r := range cs.gitAdmin.projects[project] {
+		if r != name {
+			repos = append(repos, r)
+		}
+	}
+	cs.gitAdmin.projects[project] = repos
+	return nil
+}
+
+func TestGitProjectStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	gitAdmin := &testGitAdmin{projects: map[string][]string{"empty": {}, "prj": {"cs1", "cs2"}}}
+	events := &recordingSubscriber{}
+	bus := NewEventBus()
+	bus.Subscribe(events)
+	store := NewGitProjectStore(gitAdmin, &testCodesetStore{gitAdmin: gitAdmin}, bus)
+
+	if err := store.Delete(ctx, "missing", false); errors.Cause(err) != domain.ErrProjectNotFound {
+		t.Errorf("got error %v want %v", err, domain.ErrProjectNotFound)
+	}
+
+	// a project without codesets does not need cascade
+	if err := store.Delete(ctx, "empty", false); err != nil {
+		t.Errorf("Unexpected error deleting empty project: %v", err)
+	}
+	if _, ok := gitAdmin.projects["empty"]; ok {
+		t.Errorf("Empty project was not deleted")
+	}
+	events.events = nil
+
+	// the codesets are only deleted with cascade
+	if err := store.Delete(ctx, "prj", false); err != domain.ErrProjectNotEmpty {
+		t.Errorf("got error %v want %v", err, domain.ErrProjectNotEmpty)
+	}
+	if len(gitAdmin.projects["prj"]) != 2 {
+		t.Errorf("Codesets deleted without cascade: %v", gitAdmin.projects["prj"])
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Project announced as deleted without cascade: %v", events.events)
+	}
+
+	if err := store.Delete(ctx, "prj", true); err != nil {
+		t.Errorf("Unexpected error deleting project with cascade: %v", err)
+	}
+	if _, ok := gitAdmin.projects["prj"]; ok {
+		t.Errorf("Project was not deleted with cascade")
+	}
+	if len(events.events) != 1 || events.events[0].Type != domain.EventDeleting {
+		t.Errorf("Expected a deleting event, got %v", events.events)
+	}
+}
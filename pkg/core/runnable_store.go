@@ -28,8 +28,9 @@ const (
 
 // Find returns a list of runnables matching the input query.
 // Runnables may be matched by id, kind or labels. Only runnables that match all the
-// supplied criteria will be returned.
-func (s *RunnableStore) Find(ctx context.Context, id string, kind string, labels map[string]string) (res []*domain.Runnable, err error) {
+// supplied criteria will be returned, ordered and paged according to the list options.
+func (s *RunnableStore) Find(ctx context.Context, id string, kind string, labels map[string]string,
+	opts *domain.ListOptions) (res []*domain.Runnable, next string, err error) {
 	res = make([]*domain.Runnable, 0)
 
 RUNNABLES:
@@ -71,6 +72,10 @@ RUNNABLES:
 		copier.Copy(&rMatch, r)
 		res = append(res, rMatch)
 	}
+	next, err = domain.Paginate(&res, opts, domain.RunnableSortFields, domain.RunnableDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
 	return
 }
 
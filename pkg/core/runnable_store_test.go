@@ -0,0 +1,14 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/storetest"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func TestRunnableStore(t *testing.T) {
+	storetest.TestRunnableStore(t, func(t *testing.T) (domain.RunnableStore, func()) {
+		return NewRunnableStore(), func() {}
+	})
+}
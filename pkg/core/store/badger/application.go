@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 	"github.com/timshannon/badgerhold/v3"
 )
 
@@ -19,18 +20,30 @@ func NewApplicationStore(store *badgerhold.Store) *ApplicationStore {
 
 // Find returns a application identified by id
 func (as *ApplicationStore) Find(ctx context.Context, name string) *domain.Application {
-	app := domain.Application{}
-	err := as.store.Get(name, &app)
+	ctx, span := tracing.Start(ctx, "badger.ApplicationStore.Find")
+	defer span.End()
+
+	r := applicationRecord{}
+	err := as.store.Get(name, &r)
 	if err != nil {
 		return nil
 	}
-	return &app
+	return r.toDomain()
 }
 
-// GetAll returns all applications of a given type.
+// GetAll returns the page of applications of a given type selected by the list options.
 // If type is not specified, return all applications.
-func (as *ApplicationStore) GetAll(ctx context.Context, applicationType *string, applicationWorkflow *string) ([]*domain.Application, error) {
-	result := []*domain.Application{}
+func (as *ApplicationStore) GetAll(ctx context.Context, applicationType *string, applicationWorkflow *string,
+	opts *domain.ListOptions) (_ []*domain.Application, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ApplicationStore.GetAll")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.ApplicationSortFields, domain.ApplicationDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	records := []*applicationRecord{}
 	query := &badgerhold.Query{}
 
 	if applicationType != nil && applicationWorkflow != nil {
@@ -41,29 +54,47 @@ func (as *ApplicationStore) GetAll(ctx context.Context, applicationType *string,
 		query = badgerhold.Where("Workflow").Eq(*applicationWorkflow)
 	}
 
-	err := as.store.Find(&result, query)
+	next, err := findPage(as.store, &records, query, window)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return result, nil
+	return applicationsToDomain(records), next, nil
 }
 
 // Add adds a new application, based on the Application structure provided as argument
-func (as *ApplicationStore) Add(ctx context.Context, a *domain.Application) (*domain.Application, error) {
-	// TODO What if such application already exist? Should we thrown an error, or silently replace it?
-	err := as.store.Insert(a.Name, a)
+func (as *ApplicationStore) Add(ctx context.Context, a *domain.Application) (_ *domain.Application, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ApplicationStore.Add")
+	defer tracing.End(span, &err)
+
+	err = as.store.Insert(a.Name, newApplicationRecord(a))
 	if err != nil {
-		as.store.Delete(a.Name, a)
-		err := as.store.Insert(a.Name, a)
-		if err != nil {
-			return nil, err
+		if err == badgerhold.ErrKeyExists {
+			return nil, domain.ErrApplicationExists
 		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// Update replaces an existing application
+func (as *ApplicationStore) Update(ctx context.Context, a *domain.Application) (_ *domain.Application, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ApplicationStore.Update")
+	defer tracing.End(span, &err)
+
+	err = as.store.Update(a.Name, newApplicationRecord(a))
+	if err != nil {
+		if err == badgerhold.ErrNotFound {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, err
 	}
 	return a, nil
 }
 
 // Delete deletes the application registered by FuseML
-func (as *ApplicationStore) Delete(ctx context.Context, name string) error {
-	a := domain.Application{}
-	return as.store.Delete(name, a)
+func (as *ApplicationStore) Delete(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ApplicationStore.Delete")
+	defer tracing.End(span, &err)
+
+	return as.store.Delete(name, applicationRecord{})
 }
@@ -0,0 +1,82 @@
+package badger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// maxPendingWrites is the number of pending writes allowed while loading a backup
+const maxPendingWrites = 256
+
+// schemaInfoPrefix is the prefix badgerhold uses for the keys of the schemaInfo records
+var schemaInfoPrefix = []byte("bh_schemaInfo")
+
+// BackupStore is a wrapper around a badgerhold.Store that implements the domain.BackupStore interface, using
+// the badger backup format.
+type BackupStore struct {
+	logger *slog.Logger
+	store  *badgerhold.Store
+}
+
+// NewBackupStore creates a new BackupStore.
+func NewBackupStore(logger *slog.Logger, store *badgerhold.Store) *BackupStore {
+	return &BackupStore{logger: logger, store: store}
+}
+
+// Backup writes all the keys in the database, as seen by a single read transaction.
+func (bs *BackupStore) Backup(ctx context.Context, w io.Writer) (err error) {
+	_, span := tracing.Start(ctx, "badger.BackupStore.Backup")
+	defer tracing.End(span, &err)
+
+	_, err = bs.store.Badger().Backup(w, 0)
+	return err
+}
+
+// Restore loads a backup into the database, which must be empty, and migrates the loaded records to the
+// current schema version.
+func (bs *BackupStore) Restore(ctx context.Context, r io.Reader) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.BackupStore.Restore")
+	defer tracing.End(span, &err)
+
+	db := bs.store.Badger()
+	empty := true
+	err = db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{})
+		defer it.Close()
+		for it.Rewind(); it.Valid() && empty; it.Next() {
+			empty = bytes.HasPrefix(it.Item().Key(), schemaInfoPrefix)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return domain.ErrStoreNotEmpty
+	}
+	// the schema version is that of the backup, which has none if it was taken before the first migration
+	err = bs.store.Delete(schemaInfoKey, schemaInfo{})
+	if err != nil && err != badgerhold.ErrNotFound {
+		return err
+	}
+
+	// badger does not validate the size of the entries it loads and panics on some malformed inputs
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("%w: %v", domain.ErrInvalidBackup, v)
+		}
+	}()
+	if err = db.Load(r, maxPendingWrites); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidBackup, err)
+	}
+	return Migrate(ctx, bs.logger, bs.store)
+}
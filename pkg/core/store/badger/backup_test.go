@@ -0,0 +1,103 @@
+package badger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func newBackupStore(t *testing.T) (*badgerhold.Store, *BackupStore, func()) {
+	t.Helper()
+
+	dir := tmpDir(t)
+	opt := badgerhold.DefaultOptions
+	opt.Logger = nil
+	opt.Dir = dir
+	opt.ValueDir = dir
+
+	store, err := OpenStore(slog.Default(), opt)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	return store, NewBackupStore(slog.Default(), store), func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestBackupStore(t *testing.T) {
+	ctx := context.TODO()
+	source, sourceBackup, cleanup := newBackupStore(t)
+	defer cleanup()
+
+	workflows := NewWorkflowStore(source)
+	codeset := &domain.Codeset{Name: "mlflow-app-01", Project: "workspace"}
+	hookID := int64(1)
+	for _, name := range []string{"wf-a", "wf-b"} {
+		if _, err := workflows.AddWorkflow(ctx, &domain.Workflow{Name: name}); err != nil {
+			t.Fatalf("failed to add workflow: %v", err)
+		}
+	}
+	if _, err := workflows.AddCodesetAssignment(ctx, "wf-a", codeset, &hookID); err != nil {
+		t.Fatalf("failed to add assignment: %v", err)
+	}
+
+	var backup bytes.Buffer
+	assertNoError(t, sourceBackup.Backup(ctx, &backup))
+
+	t.Run("restore", func(t *testing.T) {
+		target, targetBackup, cleanup := newBackupStore(t)
+		defer cleanup()
+
+		assertNoError(t, targetBackup.Restore(ctx, bytes.NewReader(backup.Bytes())))
+		restored := NewWorkflowStore(target)
+		got, _, err := restored.GetWorkflows(ctx, nil, nil)
+		assertNoError(t, err)
+		if len(got) != 2 {
+			t.Errorf("got %d workflows, want 2", len(got))
+		}
+		assignment, err := restored.GetCodesetAssignment(ctx, "wf-a", codeset)
+		assertNoError(t, err)
+		if assignment != nil && *assignment.WebhookID != hookID {
+			t.Errorf("got webhook %d, want %d", *assignment.WebhookID, hookID)
+		}
+	})
+
+	t.Run("restore schema v0", func(t *testing.T) {
+		target, targetBackup, cleanup := newBackupStore(t)
+		defer cleanup()
+
+		f, err := os.Open(filepath.Join("testdata", "schema-v0.backup"))
+		if err != nil {
+			t.Fatalf("failed to open fixture: %v", err)
+		}
+		defer f.Close()
+		assertNoError(t, targetBackup.Restore(ctx, f))
+		assertSchemaV0Migrated(t, target)
+	})
+
+	t.Run("not empty", func(t *testing.T) {
+		err := sourceBackup.Restore(ctx, bytes.NewReader(backup.Bytes()))
+		assertError(t, err, domain.ErrStoreNotEmpty)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, targetBackup, cleanup := newBackupStore(t)
+		defer cleanup()
+
+		err := targetBackup.Restore(ctx, strings.NewReader("not a badger backup"))
+		if !errors.Is(err, domain.ErrInvalidBackup) {
+			t.Errorf("got error %v, want %v", err, domain.ErrInvalidBackup)
+		}
+	})
+}
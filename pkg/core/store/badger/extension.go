@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 	"github.com/timshannon/badgerhold/v3"
 )
 
@@ -19,11 +20,14 @@ func NewExtensionStore(store *badgerhold.Store) *ExtensionStore {
 }
 
 // AddExtension adds a new extension to the store.
-func (es *ExtensionStore) AddExtension(ctx context.Context, extension *domain.Extension) (*domain.Extension, error) {
+func (es *ExtensionStore) AddExtension(ctx context.Context, extension *domain.Extension) (_ *domain.Extension, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.AddExtension")
+	defer tracing.End(span, &err)
+
 	extension.EnsureID(ctx, es)
 	extension.SetCreated(ctx)
 
-	err := es.store.Insert(extension.ID, extension)
+	err = es.store.Insert(extension.ID, newExtensionRecord(extension))
 	if err != nil {
 		return nil, domain.NewErrExtensionExists(extension.ID)
 	}
@@ -31,17 +35,37 @@ func (es *ExtensionStore) AddExtension(ctx context.Context, extension *domain.Ex
 }
 
 // GetExtension retrieves an extension by its ID.
-func (es *ExtensionStore) GetExtension(ctx context.Context, extensionID string) (*domain.Extension, error) {
-	extension := &domain.Extension{}
-	err := es.store.Get(extensionID, extension)
+func (es *ExtensionStore) GetExtension(ctx context.Context, extensionID string) (_ *domain.Extension, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.GetExtension")
+	defer tracing.End(span, &err)
+
+	r := extensionRecord{}
+	err = es.store.Get(extensionID, &r)
 	if err != nil {
 		return nil, domain.NewErrExtensionNotFound(extensionID)
 	}
-	return extension, nil
+	return r.toDomain(), nil
 }
 
-// ListExtensions retrieves all stored extensions.
-func (es *ExtensionStore) ListExtensions(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.Extension) {
+// ListExtensions retrieves the page of stored extensions matching the query selected by the list options.
+func (es *ExtensionStore) ListExtensions(ctx context.Context, query *domain.ExtensionQuery,
+	opts *domain.ListOptions) (_ []*domain.Extension, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.ListExtensions")
+	defer tracing.End(span, &err)
+
+	result, err := es.listExtensions(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+	next, err := domain.Paginate(&result, opts, domain.ExtensionSortFields, domain.ExtensionDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, next, nil
+}
+
+// listExtensions retrieves all stored extensions matching the query.
+func (es *ExtensionStore) listExtensions(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.Extension, err error) {
 	result = []*domain.Extension{}
 
 	// TODO: Replace with a badgerhold query.
@@ -54,14 +78,17 @@ func (es *ExtensionStore) ListExtensions(ctx context.Context, query *domain.Exte
 					result = append(result, matchingExtension)
 				}
 			}
-			return
+			return result, nil
 		}
 
-		allExtensions := []*domain.Extension{}
-		es.store.Find(&allExtensions, nil)
+		records := []*extensionRecord{}
+		err = es.store.Find(&records, nil)
+		if err != nil {
+			return nil, err
+		}
 
-		for _, extension := range allExtensions {
-			matchingExtension := extension.GetExtensionIfMatch(query)
+		for _, r := range records {
+			matchingExtension := r.toDomain().GetExtensionIfMatch(query)
 			if matchingExtension != nil {
 				result = append(result, matchingExtension)
 			}
@@ -69,12 +96,19 @@ func (es *ExtensionStore) ListExtensions(ctx context.Context, query *domain.Exte
 		return
 	}
 
-	es.store.Find(&result, nil)
-	return
+	records := []*extensionRecord{}
+	err = es.store.Find(&records, nil)
+	if err != nil {
+		return nil, err
+	}
+	return extensionsToDomain(records), nil
 }
 
 // UpdateExtension updates an existing extension.
-func (es *ExtensionStore) UpdateExtension(ctx context.Context, newExtension *domain.Extension) error {
+func (es *ExtensionStore) UpdateExtension(ctx context.Context, newExtension *domain.Extension) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.UpdateExtension")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, newExtension.ID)
 	if err != nil {
 		return err
@@ -83,14 +117,16 @@ func (es *ExtensionStore) UpdateExtension(ctx context.Context, newExtension *dom
 	newExtension.Updated = time.Now()
 
 	for _, newExtService := range newExtension.ListServices() {
-		_, err := extension.GetService(newExtService.ID)
+		oldExtService, err := extension.GetService(newExtService.ID)
 		if err != nil {
 			// If the service is new, set the creation time
 			newExtService.SetCreated(newExtension.Updated)
+		} else {
+			newExtService.InheritEndpointStatus(oldExtService)
 		}
 	}
 
-	err = es.store.Update(newExtension.ID, newExtension)
+	err = es.store.Update(newExtension.ID, newExtensionRecord(newExtension))
 	if err != nil {
 		return domain.NewErrExtensionNotFound(newExtension.ID)
 	}
@@ -98,16 +134,22 @@ func (es *ExtensionStore) UpdateExtension(ctx context.Context, newExtension *dom
 }
 
 // DeleteExtension deletes an extension from the store.
-func (es *ExtensionStore) DeleteExtension(ctx context.Context, extensionID string) error {
+func (es *ExtensionStore) DeleteExtension(ctx context.Context, extensionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.DeleteExtension")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return err
 	}
-	return es.store.Delete(extension.ID, extension)
+	return es.store.Delete(extension.ID, extensionRecord{})
 }
 
 // AddExtensionService adds a new extension service to an extension.
-func (es *ExtensionStore) AddExtensionService(ctx context.Context, extensionID string, service *domain.ExtensionService) (*domain.ExtensionService, error) {
+func (es *ExtensionStore) AddExtensionService(ctx context.Context, extensionID string, service *domain.ExtensionService) (_ *domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.AddExtensionService")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -124,7 +166,10 @@ func (es *ExtensionStore) AddExtensionService(ctx context.Context, extensionID s
 }
 
 // GetExtensionService retrieves an extension service by its ID.
-func (es *ExtensionStore) GetExtensionService(ctx context.Context, extensionID string, serviceID string) (*domain.ExtensionService, error) {
+func (es *ExtensionStore) GetExtensionService(ctx context.Context, extensionID string, serviceID string) (_ *domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.GetExtensionService")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -133,7 +178,10 @@ func (es *ExtensionStore) GetExtensionService(ctx context.Context, extensionID s
 }
 
 // ListExtensionServices retrieves all services belonging to an extension.
-func (es *ExtensionStore) ListExtensionServices(ctx context.Context, extensionID string) ([]*domain.ExtensionService, error) {
+func (es *ExtensionStore) ListExtensionServices(ctx context.Context, extensionID string) (_ []*domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.ListExtensionServices")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -142,7 +190,10 @@ func (es *ExtensionStore) ListExtensionServices(ctx context.Context, extensionID
 }
 
 // UpdateExtensionService updates a service belonging to an extension.
-func (es *ExtensionStore) UpdateExtensionService(ctx context.Context, extensionID string, newService *domain.ExtensionService) error {
+func (es *ExtensionStore) UpdateExtensionService(ctx context.Context, extensionID string, newService *domain.ExtensionService) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.UpdateExtensionService")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return err
@@ -155,7 +206,10 @@ func (es *ExtensionStore) UpdateExtensionService(ctx context.Context, extensionI
 }
 
 // DeleteExtensionService deletes an extension service from an extension.
-func (es *ExtensionStore) DeleteExtensionService(ctx context.Context, extensionID string, serviceID string) error {
+func (es *ExtensionStore) DeleteExtensionService(ctx context.Context, extensionID string, serviceID string) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.DeleteExtensionService")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return err
@@ -168,7 +222,10 @@ func (es *ExtensionStore) DeleteExtensionService(ctx context.Context, extensionI
 }
 
 // AddExtensionServiceEndpoint adds a new endpoint to an extension service.
-func (es *ExtensionStore) AddExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpoint *domain.ExtensionServiceEndpoint) (*domain.ExtensionServiceEndpoint, error) {
+func (es *ExtensionStore) AddExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpoint *domain.ExtensionServiceEndpoint) (_ *domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.AddExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -189,7 +246,10 @@ func (es *ExtensionStore) AddExtensionServiceEndpoint(ctx context.Context, exten
 }
 
 // GetExtensionServiceEndpoint retrieves an extension endpoint by its ID.
-func (es *ExtensionStore) GetExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpointID string) (*domain.ExtensionServiceEndpoint, error) {
+func (es *ExtensionStore) GetExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpointID string) (_ *domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.GetExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -202,7 +262,10 @@ func (es *ExtensionStore) GetExtensionServiceEndpoint(ctx context.Context, exten
 }
 
 // ListExtensionServiceEndpoints retrieves all endpoints belonging to an extension service.
-func (es *ExtensionStore) ListExtensionServiceEndpoints(ctx context.Context, extensionID string, serviceID string) ([]*domain.ExtensionServiceEndpoint, error) {
+func (es *ExtensionStore) ListExtensionServiceEndpoints(ctx context.Context, extensionID string, serviceID string) (_ []*domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.ListExtensionServiceEndpoints")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -215,7 +278,10 @@ func (es *ExtensionStore) ListExtensionServiceEndpoints(ctx context.Context, ext
 }
 
 // UpdateExtensionServiceEndpoint updates an endpoint belonging to an extension service.
-func (es *ExtensionStore) UpdateExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, newEndpoint *domain.ExtensionServiceEndpoint) error {
+func (es *ExtensionStore) UpdateExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, newEndpoint *domain.ExtensionServiceEndpoint) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.UpdateExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return err
@@ -231,8 +297,33 @@ func (es *ExtensionStore) UpdateExtensionServiceEndpoint(ctx context.Context, ex
 	return es.UpdateExtension(ctx, extension)
 }
 
+// UpdateExtensionServiceEndpointStatus updates the operational status of an endpoint belonging to an extension service.
+func (es *ExtensionStore) UpdateExtensionServiceEndpointStatus(ctx context.Context, extensionID string, serviceID string, endpointURL string, status domain.ExtensionServiceEndpointStatus) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.UpdateExtensionServiceEndpointStatus")
+	defer tracing.End(span, &err)
+
+	extension, err := es.GetExtension(ctx, extensionID)
+	if err != nil {
+		return err
+	}
+	endpoint, err := extension.GetServiceEndpoint(serviceID, endpointURL)
+	if err != nil {
+		return err
+	}
+	endpoint.Status = status
+	// the status is not part of the extension configuration, so the update time is not changed
+	err = es.store.Update(extension.ID, newExtensionRecord(extension))
+	if err != nil {
+		return domain.NewErrExtensionNotFound(extension.ID)
+	}
+	return nil
+}
+
 // DeleteExtensionServiceEndpoint deletes an extension endpoint from an extension service.
-func (es *ExtensionStore) DeleteExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpointID string) error {
+func (es *ExtensionStore) DeleteExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpointID string) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.DeleteExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return err
@@ -249,7 +340,10 @@ func (es *ExtensionStore) DeleteExtensionServiceEndpoint(ctx context.Context, ex
 }
 
 // AddExtensionServiceCredentials adds a new credential to an extension service.
-func (es *ExtensionStore) AddExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentials *domain.ExtensionServiceCredentials) (*domain.ExtensionServiceCredentials, error) {
+func (es *ExtensionStore) AddExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentials *domain.ExtensionServiceCredentials) (_ *domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.AddExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -270,7 +364,10 @@ func (es *ExtensionStore) AddExtensionServiceCredentials(ctx context.Context, ex
 }
 
 // GetExtensionServiceCredentials retrieves an extension credential by its ID.
-func (es *ExtensionStore) GetExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentialsID string) (*domain.ExtensionServiceCredentials, error) {
+func (es *ExtensionStore) GetExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentialsID string) (_ *domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.GetExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -283,7 +380,10 @@ func (es *ExtensionStore) GetExtensionServiceCredentials(ctx context.Context, ex
 }
 
 // ListExtensionServiceCredentials retrieves all credentials belonging to an extension service.
-func (es *ExtensionStore) ListExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string) ([]*domain.ExtensionServiceCredentials, error) {
+func (es *ExtensionStore) ListExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string) (_ []*domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.ListExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return nil, err
@@ -296,7 +396,10 @@ func (es *ExtensionStore) ListExtensionServiceCredentials(ctx context.Context, e
 }
 
 // UpdateExtensionServiceCredentials updates an extension credential.
-func (es *ExtensionStore) UpdateExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, newCredentials *domain.ExtensionServiceCredentials) error {
+func (es *ExtensionStore) UpdateExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, newCredentials *domain.ExtensionServiceCredentials) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.UpdateExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return err
@@ -313,7 +416,10 @@ func (es *ExtensionStore) UpdateExtensionServiceCredentials(ctx context.Context,
 }
 
 // DeleteExtensionServiceCredentials deletes an extension credential from an extension service.
-func (es *ExtensionStore) DeleteExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentialsID string) error {
+func (es *ExtensionStore) DeleteExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentialsID string) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.DeleteExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
 	extension, err := es.GetExtension(ctx, extensionID)
 	if err != nil {
 		return err
@@ -331,9 +437,16 @@ func (es *ExtensionStore) DeleteExtensionServiceCredentials(ctx context.Context,
 
 // GetExtensionAccessDescriptors retrieves access descriptors belonging to an extension that matches the query.
 func (es *ExtensionStore) GetExtensionAccessDescriptors(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.ExtensionAccessDescriptor, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionStore.GetExtensionAccessDescriptors")
+	defer tracing.End(span, &err)
+
 	result = make([]*domain.ExtensionAccessDescriptor, 0)
 
-	for _, extension := range es.ListExtensions(ctx, query) {
+	extensions, err := es.listExtensions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, extension := range extensions {
 		result = append(result, extension.GetAccessDescriptors()...)
 	}
 	return result, nil
@@ -0,0 +1,69 @@
+package badger
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+	"github.com/timshannon/badgerhold/v3"
+)
+
+// ExtensionUsageStore is a wrapper around a badgerhold.Store that implements the domain.ExtensionUsageStore interface.
+type ExtensionUsageStore struct {
+	store *badgerhold.Store
+}
+
+// NewExtensionUsageStore creates a new ExtensionUsageStore.
+func NewExtensionUsageStore(store *badgerhold.Store) *ExtensionUsageStore {
+	return &ExtensionUsageStore{store: store}
+}
+
+// AddUsage adds new usage records
+func (us *ExtensionUsageStore) AddUsage(ctx context.Context, usage []*domain.ExtensionUsage) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionUsageStore.AddUsage")
+	defer tracing.End(span, &err)
+
+	for _, u := range usage {
+		if err := us.store.Insert(badgerhold.NextSequence(), newExtensionUsageRecord(u)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleaseUsage marks all active usage records of a workflow as released
+func (us *ExtensionUsageStore) ReleaseUsage(ctx context.Context, workflowName string, released time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionUsageStore.ReleaseUsage")
+	defer tracing.End(span, &err)
+
+	return us.store.UpdateMatching(&extensionUsageRecord{}, badgerhold.Where("Workflow").Eq(workflowName),
+		func(record interface{}) error {
+			r := record.(*extensionUsageRecord)
+			if r.toDomain().Active() {
+				r.Released = released
+			}
+			return nil
+		})
+}
+
+// GetUsage retrieves the usage records that match a filter, ordered by the time when the bindings were made
+func (us *ExtensionUsageStore) GetUsage(ctx context.Context, filter *domain.ExtensionUsageFilter) (_ []*domain.ExtensionUsage, err error) {
+	ctx, span := tracing.Start(ctx, "badger.ExtensionUsageStore.GetUsage")
+	defer tracing.End(span, &err)
+
+	records := []*extensionUsageRecord{}
+	if err := us.store.Find(&records, nil); err != nil {
+		return nil, err
+	}
+
+	result := []*domain.ExtensionUsage{}
+	for _, r := range records {
+		if u := r.toDomain(); filter.Matches(u) {
+			result = append(result, u)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Bound.Before(result[j].Bound) })
+	return result, nil
+}
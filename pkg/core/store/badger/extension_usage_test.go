@@ -0,0 +1,37 @@
+package badger
+
+import (
+	"os"
+	"testing"
+
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/storetest"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func TestExtensionUsageStore(t *testing.T) {
+	storetest.TestExtensionUsageStore(t, func(t *testing.T) (domain.ExtensionUsageStore, func()) {
+		return newExtensionUsageStore(t)
+	})
+}
+
+func newExtensionUsageStore(t *testing.T) (*ExtensionUsageStore, func()) {
+	t.Helper()
+
+	dir := tmpDir(t)
+	opt := badgerhold.DefaultOptions
+	opt.Logger = nil
+	opt.Dir = dir
+	opt.ValueDir = dir
+
+	store, err := badgerhold.Open(opt)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	return NewExtensionUsageStore(store), func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
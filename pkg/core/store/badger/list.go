@@ -0,0 +1,48 @@
+package badger
+
+import (
+	"reflect"
+
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// findPage runs a query that retrieves the records in a list window, ordered by the window sort fields,
+// into the slice pointed to by result. It returns the continue token for the next page.
+func findPage(store *badgerhold.Store, result interface{}, query *badgerhold.Query, window *domain.ListWindow) (string, error) {
+	if window.After != nil {
+		// only the records following the last one of the previous page are part of the window
+		follows := func(ra *badgerhold.RecordAccess) (bool, error) {
+			return window.Follows(ra.Record()), nil
+		}
+		if query.IsEmpty() {
+			query = badgerhold.Where(window.Fields[0]).MatchFunc(follows)
+		} else {
+			query = query.And(window.Fields[0]).MatchFunc(follows)
+		}
+	}
+	query = query.SortBy(window.Fields...)
+	if window.Descending {
+		query = query.Reverse()
+	}
+	if window.Limit > 0 {
+		// fetch an extra record to find out if there are more pages
+		query = query.Limit(window.Limit + 1)
+	}
+
+	err := store.Find(result, query)
+	if err != nil {
+		return "", err
+	}
+
+	v := reflect.ValueOf(result).Elem()
+	more := window.Limit > 0 && v.Len() > window.Limit
+	if more {
+		v.Set(v.Slice(0, window.Limit))
+	}
+	if v.Len() == 0 {
+		return "", nil
+	}
+	return window.Next(v.Index(v.Len()-1).Interface(), more), nil
+}
@@ -0,0 +1,165 @@
+package badger
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+)
+
+// SchemaVersion is the schema version of the records written by the stores. It is the version of the last
+// migration, which must be added whenever the layout of the records changes.
+const SchemaVersion = 2
+
+// migrations are the schema migrations, ordered by version
+var migrations = []migration{
+	{
+		version:     1,
+		description: "store workflows, extensions and applications as versioned records",
+		migrate:     migrateToRecords,
+	},
+	{
+		version:     2,
+		description: "store extension usage, notification targets and notification deliveries as versioned records",
+		migrate:     migrateUsageAndNotificationsToRecords,
+	},
+}
+
+// migrateToRecords moves the workflows, extensions and applications, stored as the domain structs under the
+// names of their types, to the versioned records. The records use the same field names, so the stored domain
+// structs decode into them.
+func migrateToRecords(store *badgerhold.Store, tx *badger.Txn) error {
+	// badgerhold prefixes the keys with the names of the types, which the local types repeat
+	type Workflow workflowRecord
+	type Application applicationRecord
+
+	workflows := []*Workflow{}
+	if err := store.TxFind(tx, &workflows, nil); err != nil {
+		return err
+	}
+	for _, w := range workflows {
+		r := (*workflowRecord)(w)
+		r.SchemaVersion = 1
+		if err := moveRecord(store, tx, r.Name, Workflow{}, r); err != nil {
+			return err
+		}
+	}
+
+	// the keys of the extension usage records also start with the prefix of the extension keys, so the
+	// extensions cannot be queried by type
+	keys, extensions, err := legacyExtensions(tx)
+	if err != nil {
+		return err
+	}
+	for i, r := range extensions {
+		r.SchemaVersion = 1
+		if err := tx.Delete(keys[i]); err != nil {
+			return err
+		}
+		if err := store.TxUpsert(tx, r.ID, r); err != nil {
+			return err
+		}
+	}
+
+	applications := []*Application{}
+	if err := store.TxFind(tx, &applications, nil); err != nil {
+		return err
+	}
+	for _, a := range applications {
+		r := (*applicationRecord)(a)
+		r.SchemaVersion = 1
+		if err := moveRecord(store, tx, r.Name, Application{}, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateUsageAndNotificationsToRecords moves the extension usage records, the notification targets and the
+// notification deliveries, stored as the domain structs under the names of their types, to the versioned
+// records. The extension usage records and the deliveries are keyed by sequence numbers, which are issued
+// again by the sequences of the record types, in the order of the stored entries, so the deliveries get new
+// IDs but keep their order.
+func migrateUsageAndNotificationsToRecords(store *badgerhold.Store, tx *badger.Txn) error {
+	// badgerhold prefixes the keys with the names of the types, which the local types repeat
+	type ExtensionUsage extensionUsageRecord
+	type NotificationTarget notificationTargetRecord
+	type NotificationDelivery record.NotificationDelivery
+
+	usage := []*ExtensionUsage{}
+	if err := store.TxFind(tx, &usage, nil); err != nil {
+		return err
+	}
+	if err := store.TxDeleteMatching(tx, &ExtensionUsage{}, nil); err != nil {
+		return err
+	}
+	for _, u := range usage {
+		r := (*extensionUsageRecord)(u)
+		r.SchemaVersion = 1
+		if err := store.TxInsert(tx, badgerhold.NextSequence(), r); err != nil {
+			return err
+		}
+	}
+
+	targets := []*NotificationTarget{}
+	if err := store.TxFind(tx, &targets, nil); err != nil {
+		return err
+	}
+	for _, t := range targets {
+		r := (*notificationTargetRecord)(t)
+		r.SchemaVersion = 1
+		if err := moveRecord(store, tx, r.Name, NotificationTarget{}, r); err != nil {
+			return err
+		}
+	}
+
+	deliveries := []*NotificationDelivery{}
+	if err := store.TxFind(tx, &deliveries, nil); err != nil {
+		return err
+	}
+	if err := store.TxDeleteMatching(tx, &NotificationDelivery{}, nil); err != nil {
+		return err
+	}
+	for _, d := range deliveries {
+		r := &notificationDeliveryRecord{NotificationDelivery: record.NotificationDelivery(*d)}
+		r.SchemaVersion = 1
+		if err := store.TxInsert(tx, badgerhold.NextSequence(), r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveRecord replaces the entry stored under a key for a type with a record of another type
+func moveRecord(store *badgerhold.Store, tx *badger.Txn, key string, from, to interface{}) error {
+	if err := store.TxDelete(tx, key, from); err != nil {
+		return err
+	}
+	return store.TxUpsert(tx, key, to)
+}
+
+// legacyExtensions returns the keys of the extensions stored as domain structs, and the extensions decoded
+// as records
+func legacyExtensions(tx *badger.Txn) (keys [][]byte, extensions []*extensionRecord, err error) {
+	prefix, usagePrefix := []byte("bh_Extension"), []byte("bh_ExtensionUsage")
+	it := tx.NewIterator(badger.IteratorOptions{PrefetchValues: true, Prefix: prefix})
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		if bytes.HasPrefix(item.Key(), usagePrefix) {
+			continue
+		}
+		r := &extensionRecord{}
+		err := item.Value(func(v []byte) error {
+			return badgerhold.DefaultDecode(v, r)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, item.KeyCopy(nil))
+		extensions = append(extensions, r)
+	}
+	return keys, extensions, nil
+}
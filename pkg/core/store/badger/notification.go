@@ -0,0 +1,143 @@
+package badger
+
+import (
+	"context"
+
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// NotificationStore is a wrapper around a badgerhold.Store that implements the domain.NotificationStore interface.
+type NotificationStore struct {
+	store *badgerhold.Store
+}
+
+// NewNotificationStore creates a new NotificationStore.
+func NewNotificationStore(store *badgerhold.Store) *NotificationStore {
+	return &NotificationStore{store: store}
+}
+
+// AddTarget adds a notification target to the store
+func (ns *NotificationStore) AddTarget(ctx context.Context, target *domain.NotificationTarget) (_ *domain.NotificationTarget, err error) {
+	ctx, span := tracing.Start(ctx, "badger.NotificationStore.AddTarget")
+	defer tracing.End(span, &err)
+
+	err = ns.store.Insert(target.Name, newNotificationTargetRecord(target))
+	if err != nil {
+		if err == badgerhold.ErrKeyExists {
+			return nil, domain.ErrNotificationTargetExists
+		}
+		return nil, err
+	}
+	return target, nil
+}
+
+// GetTarget retrieves a notification target from the store
+func (ns *NotificationStore) GetTarget(ctx context.Context, name string) (_ *domain.NotificationTarget, err error) {
+	ctx, span := tracing.Start(ctx, "badger.NotificationStore.GetTarget")
+	defer tracing.End(span, &err)
+
+	r := notificationTargetRecord{}
+	err = ns.store.Get(name, &r)
+	if err != nil {
+		if err == badgerhold.ErrNotFound {
+			return nil, domain.ErrNotificationTargetNotFound
+		}
+		return nil, err
+	}
+	return r.toDomain(), nil
+}
+
+// GetTargets returns the page of notification targets, filtered by workflow and project, selected by the
+// list options
+func (ns *NotificationStore) GetTargets(ctx context.Context, workflow, project string,
+	opts *domain.ListOptions) (_ []*domain.NotificationTarget, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "badger.NotificationStore.GetTargets")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.NotificationSortFields, domain.NotificationDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := &badgerhold.Query{}
+	if workflow != "" && project != "" {
+		query = badgerhold.Where("Workflow").Eq(workflow).And("Project").Eq(project)
+	} else if workflow != "" {
+		query = badgerhold.Where("Workflow").Eq(workflow)
+	} else if project != "" {
+		query = badgerhold.Where("Project").Eq(project)
+	}
+
+	records := []*notificationTargetRecord{}
+	next, err := findPage(ns.store, &records, query, window)
+	if err != nil {
+		return nil, "", err
+	}
+	result := make([]*domain.NotificationTarget, len(records))
+	for i, r := range records {
+		result[i] = r.toDomain()
+	}
+	return result, next, nil
+}
+
+// DeleteTarget deletes a notification target and its delivery log from the store
+func (ns *NotificationStore) DeleteTarget(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.NotificationStore.DeleteTarget")
+	defer tracing.End(span, &err)
+
+	err = ns.store.Delete(name, notificationTargetRecord{})
+	if err != nil {
+		if err == badgerhold.ErrNotFound {
+			return domain.ErrNotificationTargetNotFound
+		}
+		return err
+	}
+	return ns.store.DeleteMatching(&notificationDeliveryRecord{}, badgerhold.Where("Target").Eq(name))
+}
+
+// AddDelivery adds a delivery to the delivery log and assigns its ID
+func (ns *NotificationStore) AddDelivery(ctx context.Context, delivery *domain.NotificationDelivery) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.NotificationStore.AddDelivery")
+	defer tracing.End(span, &err)
+
+	r := newNotificationDeliveryRecord(delivery)
+	if err := ns.store.Insert(badgerhold.NextSequence(), r); err != nil {
+		return err
+	}
+	delivery.ID = r.ID
+	return nil
+}
+
+// UpdateDelivery replaces a delivery in the delivery log
+func (ns *NotificationStore) UpdateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.NotificationStore.UpdateDelivery")
+	defer tracing.End(span, &err)
+
+	return ns.store.Update(delivery.ID, newNotificationDeliveryRecord(delivery))
+}
+
+// GetDeliveries returns the page of deliveries made to a notification target selected by the list options
+func (ns *NotificationStore) GetDeliveries(ctx context.Context, target string,
+	opts *domain.ListOptions) (_ []*domain.NotificationDelivery, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "badger.NotificationStore.GetDeliveries")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.NotificationDeliverySortFields, domain.NotificationDeliveryDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	records := []*notificationDeliveryRecord{}
+	next, err := findPage(ns.store, &records, badgerhold.Where("Target").Eq(target), window)
+	if err != nil {
+		return nil, "", err
+	}
+	result := make([]*domain.NotificationDelivery, len(records))
+	for i, r := range records {
+		result[i] = r.toDomain()
+	}
+	return result, next, nil
+}
@@ -0,0 +1,95 @@
+package badger
+
+import (
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// The records are stored under key prefixes named after the types below, which are distinct from the
+// prefixes of the domain structs stored before the records were introduced.
+type (
+	workflowRecord           record.Workflow
+	extensionRecord          record.Extension
+	applicationRecord        record.Application
+	extensionUsageRecord     record.ExtensionUsage
+	notificationTargetRecord record.NotificationTarget
+)
+
+// notificationDeliveryRecord is the record of a notification delivery, along with the ID of the delivery,
+// which is the key it is stored under
+type notificationDeliveryRecord struct {
+	ID uint64 `badgerhold:"key"`
+	record.NotificationDelivery
+}
+
+func newWorkflowRecord(w *domain.Workflow) *workflowRecord {
+	return (*workflowRecord)(record.NewWorkflow(w))
+}
+
+func (r *workflowRecord) toDomain() *domain.Workflow {
+	return (*record.Workflow)(r).ToDomain()
+}
+
+func workflowsToDomain(records []*workflowRecord) []*domain.Workflow {
+	workflows := make([]*domain.Workflow, len(records))
+	for i, r := range records {
+		workflows[i] = r.toDomain()
+	}
+	return workflows
+}
+
+func newExtensionRecord(e *domain.Extension) *extensionRecord {
+	return (*extensionRecord)(record.NewExtension(e))
+}
+
+func (r *extensionRecord) toDomain() *domain.Extension {
+	return (*record.Extension)(r).ToDomain()
+}
+
+func extensionsToDomain(records []*extensionRecord) []*domain.Extension {
+	extensions := make([]*domain.Extension, len(records))
+	for i, r := range records {
+		extensions[i] = r.toDomain()
+	}
+	return extensions
+}
+
+func newApplicationRecord(a *domain.Application) *applicationRecord {
+	return (*applicationRecord)(record.NewApplication(a))
+}
+
+func (r *applicationRecord) toDomain() *domain.Application {
+	return (*record.Application)(r).ToDomain()
+}
+
+func applicationsToDomain(records []*applicationRecord) []*domain.Application {
+	applications := make([]*domain.Application, len(records))
+	for i, r := range records {
+		applications[i] = r.toDomain()
+	}
+	return applications
+}
+
+func newExtensionUsageRecord(u *domain.ExtensionUsage) *extensionUsageRecord {
+	return (*extensionUsageRecord)(record.NewExtensionUsage(u))
+}
+
+func (r *extensionUsageRecord) toDomain() *domain.ExtensionUsage {
+	return (*record.ExtensionUsage)(r).ToDomain()
+}
+
+func newNotificationTargetRecord(t *domain.NotificationTarget) *notificationTargetRecord {
+	return (*notificationTargetRecord)(record.NewNotificationTarget(t))
+}
+
+func (r *notificationTargetRecord) toDomain() *domain.NotificationTarget {
+	return (*record.NotificationTarget)(r).ToDomain()
+}
+
+func newNotificationDeliveryRecord(d *domain.NotificationDelivery) *notificationDeliveryRecord {
+	return &notificationDeliveryRecord{ID: d.ID, NotificationDelivery: *record.NewNotificationDelivery(d)}
+}
+
+func (r *notificationDeliveryRecord) toDomain() *domain.NotificationDelivery {
+	return r.NotificationDelivery.ToDomain(r.ID)
+}
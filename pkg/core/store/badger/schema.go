@@ -0,0 +1,89 @@
+package badger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/timshannon/badgerhold/v3"
+)
+
+// schemaInfoKey is the key of the schemaInfo record
+const schemaInfoKey = "schema"
+
+// schemaInfo records the schema version of the database, which is the version of the last migration applied
+type schemaInfo struct {
+	Version  int
+	Migrated time.Time
+}
+
+// migration upgrades the records stored in the database to a new schema version. Migrations run in the
+// transaction that records the new schema version, so they are applied atomically.
+type migration struct {
+	version     int
+	description string
+	migrate     func(store *badgerhold.Store, tx *badger.Txn) error
+}
+
+// OpenStore opens the database and migrates it to the schema version of the records used by the stores.
+func OpenStore(logger *slog.Logger, options badgerhold.Options) (*badgerhold.Store, error) {
+	store, err := badgerhold.Open(options)
+	if err != nil {
+		return nil, err
+	}
+	if err := Migrate(context.Background(), logger, store); err != nil {
+		store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Migrate applies, in order, the migrations with a version higher than the schema version of the database.
+// It fails if the database was migrated by a newer server, which stores records this server cannot read.
+func Migrate(ctx context.Context, logger *slog.Logger, store *badgerhold.Store) error {
+	return runMigrations(ctx, logger, store, migrations)
+}
+
+func runMigrations(ctx context.Context, logger *slog.Logger, store *badgerhold.Store, migrations []migration) error {
+	current, err := schemaVersion(store)
+	if err != nil {
+		return err
+	}
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].version
+	}
+	if current > latest {
+		return fmt.Errorf("database schema version %d is newer than the supported version %d", current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		logger.InfoContext(ctx, "Migrating database schema", "from", current, "to", m.version, "migration", m.description)
+		err := store.Badger().Update(func(tx *badger.Txn) error {
+			if err := m.migrate(store, tx); err != nil {
+				return err
+			}
+			return store.TxUpsert(tx, schemaInfoKey, schemaInfo{Version: m.version, Migrated: time.Now().UTC()})
+		})
+		if err != nil {
+			return fmt.Errorf("migrating database schema to version %d: %w", m.version, err)
+		}
+		current = m.version
+	}
+	return nil
+}
+
+// schemaVersion returns the schema version of the database, which is 0 if no migration was applied
+func schemaVersion(store *badgerhold.Store) (int, error) {
+	info := schemaInfo{}
+	err := store.Get(schemaInfoKey, &info)
+	if err == badgerhold.ErrNotFound {
+		return 0, nil
+	}
+	return info.Version, err
+}
@@ -0,0 +1,265 @@
+package badger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// openFixture opens a new database with the contents of a fixture from the testdata directory. The fixtures
+// are badger backups of databases written by former versions of the stores:
+//   - schema-v0.backup: a workflow assigned to a codeset and using an extension, the extension, an application
+//     and an extension usage record, stored as the domain structs, before the first migration
+//   - schema-v1.backup: the schema-v0 fixture migrated to version 1, with a notification target and two
+//     deliveries added, the extension usage and notifications still stored as the domain structs
+func openFixture(t *testing.T, name string) (*badgerhold.Store, func()) {
+	t.Helper()
+
+	dir := tmpDir(t)
+	opt := badgerhold.DefaultOptions
+	opt.Logger = nil
+	opt.Dir = dir
+	opt.ValueDir = dir
+
+	store, err := badgerhold.Open(opt)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	cleanup := func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		cleanup()
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer f.Close()
+	if err := store.Badger().Load(f, maxPendingWrites); err != nil {
+		cleanup()
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+	return store, cleanup
+}
+
+// assertSchemaV0Migrated checks that the records of the schema-v0 fixture are read by the stores
+func assertSchemaV0Migrated(t *testing.T, store *badgerhold.Store) {
+	t.Helper()
+	ctx := context.TODO()
+
+	version, err := schemaVersion(store)
+	assertNoError(t, err)
+	if version != SchemaVersion {
+		t.Errorf("got schema version %d, want %d", version, SchemaVersion)
+	}
+
+	workflows, _, err := NewWorkflowStore(store).GetWorkflows(ctx, nil, nil)
+	assertNoError(t, err)
+	if len(workflows) != 1 {
+		t.Fatalf("got %d workflows, want 1", len(workflows))
+	}
+	wf := workflows[0]
+	if wf.Name != "mlflow-e2e" || len(wf.Inputs) != 2 || wf.Inputs[1].Default != "auto" || len(wf.Steps) != 1 {
+		t.Errorf("unexpected workflow: %+v", wf)
+	}
+	step := wf.Steps[0]
+	if step.Inputs[0].Codeset.Path != "/project" || step.Outputs[0].Image.Name != "registry/mlflow-env" ||
+		step.Resources.Limits["memory"] != "2Gi" {
+		t.Errorf("unexpected workflow step: %+v", step)
+	}
+	access := step.Extensions[0].ExtensionAccess
+	if access == nil || access.Extension.ID != "mlflow-0001" || access.Endpoint.Status.Health != domain.EEHHealthy ||
+		access.Credentials.Configuration["user"] != "fuseml" {
+		t.Errorf("unexpected extension access descriptor: %+v", access)
+	}
+	assignments := wf.GetCodesetAssignments(ctx)
+	if len(assignments) != 1 || assignments[0].Codeset.Name != "mlflow-app-01" || *assignments[0].WebhookID != 7 {
+		t.Errorf("unexpected codeset assignments: %+v", assignments)
+	}
+
+	ext, err := NewExtensionStore(store).GetExtension(ctx, "mlflow-0001")
+	assertNoError(t, err)
+	if ext != nil {
+		endpoint, err := ext.GetServiceEndpoint("mlflow-tracking", "http://mlflow")
+		assertNoError(t, err)
+		if ext.Version != "1.19.0" || endpoint == nil || endpoint.Type != domain.EETInternal {
+			t.Errorf("unexpected extension: %+v", ext)
+		}
+	}
+
+	app := NewApplicationStore(store).Find(ctx, "workspace-mlflow-app-01")
+	if app == nil || app.Type != "predictor" || len(app.K8sResources) != 1 || app.K8sResources[0].Kind != "InferenceService" {
+		t.Errorf("unexpected application: %+v", app)
+	}
+
+	usage, err := NewExtensionUsageStore(store).GetUsage(ctx, nil)
+	assertNoError(t, err)
+	if len(usage) != 1 || usage[0].ExtensionID != "mlflow-0001" {
+		t.Errorf("unexpected extension usage: %+v", usage)
+	}
+}
+
+// assertSchemaV1Migrated checks that the records of the schema-v1 fixture are read by the stores
+func assertSchemaV1Migrated(t *testing.T, store *badgerhold.Store) {
+	t.Helper()
+	ctx := context.TODO()
+
+	assertSchemaV0Migrated(t, store)
+
+	ns := NewNotificationStore(store)
+	target, err := ns.GetTarget(ctx, "nightly-failures")
+	assertNoError(t, err)
+	if target != nil && (target.Type != domain.WebhookTarget || target.Workflow != "mlflow-e2e" ||
+		len(target.Statuses) != 1 || target.Secret != "s3cr3t" || target.Created.IsZero()) {
+		t.Errorf("unexpected notification target: %+v", target)
+	}
+
+	deliveries, _, err := ns.GetDeliveries(ctx, "nightly-failures", &domain.ListOptions{Sort: "created"})
+	assertNoError(t, err)
+	if len(deliveries) != 2 {
+		t.Fatalf("got %d notification deliveries, want 2", len(deliveries))
+	}
+	if deliveries[0].Run != "mlflow-e2e-run0" || deliveries[0].Status != domain.DeliverySucceeded ||
+		deliveries[1].Run != "mlflow-e2e-run1" || deliveries[1].Status != domain.DeliveryFailed ||
+		deliveries[1].Error != "503 Service Unavailable" || deliveries[1].Attempts != 2 {
+		t.Errorf("unexpected notification deliveries: %+v, %+v", deliveries[0], deliveries[1])
+	}
+	if deliveries[0].ID >= deliveries[1].ID {
+		t.Errorf("the deliveries lost their order: IDs %d and %d", deliveries[0].ID, deliveries[1].ID)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("schema v0", func(t *testing.T) {
+		store, cleanup := openFixture(t, "schema-v0.backup")
+		defer cleanup()
+
+		assertNoError(t, Migrate(ctx, slog.Default(), store))
+		assertSchemaV0Migrated(t, store)
+
+		// the domain structs are removed
+		type Workflow workflowRecord
+		legacy := []*Workflow{}
+		assertNoError(t, store.Find(&legacy, nil))
+		if len(legacy) != 0 {
+			t.Errorf("got %d legacy workflows, want 0", len(legacy))
+		}
+
+		// migrating again does not change anything
+		assertNoError(t, Migrate(ctx, slog.Default(), store))
+		assertSchemaV0Migrated(t, store)
+	})
+
+	t.Run("schema v1", func(t *testing.T) {
+		store, cleanup := openFixture(t, "schema-v1.backup")
+		defer cleanup()
+
+		assertNoError(t, Migrate(ctx, slog.Default(), store))
+		assertSchemaV1Migrated(t, store)
+
+		// the domain structs are removed
+		type NotificationTarget notificationTargetRecord
+		type NotificationDelivery record.NotificationDelivery
+		type ExtensionUsage extensionUsageRecord
+		for name, legacy := range map[string]interface{}{"notification targets": &NotificationTarget{},
+			"notification deliveries": &NotificationDelivery{}, "extension usage records": &ExtensionUsage{}} {
+			count, err := store.Count(legacy, nil)
+			assertNoError(t, err)
+			if count != 0 {
+				t.Errorf("got %d legacy %s, want 0", count, name)
+			}
+		}
+
+		// new deliveries do not reuse the IDs of the migrated ones
+		ns := NewNotificationStore(store)
+		delivery := &domain.NotificationDelivery{Target: "nightly-failures", Created: time.Now()}
+		assertNoError(t, ns.AddDelivery(ctx, delivery))
+		deliveries, _, err := ns.GetDeliveries(ctx, "nightly-failures", nil)
+		assertNoError(t, err)
+		if len(deliveries) != 3 {
+			t.Errorf("got %d notification deliveries, want 3", len(deliveries))
+		}
+
+		// migrating again does not change anything
+		assertNoError(t, Migrate(ctx, slog.Default(), store))
+		assertNoError(t, ns.DeleteTarget(ctx, "nightly-failures"))
+		_, err = ns.GetTarget(ctx, "nightly-failures")
+		if err != domain.ErrNotificationTargetNotFound {
+			t.Errorf("got error %v, want %v", err, domain.ErrNotificationTargetNotFound)
+		}
+	})
+
+	t.Run("newer schema", func(t *testing.T) {
+		store, cleanup := openFixture(t, "schema-v0.backup")
+		defer cleanup()
+
+		assertNoError(t, store.Upsert(schemaInfoKey, schemaInfo{Version: SchemaVersion + 1}))
+		if err := Migrate(ctx, slog.Default(), store); err == nil {
+			t.Errorf("migrated a database with a newer schema version")
+		}
+	})
+
+	t.Run("failed migration", func(t *testing.T) {
+		store, cleanup := openFixture(t, "schema-v0.backup")
+		defer cleanup()
+
+		failed := errors.New("failed")
+		err := runMigrations(ctx, slog.Default(), store, append(migrations[:len(migrations):len(migrations)], migration{
+			version: SchemaVersion + 1, description: "failing", migrate: func(store *badgerhold.Store, tx *badger.Txn) error {
+				if err := store.TxDelete(tx, "mlflow-e2e", workflowRecord{}); err != nil {
+					return err
+				}
+				return failed
+			}}))
+		if !errors.Is(err, failed) {
+			t.Errorf("got error %v, want %v", err, failed)
+		}
+		// the failed migration is rolled back
+		assertSchemaV0Migrated(t, store)
+	})
+}
+
+func TestMigrations(t *testing.T) {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("migration %q has version %d, want %d", m.description, m.version, i+1)
+		}
+	}
+	if len(migrations) != SchemaVersion {
+		t.Errorf("the last migration has version %d, want the schema version %d", len(migrations), SchemaVersion)
+	}
+}
+
+func TestOpenStore(t *testing.T) {
+	dir := tmpDir(t)
+	defer os.RemoveAll(dir)
+	opt := badgerhold.DefaultOptions
+	opt.Logger = nil
+	opt.Dir = dir
+	opt.ValueDir = dir
+
+	store, err := OpenStore(slog.Default(), opt)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	version, err := schemaVersion(store)
+	assertNoError(t, err)
+	if version != SchemaVersion {
+		t.Errorf("got schema version %d, want %d", version, SchemaVersion)
+	}
+}
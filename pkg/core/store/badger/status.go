@@ -0,0 +1,47 @@
+package badger
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/timshannon/badgerhold/v3"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// badgerModule is the module providing the badger database, used to report its version
+const badgerModule = "github.com/dgraph-io/badger/v3"
+
+// StatusChecker reports whether the badger database backing the stores can be used. It implements the
+// domain.DependencyChecker interface.
+type StatusChecker struct {
+	store *badgerhold.Store
+}
+
+// NewStatusChecker creates a new StatusChecker.
+func NewStatusChecker(store *badgerhold.Store) *StatusChecker {
+	return &StatusChecker{store: store}
+}
+
+// CheckDependencies checks that the badger database is open and can be read from.
+func (sc *StatusChecker) CheckDependencies(ctx context.Context) []*domain.DependencyStatus {
+	db := sc.store.Badger()
+	status := &domain.DependencyStatus{Name: "badger", Endpoint: db.Opts().Dir, Version: moduleVersion(badgerModule)}
+	if err := db.View(func(txn *badger.Txn) error { return nil }); err != nil {
+		status.Error = err.Error()
+	}
+	return []*domain.DependencyStatus{status}
+}
+
+// moduleVersion returns the version of a module the server was built with, if known
+func moduleVersion(path string) string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == path {
+				return dep.Version
+			}
+		}
+	}
+	return ""
+}
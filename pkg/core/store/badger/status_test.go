@@ -0,0 +1,35 @@
+package badger
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/timshannon/badgerhold/v3"
+)
+
+func TestStatusChecker(t *testing.T) {
+	dir := tmpDir(t)
+	defer os.RemoveAll(dir)
+	opt := badgerhold.DefaultOptions
+	opt.Logger = nil
+	opt.Dir = dir
+	opt.ValueDir = dir
+
+	store, err := badgerhold.Open(opt)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	checker := NewStatusChecker(store)
+
+	got := checker.CheckDependencies(context.TODO())
+	if len(got) != 1 || got[0].Name != "badger" || got[0].Endpoint != dir || !got[0].Available() {
+		t.Errorf("Unexpected status of open store: %+v", got[0])
+	}
+
+	store.Close()
+	got = checker.CheckDependencies(context.TODO())
+	if got[0].Available() {
+		t.Errorf("Closed store reported as available")
+	}
+}
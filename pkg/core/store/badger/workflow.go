@@ -2,14 +2,19 @@ package badger
 
 import (
 	"context"
+	"sync"
 
+	"github.com/dgraph-io/badger/v3"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 	"github.com/timshannon/badgerhold/v3"
 )
 
 // WorkflowStore is a wrapper around a badgerhold.Store that implements the domain.WorkflowStore interface.
 type WorkflowStore struct {
 	store *badgerhold.Store
+	// mu serializes the changes made to the codeset assignments of the workflows
+	mu sync.Mutex
 }
 
 // NewWorkflowStore creates a new WorkflowStore.
@@ -18,47 +23,71 @@ func NewWorkflowStore(store *badgerhold.Store) *WorkflowStore {
 }
 
 // GetWorkflow returns a workflow identified by its name.
-func (ws *WorkflowStore) GetWorkflow(ctx context.Context, name string) (*domain.Workflow, error) {
-	wf := &domain.Workflow{}
-	err := ws.store.Get(name, wf)
+func (ws *WorkflowStore) GetWorkflow(ctx context.Context, name string) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.GetWorkflow")
+	defer tracing.End(span, &err)
+
+	return ws.getWorkflow(name)
+}
+
+// GetWorkflows returns the page of workflows selected by the list options, or the one that matches a given name.
+func (ws *WorkflowStore) GetWorkflows(ctx context.Context, name *string, opts *domain.ListOptions) (_ []*domain.Workflow, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.GetWorkflows")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.WorkflowSortFields, domain.WorkflowDefaultSort)
 	if err != nil {
-		return nil, domain.ErrWorkflowNotFound
+		return nil, "", err
 	}
-	return wf, nil
-}
 
-// GetWorkflows returns all workflows or the one that matches a given name.
-func (ws *WorkflowStore) GetWorkflows(ctx context.Context, name *string) []*domain.Workflow {
 	result := []*domain.Workflow{}
 	if name != nil {
-		wf := &domain.Workflow{}
-		err := ws.store.Get(name, wf)
+		wf, err := ws.getWorkflow(*name)
 		if err == nil {
 			result = append(result, wf)
 		}
-		return result
+		return result, "", nil
 	}
 
-	err := ws.store.Find(&result, nil)
+	records := []*workflowRecord{}
+	next, err := findPage(ws.store, &records, &badgerhold.Query{}, window)
 	if err != nil {
-		return result
+		return nil, "", err
 	}
-	return result
+	return workflowsToDomain(records), next, nil
 }
 
 // AddWorkflow adds a new workflow based on the Workflow structure provided as argument.
-func (ws *WorkflowStore) AddWorkflow(ctx context.Context, w *domain.Workflow) (*domain.Workflow, error) {
-	err := ws.store.Insert(w.Name, w)
+func (ws *WorkflowStore) AddWorkflow(ctx context.Context, w *domain.Workflow) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.AddWorkflow")
+	defer tracing.End(span, &err)
+
+	err = ws.store.Insert(w.Name, newWorkflowRecord(w))
 	if err != nil {
 		return nil, domain.ErrWorkflowExists
 	}
 	return w, nil
 }
 
+// UpdateWorkflow replaces the definition of a workflow in the store, keeping its stored codeset assignments.
+func (ws *WorkflowStore) UpdateWorkflow(ctx context.Context, w *domain.Workflow) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.UpdateWorkflow")
+	defer tracing.End(span, &err)
+
+	return ws.updateWorkflow(w.Name, func(wf *domain.Workflow) error {
+		assignedTo := wf.AssignedTo
+		*wf = *w
+		wf.AssignedTo = assignedTo
+		return nil
+	})
+}
+
 // DeleteWorkflow deletes the workflow from the store.
-func (ws *WorkflowStore) DeleteWorkflow(ctx context.Context, name string) error {
-	wf := domain.Workflow{}
-	err := ws.store.Get(name, &wf)
+func (ws *WorkflowStore) DeleteWorkflow(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.DeleteWorkflow")
+	defer tracing.End(span, &err)
+
+	wf, err := ws.getWorkflow(name)
 	if err != nil {
 		return nil
 	}
@@ -66,15 +95,17 @@ func (ws *WorkflowStore) DeleteWorkflow(ctx context.Context, name string) error
 		return domain.ErrCannotDeleteAssignedWorkflow
 	}
 
-	return ws.store.Delete(name, wf)
+	return ws.store.Delete(name, workflowRecord{})
 }
 
 // GetCodesetAssignment returns a list of codesets assigned to the specified workflow.
-func (ws *WorkflowStore) GetCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset) (*domain.CodesetAssignment, error) {
-	wf := domain.Workflow{}
-	err := ws.store.Get(workflowName, &wf)
+func (ws *WorkflowStore) GetCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset) (_ *domain.CodesetAssignment, err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.GetCodesetAssignment")
+	defer tracing.End(span, &err)
+
+	wf, err := ws.getWorkflow(workflowName)
 	if err != nil {
-		return nil, domain.ErrWorkflowNotFound
+		return nil, err
 	}
 
 	return wf.GetCodesetAssignment(ctx, codeset)
@@ -82,8 +113,10 @@ func (ws *WorkflowStore) GetCodesetAssignment(ctx context.Context, workflowName
 
 // GetCodesetAssignments returns a AssignedCodeset for the Workflow and Codeset.
 func (ws *WorkflowStore) GetCodesetAssignments(ctx context.Context, workflowName string) []*domain.CodesetAssignment {
-	wf := domain.Workflow{}
-	err := ws.store.Get(workflowName, &wf)
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.GetCodesetAssignments")
+	defer span.End()
+
+	wf, err := ws.getWorkflow(workflowName)
 	if err == nil {
 		return wf.GetCodesetAssignments(ctx)
 	}
@@ -93,21 +126,25 @@ func (ws *WorkflowStore) GetCodesetAssignments(ctx context.Context, workflowName
 
 // GetAllCodesetAssignments returns a map of workflows and its assigned codesets.
 func (ws *WorkflowStore) GetAllCodesetAssignments(ctx context.Context, workflowName *string) (result map[string][]*domain.CodesetAssignment) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.GetAllCodesetAssignments")
+	defer span.End()
+
 	result = make(map[string][]*domain.CodesetAssignment)
 	if workflowName != nil {
-		wf := domain.Workflow{}
-		err := ws.store.Get(*workflowName, &wf)
-		assignments := wf.GetCodesetAssignments(ctx)
-		if err != nil || len(assignments) == 0 {
+		wf, err := ws.getWorkflow(*workflowName)
+		if err != nil {
 			return
 		}
-		result[*workflowName] = assignments
+		if assignments := wf.GetCodesetAssignments(ctx); len(assignments) > 0 {
+			result[*workflowName] = assignments
+		}
 		return
 	}
 
-	workflows := []*domain.Workflow{}
-	ws.store.Find(&workflows, nil)
-	for _, wf := range workflows {
+	records := []*workflowRecord{}
+	ws.store.Find(&records, nil)
+	for _, r := range records {
+		wf := r.toDomain()
 		assignments := wf.GetCodesetAssignments(ctx)
 		if len(assignments) > 0 {
 			result[wf.Name] = assignments
@@ -118,42 +155,81 @@ func (ws *WorkflowStore) GetAllCodesetAssignments(ctx context.Context, workflowN
 
 // AddCodesetAssignment adds a codeset to the list of assigned codesets of a workflow if it does not already exists.
 func (ws *WorkflowStore) AddCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset,
-	webhookID *int64) ([]*domain.CodesetAssignment, error) {
-	wf := domain.Workflow{}
-	err := ws.store.Get(workflowName, &wf)
-	if err != nil {
-		return nil, domain.ErrWorkflowNotFound
-	}
-
-	err = wf.AssignToCodeset(ctx, codeset, webhookID)
-	if err != nil {
-		return nil, err
-	}
+	webhookID *int64) (_ []*domain.CodesetAssignment, err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.AddCodesetAssignment")
+	defer tracing.End(span, &err)
 
-	err = ws.store.Update(workflowName, &wf)
+	wf, err := ws.updateWorkflow(workflowName, func(wf *domain.Workflow) error {
+		return wf.AssignToCodeset(ctx, codeset, webhookID)
+	})
 	if err != nil {
 		return nil, err
 	}
 	return wf.GetCodesetAssignments(ctx), nil
 }
 
+// UpdateCodesetAssignmentWebhook changes the webhook of a codeset assignment of a workflow.
+func (ws *WorkflowStore) UpdateCodesetAssignmentWebhook(ctx context.Context, workflowName string, codeset *domain.Codeset,
+	webhookID *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.UpdateCodesetAssignmentWebhook")
+	defer tracing.End(span, &err)
+
+	_, err = ws.updateWorkflow(workflowName, func(wf *domain.Workflow) error {
+		assignment, err := wf.GetCodesetAssignment(ctx, codeset)
+		if err != nil {
+			return err
+		}
+		assignment.WebhookID = webhookID
+		return nil
+	})
+	return err
+}
+
 // DeleteCodesetAssignment deletes a codeset from the list of assigned codesets of a workflow if it exists.
-func (ws *WorkflowStore) DeleteCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset) ([]*domain.CodesetAssignment, error) {
-	wf := domain.Workflow{}
-	err := ws.store.Get(workflowName, &wf)
-	if err != nil {
-		return nil, domain.ErrWorkflowNotFound
-	}
+func (ws *WorkflowStore) DeleteCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset) (_ []*domain.CodesetAssignment, err error) {
+	ctx, span := tracing.Start(ctx, "badger.WorkflowStore.DeleteCodesetAssignment")
+	defer tracing.End(span, &err)
 
-	err = wf.UnassignFromCodeset(ctx, codeset)
+	wf, err := ws.updateWorkflow(workflowName, func(wf *domain.Workflow) error {
+		return wf.UnassignFromCodeset(ctx, codeset)
+	})
 	if err != nil {
 		return nil, err
 	}
+	return wf.GetCodesetAssignments(ctx), nil
+}
 
-	err = ws.store.Update(workflowName, &wf)
+// updateWorkflow changes a stored workflow in a transaction, holding the store lock, and returns the changed
+// workflow
+func (ws *WorkflowStore) updateWorkflow(name string, change func(wf *domain.Workflow) error) (wf *domain.Workflow, err error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	err = ws.store.Badger().Update(func(tx *badger.Txn) error {
+		r := workflowRecord{}
+		if err := ws.store.TxGet(tx, name, &r); err != nil {
+			if err == badgerhold.ErrNotFound {
+				return domain.ErrWorkflowNotFound
+			}
+			return err
+		}
+		wf = r.toDomain()
+		if err := change(wf); err != nil {
+			return err
+		}
+		return ws.store.TxUpdate(tx, name, newWorkflowRecord(wf))
+	})
 	if err != nil {
 		return nil, err
 	}
+	return wf, nil
+}
 
-	return wf.GetCodesetAssignments(ctx), nil
+// getWorkflow returns the workflow stored under a name
+func (ws *WorkflowStore) getWorkflow(name string) (*domain.Workflow, error) {
+	r := workflowRecord{}
+	if err := ws.store.Get(name, &r); err != nil {
+		return nil, domain.ErrWorkflowNotFound
+	}
+	return r.toDomain(), nil
 }
@@ -0,0 +1,54 @@
+package record
+
+import (
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// Application is the stored form of a domain.Application.
+type Application struct {
+	SchemaVersion int
+	Name          string
+	Type          string
+	Description   string
+	URL           string
+	Workflow      string
+	K8sResources  []*KubernetesResource
+	K8sNamespace  string
+}
+
+// KubernetesResource is the stored form of a domain.KubernetesResource.
+type KubernetesResource struct {
+	Name string
+	Kind string
+}
+
+// NewApplication returns the record of an application.
+func NewApplication(a *domain.Application) *Application {
+	return &Application{
+		SchemaVersion: SchemaVersion,
+		Name:          a.Name,
+		Type:          a.Type,
+		Description:   a.Description,
+		URL:           a.URL,
+		Workflow:      a.Workflow,
+		K8sResources: mapSlice(a.K8sResources, func(r *domain.KubernetesResource) *KubernetesResource {
+			return &KubernetesResource{Name: r.Name, Kind: r.Kind}
+		}),
+		K8sNamespace: a.K8sNamespace,
+	}
+}
+
+// ToDomain returns the application stored in the record.
+func (r *Application) ToDomain() *domain.Application {
+	return &domain.Application{
+		Name:        r.Name,
+		Type:        r.Type,
+		Description: r.Description,
+		URL:         r.URL,
+		Workflow:    r.Workflow,
+		K8sResources: mapSlice(r.K8sResources, func(r *KubernetesResource) *domain.KubernetesResource {
+			return &domain.KubernetesResource{Name: r.Name, Kind: r.Kind}
+		}),
+		K8sNamespace: r.K8sNamespace,
+	}
+}
@@ -0,0 +1,234 @@
+package record
+
+import (
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// Extension is the stored form of a domain.Extension. It is also embedded in the workflow records,
+// as part of the extension access descriptors resolved for the workflow steps.
+type Extension struct {
+	SchemaVersion int
+	ID            string
+	Product       string
+	Version       string
+	Description   string
+	Zone          string
+	Configuration map[string]string
+	Created       time.Time
+	Updated       time.Time
+	Services      map[string]*ExtensionService
+	Discovered    bool
+}
+
+// ExtensionService is the stored form of a domain.ExtensionService.
+type ExtensionService struct {
+	ID            string
+	Resource      string
+	Category      string
+	Description   string
+	AuthRequired  bool
+	Configuration map[string]string
+	Created       time.Time
+	Updated       time.Time
+	Endpoints     map[string]*ExtensionServiceEndpoint
+	Credentials   map[string]*ExtensionServiceCredentials
+}
+
+// ExtensionServiceEndpoint is the stored form of a domain.ExtensionServiceEndpoint.
+type ExtensionServiceEndpoint struct {
+	URL           string
+	Type          string
+	Configuration map[string]string
+	Created       time.Time
+	Updated       time.Time
+	Status        ExtensionServiceEndpointStatus
+}
+
+// ExtensionServiceEndpointStatus is the stored form of a domain.ExtensionServiceEndpointStatus.
+type ExtensionServiceEndpointStatus struct {
+	Health      string
+	Message     string
+	LastChecked time.Time
+	LastSeen    time.Time
+}
+
+// ExtensionServiceCredentials is the stored form of a domain.ExtensionServiceCredentials.
+type ExtensionServiceCredentials struct {
+	ID            string
+	Scope         string
+	Default       bool
+	Projects      []string
+	Users         []string
+	Configuration map[string]string
+	Created       time.Time
+	Updated       time.Time
+}
+
+// ExtensionAccessDescriptor is the stored form of a domain.ExtensionAccessDescriptor.
+type ExtensionAccessDescriptor struct {
+	Extension   Extension
+	Service     ExtensionService
+	Endpoint    ExtensionServiceEndpoint
+	Credentials *ExtensionServiceCredentials
+}
+
+// NewExtension returns the record of an extension.
+func NewExtension(e *domain.Extension) *Extension {
+	return &Extension{
+		SchemaVersion: SchemaVersion,
+		ID:            e.ID,
+		Product:       e.Product,
+		Version:       e.Version,
+		Description:   e.Description,
+		Zone:          e.Zone,
+		Configuration: e.Configuration,
+		Created:       e.Created,
+		Updated:       e.Updated,
+		Services:      mapValues(e.Services, NewExtensionService),
+		Discovered:    e.Discovered,
+	}
+}
+
+// NewExtensionService returns the record of an extension service.
+func NewExtensionService(s *domain.ExtensionService) *ExtensionService {
+	return &ExtensionService{
+		ID:            s.ID,
+		Resource:      s.Resource,
+		Category:      s.Category,
+		Description:   s.Description,
+		AuthRequired:  s.AuthRequired,
+		Configuration: s.Configuration,
+		Created:       s.Created,
+		Updated:       s.Updated,
+		Endpoints:     mapValues(s.Endpoints, NewExtensionServiceEndpoint),
+		Credentials:   mapValues(s.Credentials, NewExtensionServiceCredentials),
+	}
+}
+
+// NewExtensionServiceEndpoint returns the record of an extension endpoint.
+func NewExtensionServiceEndpoint(e *domain.ExtensionServiceEndpoint) *ExtensionServiceEndpoint {
+	return &ExtensionServiceEndpoint{
+		URL:           e.URL,
+		Type:          string(e.Type),
+		Configuration: e.Configuration,
+		Created:       e.Created,
+		Updated:       e.Updated,
+		Status: ExtensionServiceEndpointStatus{
+			Health:      string(e.Status.Health),
+			Message:     e.Status.Message,
+			LastChecked: e.Status.LastChecked,
+			LastSeen:    e.Status.LastSeen,
+		},
+	}
+}
+
+// NewExtensionServiceCredentials returns the record of a set of extension credentials.
+func NewExtensionServiceCredentials(c *domain.ExtensionServiceCredentials) *ExtensionServiceCredentials {
+	return &ExtensionServiceCredentials{
+		ID:            c.ID,
+		Scope:         string(c.Scope),
+		Default:       c.Default,
+		Projects:      c.Projects,
+		Users:         c.Users,
+		Configuration: c.Configuration,
+		Created:       c.Created,
+		Updated:       c.Updated,
+	}
+}
+
+// NewExtensionAccessDescriptor returns the record of an extension access descriptor.
+func NewExtensionAccessDescriptor(a *domain.ExtensionAccessDescriptor) *ExtensionAccessDescriptor {
+	if a == nil {
+		return nil
+	}
+	r := &ExtensionAccessDescriptor{
+		Extension: *NewExtension(&a.Extension),
+		Service:   *NewExtensionService(&a.Service),
+		Endpoint:  *NewExtensionServiceEndpoint(&a.Endpoint),
+	}
+	if a.Credentials != nil {
+		r.Credentials = NewExtensionServiceCredentials(a.Credentials)
+	}
+	return r
+}
+
+// ToDomain returns the extension stored in the record.
+func (r *Extension) ToDomain() *domain.Extension {
+	return &domain.Extension{
+		ID:            r.ID,
+		Product:       r.Product,
+		Version:       r.Version,
+		Description:   r.Description,
+		Zone:          r.Zone,
+		Configuration: r.Configuration,
+		Created:       r.Created,
+		Updated:       r.Updated,
+		Services:      mapValues(r.Services, (*ExtensionService).ToDomain),
+		Discovered:    r.Discovered,
+	}
+}
+
+// ToDomain returns the extension service stored in the record.
+func (r *ExtensionService) ToDomain() *domain.ExtensionService {
+	return &domain.ExtensionService{
+		ID:            r.ID,
+		Resource:      r.Resource,
+		Category:      r.Category,
+		Description:   r.Description,
+		AuthRequired:  r.AuthRequired,
+		Configuration: r.Configuration,
+		Created:       r.Created,
+		Updated:       r.Updated,
+		Endpoints:     mapValues(r.Endpoints, (*ExtensionServiceEndpoint).ToDomain),
+		Credentials:   mapValues(r.Credentials, (*ExtensionServiceCredentials).ToDomain),
+	}
+}
+
+// ToDomain returns the extension endpoint stored in the record.
+func (r *ExtensionServiceEndpoint) ToDomain() *domain.ExtensionServiceEndpoint {
+	return &domain.ExtensionServiceEndpoint{
+		URL:           r.URL,
+		Type:          domain.ExtensionServiceEndpointType(r.Type),
+		Configuration: r.Configuration,
+		Created:       r.Created,
+		Updated:       r.Updated,
+		Status: domain.ExtensionServiceEndpointStatus{
+			Health:      domain.ExtensionServiceEndpointHealth(r.Status.Health),
+			Message:     r.Status.Message,
+			LastChecked: r.Status.LastChecked,
+			LastSeen:    r.Status.LastSeen,
+		},
+	}
+}
+
+// ToDomain returns the set of extension credentials stored in the record.
+func (r *ExtensionServiceCredentials) ToDomain() *domain.ExtensionServiceCredentials {
+	return &domain.ExtensionServiceCredentials{
+		ID:            r.ID,
+		Scope:         domain.ExtensionServiceCredentialsScope(r.Scope),
+		Default:       r.Default,
+		Projects:      r.Projects,
+		Users:         r.Users,
+		Configuration: r.Configuration,
+		Created:       r.Created,
+		Updated:       r.Updated,
+	}
+}
+
+// ToDomain returns the extension access descriptor stored in the record.
+func (r *ExtensionAccessDescriptor) ToDomain() *domain.ExtensionAccessDescriptor {
+	if r == nil {
+		return nil
+	}
+	a := &domain.ExtensionAccessDescriptor{
+		Extension: *r.Extension.ToDomain(),
+		Service:   *r.Service.ToDomain(),
+		Endpoint:  *r.Endpoint.ToDomain(),
+	}
+	if r.Credentials != nil {
+		a.Credentials = r.Credentials.ToDomain()
+	}
+	return a
+}
@@ -0,0 +1,52 @@
+package record
+
+import (
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// ExtensionUsage is the stored form of a domain.ExtensionUsage.
+type ExtensionUsage struct {
+	SchemaVersion int
+	Workflow      string
+	Step          string
+	Requirement   string
+	ExtensionID   string
+	ServiceID     string
+	EndpointURL   string
+	CredentialsID string
+	Bound         time.Time
+	Released      time.Time
+}
+
+// NewExtensionUsage returns the record of an extension usage.
+func NewExtensionUsage(u *domain.ExtensionUsage) *ExtensionUsage {
+	return &ExtensionUsage{
+		SchemaVersion: SchemaVersion,
+		Workflow:      u.Workflow,
+		Step:          u.Step,
+		Requirement:   u.Requirement,
+		ExtensionID:   u.ExtensionID,
+		ServiceID:     u.ServiceID,
+		EndpointURL:   u.EndpointURL,
+		CredentialsID: u.CredentialsID,
+		Bound:         u.Bound,
+		Released:      u.Released,
+	}
+}
+
+// ToDomain returns the extension usage stored in the record.
+func (r *ExtensionUsage) ToDomain() *domain.ExtensionUsage {
+	return &domain.ExtensionUsage{
+		Workflow:      r.Workflow,
+		Step:          r.Step,
+		Requirement:   r.Requirement,
+		ExtensionID:   r.ExtensionID,
+		ServiceID:     r.ServiceID,
+		EndpointURL:   r.EndpointURL,
+		CredentialsID: r.CredentialsID,
+		Bound:         r.Bound,
+		Released:      r.Released,
+	}
+}
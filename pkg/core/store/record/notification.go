@@ -0,0 +1,105 @@
+package record
+
+import (
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// NotificationTarget is the stored form of a domain.NotificationTarget.
+type NotificationTarget struct {
+	SchemaVersion int
+	Name          string
+	Description   string
+	Type          string
+	Workflow      string
+	Project       string
+	Statuses      []string
+	URL           string
+	Secret        string
+	Recipients    []string
+	Template      string
+	Created       time.Time
+}
+
+// NewNotificationTarget returns the record of a notification target.
+func NewNotificationTarget(t *domain.NotificationTarget) *NotificationTarget {
+	return &NotificationTarget{
+		SchemaVersion: SchemaVersion,
+		Name:          t.Name,
+		Description:   t.Description,
+		Type:          string(t.Type),
+		Workflow:      t.Workflow,
+		Project:       t.Project,
+		Statuses:      t.Statuses,
+		URL:           t.URL,
+		Secret:        t.Secret,
+		Recipients:    t.Recipients,
+		Template:      t.Template,
+		Created:       t.Created,
+	}
+}
+
+// ToDomain returns the notification target stored in the record.
+func (r *NotificationTarget) ToDomain() *domain.NotificationTarget {
+	return &domain.NotificationTarget{
+		Name:        r.Name,
+		Description: r.Description,
+		Type:        domain.NotificationTargetType(r.Type),
+		Workflow:    r.Workflow,
+		Project:     r.Project,
+		Statuses:    r.Statuses,
+		URL:         r.URL,
+		Secret:      r.Secret,
+		Recipients:  r.Recipients,
+		Template:    r.Template,
+		Created:     r.Created,
+	}
+}
+
+// NotificationDelivery is the stored form of a domain.NotificationDelivery. The ID of the delivery is assigned
+// by the store and is not part of the record.
+type NotificationDelivery struct {
+	SchemaVersion int
+	Target        string
+	Workflow      string
+	Run           string
+	RunStatus     string
+	Status        string
+	Attempts      int
+	Error         string
+	Created       time.Time
+	Updated       time.Time
+}
+
+// NewNotificationDelivery returns the record of a notification delivery.
+func NewNotificationDelivery(d *domain.NotificationDelivery) *NotificationDelivery {
+	return &NotificationDelivery{
+		SchemaVersion: SchemaVersion,
+		Target:        d.Target,
+		Workflow:      d.Workflow,
+		Run:           d.Run,
+		RunStatus:     d.RunStatus,
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		Error:         d.Error,
+		Created:       d.Created,
+		Updated:       d.Updated,
+	}
+}
+
+// ToDomain returns the notification delivery stored in the record, with the given ID.
+func (r *NotificationDelivery) ToDomain(id uint64) *domain.NotificationDelivery {
+	return &domain.NotificationDelivery{
+		ID:        id,
+		Target:    r.Target,
+		Workflow:  r.Workflow,
+		Run:       r.Run,
+		RunStatus: r.RunStatus,
+		Status:    domain.NotificationDeliveryStatus(r.Status),
+		Attempts:  r.Attempts,
+		Error:     r.Error,
+		Created:   r.Created,
+		Updated:   r.Updated,
+	}
+}
@@ -0,0 +1,31 @@
+// Package record defines the stored forms of the domain types. The records are decoupled from the domain
+// types, so that the domain can change without breaking the data already stored. Records carry the version
+// of their layout, and changing the layout requires a new SchemaVersion and migrations in the stores.
+package record
+
+// SchemaVersion is the version of the layout of the records
+const SchemaVersion = 1
+
+// mapSlice converts the elements of a slice, keeping nil slices nil
+func mapSlice[S, D any](s []S, convert func(S) D) []D {
+	if s == nil {
+		return nil
+	}
+	d := make([]D, len(s))
+	for i, v := range s {
+		d[i] = convert(v)
+	}
+	return d
+}
+
+// mapValues converts the values of a map, keeping nil maps nil
+func mapValues[K comparable, S, D any](m map[K]S, convert func(S) D) map[K]D {
+	if m == nil {
+		return nil
+	}
+	d := make(map[K]D, len(m))
+	for k, v := range m {
+		d[k] = convert(v)
+	}
+	return d
+}
@@ -0,0 +1,163 @@
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// runnableArgTypes creates the domain types that can be used as runnable inputs and outputs, by type name
+var runnableArgTypes = map[string]func() interface{}{
+	"RunnableInputParameter":  func() interface{} { return &domain.RunnableInputParameter{} },
+	"RunnableOutputParameter": func() interface{} { return &domain.RunnableOutputParameter{} },
+	"RunnableInputArtifact":   func() interface{} { return &domain.RunnableInputArtifact{} },
+	"RunnableOutputArtifact":  func() interface{} { return &domain.RunnableOutputArtifact{} },
+	"RunnableInputCodeset":    func() interface{} { return &domain.RunnableInputCodeset{} },
+	"RunnableOutputCodeset":   func() interface{} { return &domain.RunnableOutputCodeset{} },
+	"RunnableInputModel":      func() interface{} { return &domain.RunnableInputModel{} },
+	"RunnableOutputModel":     func() interface{} { return &domain.RunnableOutputModel{} },
+	"RunnableInputDataset":    func() interface{} { return &domain.RunnableInputDataset{} },
+	"RunnableOutputDataset":   func() interface{} { return &domain.RunnableOutputDataset{} },
+	"RunnableInputRunnable":   func() interface{} { return &domain.RunnableInputRunnable{} },
+	"RunnableOutputRunnable":  func() interface{} { return &domain.RunnableOutputRunnable{} },
+}
+
+// Runnable is the stored form of a domain.Runnable.
+type Runnable struct {
+	SchemaVersion     int
+	ID                string
+	Created           time.Time
+	Description       string
+	Author            string
+	Source            string
+	Kind              string
+	Container         RunnableContainer
+	Inputs            map[string]*RunnableArg
+	Outputs           map[string]*RunnableArg
+	DefaultInputPath  string
+	DefaultOutputPath string
+	Labels            map[string]string
+}
+
+// RunnableContainer is the stored form of a domain.RunnableContainer.
+type RunnableContainer struct {
+	Image      string
+	LocalImage bool
+	Env        map[string]string
+	Entrypoint string
+	Args       []string
+}
+
+// RunnableArg is the stored form of a runnable input or output. Inputs and outputs have different domain
+// types, so the record holds the name of the type along with the JSON encoding of the value.
+type RunnableArg struct {
+	Type  string
+	Value json.RawMessage
+}
+
+// NewRunnable returns the record of a runnable. It fails if the runnable has inputs or outputs of
+// unknown types.
+func NewRunnable(r *domain.Runnable) (*Runnable, error) {
+	inputs, err := newRunnableArgs(r.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("runnable %q inputs: %w", r.ID, err)
+	}
+	outputs, err := newRunnableArgs(r.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("runnable %q outputs: %w", r.ID, err)
+	}
+	return &Runnable{
+		SchemaVersion: SchemaVersion,
+		ID:            r.ID,
+		Created:       r.Created,
+		Description:   r.Description,
+		Author:        r.Author,
+		Source:        r.Source,
+		Kind:          r.Kind,
+		Container: RunnableContainer{
+			Image:      r.Container.Image,
+			LocalImage: r.Container.LocalImage,
+			Env:        r.Container.Env,
+			Entrypoint: r.Container.Entrypoint,
+			Args:       r.Container.Args,
+		},
+		Inputs:            inputs,
+		Outputs:           outputs,
+		DefaultInputPath:  r.DefaultInputPath,
+		DefaultOutputPath: r.DefaultOutputPath,
+		Labels:            r.Labels,
+	}, nil
+}
+
+func newRunnableArgs(args map[string]interface{}) (map[string]*RunnableArg, error) {
+	if args == nil {
+		return nil, nil
+	}
+	records := make(map[string]*RunnableArg, len(args))
+	for name, arg := range args {
+		typeName := reflect.Indirect(reflect.ValueOf(arg)).Type().Name()
+		if _, ok := runnableArgTypes[typeName]; !ok {
+			return nil, fmt.Errorf("%q has unsupported type %T", name, arg)
+		}
+		value, err := json.Marshal(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", name, err)
+		}
+		records[name] = &RunnableArg{Type: typeName, Value: value}
+	}
+	return records, nil
+}
+
+// ToDomain returns the runnable stored in the record.
+func (r *Runnable) ToDomain() (*domain.Runnable, error) {
+	inputs, err := runnableArgsToDomain(r.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("runnable %q inputs: %w", r.ID, err)
+	}
+	outputs, err := runnableArgsToDomain(r.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("runnable %q outputs: %w", r.ID, err)
+	}
+	return &domain.Runnable{
+		ID:          r.ID,
+		Created:     r.Created,
+		Description: r.Description,
+		Author:      r.Author,
+		Source:      r.Source,
+		Kind:        r.Kind,
+		Container: domain.RunnableContainer{
+			Image:      r.Container.Image,
+			LocalImage: r.Container.LocalImage,
+			Env:        r.Container.Env,
+			Entrypoint: r.Container.Entrypoint,
+			Args:       r.Container.Args,
+		},
+		Inputs:            inputs,
+		Outputs:           outputs,
+		DefaultInputPath:  r.DefaultInputPath,
+		DefaultOutputPath: r.DefaultOutputPath,
+		Labels:            r.Labels,
+	}, nil
+}
+
+func runnableArgsToDomain(records map[string]*RunnableArg) (map[string]interface{}, error) {
+	if records == nil {
+		return nil, nil
+	}
+	args := make(map[string]interface{}, len(records))
+	for name, r := range records {
+		newArg, ok := runnableArgTypes[r.Type]
+		if !ok {
+			return nil, fmt.Errorf("%q has unsupported type %s", name, r.Type)
+		}
+		arg := newArg()
+		if err := json.Unmarshal(r.Value, arg); err != nil {
+			return nil, fmt.Errorf("%q: %w", name, err)
+		}
+		args[name] = arg
+	}
+	return args, nil
+}
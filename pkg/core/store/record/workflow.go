@@ -0,0 +1,261 @@
+package record
+
+import (
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// Workflow is the stored form of a domain.Workflow. The field names are those of the domain types, as
+// records are matched by field name when decoded and the list queries sort by them.
+type Workflow struct {
+	SchemaVersion int
+	Created       time.Time
+	Name          string
+	Description   string
+	Inputs        []*WorkflowInput
+	Outputs       []*WorkflowOutput
+	Steps         []*WorkflowStep
+	AssignedTo    *WorkflowAssignment
+}
+
+// WorkflowInput is the stored form of a domain.WorkflowInput.
+type WorkflowInput struct {
+	Name        string
+	Description string
+	Type        string
+	Default     string
+	Labels      []string
+}
+
+// WorkflowOutput is the stored form of a domain.WorkflowOutput.
+type WorkflowOutput struct {
+	Name        string
+	Description string
+	Type        string
+}
+
+// WorkflowStep is the stored form of a domain.WorkflowStep.
+type WorkflowStep struct {
+	Name       string
+	Image      string
+	Inputs     []*WorkflowStepInput
+	Outputs    []*WorkflowStepOutput
+	Extensions []*WorkflowStepExtension
+	Env        []*WorkflowStepEnv
+	Resources  WorkflowStepResources
+}
+
+// WorkflowStepInput is the stored form of a domain.WorkflowStepInput.
+type WorkflowStepInput struct {
+	Name    string
+	Value   string
+	Codeset *WorkflowStepInputCodeset
+}
+
+// WorkflowStepInputCodeset is the stored form of a domain.WorkflowStepInputCodeset.
+type WorkflowStepInputCodeset struct {
+	Name string
+	Path string
+}
+
+// WorkflowStepOutput is the stored form of a domain.WorkflowStepOutput.
+type WorkflowStepOutput struct {
+	Name  string
+	Image *WorkflowStepOutputImage
+}
+
+// WorkflowStepOutputImage is the stored form of a domain.WorkflowStepOutputImage.
+type WorkflowStepOutputImage struct {
+	Dockerfile string
+	Name       string
+}
+
+// WorkflowStepExtension is the stored form of a domain.WorkflowStepExtension.
+type WorkflowStepExtension struct {
+	Name               string
+	ExtensionID        string
+	Product            string
+	VersionConstraints string
+	Zone               string
+	ServiceID          string
+	ServiceResource    string
+	ServiceCategory    string
+	ExtensionAccess    *ExtensionAccessDescriptor
+}
+
+// WorkflowStepEnv is the stored form of a domain.WorkflowStepEnv.
+type WorkflowStepEnv struct {
+	Name  string
+	Value string
+}
+
+// WorkflowStepResources is the stored form of a domain.WorkflowStepResources.
+type WorkflowStepResources struct {
+	Requests map[string]string
+	Limits   map[string]string
+}
+
+// WorkflowAssignment is the stored form of a domain.WorkflowAssignment.
+type WorkflowAssignment struct {
+	Codesets []*CodesetAssignment
+}
+
+// CodesetAssignment is the stored form of a domain.CodesetAssignment.
+type CodesetAssignment struct {
+	Codeset   *Codeset
+	WebhookID *int64
+}
+
+// Codeset is the stored form of the domain.Codeset referenced by a codeset assignment.
+type Codeset struct {
+	Name        string
+	Project     string
+	Description string
+	Labels      []string
+	URL         string
+}
+
+// NewWorkflow returns the record of a workflow.
+func NewWorkflow(w *domain.Workflow) *Workflow {
+	return &Workflow{
+		SchemaVersion: SchemaVersion,
+		Created:       w.Created,
+		Name:          w.Name,
+		Description:   w.Description,
+		Inputs: mapSlice(w.Inputs, func(i *domain.WorkflowInput) *WorkflowInput {
+			return &WorkflowInput{Name: i.Name, Description: i.Description, Type: string(i.Type),
+				Default: i.Default, Labels: i.Labels}
+		}),
+		Outputs: mapSlice(w.Outputs, func(o *domain.WorkflowOutput) *WorkflowOutput {
+			return &WorkflowOutput{Name: o.Name, Description: o.Description, Type: string(o.Type)}
+		}),
+		Steps:      mapSlice(w.Steps, NewWorkflowStep),
+		AssignedTo: NewWorkflowAssignment(w.AssignedTo),
+	}
+}
+
+// NewWorkflowStep returns the record of a workflow step.
+func NewWorkflowStep(s *domain.WorkflowStep) *WorkflowStep {
+	return &WorkflowStep{
+		Name:  s.Name,
+		Image: s.Image,
+		Inputs: mapSlice(s.Inputs, func(i *domain.WorkflowStepInput) *WorkflowStepInput {
+			r := &WorkflowStepInput{Name: i.Name, Value: i.Value}
+			if i.Codeset != nil {
+				r.Codeset = &WorkflowStepInputCodeset{Name: i.Codeset.Name, Path: i.Codeset.Path}
+			}
+			return r
+		}),
+		Outputs: mapSlice(s.Outputs, func(o *domain.WorkflowStepOutput) *WorkflowStepOutput {
+			r := &WorkflowStepOutput{Name: o.Name}
+			if o.Image != nil {
+				r.Image = &WorkflowStepOutputImage{Dockerfile: o.Image.Dockerfile, Name: o.Image.Name}
+			}
+			return r
+		}),
+		Extensions: mapSlice(s.Extensions, func(e *domain.WorkflowStepExtension) *WorkflowStepExtension {
+			return &WorkflowStepExtension{
+				Name:               e.Name,
+				ExtensionID:        e.ExtensionID,
+				Product:            e.Product,
+				VersionConstraints: e.VersionConstraints,
+				Zone:               e.Zone,
+				ServiceID:          e.ServiceID,
+				ServiceResource:    e.ServiceResource,
+				ServiceCategory:    e.ServiceCategory,
+				ExtensionAccess:    NewExtensionAccessDescriptor(e.ExtensionAccess),
+			}
+		}),
+		Env: mapSlice(s.Env, func(e *domain.WorkflowStepEnv) *WorkflowStepEnv {
+			return &WorkflowStepEnv{Name: e.Name, Value: e.Value}
+		}),
+		Resources: WorkflowStepResources{Requests: s.Resources.Requests, Limits: s.Resources.Limits},
+	}
+}
+
+// NewWorkflowAssignment returns the record of the codeset assignments of a workflow.
+func NewWorkflowAssignment(a *domain.WorkflowAssignment) *WorkflowAssignment {
+	if a == nil {
+		return nil
+	}
+	return &WorkflowAssignment{Codesets: mapSlice(a.Codesets, NewCodesetAssignment)}
+}
+
+// NewCodesetAssignment returns the record of a codeset assignment.
+func NewCodesetAssignment(a *domain.CodesetAssignment) *CodesetAssignment {
+	r := &CodesetAssignment{WebhookID: a.WebhookID}
+	if c := a.Codeset; c != nil {
+		r.Codeset = &Codeset{Name: c.Name, Project: c.Project, Description: c.Description, Labels: c.Labels, URL: c.URL}
+	}
+	return r
+}
+
+// ToDomain returns the workflow stored in the record.
+func (r *Workflow) ToDomain() *domain.Workflow {
+	w := &domain.Workflow{
+		Created:     r.Created,
+		Name:        r.Name,
+		Description: r.Description,
+		Inputs: mapSlice(r.Inputs, func(i *WorkflowInput) *domain.WorkflowInput {
+			return &domain.WorkflowInput{Name: i.Name, Description: i.Description, Type: domain.WorkflowIOType(i.Type),
+				Default: i.Default, Labels: i.Labels}
+		}),
+		Outputs: mapSlice(r.Outputs, func(o *WorkflowOutput) *domain.WorkflowOutput {
+			return &domain.WorkflowOutput{Name: o.Name, Description: o.Description, Type: domain.WorkflowIOType(o.Type)}
+		}),
+		Steps: mapSlice(r.Steps, (*WorkflowStep).ToDomain),
+	}
+	if r.AssignedTo != nil {
+		w.AssignedTo = &domain.WorkflowAssignment{Codesets: mapSlice(r.AssignedTo.Codesets, (*CodesetAssignment).ToDomain)}
+	}
+	return w
+}
+
+// ToDomain returns the codeset assignment stored in the record.
+func (r *CodesetAssignment) ToDomain() *domain.CodesetAssignment {
+	a := &domain.CodesetAssignment{WebhookID: r.WebhookID}
+	if c := r.Codeset; c != nil {
+		a.Codeset = &domain.Codeset{Name: c.Name, Project: c.Project, Description: c.Description, Labels: c.Labels, URL: c.URL}
+	}
+	return a
+}
+
+// ToDomain returns the workflow step stored in the record.
+func (r *WorkflowStep) ToDomain() *domain.WorkflowStep {
+	return &domain.WorkflowStep{
+		Name:  r.Name,
+		Image: r.Image,
+		Inputs: mapSlice(r.Inputs, func(i *WorkflowStepInput) *domain.WorkflowStepInput {
+			in := &domain.WorkflowStepInput{Name: i.Name, Value: i.Value}
+			if i.Codeset != nil {
+				in.Codeset = &domain.WorkflowStepInputCodeset{Name: i.Codeset.Name, Path: i.Codeset.Path}
+			}
+			return in
+		}),
+		Outputs: mapSlice(r.Outputs, func(o *WorkflowStepOutput) *domain.WorkflowStepOutput {
+			out := &domain.WorkflowStepOutput{Name: o.Name}
+			if o.Image != nil {
+				out.Image = &domain.WorkflowStepOutputImage{Dockerfile: o.Image.Dockerfile, Name: o.Image.Name}
+			}
+			return out
+		}),
+		Extensions: mapSlice(r.Extensions, func(e *WorkflowStepExtension) *domain.WorkflowStepExtension {
+			return &domain.WorkflowStepExtension{
+				Name:               e.Name,
+				ExtensionID:        e.ExtensionID,
+				Product:            e.Product,
+				VersionConstraints: e.VersionConstraints,
+				Zone:               e.Zone,
+				ServiceID:          e.ServiceID,
+				ServiceResource:    e.ServiceResource,
+				ServiceCategory:    e.ServiceCategory,
+				ExtensionAccess:    e.ExtensionAccess.ToDomain(),
+			}
+		}),
+		Env: mapSlice(r.Env, func(e *WorkflowStepEnv) *domain.WorkflowStepEnv {
+			return &domain.WorkflowStepEnv{Name: e.Name, Value: e.Value}
+		}),
+		Resources: domain.WorkflowStepResources{Requests: r.Resources.Requests, Limits: r.Resources.Limits},
+	}
+}
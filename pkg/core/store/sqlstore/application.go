@@ -0,0 +1,137 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// applicationColumns maps the application sort fields to the columns of the applications table
+var applicationColumns = map[string]string{"Name": "name", "Type": "type", "Workflow": "workflow"}
+
+// ApplicationStore is a SQL implementation of the domain.ApplicationStore interface.
+type ApplicationStore struct {
+	store *Store
+}
+
+// NewApplicationStore creates a new ApplicationStore.
+func NewApplicationStore(store *Store) *ApplicationStore {
+	return &ApplicationStore{store: store}
+}
+
+// Find returns a application identified by id
+func (as *ApplicationStore) Find(ctx context.Context, name string) *domain.Application {
+	ctx, span := tracing.Start(ctx, "sql.ApplicationStore.Find")
+	defer span.End()
+
+	r := record.Application{}
+	if err := queryDocument(ctx, as.store.db, &r, "SELECT document FROM applications WHERE name = $1", name); err != nil {
+		return nil
+	}
+	return r.ToDomain()
+}
+
+// GetAll returns the page of applications of a given type selected by the list options.
+// If type is not specified, return all applications.
+func (as *ApplicationStore) GetAll(ctx context.Context, applicationType *string, applicationWorkflow *string,
+	opts *domain.ListOptions) (_ []*domain.Application, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ApplicationStore.GetAll")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.ApplicationSortFields, domain.ApplicationDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conditions, args := []string{}, []interface{}{}
+	if applicationType != nil {
+		args = append(args, *applicationType)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if applicationWorkflow != nil {
+		args = append(args, *applicationWorkflow)
+		conditions = append(conditions, fmt.Sprintf("workflow = $%d", len(args)))
+	}
+	query, args := as.store.pageQuery("SELECT document FROM applications", conditions, args, window,
+		applicationColumns)
+
+	records, err := queryDocuments[record.Application](ctx, as.store.db, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	result := make([]*domain.Application, len(records))
+	for i, r := range records {
+		result[i] = r.ToDomain()
+	}
+	result, next := page(result, window)
+	return result, next, nil
+}
+
+// Add adds a new application, based on the Application structure provided as argument
+func (as *ApplicationStore) Add(ctx context.Context, a *domain.Application) (_ *domain.Application, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ApplicationStore.Add")
+	defer tracing.End(span, &err)
+
+	document, err := encode(record.NewApplication(a))
+	if err != nil {
+		return nil, err
+	}
+	result, err := as.store.db.ExecContext(ctx,
+		"INSERT INTO applications (name, type, workflow, document) VALUES ($1, $2, $3, $4) ON CONFLICT (name) DO NOTHING",
+		a.Name, a.Type, a.Workflow, document)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = domain.ErrApplicationExists
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// Update replaces an existing application
+func (as *ApplicationStore) Update(ctx context.Context, a *domain.Application) (_ *domain.Application, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ApplicationStore.Update")
+	defer tracing.End(span, &err)
+
+	document, err := encode(record.NewApplication(a))
+	if err != nil {
+		return nil, err
+	}
+	result, err := as.store.db.ExecContext(ctx,
+		"UPDATE applications SET type = $1, workflow = $2, document = $3 WHERE name = $4",
+		a.Type, a.Workflow, document, a.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = domain.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// Delete deletes the application registered by FuseML
+func (as *ApplicationStore) Delete(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ApplicationStore.Delete")
+	defer tracing.End(span, &err)
+
+	result, err := as.store.db.ExecContext(ctx, "DELETE FROM applications WHERE name = $1", name)
+	if err != nil {
+		return err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = domain.ErrApplicationNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,436 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// ExtensionStore is a SQL implementation of the domain.ExtensionStore interface. The services, endpoints and
+// credentials are stored with their extension, which is locked while they are changed.
+type ExtensionStore struct {
+	store *Store
+}
+
+// NewExtensionStore creates a new ExtensionStore.
+func NewExtensionStore(store *Store) *ExtensionStore {
+	return &ExtensionStore{store: store}
+}
+
+// AddExtension adds a new extension to the store.
+func (es *ExtensionStore) AddExtension(ctx context.Context, extension *domain.Extension) (_ *domain.Extension, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.AddExtension")
+	defer tracing.End(span, &err)
+
+	extension.EnsureID(ctx, es)
+	extension.SetCreated(ctx)
+
+	document, err := encode(record.NewExtension(extension))
+	if err != nil {
+		return nil, err
+	}
+	result, err := es.store.db.ExecContext(ctx,
+		"INSERT INTO extensions (id, document) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING", extension.ID, document)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = domain.NewErrExtensionExists(extension.ID)
+		}
+		return nil, err
+	}
+	return extension, nil
+}
+
+// GetExtension retrieves an extension by its ID.
+func (es *ExtensionStore) GetExtension(ctx context.Context, extensionID string) (_ *domain.Extension, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.GetExtension")
+	defer tracing.End(span, &err)
+
+	return es.getExtension(ctx, es.store.db, extensionID, false)
+}
+
+// ListExtensions retrieves the page of stored extensions matching the query selected by the list options.
+func (es *ExtensionStore) ListExtensions(ctx context.Context, query *domain.ExtensionQuery,
+	opts *domain.ListOptions) (_ []*domain.Extension, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.ListExtensions")
+	defer tracing.End(span, &err)
+
+	result, err := es.listExtensions(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+	next, err := domain.Paginate(&result, opts, domain.ExtensionSortFields, domain.ExtensionDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, next, nil
+}
+
+// listExtensions retrieves all stored extensions matching the query, ordered by ID.
+func (es *ExtensionStore) listExtensions(ctx context.Context, query *domain.ExtensionQuery) ([]*domain.Extension, error) {
+	result := []*domain.Extension{}
+	if query != nil && query.ExtensionID != "" {
+		extension, err := es.getExtension(ctx, es.store.db, query.ExtensionID, false)
+		if err == nil {
+			if match := extension.GetExtensionIfMatch(query); match != nil {
+				result = append(result, match)
+			}
+		}
+		return result, nil
+	}
+
+	records, err := queryDocuments[record.Extension](ctx, es.store.db, "SELECT document FROM extensions ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		extension := r.ToDomain()
+		if query != nil {
+			// the services, endpoints and credentials that don't match are left out
+			if extension = extension.GetExtensionIfMatch(query); extension == nil {
+				continue
+			}
+		}
+		result = append(result, extension)
+	}
+	return result, nil
+}
+
+// UpdateExtension updates an existing extension.
+func (es *ExtensionStore) UpdateExtension(ctx context.Context, newExtension *domain.Extension) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.UpdateExtension")
+	defer tracing.End(span, &err)
+
+	return es.update(ctx, newExtension.ID, true, func(*domain.Extension) (*domain.Extension, error) {
+		return newExtension, nil
+	})
+}
+
+// DeleteExtension deletes an extension from the store.
+func (es *ExtensionStore) DeleteExtension(ctx context.Context, extensionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.DeleteExtension")
+	defer tracing.End(span, &err)
+
+	result, err := es.store.db.ExecContext(ctx, "DELETE FROM extensions WHERE id = $1", extensionID)
+	if err != nil {
+		return err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = domain.NewErrExtensionNotFound(extensionID)
+		}
+		return err
+	}
+	return nil
+}
+
+// AddExtensionService adds a new extension service to an extension.
+func (es *ExtensionStore) AddExtensionService(ctx context.Context, extensionID string, service *domain.ExtensionService) (svc *domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.AddExtensionService")
+	defer tracing.End(span, &err)
+
+	err = es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		svc, err = extension.AddService(service)
+		return extension, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+// GetExtensionService retrieves an extension service by its ID.
+func (es *ExtensionStore) GetExtensionService(ctx context.Context, extensionID string, serviceID string) (_ *domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.GetExtensionService")
+	defer tracing.End(span, &err)
+
+	extension, err := es.getExtension(ctx, es.store.db, extensionID, false)
+	if err != nil {
+		return nil, err
+	}
+	return extension.GetService(serviceID)
+}
+
+// ListExtensionServices retrieves all services belonging to an extension.
+func (es *ExtensionStore) ListExtensionServices(ctx context.Context, extensionID string) (_ []*domain.ExtensionService, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.ListExtensionServices")
+	defer tracing.End(span, &err)
+
+	extension, err := es.getExtension(ctx, es.store.db, extensionID, false)
+	if err != nil {
+		return nil, err
+	}
+	return extension.ListServices(), nil
+}
+
+// UpdateExtensionService updates a service belonging to an extension.
+func (es *ExtensionStore) UpdateExtensionService(ctx context.Context, extensionID string, newService *domain.ExtensionService) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.UpdateExtensionService")
+	defer tracing.End(span, &err)
+
+	return es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		return extension, extension.UpdateService(newService)
+	})
+}
+
+// DeleteExtensionService deletes an extension service from an extension.
+func (es *ExtensionStore) DeleteExtensionService(ctx context.Context, extensionID string, serviceID string) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.DeleteExtensionService")
+	defer tracing.End(span, &err)
+
+	return es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		return extension, extension.DeleteService(serviceID)
+	})
+}
+
+// AddExtensionServiceEndpoint adds a new endpoint to an extension service.
+func (es *ExtensionStore) AddExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpoint *domain.ExtensionServiceEndpoint) (_ *domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.AddExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
+	err = es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		svc, err := extension.GetService(serviceID)
+		if err != nil {
+			return nil, err
+		}
+		endpoint, err = svc.AddEndpoint(endpoint)
+		return extension, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// GetExtensionServiceEndpoint retrieves an extension endpoint by its ID.
+func (es *ExtensionStore) GetExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpointID string) (_ *domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.GetExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
+	svc, err := es.getExtensionService(ctx, extensionID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return svc.GetEndpoint(endpointID)
+}
+
+// ListExtensionServiceEndpoints retrieves all endpoints belonging to an extension service.
+func (es *ExtensionStore) ListExtensionServiceEndpoints(ctx context.Context, extensionID string, serviceID string) (_ []*domain.ExtensionServiceEndpoint, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.ListExtensionServiceEndpoints")
+	defer tracing.End(span, &err)
+
+	svc, err := es.getExtensionService(ctx, extensionID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return svc.ListEndpoints(), nil
+}
+
+// UpdateExtensionServiceEndpoint updates an endpoint belonging to an extension service.
+func (es *ExtensionStore) UpdateExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, newEndpoint *domain.ExtensionServiceEndpoint) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.UpdateExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
+	return es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		svc, err := extension.GetService(serviceID)
+		if err != nil {
+			return nil, err
+		}
+		return extension, svc.UpdateEndpoint(newEndpoint)
+	})
+}
+
+// UpdateExtensionServiceEndpointStatus updates the operational status of an endpoint belonging to an extension service.
+func (es *ExtensionStore) UpdateExtensionServiceEndpointStatus(ctx context.Context, extensionID string, serviceID string, endpointURL string, status domain.ExtensionServiceEndpointStatus) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.UpdateExtensionServiceEndpointStatus")
+	defer tracing.End(span, &err)
+
+	// the status is not part of the extension configuration, so the update time is not changed
+	return es.update(ctx, extensionID, false, func(extension *domain.Extension) (*domain.Extension, error) {
+		endpoint, err := extension.GetServiceEndpoint(serviceID, endpointURL)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.Status = status
+		return extension, nil
+	})
+}
+
+// DeleteExtensionServiceEndpoint deletes an extension endpoint from an extension service.
+func (es *ExtensionStore) DeleteExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, endpointID string) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.DeleteExtensionServiceEndpoint")
+	defer tracing.End(span, &err)
+
+	return es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		svc, err := extension.GetService(serviceID)
+		if err != nil {
+			return nil, err
+		}
+		return extension, svc.DeleteEndpoint(endpointID)
+	})
+}
+
+// AddExtensionServiceCredentials adds a new credential to an extension service.
+func (es *ExtensionStore) AddExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentials *domain.ExtensionServiceCredentials) (_ *domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.AddExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
+	err = es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		svc, err := extension.GetService(serviceID)
+		if err != nil {
+			return nil, err
+		}
+		credentials, err = svc.AddCredentials(credentials)
+		return extension, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// GetExtensionServiceCredentials retrieves an extension credential by its ID.
+func (es *ExtensionStore) GetExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentialsID string) (_ *domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.GetExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
+	svc, err := es.getExtensionService(ctx, extensionID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return svc.GetCredentials(credentialsID)
+}
+
+// ListExtensionServiceCredentials retrieves all credentials belonging to an extension service.
+func (es *ExtensionStore) ListExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string) (_ []*domain.ExtensionServiceCredentials, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.ListExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
+	svc, err := es.getExtensionService(ctx, extensionID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return svc.ListCredentials(), nil
+}
+
+// UpdateExtensionServiceCredentials updates an extension credential.
+func (es *ExtensionStore) UpdateExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, newCredentials *domain.ExtensionServiceCredentials) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.UpdateExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
+	return es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		svc, err := extension.GetService(serviceID)
+		if err != nil {
+			return nil, err
+		}
+		return extension, svc.UpdateCredentials(newCredentials)
+	})
+}
+
+// DeleteExtensionServiceCredentials deletes an extension credential from an extension service.
+func (es *ExtensionStore) DeleteExtensionServiceCredentials(ctx context.Context, extensionID string, serviceID string, credentialsID string) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.DeleteExtensionServiceCredentials")
+	defer tracing.End(span, &err)
+
+	return es.update(ctx, extensionID, true, func(extension *domain.Extension) (*domain.Extension, error) {
+		svc, err := extension.GetService(serviceID)
+		if err != nil {
+			return nil, err
+		}
+		return extension, svc.DeleteCredentials(credentialsID)
+	})
+}
+
+// GetExtensionAccessDescriptors retrieves access descriptors belonging to an extension that matches the query.
+func (es *ExtensionStore) GetExtensionAccessDescriptors(ctx context.Context, query *domain.ExtensionQuery) (result []*domain.ExtensionAccessDescriptor, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionStore.GetExtensionAccessDescriptors")
+	defer tracing.End(span, &err)
+
+	result = make([]*domain.ExtensionAccessDescriptor, 0)
+
+	extensions, err := es.listExtensions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, extension := range extensions {
+		result = append(result, extension.GetAccessDescriptors()...)
+	}
+	return result, nil
+}
+
+// getExtension returns the extension stored under an ID, locking it until the end of the transaction if lock
+// is set
+func (es *ExtensionStore) getExtension(ctx context.Context, q querier, extensionID string, lock bool) (*domain.Extension, error) {
+	query := "SELECT document FROM extensions WHERE id = $1"
+	if lock {
+		query += es.store.dialect.forUpdate
+	}
+	r := record.Extension{}
+	if err := queryDocument(ctx, q, &r, query, extensionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewErrExtensionNotFound(extensionID)
+		}
+		return nil, err
+	}
+	return r.ToDomain(), nil
+}
+
+// getExtensionService returns a service of a stored extension
+func (es *ExtensionStore) getExtensionService(ctx context.Context, extensionID string, serviceID string) (*domain.ExtensionService, error) {
+	extension, err := es.getExtension(ctx, es.store.db, extensionID, false)
+	if err != nil {
+		return nil, err
+	}
+	return extension.GetService(serviceID)
+}
+
+// update replaces a stored extension with the one returned by the change function, which is given a copy of
+// the stored extension, in a transaction that holds a lock on the extension. If touch is set, the update
+// time of the extension and the creation time of the new services are set.
+func (es *ExtensionStore) update(ctx context.Context, extensionID string, touch bool,
+	change func(extension *domain.Extension) (*domain.Extension, error)) error {
+	return es.store.inTx(ctx, func(tx *sql.Tx) error {
+		stored, err := es.getExtension(ctx, tx, extensionID, true)
+		if err != nil {
+			return err
+		}
+		extension, err := change(record.NewExtension(stored).ToDomain())
+		if err != nil {
+			return err
+		}
+		if touch {
+			touchExtension(stored, extension)
+		}
+
+		document, err := encode(record.NewExtension(extension))
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE extensions SET document = $1 WHERE id = $2", document, extensionID)
+		return err
+	})
+}
+
+// touchExtension sets the update time of an extension replacing a stored one, keeping the creation time and
+// the endpoint statuses of the stored extension
+func touchExtension(stored, extension *domain.Extension) {
+	extension.Created = stored.Created
+	extension.Updated = time.Now()
+
+	for _, svc := range extension.ListServices() {
+		storedSvc, err := stored.GetService(svc.ID)
+		if err != nil {
+			// If the service is new, set the creation time
+			svc.SetCreated(extension.Updated)
+		} else {
+			svc.InheritEndpointStatus(storedSvc)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// ExtensionUsageStore is a SQL implementation of the domain.ExtensionUsageStore interface. The released column
+// is NULL for the bindings still in use.
+type ExtensionUsageStore struct {
+	store *Store
+}
+
+// NewExtensionUsageStore creates a new ExtensionUsageStore.
+func NewExtensionUsageStore(store *Store) *ExtensionUsageStore {
+	return &ExtensionUsageStore{store: store}
+}
+
+// AddUsage adds new usage records
+func (us *ExtensionUsageStore) AddUsage(ctx context.Context, usage []*domain.ExtensionUsage) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionUsageStore.AddUsage")
+	defer tracing.End(span, &err)
+
+	return us.store.inTx(ctx, func(tx *sql.Tx) error {
+		for _, u := range usage {
+			if err := us.saveUsage(ctx, tx, 0, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReleaseUsage marks all active usage records of a workflow as released
+func (us *ExtensionUsageStore) ReleaseUsage(ctx context.Context, workflowName string, released time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionUsageStore.ReleaseUsage")
+	defer tracing.End(span, &err)
+
+	return us.store.inTx(ctx, func(tx *sql.Tx) error {
+		ids, usage, err := us.queryUsage(ctx, tx,
+			"WHERE workflow = $1 AND released IS NULL"+us.store.dialect.forUpdate, workflowName)
+		if err != nil {
+			return err
+		}
+		for i, u := range usage {
+			u.Released = released
+			if err := us.saveUsage(ctx, tx, ids[i], u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetUsage retrieves the usage records that match a filter, ordered by the time when the bindings were made
+func (us *ExtensionUsageStore) GetUsage(ctx context.Context, filter *domain.ExtensionUsageFilter) (_ []*domain.ExtensionUsage, err error) {
+	ctx, span := tracing.Start(ctx, "sql.ExtensionUsageStore.GetUsage")
+	defer tracing.End(span, &err)
+
+	conditions, args := []string{}, []interface{}{}
+	if filter != nil {
+		for _, c := range []struct{ column, value string }{
+			{"workflow", filter.Workflow},
+			{"extension_id", filter.ExtensionID},
+			{"service_id", filter.ServiceID},
+			{"credentials_id", filter.CredentialsID},
+		} {
+			if c.value != "" {
+				args = append(args, c.value)
+				conditions = append(conditions, fmt.Sprintf("%s = $%d", c.column, len(args)))
+			}
+		}
+		if !filter.All {
+			conditions = append(conditions, "released IS NULL")
+		}
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	_, usage, err := us.queryUsage(ctx, us.store.db, where+" ORDER BY bound, id", args...)
+	return usage, err
+}
+
+// queryUsage returns the IDs and the usage records of the rows selected by the WHERE and ORDER BY clauses
+func (us *ExtensionUsageStore) queryUsage(ctx context.Context, q querier, clauses string,
+	args ...interface{}) ([]int64, []*domain.ExtensionUsage, error) {
+	rows, err := q.QueryContext(ctx, "SELECT id, document FROM extension_usage "+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	ids, usage := []int64{}, []*domain.ExtensionUsage{}
+	for rows.Next() {
+		var id int64
+		var document string
+		if err := rows.Scan(&id, &document); err != nil {
+			return nil, nil, err
+		}
+		r := record.ExtensionUsage{}
+		if err := decode(document, &r); err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, id)
+		usage = append(usage, r.ToDomain())
+	}
+	return ids, usage, rows.Err()
+}
+
+// saveUsage adds a usage record, if id is zero, or replaces the usage record with the given id
+func (us *ExtensionUsageStore) saveUsage(ctx context.Context, tx *sql.Tx, id int64, u *domain.ExtensionUsage) error {
+	document, err := encode(record.NewExtensionUsage(u))
+	if err != nil {
+		return err
+	}
+	var released sql.NullInt64
+	if !u.Active() {
+		released = sql.NullInt64{Int64: u.Released.UnixMicro(), Valid: true}
+	}
+	if id == 0 {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO extension_usage (workflow, extension_id, service_id, credentials_id, bound, released, document)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			u.Workflow, u.ExtensionID, u.ServiceID, u.CredentialsID, u.Bound.UnixMicro(), released, document)
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE extension_usage SET released = $1, document = $2 WHERE id = $3",
+		released, document, id)
+	return err
+}
@@ -0,0 +1,212 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+const (
+	errDeliveryNotFound = "notification delivery not found"
+)
+
+// notificationColumns maps the notification target sort fields to the columns of the notification_targets table
+var notificationColumns = map[string]string{"Name": "name", "Type": "type", "Created": "created"}
+
+// deliveryColumns maps the notification delivery sort fields to the columns of the notification_deliveries table
+var deliveryColumns = map[string]string{"ID": "id", "Status": "status", "Created": "created"}
+
+// NotificationStore is a SQL implementation of the domain.NotificationStore interface. The delivery IDs are
+// assigned by the database.
+type NotificationStore struct {
+	store *Store
+}
+
+// NewNotificationStore creates a new NotificationStore.
+func NewNotificationStore(store *Store) *NotificationStore {
+	return &NotificationStore{store: store}
+}
+
+// AddTarget adds a notification target to the store
+func (ns *NotificationStore) AddTarget(ctx context.Context, target *domain.NotificationTarget) (_ *domain.NotificationTarget, err error) {
+	ctx, span := tracing.Start(ctx, "sql.NotificationStore.AddTarget")
+	defer tracing.End(span, &err)
+
+	document, err := encode(record.NewNotificationTarget(target))
+	if err != nil {
+		return nil, err
+	}
+	result, err := ns.store.db.ExecContext(ctx,
+		`INSERT INTO notification_targets (name, type, workflow, project, created, document) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name) DO NOTHING`,
+		target.Name, string(target.Type), target.Workflow, target.Project, target.Created.UnixMicro(), document)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = domain.ErrNotificationTargetExists
+		}
+		return nil, err
+	}
+	return target, nil
+}
+
+// GetTarget retrieves a notification target from the store
+func (ns *NotificationStore) GetTarget(ctx context.Context, name string) (_ *domain.NotificationTarget, err error) {
+	ctx, span := tracing.Start(ctx, "sql.NotificationStore.GetTarget")
+	defer tracing.End(span, &err)
+
+	r := record.NotificationTarget{}
+	if err := queryDocument(ctx, ns.store.db, &r, "SELECT document FROM notification_targets WHERE name = $1", name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotificationTargetNotFound
+		}
+		return nil, err
+	}
+	return r.ToDomain(), nil
+}
+
+// GetTargets returns the page of notification targets, filtered by workflow and project, selected by the
+// list options
+func (ns *NotificationStore) GetTargets(ctx context.Context, workflow, project string,
+	opts *domain.ListOptions) (_ []*domain.NotificationTarget, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "sql.NotificationStore.GetTargets")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.NotificationSortFields, domain.NotificationDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conditions, args := []string{}, []interface{}{}
+	if workflow != "" {
+		args = append(args, workflow)
+		conditions = append(conditions, fmt.Sprintf("workflow = $%d", len(args)))
+	}
+	if project != "" {
+		args = append(args, project)
+		conditions = append(conditions, fmt.Sprintf("project = $%d", len(args)))
+	}
+	query, args := ns.store.pageQuery("SELECT document FROM notification_targets", conditions, args, window,
+		notificationColumns)
+
+	records, err := queryDocuments[record.NotificationTarget](ctx, ns.store.db, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	result := make([]*domain.NotificationTarget, len(records))
+	for i, r := range records {
+		result[i] = r.ToDomain()
+	}
+	result, next := page(result, window)
+	return result, next, nil
+}
+
+// DeleteTarget deletes a notification target and its delivery log from the store
+func (ns *NotificationStore) DeleteTarget(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.NotificationStore.DeleteTarget")
+	defer tracing.End(span, &err)
+
+	return ns.store.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM notification_targets WHERE name = $1", name)
+		if err != nil {
+			return err
+		}
+		if ok, err := affected(result); err != nil || !ok {
+			if err == nil {
+				err = domain.ErrNotificationTargetNotFound
+			}
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM notification_deliveries WHERE target = $1", name)
+		return err
+	})
+}
+
+// AddDelivery adds a delivery to the delivery log and assigns its ID
+func (ns *NotificationStore) AddDelivery(ctx context.Context, delivery *domain.NotificationDelivery) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.NotificationStore.AddDelivery")
+	defer tracing.End(span, &err)
+
+	document, err := encode(record.NewNotificationDelivery(delivery))
+	if err != nil {
+		return err
+	}
+	id, err := ns.store.insert(ctx, ns.store.db,
+		"INSERT INTO notification_deliveries (target, status, created, document) VALUES ($1, $2, $3, $4)",
+		delivery.Target, string(delivery.Status), delivery.Created.UnixMicro(), document)
+	if err != nil {
+		return err
+	}
+	delivery.ID = uint64(id)
+	return nil
+}
+
+// UpdateDelivery replaces a delivery in the delivery log
+func (ns *NotificationStore) UpdateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.NotificationStore.UpdateDelivery")
+	defer tracing.End(span, &err)
+
+	document, err := encode(record.NewNotificationDelivery(delivery))
+	if err != nil {
+		return err
+	}
+	result, err := ns.store.db.ExecContext(ctx,
+		"UPDATE notification_deliveries SET target = $1, status = $2, created = $3, document = $4 WHERE id = $5",
+		delivery.Target, string(delivery.Status), delivery.Created.UnixMicro(), document, int64(delivery.ID))
+	if err != nil {
+		return err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = errors.New(errDeliveryNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetDeliveries returns the page of deliveries made to a notification target selected by the list options
+func (ns *NotificationStore) GetDeliveries(ctx context.Context, target string,
+	opts *domain.ListOptions) (_ []*domain.NotificationDelivery, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "sql.NotificationStore.GetDeliveries")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.NotificationDeliverySortFields, domain.NotificationDeliveryDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query, args := ns.store.pageQuery("SELECT id, document FROM notification_deliveries",
+		[]string{"target = $1"}, []interface{}{target}, window, deliveryColumns)
+	rows, err := ns.store.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	result := []*domain.NotificationDelivery{}
+	for rows.Next() {
+		var id int64
+		var document string
+		if err := rows.Scan(&id, &document); err != nil {
+			return nil, "", err
+		}
+		r := record.NotificationDelivery{}
+		if err := decode(document, &r); err != nil {
+			return nil, "", err
+		}
+		result = append(result, r.ToDomain(uint64(id)))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+	result, next := page(result, window)
+	return result, next, nil
+}
@@ -0,0 +1,129 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+const (
+	errRunnableExists = "a runnable with that ID already exists"
+)
+
+// RunnableStore is a SQL implementation of the domain.RunnableStore interface.
+type RunnableStore struct {
+	store *Store
+}
+
+// NewRunnableStore creates a new RunnableStore.
+func NewRunnableStore(store *Store) *RunnableStore {
+	return &RunnableStore{store: store}
+}
+
+// Find returns a list of runnables matching the input query.
+// Runnables may be matched by id, kind or labels. Only runnables that match all the
+// supplied criteria will be returned, ordered and paged according to the list options.
+func (rs *RunnableStore) Find(ctx context.Context, id string, kind string, labels map[string]string,
+	opts *domain.ListOptions) (res []*domain.Runnable, next string, err error) {
+	ctx, span := tracing.Start(ctx, "sql.RunnableStore.Find")
+	defer tracing.End(span, &err)
+
+	// the queries are regular expressions, so the runnables are matched here rather than by the database
+	records, err := queryDocuments[record.Runnable](ctx, rs.store.db, "SELECT document FROM runnables ORDER BY id")
+	if err != nil {
+		return nil, "", err
+	}
+	res = make([]*domain.Runnable, 0)
+	for _, rec := range records {
+		r, err := rec.ToDomain()
+		if err != nil {
+			return nil, "", err
+		}
+		if matchRunnable(r, id, kind, labels) {
+			res = append(res, r)
+		}
+	}
+	next, err = domain.Paginate(&res, opts, domain.RunnableSortFields, domain.RunnableDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+	return res, next, nil
+}
+
+// Register adds a new runnable, based on the Runnable structure provided as argument
+func (rs *RunnableStore) Register(ctx context.Context, r *domain.Runnable) (res *domain.Runnable, err error) {
+	ctx, span := tracing.Start(ctx, "sql.RunnableStore.Register")
+	defer tracing.End(span, &err)
+
+	registered := *r
+	registered.Created = time.Now()
+	rec, err := record.NewRunnable(&registered)
+	if err != nil {
+		return nil, err
+	}
+	document, err := encode(rec)
+	if err != nil {
+		return nil, err
+	}
+	result, err := rs.store.db.ExecContext(ctx,
+		"INSERT INTO runnables (id, document) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING", r.ID, document)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := affected(result); err != nil || !ok {
+		if err == nil {
+			err = errors.New(errRunnableExists)
+		}
+		return nil, err
+	}
+	return &registered, nil
+}
+
+// Get returns a runnable identified by id
+func (rs *RunnableStore) Get(ctx context.Context, id string) (res *domain.Runnable, err error) {
+	ctx, span := tracing.Start(ctx, "sql.RunnableStore.Get")
+	defer tracing.End(span, &err)
+
+	rec := record.Runnable{}
+	if err := queryDocument(ctx, rs.store.db, &rec, "SELECT document FROM runnables WHERE id = $1", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rec.ToDomain()
+}
+
+// matchRunnable returns whether a runnable matches the id, kind and label queries, which are matched exactly
+// or as regular expressions
+func matchRunnable(r *domain.Runnable, id string, kind string, labels map[string]string) bool {
+	if id != "" && r.ID != id {
+		if match, _ := regexp.MatchString(id, r.ID); !match {
+			return false
+		}
+	}
+	if kind != "" && r.Kind != kind {
+		if match, _ := regexp.MatchString(kind, r.Kind); !match {
+			return false
+		}
+	}
+	for key, value := range labels {
+		label, hasLabel := r.Labels[key]
+		if !hasLabel {
+			return false
+		}
+		if value == "" || value == label {
+			continue
+		}
+		if match, _ := regexp.MatchString(value, label); !match {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the database schema used by the stores. It is the version of the last
+// migration, which must be added whenever the tables or the layout of the records change.
+const SchemaVersion = 2
+
+// migration upgrades the database schema to a new version. Migrations run in the transaction that records
+// the new schema version, so they are applied atomically.
+type migration struct {
+	version     int
+	description string
+	migrate     func(ctx context.Context, tx *sql.Tx, d dialect) error
+}
+
+// migrations are the schema migrations, ordered by version
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create the workflow, extension, application and runnable tables",
+		migrate: execMigration(
+			`CREATE TABLE workflows (
+				name {{text}} PRIMARY KEY,
+				created BIGINT NOT NULL,
+				document TEXT NOT NULL
+			)`,
+			`CREATE TABLE workflow_codesets (
+				workflow {{text}} NOT NULL REFERENCES workflows (name) ON DELETE CASCADE,
+				seq INTEGER NOT NULL,
+				document TEXT NOT NULL,
+				PRIMARY KEY (workflow, seq)
+			)`,
+			`CREATE TABLE extensions (
+				id {{text}} PRIMARY KEY,
+				document TEXT NOT NULL
+			)`,
+			`CREATE TABLE applications (
+				name {{text}} PRIMARY KEY,
+				type {{text}} NOT NULL,
+				workflow {{text}} NOT NULL,
+				document TEXT NOT NULL
+			)`,
+			`CREATE INDEX applications_workflow ON applications (workflow)`,
+			`CREATE TABLE runnables (
+				id {{text}} PRIMARY KEY,
+				document TEXT NOT NULL
+			)`,
+		),
+	},
+	{
+		version:     2,
+		description: "create the notification and extension usage tables",
+		migrate: execMigration(
+			`CREATE TABLE notification_targets (
+				name {{text}} PRIMARY KEY,
+				type {{text}} NOT NULL,
+				workflow {{text}} NOT NULL,
+				project {{text}} NOT NULL,
+				created BIGINT NOT NULL,
+				document TEXT NOT NULL
+			)`,
+			`CREATE TABLE notification_deliveries (
+				id {{serial}},
+				target {{text}} NOT NULL,
+				status {{text}} NOT NULL,
+				created BIGINT NOT NULL,
+				document TEXT NOT NULL
+			)`,
+			`CREATE INDEX notification_deliveries_target ON notification_deliveries (target)`,
+			`CREATE TABLE extension_usage (
+				id {{serial}},
+				workflow {{text}} NOT NULL,
+				extension_id {{text}} NOT NULL,
+				service_id {{text}} NOT NULL,
+				credentials_id {{text}} NOT NULL,
+				bound BIGINT NOT NULL,
+				released BIGINT,
+				document TEXT NOT NULL
+			)`,
+			`CREATE INDEX extension_usage_workflow ON extension_usage (workflow)`,
+			`CREATE INDEX extension_usage_extension ON extension_usage (extension_id)`,
+		),
+	},
+}
+
+// execMigration returns a migration that runs SQL statements, in which {{text}} and {{serial}} stand for the
+// dialect types of the text columns and of the serial primary key columns
+func execMigration(statements ...string) func(ctx context.Context, tx *sql.Tx, d dialect) error {
+	return func(ctx context.Context, tx *sql.Tx, d dialect) error {
+		types := strings.NewReplacer("{{text}}", d.text, "{{serial}}", d.serial)
+		for _, s := range statements {
+			if _, err := tx.ExecContext(ctx, types.Replace(s)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Migrate applies, in order, the migrations with a version higher than the schema version of the database.
+// It fails if the database was migrated by a newer server, which uses a schema this server cannot read.
+func (s *Store) Migrate(ctx context.Context, logger *slog.Logger) error {
+	return s.runMigrations(ctx, logger, migrations)
+}
+
+func (s *Store) runMigrations(ctx context.Context, logger *slog.Logger, migrations []migration) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		migrated BIGINT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("creating the schema migrations table: %w", err)
+	}
+
+	current, err := schemaVersion(ctx, s.db)
+	if err != nil {
+		return err
+	}
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].version
+	}
+	if current > latest {
+		return fmt.Errorf("database schema version %d is newer than the supported version %d", current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		err := s.inTx(ctx, func(tx *sql.Tx) error {
+			if s.dialect.lockMigrations != "" {
+				if _, err := tx.ExecContext(ctx, s.dialect.lockMigrations); err != nil {
+					return err
+				}
+				// another server may have applied the migration while this one waited for the lock
+				if current, err = schemaVersion(ctx, tx); err != nil || current >= m.version {
+					return err
+				}
+			}
+			logger.InfoContext(ctx, "Migrating database schema", "from", current, "to", m.version, "migration", m.description)
+			if err := m.migrate(ctx, tx, s.dialect); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, description, migrated) VALUES ($1, $2, $3)",
+				m.version, m.description, time.Now().Unix())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migrating database schema to version %d: %w", m.version, err)
+		}
+		current = m.version
+	}
+	return nil
+}
+
+// schemaVersion returns the schema version of the database, which is 0 if no migration was applied
+func schemaVersion(ctx context.Context, q querier) (int, error) {
+	var version sql.NullInt64
+	if err := q.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
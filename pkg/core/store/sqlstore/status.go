@@ -0,0 +1,27 @@
+package sqlstore
+
+import (
+	"context"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// StatusChecker reports whether the SQL database backing the stores can be used. It implements the
+// domain.DependencyChecker interface.
+type StatusChecker struct {
+	store *Store
+}
+
+// NewStatusChecker creates a new StatusChecker.
+func NewStatusChecker(store *Store) *StatusChecker {
+	return &StatusChecker{store: store}
+}
+
+// CheckDependencies checks that the database can be queried, and reports its version.
+func (sc *StatusChecker) CheckDependencies(ctx context.Context) []*domain.DependencyStatus {
+	status := &domain.DependencyStatus{Name: sc.store.driver}
+	if err := sc.store.db.QueryRowContext(ctx, sc.store.dialect.version).Scan(&status.Version); err != nil {
+		status.Error = err.Error()
+	}
+	return []*domain.DependencyStatus{status}
+}
@@ -0,0 +1,23 @@
+package sqlstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStatusChecker(t *testing.T) {
+	store, done := newTestStore(t)
+	defer done()
+	checker := NewStatusChecker(store)
+
+	got := checker.CheckDependencies(context.TODO())
+	if len(got) != 1 || got[0].Name != store.driver || got[0].Version == "" || !got[0].Available() {
+		t.Errorf("Unexpected status of open store: %+v", got[0])
+	}
+
+	store.Close()
+	got = checker.CheckDependencies(context.TODO())
+	if got[0].Available() {
+		t.Errorf("Closed store reported as available")
+	}
+}
@@ -0,0 +1,242 @@
+// Package sqlstore implements the workflow, extension, application, runnable, extension usage and notification
+// stores on a SQL database.
+// PostgreSQL is the supported database. SQLite is also supported, to run the store tests without a database
+// server. The objects are stored as JSON encoded records, along with the columns used to query them.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// dialect holds the differences between the SQL databases supported by the stores
+type dialect struct {
+	// text is the type of the text columns that objects are looked up and ordered by, which must compare
+	// text as bytes, like the other stores
+	text string
+	// serial is the type of the primary key columns holding IDs assigned by the database
+	serial string
+	// returning is appended to INSERT statements to return the ID assigned by the database. The ID is read
+	// from the statement result if empty.
+	returning string
+	// forUpdate locks the rows selected in a transaction until the transaction ends
+	forUpdate string
+	// noLimit is the LIMIT value that selects all rows
+	noLimit string
+	// lockMigrations serializes the migrations run by servers sharing the database
+	lockMigrations string
+	// maxOpenConns is the maximum number of open connections, zero meaning unlimited
+	maxOpenConns int
+	// version is the query returning the version of the database
+	version string
+}
+
+// dialects are the dialects of the supported SQL databases, indexed by database/sql driver name
+var dialects = map[string]dialect{
+	"postgres": {
+		text:           `TEXT COLLATE "C"`,
+		serial:         "BIGSERIAL PRIMARY KEY",
+		returning:      " RETURNING id",
+		forUpdate:      " FOR UPDATE",
+		noLimit:        "ALL",
+		lockMigrations: "SELECT pg_advisory_xact_lock(7235119)",
+		version:        "SHOW server_version",
+	},
+	// SQLite compares text as bytes by default and locks the whole database for writing, so a single
+	// connection avoids failing concurrent write transactions
+	"sqlite3": {
+		text:         "TEXT",
+		serial:       "INTEGER PRIMARY KEY AUTOINCREMENT",
+		noLimit:      "-1",
+		maxOpenConns: 1,
+		version:      "SELECT sqlite_version()",
+	},
+}
+
+// Store is a SQL database holding the FuseML objects, shared by the stores in this package.
+type Store struct {
+	db      *sql.DB
+	driver  string
+	dialect dialect
+}
+
+// querier runs queries on a database or in a transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Open connects to the database identified by the database/sql driver name and data source name, and
+// migrates it to the schema version used by the stores. The driver must be registered by the caller.
+func Open(logger *slog.Logger, driver, dsn string) (*Store, error) {
+	d, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(d.maxOpenConns)
+
+	s := &Store{db: db, driver: driver, dialect: d}
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to the database: %w", err)
+	}
+	if err := s.Migrate(ctx, logger); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the connections to the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// inTx runs a function in a database transaction, which is committed if the function succeeds and rolled
+// back otherwise
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// insert runs an INSERT statement adding a row to a table with a serial id column, and returns the ID
+// assigned to the row
+func (s *Store) insert(ctx context.Context, q querier, statement string, args ...interface{}) (int64, error) {
+	if s.dialect.returning != "" {
+		var id int64
+		err := q.QueryRowContext(ctx, statement+s.dialect.returning, args...).Scan(&id)
+		return id, err
+	}
+	result, err := q.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// pageQuery completes a query with the WHERE, ORDER BY and LIMIT clauses that select the rows in a list
+// window, along with the given conditions. The columns map the window sort fields to the columns holding
+// them. An extra row is selected, to find out if there are more pages. It returns the query and its arguments.
+func (s *Store) pageQuery(query string, conditions []string, args []interface{}, window *domain.ListWindow,
+	columns map[string]string) (string, []interface{}) {
+	order := make([]string, len(window.Fields))
+	for i, field := range window.Fields {
+		order[i] = columns[field]
+	}
+	if window.After != nil {
+		// the rows following the last one of the previous page, compared as row values
+		params := make([]string, len(window.After))
+		for i, v := range window.After {
+			if t, ok := v.(time.Time); ok {
+				v = t.UnixMicro()
+			} else if u, ok := v.(uint64); ok {
+				v = int64(u)
+			}
+			args = append(args, v)
+			params[i] = fmt.Sprintf("$%d", len(args))
+		}
+		op := ">"
+		if window.Descending {
+			op = "<"
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s) %s (%s)", strings.Join(order, ", "), op,
+			strings.Join(params, ", ")))
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	if window.Descending {
+		for i := range order {
+			order[i] += " DESC"
+		}
+	}
+	query += " ORDER BY " + strings.Join(order, ", ")
+	if window.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", window.Limit+1)
+	}
+	return query, args
+}
+
+// page cuts down the items selected by pageQuery to the window and returns the continue token for the
+// next page
+func page[T any](items []T, window *domain.ListWindow) ([]T, string) {
+	more := window.Limit > 0 && len(items) > window.Limit
+	if more {
+		items = items[:window.Limit]
+	}
+	if len(items) == 0 {
+		return items, ""
+	}
+	return items, window.Next(items[len(items)-1], more)
+}
+
+// queryDocuments runs a query selecting a single column of JSON encoded records and decodes them
+func queryDocuments[T any](ctx context.Context, q querier, query string, args ...interface{}) ([]*T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []*T{}
+	for rows.Next() {
+		var document string
+		if err := rows.Scan(&document); err != nil {
+			return nil, err
+		}
+		r := new(T)
+		if err := decode(document, r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// queryDocument runs a query selecting a JSON encoded record and decodes it into r. It returns
+// sql.ErrNoRows if the query selects no rows.
+func queryDocument(ctx context.Context, q querier, r interface{}, query string, args ...interface{}) error {
+	var document string
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&document); err != nil {
+		return err
+	}
+	return decode(document, r)
+}
+
+// encode returns the JSON encoding of a record
+func encode(r interface{}) (string, error) {
+	document, err := json.Marshal(r)
+	return string(document), err
+}
+
+// decode decodes a JSON encoded record into r
+func decode(document string, r interface{}) error {
+	return json.Unmarshal([]byte(document), r)
+}
+
+// affected returns whether a statement changed any rows
+func affected(result sql.Result) (bool, error) {
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
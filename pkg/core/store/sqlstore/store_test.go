@@ -0,0 +1,184 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/storetest"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// testDatabaseEnv names the environment variable holding the URL of a PostgreSQL database to run the tests
+// against, instead of a SQLite database. The tables of the stores are dropped from the database.
+const testDatabaseEnv = "FUSEML_TEST_DATABASE_URL"
+
+// tables are the tables created by the migrations, in the order they can be dropped
+var tables = []string{"extension_usage", "notification_deliveries", "notification_targets", "runnables", "applications", "extensions", "workflow_codesets", "workflows", "schema_migrations"}
+
+// newTestStore opens an empty, migrated database
+func newTestStore(t *testing.T) (*Store, func()) {
+	t.Helper()
+
+	driver, dsn := "sqlite3", filepath.Join(t.TempDir(), "fuseml.db")
+	if url := os.Getenv(testDatabaseEnv); url != "" {
+		driver, dsn = "postgres", url
+		dropTables(t, driver, dsn)
+	}
+
+	store, err := Open(slog.Default(), driver, dsn)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	return store, func() { store.Close() }
+}
+
+// dropTables drops the tables of the stores from a PostgreSQL test database
+func dropTables(t *testing.T, driver, dsn string) {
+	t.Helper()
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	for _, table := range tables {
+		if _, err := db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+			t.Fatalf("failed to drop table %s: %v", table, err)
+		}
+	}
+}
+
+func TestWorkflowStore(t *testing.T) {
+	storetest.TestWorkflowStore(t, func(t *testing.T) (domain.WorkflowStore, func()) {
+		store, done := newTestStore(t)
+		return NewWorkflowStore(store), done
+	})
+}
+
+func TestExtensionStore(t *testing.T) {
+	storetest.TestExtensionStore(t, func(t *testing.T) (domain.ExtensionStore, func()) {
+		store, done := newTestStore(t)
+		return NewExtensionStore(store), done
+	})
+}
+
+func TestApplicationStore(t *testing.T) {
+	storetest.TestApplicationStore(t, func(t *testing.T) (domain.ApplicationStore, func()) {
+		store, done := newTestStore(t)
+		return NewApplicationStore(store), done
+	})
+}
+
+func TestRunnableStore(t *testing.T) {
+	storetest.TestRunnableStore(t, func(t *testing.T) (domain.RunnableStore, func()) {
+		store, done := newTestStore(t)
+		return NewRunnableStore(store), done
+	})
+}
+
+func TestExtensionUsageStore(t *testing.T) {
+	storetest.TestExtensionUsageStore(t, func(t *testing.T) (domain.ExtensionUsageStore, func()) {
+		store, done := newTestStore(t)
+		return NewExtensionUsageStore(store), done
+	})
+}
+
+func TestNotificationStore(t *testing.T) {
+	storetest.TestNotificationStore(t, func(t *testing.T) (domain.NotificationStore, func()) {
+		store, done := newTestStore(t)
+		return NewNotificationStore(store), done
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("up to date", func(t *testing.T) {
+		store, done := newTestStore(t)
+		defer done()
+
+		version, err := schemaVersion(ctx, store.db)
+		assertNoError(t, err)
+		if version != SchemaVersion {
+			t.Errorf("got schema version %d, want %d", version, SchemaVersion)
+		}
+
+		// migrating again does not change anything
+		assertNoError(t, store.Migrate(ctx, slog.Default()))
+	})
+
+	t.Run("newer schema", func(t *testing.T) {
+		store, done := newTestStore(t)
+		defer done()
+
+		_, err := store.db.Exec("INSERT INTO schema_migrations (version, description, migrated) VALUES ($1, $2, $3)",
+			SchemaVersion+1, "newer", 0)
+		assertNoError(t, err)
+		if err := store.Migrate(ctx, slog.Default()); err == nil {
+			t.Errorf("migrated a database with a newer schema version")
+		}
+	})
+
+	t.Run("failed migration", func(t *testing.T) {
+		store, done := newTestStore(t)
+		defer done()
+
+		failed := errors.New("failed")
+		err := store.runMigrations(ctx, slog.Default(), append(migrations, migration{
+			version:     SchemaVersion + 1,
+			description: "failing",
+			migrate: func(ctx context.Context, tx *sql.Tx, d dialect) error {
+				if err := execMigration("CREATE TABLE failed (name {{text}})")(ctx, tx, d); err != nil {
+					return err
+				}
+				return failed
+			},
+		}))
+		if !errors.Is(err, failed) {
+			t.Errorf("got error %v, want %v", err, failed)
+		}
+
+		// the failed migration is rolled back
+		version, err := schemaVersion(ctx, store.db)
+		assertNoError(t, err)
+		if version != SchemaVersion {
+			t.Errorf("got schema version %d, want %d", version, SchemaVersion)
+		}
+		if _, err := store.db.Exec("SELECT * FROM failed"); err == nil {
+			t.Errorf("the table created by the failed migration exists")
+		}
+	})
+}
+
+func TestMigrations(t *testing.T) {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("migration %q has version %d, want %d", m.description, m.version, i+1)
+		}
+	}
+	if len(migrations) != SchemaVersion {
+		t.Errorf("the last migration has version %d, want the schema version %d", len(migrations), SchemaVersion)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if _, err := Open(slog.Default(), "mysql", ""); err == nil {
+		t.Errorf("opened a database with an unsupported driver")
+	}
+}
+
+func assertNoError(t testing.TB, got error) {
+	t.Helper()
+
+	if got != nil {
+		t.Errorf("got error %q wants no error", got)
+	}
+}
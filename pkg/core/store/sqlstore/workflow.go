@@ -0,0 +1,339 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/fuseml/fuseml-core/pkg/core/store/record"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// workflowColumns maps the workflow sort fields to the columns of the workflows table
+var workflowColumns = map[string]string{"Name": "name", "Created": "created"}
+
+// WorkflowStore is a SQL implementation of the domain.WorkflowStore interface. The codeset assignments are
+// stored apart from the workflows, in the workflow_codesets table, and are updated in the same transaction.
+type WorkflowStore struct {
+	store *Store
+}
+
+// NewWorkflowStore creates a new WorkflowStore.
+func NewWorkflowStore(store *Store) *WorkflowStore {
+	return &WorkflowStore{store: store}
+}
+
+// GetWorkflow returns a workflow identified by its name.
+func (ws *WorkflowStore) GetWorkflow(ctx context.Context, name string) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.GetWorkflow")
+	defer tracing.End(span, &err)
+
+	return ws.getWorkflow(ctx, ws.store.db, name, false)
+}
+
+// GetWorkflows returns the page of workflows selected by the list options, or the one that matches a given name.
+func (ws *WorkflowStore) GetWorkflows(ctx context.Context, name *string, opts *domain.ListOptions) (_ []*domain.Workflow, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.GetWorkflows")
+	defer tracing.End(span, &err)
+
+	window, err := opts.Window(domain.WorkflowSortFields, domain.WorkflowDefaultSort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := []*domain.Workflow{}
+	if name != nil {
+		wf, err := ws.getWorkflow(ctx, ws.store.db, *name, false)
+		if err == nil {
+			result = append(result, wf)
+		}
+		return result, "", nil
+	}
+
+	query, args := ws.store.pageQuery("SELECT document FROM workflows", nil, nil, window, workflowColumns)
+	records, err := queryDocuments[record.Workflow](ctx, ws.store.db, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	assignments, err := ws.codesetAssignments(ctx, ws.store.db, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, r := range records {
+		result = append(result, toDomainWorkflow(r, assignments[r.Name]))
+	}
+	result, next := page(result, window)
+	return result, next, nil
+}
+
+// AddWorkflow adds a new workflow based on the Workflow structure provided as argument.
+func (ws *WorkflowStore) AddWorkflow(ctx context.Context, w *domain.Workflow) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.AddWorkflow")
+	defer tracing.End(span, &err)
+
+	err = ws.store.inTx(ctx, func(tx *sql.Tx) error {
+		return ws.saveWorkflow(ctx, tx, w, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// UpdateWorkflow replaces the definition of a workflow in the store, keeping its stored codeset assignments.
+func (ws *WorkflowStore) UpdateWorkflow(ctx context.Context, w *domain.Workflow) (_ *domain.Workflow, err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.UpdateWorkflow")
+	defer tracing.End(span, &err)
+
+	var wf *domain.Workflow
+	err = ws.store.inTx(ctx, func(tx *sql.Tx) error {
+		if err := ws.saveWorkflow(ctx, tx, w, false); err != nil {
+			return err
+		}
+		wf, err = ws.getWorkflow(ctx, tx, w.Name, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wf, nil
+}
+
+// DeleteWorkflow deletes the workflow from the store.
+func (ws *WorkflowStore) DeleteWorkflow(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.DeleteWorkflow")
+	defer tracing.End(span, &err)
+
+	return ws.store.inTx(ctx, func(tx *sql.Tx) error {
+		wf, err := ws.getWorkflow(ctx, tx, name, true)
+		if err != nil {
+			if err == domain.ErrWorkflowNotFound {
+				return nil
+			}
+			return err
+		}
+		if len(wf.GetCodesetAssignments(ctx)) > 0 {
+			return domain.ErrCannotDeleteAssignedWorkflow
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM workflows WHERE name = $1", name)
+		return err
+	})
+}
+
+// GetCodesetAssignment returns a list of codesets assigned to the specified workflow.
+func (ws *WorkflowStore) GetCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset) (_ *domain.CodesetAssignment, err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.GetCodesetAssignment")
+	defer tracing.End(span, &err)
+
+	wf, err := ws.getWorkflow(ctx, ws.store.db, workflowName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return wf.GetCodesetAssignment(ctx, codeset)
+}
+
+// GetCodesetAssignments returns a AssignedCodeset for the Workflow and Codeset.
+func (ws *WorkflowStore) GetCodesetAssignments(ctx context.Context, workflowName string) []*domain.CodesetAssignment {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.GetCodesetAssignments")
+	defer span.End()
+
+	assignments, err := ws.codesetAssignments(ctx, ws.store.db, &workflowName)
+	if err != nil || len(assignments[workflowName]) == 0 {
+		return []*domain.CodesetAssignment{}
+	}
+	return assignments[workflowName]
+}
+
+// GetAllCodesetAssignments returns a map of workflows and its assigned codesets.
+func (ws *WorkflowStore) GetAllCodesetAssignments(ctx context.Context, workflowName *string) map[string][]*domain.CodesetAssignment {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.GetAllCodesetAssignments")
+	defer span.End()
+
+	assignments, err := ws.codesetAssignments(ctx, ws.store.db, workflowName)
+	if err != nil {
+		return map[string][]*domain.CodesetAssignment{}
+	}
+	return assignments
+}
+
+// AddCodesetAssignment adds a codeset to the list of assigned codesets of a workflow if it does not already exists.
+func (ws *WorkflowStore) AddCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset,
+	webhookID *int64) (_ []*domain.CodesetAssignment, err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.AddCodesetAssignment")
+	defer tracing.End(span, &err)
+
+	return ws.updateCodesetAssignments(ctx, workflowName, func(wf *domain.Workflow) error {
+		return wf.AssignToCodeset(ctx, codeset, webhookID)
+	})
+}
+
+// UpdateCodesetAssignmentWebhook changes the webhook of a codeset assignment of a workflow.
+func (ws *WorkflowStore) UpdateCodesetAssignmentWebhook(ctx context.Context, workflowName string, codeset *domain.Codeset,
+	webhookID *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.UpdateCodesetAssignmentWebhook")
+	defer tracing.End(span, &err)
+
+	_, err = ws.updateCodesetAssignments(ctx, workflowName, func(wf *domain.Workflow) error {
+		assignment, err := wf.GetCodesetAssignment(ctx, codeset)
+		if err != nil {
+			return err
+		}
+		assignment.WebhookID = webhookID
+		return nil
+	})
+	return err
+}
+
+// DeleteCodesetAssignment deletes a codeset from the list of assigned codesets of a workflow if it exists.
+func (ws *WorkflowStore) DeleteCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset) (_ []*domain.CodesetAssignment, err error) {
+	ctx, span := tracing.Start(ctx, "sql.WorkflowStore.DeleteCodesetAssignment")
+	defer tracing.End(span, &err)
+
+	return ws.updateCodesetAssignments(ctx, workflowName, func(wf *domain.Workflow) error {
+		return wf.UnassignFromCodeset(ctx, codeset)
+	})
+}
+
+// updateCodesetAssignments changes the codeset assignments of a workflow in a transaction, holding a lock on
+// the workflow, and returns the resulting assignments
+func (ws *WorkflowStore) updateCodesetAssignments(ctx context.Context, workflowName string,
+	change func(wf *domain.Workflow) error) (assignments []*domain.CodesetAssignment, err error) {
+	err = ws.store.inTx(ctx, func(tx *sql.Tx) error {
+		wf, err := ws.getWorkflow(ctx, tx, workflowName, true)
+		if err != nil {
+			return err
+		}
+		if err := change(wf); err != nil {
+			return err
+		}
+		if err := ws.saveCodesetAssignments(ctx, tx, wf); err != nil {
+			return err
+		}
+		assignments = wf.GetCodesetAssignments(ctx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// getWorkflow returns the workflow stored under a name, locking it until the end of the transaction if lock
+// is set
+func (ws *WorkflowStore) getWorkflow(ctx context.Context, q querier, name string, lock bool) (*domain.Workflow, error) {
+	query := "SELECT document FROM workflows WHERE name = $1"
+	if lock {
+		query += ws.store.dialect.forUpdate
+	}
+	r := record.Workflow{}
+	if err := queryDocument(ctx, q, &r, query, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWorkflowNotFound
+		}
+		return nil, err
+	}
+	assignments, err := ws.codesetAssignments(ctx, q, &name)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWorkflow(&r, assignments[name]), nil
+}
+
+// codesetAssignments returns the codeset assignments of a workflow, or of all workflows if no name is
+// given, indexed by workflow name. Workflows that are not assigned to codesets are not included.
+func (ws *WorkflowStore) codesetAssignments(ctx context.Context, q querier, workflowName *string) (map[string][]*domain.CodesetAssignment, error) {
+	query, args := "SELECT workflow, document FROM workflow_codesets", []interface{}{}
+	if workflowName != nil {
+		query, args = query+" WHERE workflow = $1", append(args, *workflowName)
+	}
+	rows, err := q.QueryContext(ctx, query+" ORDER BY workflow, seq", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*domain.CodesetAssignment)
+	for rows.Next() {
+		var workflow, document string
+		if err := rows.Scan(&workflow, &document); err != nil {
+			return nil, err
+		}
+		r := record.CodesetAssignment{}
+		if err := decode(document, &r); err != nil {
+			return nil, err
+		}
+		result[workflow] = append(result[workflow], r.ToDomain())
+	}
+	return result, rows.Err()
+}
+
+// saveWorkflow inserts a new workflow, or replaces an existing one, along with its codeset assignments
+func (ws *WorkflowStore) saveWorkflow(ctx context.Context, tx *sql.Tx, w *domain.Workflow, insert bool) error {
+	r := record.NewWorkflow(w)
+	// the codeset assignments are stored in their own table
+	r.AssignedTo = nil
+	document, err := encode(r)
+	if err != nil {
+		return err
+	}
+
+	if insert {
+		result, err := tx.ExecContext(ctx,
+			"INSERT INTO workflows (name, created, document) VALUES ($1, $2, $3) ON CONFLICT (name) DO NOTHING",
+			w.Name, w.Created.UnixMicro(), document)
+		if err != nil {
+			return err
+		}
+		if ok, err := affected(result); err != nil || !ok {
+			if err == nil {
+				err = domain.ErrWorkflowExists
+			}
+			return err
+		}
+	} else {
+		result, err := tx.ExecContext(ctx, "UPDATE workflows SET created = $1, document = $2 WHERE name = $3",
+			w.Created.UnixMicro(), document, w.Name)
+		if err != nil {
+			return err
+		}
+		if ok, err := affected(result); err != nil || !ok {
+			if err == nil {
+				err = domain.ErrWorkflowNotFound
+			}
+			return err
+		}
+		// the codeset assignments are changed on their own, an update must not overwrite them
+		return nil
+	}
+	return ws.saveCodesetAssignments(ctx, tx, w)
+}
+
+// saveCodesetAssignments replaces the stored codeset assignments of a workflow with its current ones
+func (ws *WorkflowStore) saveCodesetAssignments(ctx context.Context, tx *sql.Tx, w *domain.Workflow) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM workflow_codesets WHERE workflow = $1", w.Name); err != nil {
+		return err
+	}
+	for i, a := range w.GetCodesetAssignments(ctx) {
+		document, err := encode(record.NewCodesetAssignment(a))
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "INSERT INTO workflow_codesets (workflow, seq, document) VALUES ($1, $2, $3)",
+			w.Name, i, document)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toDomainWorkflow returns the workflow stored in a record, with its codeset assignments
+func toDomainWorkflow(r *record.Workflow, assignments []*domain.CodesetAssignment) *domain.Workflow {
+	wf := r.ToDomain()
+	if len(assignments) > 0 {
+		wf.AssignedTo = &domain.WorkflowAssignment{Codesets: assignments}
+	}
+	return wf
+}
@@ -0,0 +1,279 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// NewApplicationStore returns a new, empty application store and a function that releases it.
+type NewApplicationStore func(t *testing.T) (domain.ApplicationStore, func())
+
+// TestApplicationStore runs the conformance tests of the domain.ApplicationStore implementations against the
+// stores returned by newApplicationStore.
+func TestApplicationStore(t *testing.T, newApplicationStore NewApplicationStore) {
+	t.Run("ApplicationAdd", func(t *testing.T) { testApplicationAdd(t, newApplicationStore) })
+	t.Run("ApplicationUpdate", func(t *testing.T) { testApplicationUpdate(t, newApplicationStore) })
+	t.Run("ApplicationFind", func(t *testing.T) { testApplicationFind(t, newApplicationStore) })
+	t.Run("ApplicationGetAll", func(t *testing.T) { testApplicationGetAll(t, newApplicationStore) })
+	t.Run("ApplicationDelete", func(t *testing.T) { testApplicationDelete(t, newApplicationStore) })
+}
+
+func testApplicationAdd(t *testing.T, newApplicationStore NewApplicationStore) {
+	t.Run("new", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app := domain.Application{
+			Name: "test-app",
+		}
+
+		got, err := store.Add(context.TODO(), &app)
+		assertNoError(t, err)
+
+		if d := cmp.Diff(&app, got); d != "" {
+			t.Errorf("Unexpected Application: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app := domain.Application{
+			Name: "test-app",
+			Type: "testType1",
+		}
+
+		store.Add(context.TODO(), &app)
+
+		app.Type = "testType2"
+		_, err := store.Add(context.TODO(), &app)
+		if err != domain.ErrApplicationExists {
+			t.Errorf("got error %q want %q", err, domain.ErrApplicationExists)
+		}
+	})
+}
+
+func testApplicationUpdate(t *testing.T, newApplicationStore NewApplicationStore) {
+	t.Run("existing", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app := domain.Application{
+			Name: "test-app",
+			Type: "testType1",
+		}
+
+		store.Add(context.TODO(), &app)
+
+		app.Type = "testType2"
+		got, err := store.Update(context.TODO(), &app)
+		assertNoError(t, err)
+
+		if d := cmp.Diff(&app, got); d != "" {
+			t.Errorf("Unexpected Application: %s", diff.PrintWantGot(d))
+		}
+		if d := cmp.Diff(&app, store.Find(context.TODO(), app.Name)); d != "" {
+			t.Errorf("Unexpected Application: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("non-existing", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		_, err := store.Update(context.TODO(), &domain.Application{Name: "non-existing"})
+		if err != domain.ErrApplicationNotFound {
+			t.Errorf("got error %q want %q", err, domain.ErrApplicationNotFound)
+		}
+	})
+}
+
+func testApplicationFind(t *testing.T, newApplicationStore NewApplicationStore) {
+	t.Run("existing", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app := domain.Application{
+			Name: "test-app",
+		}
+
+		store.Add(context.TODO(), &app)
+
+		got := store.Find(context.TODO(), app.Name)
+		if d := cmp.Diff(&app, got); d != "" {
+			t.Errorf("Unexpected Application: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("non-existing", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		got := store.Find(context.TODO(), "non-existing")
+		if got != nil {
+			t.Errorf("Expected nil, got %v", got)
+		}
+	})
+}
+
+func testApplicationGetAll(t *testing.T, newApplicationStore NewApplicationStore) {
+	t.Run("all", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app1 := domain.Application{
+			Name: "test-app1",
+		}
+		app2 := domain.Application{
+			Name: "test-app2",
+		}
+		app3 := domain.Application{
+			Name: "test-app3",
+		}
+
+		store.Add(context.TODO(), &app1)
+		store.Add(context.TODO(), &app2)
+		store.Add(context.TODO(), &app3)
+
+		got, _, err := store.GetAll(context.TODO(), nil, nil, nil)
+		assertNoError(t, err)
+		want := []*domain.Application{&app1, &app2, &app3}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Applications: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("by type", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app1 := domain.Application{
+			Name: "test-app1",
+			Type: "testType1",
+		}
+
+		app2 := domain.Application{
+			Name: "test-app2",
+			Type: "testType2",
+		}
+
+		store.Add(context.TODO(), &app1)
+		store.Add(context.TODO(), &app2)
+
+		got, _, err := store.GetAll(context.TODO(), &app1.Type, nil, nil)
+		assertNoError(t, err)
+
+		want := []*domain.Application{&app1}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Applications: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("by workflow", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app1 := domain.Application{
+			Name:     "test-app1",
+			Type:     "testType1",
+			Workflow: "wf-1",
+		}
+
+		app2 := domain.Application{
+			Name:     "test-app2",
+			Type:     "testType2",
+			Workflow: "wf-2",
+		}
+
+		store.Add(context.TODO(), &app1)
+		store.Add(context.TODO(), &app2)
+
+		got, _, err := store.GetAll(context.TODO(), nil, &app1.Workflow, nil)
+		assertNoError(t, err)
+
+		want := []*domain.Application{&app1}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Applications: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("by type and workflow", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app1 := domain.Application{
+			Name:     "test-app1",
+			Type:     "testType1",
+			Workflow: "wf-1",
+		}
+
+		app2 := domain.Application{
+			Name:     "test-app2",
+			Type:     "testType2",
+			Workflow: "wf-2",
+		}
+
+		app3 := domain.Application{
+			Name:     "test-app3",
+			Type:     "testType1",
+			Workflow: "wf-2",
+		}
+
+		app4 := domain.Application{
+			Name:     "test-app4",
+			Type:     "testType2",
+			Workflow: "wf-1",
+		}
+
+		store.Add(context.TODO(), &app1)
+		store.Add(context.TODO(), &app2)
+		store.Add(context.TODO(), &app3)
+		store.Add(context.TODO(), &app4)
+
+		got, _, err := store.GetAll(context.TODO(), &app1.Type, &app1.Workflow, nil)
+		assertNoError(t, err)
+
+		want := []*domain.Application{&app1}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Applications: %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
+func testApplicationDelete(t *testing.T, newApplicationStore NewApplicationStore) {
+	t.Run("existing", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		app := domain.Application{
+			Name: "test-app",
+		}
+
+		store.Add(context.TODO(), &app)
+
+		store.Delete(context.TODO(), app.Name)
+
+		got := store.Find(context.TODO(), app.Name)
+		if got != nil {
+			t.Errorf("Expected nil, got %v", got)
+		}
+	})
+
+	t.Run("non-existing", func(t *testing.T) {
+		store, done := newApplicationStore(t)
+		defer done()
+
+		store.Delete(context.TODO(), "non-existing")
+
+		got := store.Find(context.TODO(), "non-existing")
+		if got != nil {
+			t.Errorf("Expected nil, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+// Package storetest implements the conformance tests shared by the implementations of the store interfaces.
+package storetest
+
+import "testing"
+
+func assertError(t testing.TB, got, want error) {
+	t.Helper()
+
+	if got != want {
+		t.Errorf("got error %q want %q", got, want)
+	}
+}
+
+func assertNoError(t testing.TB, got error) {
+	t.Helper()
+
+	if got != nil {
+		t.Errorf("got error %q wants no error", got)
+	}
+}
+
+func assertErrorMessage(t *testing.T, want error, got error) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if want.Error() != got.Error() {
+		t.Errorf("expected error, got %q but want %q", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// NewExtensionUsageStore returns a new, empty extension usage store and a function that releases it.
+type NewExtensionUsageStore func(t *testing.T) (domain.ExtensionUsageStore, func())
+
+// TestExtensionUsageStore runs the conformance tests of the domain.ExtensionUsageStore implementations against
+// the stores returned by newExtensionUsageStore.
+func TestExtensionUsageStore(t *testing.T, newExtensionUsageStore NewExtensionUsageStore) {
+	store, done := newExtensionUsageStore(t)
+	defer done()
+	ctx := context.TODO()
+
+	bound := time.Now()
+	err := store.AddUsage(ctx, []*domain.ExtensionUsage{
+		{Workflow: "wf0", Step: "s0", ExtensionID: "ext", ServiceID: "svc0", CredentialsID: "creds", Bound: bound},
+		{Workflow: "wf0", Step: "s1", ExtensionID: "ext", ServiceID: "svc1", Bound: bound},
+	})
+	assertNoError(t, err)
+	err = store.AddUsage(ctx, []*domain.ExtensionUsage{
+		{Workflow: "wf1", Step: "s0", ExtensionID: "ext", ServiceID: "svc0", Bound: bound.Add(time.Second)},
+	})
+	assertNoError(t, err)
+
+	count := func(filter *domain.ExtensionUsageFilter) int {
+		t.Helper()
+		usage, err := store.GetUsage(ctx, filter)
+		assertNoError(t, err)
+		return len(usage)
+	}
+
+	tests := []struct {
+		name   string
+		filter *domain.ExtensionUsageFilter
+		want   int
+	}{
+		{"all", nil, 3},
+		{"extension", &domain.ExtensionUsageFilter{ExtensionID: "ext"}, 3},
+		{"service", &domain.ExtensionUsageFilter{ExtensionID: "ext", ServiceID: "svc0"}, 2},
+		{"credentials", &domain.ExtensionUsageFilter{ExtensionID: "ext", ServiceID: "svc0", CredentialsID: "creds"}, 1},
+		{"workflow", &domain.ExtensionUsageFilter{Workflow: "wf1"}, 1},
+		{"unknown", &domain.ExtensionUsageFilter{ExtensionID: "other"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := count(tt.filter); got != tt.want {
+				t.Errorf("got %d usage records want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("release", func(t *testing.T) {
+		released := time.Now()
+		err := store.ReleaseUsage(ctx, "wf0", released)
+		assertNoError(t, err)
+
+		if got := count(&domain.ExtensionUsageFilter{ExtensionID: "ext"}); got != 1 {
+			t.Errorf("got %d active usage records want 1", got)
+		}
+		usage, err := store.GetUsage(ctx, &domain.ExtensionUsageFilter{ExtensionID: "ext", All: true})
+		assertNoError(t, err)
+		if len(usage) != 3 || usage[2].Workflow != "wf1" {
+			t.Fatalf("Unexpected usage records: %v", usage)
+		}
+		if !usage[0].Released.Equal(released) || usage[0].Step != "s0" || usage[0].CredentialsID != "creds" {
+			t.Errorf("Unexpected released usage record: %+v", usage[0])
+		}
+
+		// released bindings are not released again
+		assertNoError(t, store.ReleaseUsage(ctx, "wf0", released.Add(time.Hour)))
+		usage, err = store.GetUsage(ctx, &domain.ExtensionUsageFilter{Workflow: "wf0", All: true})
+		assertNoError(t, err)
+		if len(usage) != 2 || !usage[1].Released.Equal(released) {
+			t.Errorf("Unexpected usage records: %v", usage)
+		}
+	})
+}
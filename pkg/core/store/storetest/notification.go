@@ -0,0 +1,101 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// NewNotificationStore returns a new, empty notification store and a function that releases it.
+type NewNotificationStore func(t *testing.T) (domain.NotificationStore, func())
+
+// TestNotificationStore runs the conformance tests of the domain.NotificationStore implementations against the
+// stores returned by newNotificationStore.
+func TestNotificationStore(t *testing.T, newNotificationStore NewNotificationStore) {
+	store, done := newNotificationStore(t)
+	defer done()
+	ctx := context.TODO()
+
+	targets := []*domain.NotificationTarget{
+		{Name: "all", Type: domain.SlackTarget, URL: "http://slack.test"},
+		{Name: "nightly", Type: domain.WebhookTarget, Workflow: "nightly", URL: "http://hook.test"},
+		{Name: "team", Type: domain.EmailTarget, Project: "team", Recipients: []string{"team@fuseml.test"}},
+	}
+	for _, target := range targets {
+		_, err := store.AddTarget(ctx, target)
+		assertNoError(t, err)
+	}
+
+	t.Run("add existing", func(t *testing.T) {
+		_, err := store.AddTarget(ctx, &domain.NotificationTarget{Name: "all"})
+		assertError(t, err, domain.ErrNotificationTargetExists)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := store.GetTarget(ctx, "team")
+		assertNoError(t, err)
+		if got.Type != domain.EmailTarget || got.Project != "team" || len(got.Recipients) != 1 {
+			t.Errorf("Unexpected target: %+v", got)
+		}
+		_, err = store.GetTarget(ctx, "missing")
+		assertError(t, err, domain.ErrNotificationTargetNotFound)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, _, err := store.GetTargets(ctx, "", "", nil)
+		assertNoError(t, err)
+		if len(got) != 3 {
+			t.Errorf("got %d targets want 3", len(got))
+		}
+		got, _, err = store.GetTargets(ctx, "nightly", "", nil)
+		assertNoError(t, err)
+		if len(got) != 1 || got[0].Name != "nightly" {
+			t.Errorf("Unexpected targets: %v", got)
+		}
+		got, next, err := store.GetTargets(ctx, "", "", &domain.ListOptions{Limit: 2, Sort: "-name"})
+		assertNoError(t, err)
+		if len(got) != 2 || next == "" || got[0].Name != "team" || got[1].Name != "nightly" {
+			t.Errorf("Unexpected targets: %v", got)
+		}
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		created := time.Now()
+		for i := 0; i < 3; i++ {
+			d := &domain.NotificationDelivery{Target: "nightly", Run: "run", Status: domain.DeliveryPending, Created: created.Add(time.Duration(i) * time.Second)}
+			assertNoError(t, store.AddDelivery(ctx, d))
+			d.Status = domain.DeliverySucceeded
+			assertNoError(t, store.UpdateDelivery(ctx, d))
+		}
+		assertNoError(t, store.AddDelivery(ctx, &domain.NotificationDelivery{Target: "all", Created: created}))
+
+		got, next, err := store.GetDeliveries(ctx, "nightly", &domain.ListOptions{Limit: 2})
+		assertNoError(t, err)
+		if len(got) != 2 || next == "" || !got[0].Created.After(got[1].Created) || got[0].Status != domain.DeliverySucceeded {
+			t.Errorf("Unexpected deliveries: %v", got)
+		}
+		if got[0].ID == got[1].ID {
+			t.Errorf("Deliveries have the same ID: %v", got)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assertNoError(t, store.DeleteTarget(ctx, "nightly"))
+		_, err := store.GetTarget(ctx, "nightly")
+		assertError(t, err, domain.ErrNotificationTargetNotFound)
+		assertError(t, store.DeleteTarget(ctx, "nightly"), domain.ErrNotificationTargetNotFound)
+
+		got, _, err := store.GetDeliveries(ctx, "nightly", nil)
+		assertNoError(t, err)
+		if len(got) != 0 {
+			t.Errorf("Expected the delivery log to be deleted, got %d deliveries", len(got))
+		}
+		got, _, err = store.GetDeliveries(ctx, "all", nil)
+		assertNoError(t, err)
+		if len(got) != 1 {
+			t.Errorf("Expected the delivery log of other targets to be kept, got %d deliveries", len(got))
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/test/diff"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// NewRunnableStore returns a new, empty runnable store and a function that releases it.
+type NewRunnableStore func(t *testing.T) (domain.RunnableStore, func())
+
+// TestRunnableStore runs the conformance tests of the domain.RunnableStore implementations against the
+// stores returned by newRunnableStore.
+func TestRunnableStore(t *testing.T, newRunnableStore NewRunnableStore) {
+	t.Run("Register", func(t *testing.T) { testRunnableRegister(t, newRunnableStore) })
+	t.Run("Get", func(t *testing.T) { testRunnableGet(t, newRunnableStore) })
+	t.Run("Find", func(t *testing.T) { testRunnableFind(t, newRunnableStore) })
+}
+
+func newTestRunnable(id, kind string, labels map[string]string) *domain.Runnable {
+	return &domain.Runnable{
+		ID:        id,
+		Kind:      kind,
+		Container: domain.RunnableContainer{Image: "ghcr.io/fuseml/" + id, Args: []string{"--verbose"}},
+		Inputs: map[string]interface{}{
+			"mlflow-codeset": &domain.RunnableInputCodeset{
+				RunnableInputArtifact: domain.RunnableInputArtifact{
+					RunnableArtifactArgDesc: domain.RunnableArtifactArgDesc{
+						RunnableArgDesc: domain.RunnableArgDesc{Name: "mlflow-codeset"},
+						Provider:        []domain.ArtifactProvider{domain.APFuseml},
+					},
+					Path: "/project",
+				},
+				RunnableCodesetArtifact: domain.RunnableCodesetArtifact{Type: []string{"mlflow"}},
+			},
+			"predictor": &domain.RunnableInputParameter{
+				RunnableArgDesc: domain.RunnableArgDesc{Name: "predictor"},
+				Optional:        true,
+				DefaultValue:    "auto",
+			},
+		},
+		Outputs: map[string]interface{}{
+			"model": &domain.RunnableOutputModel{
+				RunnableOutputArtifact: domain.RunnableOutputArtifact{
+					RunnableArtifactArgDesc: domain.RunnableArtifactArgDesc{RunnableArgDesc: domain.RunnableArgDesc{Name: "model"}},
+				},
+				RunnableModelArtifact: domain.RunnableModelArtifact{Format: []string{"mlflow"}},
+			},
+		},
+		Labels: labels,
+	}
+}
+
+// ignoreCreated ignores the creation time set by the stores when runnables are registered
+var ignoreCreated = cmpopts.IgnoreFields(domain.Runnable{}, "Created")
+
+func testRunnableRegister(t *testing.T, newRunnableStore NewRunnableStore) {
+	t.Run("new", func(t *testing.T) {
+		store, done := newRunnableStore(t)
+		defer done()
+
+		r := newTestRunnable("mlflow-trainer", "trainer", map[string]string{"framework": "mlflow"})
+		got, err := store.Register(context.TODO(), r)
+		assertNoError(t, err)
+		if d := cmp.Diff(r, got, ignoreCreated); d != "" {
+			t.Errorf("Unexpected Runnable: %s", diff.PrintWantGot(d))
+		}
+		if got.Created.IsZero() {
+			t.Errorf("Registered runnable has no creation time")
+		}
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		store, done := newRunnableStore(t)
+		defer done()
+
+		r := newTestRunnable("mlflow-trainer", "trainer", nil)
+		_, err := store.Register(context.TODO(), r)
+		assertNoError(t, err)
+
+		_, err = store.Register(context.TODO(), r)
+		if err == nil {
+			t.Errorf("Registered a runnable with an existing ID")
+		}
+	})
+}
+
+func testRunnableGet(t *testing.T, newRunnableStore NewRunnableStore) {
+	t.Run("found", func(t *testing.T) {
+		store, done := newRunnableStore(t)
+		defer done()
+
+		r := newTestRunnable("mlflow-trainer", "trainer", map[string]string{"framework": "mlflow"})
+		registered, err := store.Register(context.TODO(), r)
+		assertNoError(t, err)
+
+		got, err := store.Get(context.TODO(), "mlflow-trainer")
+		assertNoError(t, err)
+		if d := cmp.Diff(registered, got); d != "" {
+			t.Errorf("Unexpected Runnable: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		store, done := newRunnableStore(t)
+		defer done()
+
+		got, err := store.Get(context.TODO(), "mlflow-trainer")
+		assertNoError(t, err)
+		if got != nil {
+			t.Errorf("Expected nil, got %v", got)
+		}
+	})
+}
+
+func testRunnableFind(t *testing.T, newRunnableStore NewRunnableStore) {
+	store, done := newRunnableStore(t)
+	defer done()
+
+	for _, r := range []*domain.Runnable{
+		newTestRunnable("mlflow-builder", "builder", map[string]string{"framework": "mlflow"}),
+		newTestRunnable("mlflow-trainer", "trainer", map[string]string{"framework": "mlflow", "gpu": "nvidia"}),
+		newTestRunnable("sklearn-trainer", "trainer", map[string]string{"framework": "sklearn"}),
+		newTestRunnable("kfserving-predictor", "predictor", nil),
+	} {
+		_, err := store.Register(context.TODO(), r)
+		assertNoError(t, err)
+	}
+
+	ids := func(runnables []*domain.Runnable) []string {
+		result := []string{}
+		for _, r := range runnables {
+			result = append(result, r.ID)
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		name   string
+		id     string
+		kind   string
+		labels map[string]string
+		want   []string
+	}{
+		{name: "all", want: []string{"kfserving-predictor", "mlflow-builder", "mlflow-trainer", "sklearn-trainer"}},
+		{name: "by id", id: "mlflow-trainer", want: []string{"mlflow-trainer"}},
+		{name: "by id regexp", id: "^mlflow-", want: []string{"mlflow-builder", "mlflow-trainer"}},
+		{name: "by kind", kind: "trainer", want: []string{"mlflow-trainer", "sklearn-trainer"}},
+		{name: "by label", labels: map[string]string{"framework": "mlflow"}, want: []string{"mlflow-builder", "mlflow-trainer"}},
+		{name: "by label key", labels: map[string]string{"gpu": ""}, want: []string{"mlflow-trainer"}},
+		{name: "by label regexp", labels: map[string]string{"framework": "^sk"}, want: []string{"sklearn-trainer"}},
+		{name: "by kind and label", kind: "trainer", labels: map[string]string{"framework": "mlflow"}, want: []string{"mlflow-trainer"}},
+		{name: "no match", id: "tensorflow", want: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, next, err := store.Find(context.TODO(), tc.id, tc.kind, tc.labels, &domain.ListOptions{})
+			assertNoError(t, err)
+			if d := cmp.Diff(tc.want, ids(got)); d != "" {
+				t.Errorf("Unexpected Runnables: %s", diff.PrintWantGot(d))
+			}
+			if next != "" {
+				t.Errorf("Unexpected continue token: %q", next)
+			}
+		})
+	}
+
+	t.Run("paged", func(t *testing.T) {
+		opts := domain.ListOptions{Limit: 3, Sort: "-kind"}
+		got, next, err := store.Find(context.TODO(), "", "", nil, &opts)
+		assertNoError(t, err)
+		if d := cmp.Diff([]string{"sklearn-trainer", "mlflow-trainer", "kfserving-predictor"}, ids(got)); d != "" {
+			t.Errorf("Unexpected Runnables: %s", diff.PrintWantGot(d))
+		}
+
+		opts.Continue = next
+		got, next, err = store.Find(context.TODO(), "", "", nil, &opts)
+		assertNoError(t, err)
+		if d := cmp.Diff([]string{"mlflow-builder"}, ids(got)); d != "" {
+			t.Errorf("Unexpected Runnables: %s", diff.PrintWantGot(d))
+		}
+		if next != "" {
+			t.Errorf("Unexpected continue token: %q", next)
+		}
+	})
+}
@@ -0,0 +1,690 @@
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// NewWorkflowStore returns a new, empty workflow store and a function that releases it.
+type NewWorkflowStore func(t *testing.T) (domain.WorkflowStore, func())
+
+// TestWorkflowStore runs the conformance tests of the domain.WorkflowStore implementations against the
+// stores returned by newWorkflowStore.
+func TestWorkflowStore(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("GetWorkflow", func(t *testing.T) { testGetWorkflow(t, newWorkflowStore) })
+	t.Run("GetWorkflows", func(t *testing.T) { testGetWorkflows(t, newWorkflowStore) })
+	t.Run("AddWorkflow", func(t *testing.T) { testAddWorkflow(t, newWorkflowStore) })
+	t.Run("UpdateWorkflow", func(t *testing.T) { testUpdateWorkflow(t, newWorkflowStore) })
+	t.Run("DeleteWorkflow", func(t *testing.T) { testDeleteWorkflow(t, newWorkflowStore) })
+	t.Run("AddCodesetAssignment", func(t *testing.T) { testAddCodesetAssignment(t, newWorkflowStore) })
+	t.Run("UpdateCodesetAssignmentWebhook", func(t *testing.T) { testUpdateCodesetAssignmentWebhook(t, newWorkflowStore) })
+	t.Run("GetCodesetAssignments", func(t *testing.T) { testGetCodesetAssignments(t, newWorkflowStore) })
+	t.Run("GetAllCodesetAssignments", func(t *testing.T) { testGetAllCodesetAssignments(t, newWorkflowStore) })
+	t.Run("DeleteCodesetAssignment", func(t *testing.T) { testDeleteCodesetAssignment(t, newWorkflowStore) })
+	t.Run("GetCodesetAssignment", func(t *testing.T) { testGetCodesetAssignment(t, newWorkflowStore) })
+}
+
+func testGetWorkflow(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("found", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wf := domain.Workflow{Name: "test"}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		got, err := store.GetWorkflow(context.TODO(), "test")
+		assertNoError(t, err)
+		if d := cmp.Diff(&wf, got); d != "" {
+			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		_, got := store.GetWorkflow(context.TODO(), "test")
+		assertError(t, got, domain.ErrWorkflowNotFound)
+	})
+}
+
+func testGetWorkflows(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("all", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		// empty
+		want := []*domain.Workflow{}
+		got, _, _ := store.GetWorkflows(context.TODO(), nil, nil)
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
+		}
+
+		// add workflows
+		for i := 0; i < 2; i++ {
+			wf := domain.Workflow{Name: fmt.Sprintf("test-%d", i)}
+			_, err := store.AddWorkflow(context.TODO(), &wf)
+			assertNoError(t, err)
+
+			want = append(want, &wf)
+		}
+
+		// should return all
+		got, _, _ = store.GetWorkflows(context.TODO(), nil, nil)
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		// empty
+		want := []*domain.Workflow{}
+		name := "test"
+		got, _, _ := store.GetWorkflows(context.TODO(), &name, nil)
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Workflows: %s", diff.PrintWantGot(d))
+		}
+
+		// add workflows
+		for i := 0; i < 2; i++ {
+			wf := domain.Workflow{Name: fmt.Sprintf("test-%d", i)}
+			_, err := store.AddWorkflow(context.TODO(), &wf)
+			assertNoError(t, err)
+
+			want = append(want, &wf)
+		}
+
+		// should return one workflow
+		got, _, _ = store.GetWorkflows(context.TODO(), &want[0].Name, nil)
+		if d := cmp.Diff(want[0], got[0]); d != "" {
+			t.Errorf("Unexpected Workflows: %s", diff.PrintWantGot(d))
+		}
+
+		// should return no workflows
+		name = "no-wf"
+		got, _, _ = store.GetWorkflows(context.TODO(), &name, nil)
+		if d := cmp.Diff([]*domain.Workflow{}, got); d != "" {
+			t.Errorf("Unexpected Workflows: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		want := []*domain.Workflow{}
+		for i := 4; i >= 0; i-- {
+			wf := domain.Workflow{Name: fmt.Sprintf("test-%d", i)}
+			_, err := store.AddWorkflow(context.TODO(), &wf)
+			assertNoError(t, err)
+
+			want = append(want, &wf)
+		}
+
+		// should return all workflows, in descending order, two at a time
+		opts := domain.ListOptions{Limit: 2, Sort: "-name"}
+		got := []*domain.Workflow{}
+		pages := 0
+		for {
+			page, next, err := store.GetWorkflows(context.TODO(), nil, &opts)
+			assertNoError(t, err)
+			if len(page) > opts.Limit {
+				t.Fatalf("Unexpected page size: %d", len(page))
+			}
+			got = append(got, page...)
+			pages++
+			if next == "" {
+				break
+			}
+			opts.Continue = next
+		}
+		if pages != 3 {
+			t.Errorf("Unexpected number of pages: %d", pages)
+		}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Workflows: %s", diff.PrintWantGot(d))
+		}
+
+		// the next page starts after the last workflow of the previous one, even if that workflow is deleted
+		opts.Continue = ""
+		first, next, err := store.GetWorkflows(context.TODO(), nil, &opts)
+		assertNoError(t, err)
+		assertNoError(t, store.DeleteWorkflow(context.TODO(), first[1].Name))
+		opts.Continue = next
+		second, _, err := store.GetWorkflows(context.TODO(), nil, &opts)
+		assertNoError(t, err)
+		if d := cmp.Diff(want[2:4], second); d != "" {
+			t.Errorf("Unexpected Workflows after deleting: %s", diff.PrintWantGot(d))
+		}
+
+		// continue token issued for a different sort order
+		opts.Sort = "name"
+		_, _, err = store.GetWorkflows(context.TODO(), nil, &opts)
+		assertErrorMessage(t, &domain.ErrInvalidListOptions{Reason: "continue token was issued for a different sort order"}, err)
+
+		// unsupported sort field
+		_, _, err = store.GetWorkflows(context.TODO(), nil, &domain.ListOptions{Sort: "status"})
+		assertErrorMessage(t, &domain.ErrInvalidListOptions{Reason: "cannot sort by 'status', supported fields: created, name"}, err)
+	})
+}
+
+func testAddWorkflow(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("new", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wf := domain.Workflow{Name: "test"}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		got, err := store.GetWorkflow(context.TODO(), "test")
+		assertNoError(t, err)
+		if d := cmp.Diff(&wf, got); d != "" {
+			t.Errorf("Unexpected Workflow: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wf := domain.Workflow{Name: "test"}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		_, err = store.AddWorkflow(context.TODO(), &wf)
+		assertError(t, err, domain.ErrWorkflowExists)
+	})
+}
+
+func testUpdateWorkflow(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("not found", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		_, err := store.UpdateWorkflow(context.TODO(), &domain.Workflow{Name: "test"})
+		assertError(t, err, domain.ErrWorkflowNotFound)
+	})
+
+	t.Run("keeps assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		_, err := store.AddWorkflow(context.TODO(), &domain.Workflow{Name: wfName})
+		assertNoError(t, err)
+
+		// the workflow is updated from a copy read before the codeset was assigned
+		update := domain.Workflow{Name: wfName, Description: "updated"}
+		cs := domain.Codeset{Name: "test-cs"}
+		webhookID := (int64)(10)
+		_, err = store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+		assertNoError(t, err)
+
+		updated, err := store.UpdateWorkflow(context.TODO(), &update)
+		assertNoError(t, err)
+
+		want := []*domain.CodesetAssignment{{Codeset: &cs, WebhookID: &webhookID}}
+		if d := cmp.Diff(want, updated.GetCodesetAssignments(context.TODO())); d != "" {
+			t.Errorf("Unexpected Assignments of the updated Workflow: %s", diff.PrintWantGot(d))
+		}
+
+		got, err := store.GetWorkflow(context.TODO(), wfName)
+		assertNoError(t, err)
+		if got.Description != "updated" {
+			t.Errorf("got description %q want %q", got.Description, "updated")
+		}
+		if d := cmp.Diff(want, got.GetCodesetAssignments(context.TODO())); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
+func testDeleteWorkflow(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("existing", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wf := domain.Workflow{Name: "test"}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		err = store.DeleteWorkflow(context.TODO(), "test")
+		assertNoError(t, err)
+
+		_, err = store.GetWorkflow(context.TODO(), "test")
+		assertError(t, err, domain.ErrWorkflowNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		err := store.DeleteWorkflow(context.TODO(), "test")
+		assertNoError(t, err)
+	})
+
+	t.Run("assigned", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+		webhookID := (int64)(10)
+
+		store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+
+		err = store.DeleteWorkflow(context.TODO(), wfName)
+		assertError(t, err, domain.ErrCannotDeleteAssignedWorkflow)
+	})
+}
+
+func testAddCodesetAssignment(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("no workflow", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		webhookID := (int64)(10)
+		_, err := store.AddCodesetAssignment(context.TODO(), "", &cs, &webhookID)
+		assertError(t, err, domain.ErrWorkflowNotFound)
+	})
+
+	t.Run("new", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		webhookID := (int64)(10)
+		got, err := store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+		assertNoError(t, err)
+
+		want := []*domain.CodesetAssignment{{Codeset: &cs, WebhookID: &webhookID}}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		webhookID := (int64)(10)
+
+		got, err := store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+		assertNoError(t, err)
+
+		want := []*domain.CodesetAssignment{{Codeset: &cs, WebhookID: &webhookID}}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+
+		got, err = store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+		assertNoError(t, err)
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
+func testUpdateCodesetAssignmentWebhook(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	cs1 := domain.Codeset{Name: "test-cs"}
+	cs2 := domain.Codeset{Name: "test-cs2"}
+
+	t.Run("no workflow", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		webhookID := (int64)(10)
+		err := store.UpdateCodesetAssignmentWebhook(context.TODO(), "test", &cs1, &webhookID)
+		assertError(t, err, domain.ErrWorkflowNotFound)
+	})
+
+	t.Run("not assigned", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		_, err := store.AddWorkflow(context.TODO(), &domain.Workflow{Name: "test-wf"})
+		assertNoError(t, err)
+
+		webhookID := (int64)(10)
+		err = store.UpdateCodesetAssignmentWebhook(context.TODO(), "test-wf", &cs1, &webhookID)
+		assertError(t, err, domain.ErrWorkflowNotAssignedToCodeset)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		_, err := store.AddWorkflow(context.TODO(), &domain.Workflow{Name: wfName})
+		assertNoError(t, err)
+		webhookID1, webhookID2, newWebhookID := (int64)(10), (int64)(20), (int64)(30)
+		_, err = store.AddCodesetAssignment(context.TODO(), wfName, &cs1, &webhookID1)
+		assertNoError(t, err)
+		_, err = store.AddCodesetAssignment(context.TODO(), wfName, &cs2, &webhookID2)
+		assertNoError(t, err)
+
+		err = store.UpdateCodesetAssignmentWebhook(context.TODO(), wfName, &cs1, &newWebhookID)
+		assertNoError(t, err)
+
+		got := store.GetCodesetAssignments(context.TODO(), wfName)
+		want := []*domain.CodesetAssignment{{Codeset: &cs1, WebhookID: &newWebhookID}, {Codeset: &cs2, WebhookID: &webhookID2}}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("concurrent assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		_, err := store.AddWorkflow(context.TODO(), &domain.Workflow{Name: wfName})
+		assertNoError(t, err)
+		webhookID1 := (int64)(10)
+		_, err = store.AddCodesetAssignment(context.TODO(), wfName, &cs1, &webhookID1)
+		assertNoError(t, err)
+
+		// the webhook of an assignment and the assignments of the workflow are changed at the same time
+		codesets := make([]domain.Codeset, 10)
+		var wg sync.WaitGroup
+		for i := range codesets {
+			codesets[i] = domain.Codeset{Name: fmt.Sprintf("cs%d", i)}
+			wg.Add(2)
+			go func(cs *domain.Codeset, id int64) {
+				defer wg.Done()
+				_, err := store.AddCodesetAssignment(context.TODO(), wfName, cs, &id)
+				assertNoError(t, err)
+			}(&codesets[i], int64(i))
+			go func(id int64) {
+				defer wg.Done()
+				err := store.UpdateCodesetAssignmentWebhook(context.TODO(), wfName, &cs1, &id)
+				assertNoError(t, err)
+			}(int64(100 + i))
+		}
+		wg.Wait()
+
+		if got := store.GetCodesetAssignments(context.TODO(), wfName); len(got) != len(codesets)+1 {
+			t.Errorf("got %d assignments want %d", len(got), len(codesets)+1)
+		}
+	})
+}
+
+func testGetCodesetAssignments(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("no workflow", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		got := store.GetCodesetAssignments(context.TODO(), "test")
+		want := []*domain.CodesetAssignment{}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("no assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		got := store.GetCodesetAssignments(context.TODO(), wfName)
+		want := []*domain.CodesetAssignment{}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("with assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		webhookID := (int64)(10)
+		store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+
+		got := store.GetCodesetAssignments(context.TODO(), wfName)
+		want := []*domain.CodesetAssignment{{Codeset: &cs, WebhookID: &webhookID}}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
+func testGetAllCodesetAssignments(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("no assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		got := store.GetAllCodesetAssignments(context.TODO(), &wfName)
+		want := map[string][]*domain.CodesetAssignment{}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("with assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		webhookID := (int64)(10)
+
+		store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+
+		// with name
+		got := store.GetAllCodesetAssignments(context.TODO(), &wfName)
+		want := map[string][]*domain.CodesetAssignment{wfName: {{Codeset: &cs, WebhookID: &webhookID}}}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+
+		// without name
+		got = store.GetAllCodesetAssignments(context.TODO(), nil)
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
+func testDeleteCodesetAssignment(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("no workflow", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		_, err := store.DeleteCodesetAssignment(context.TODO(), "test", &cs)
+		assertError(t, err, domain.ErrWorkflowNotFound)
+	})
+
+	t.Run("no assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		got, _ := store.DeleteCodesetAssignment(context.TODO(), wfName, &cs)
+		want := []*domain.CodesetAssignment{}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("with assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs1 := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		cs2 := domain.Codeset{
+			Name: "test-cs2",
+		}
+
+		webhookID := (int64)(10)
+
+		store.AddCodesetAssignment(context.TODO(), wfName, &cs1, &webhookID)
+		store.AddCodesetAssignment(context.TODO(), wfName, &cs2, &webhookID)
+
+		got, _ := store.DeleteCodesetAssignment(context.TODO(), wfName, &cs1)
+		want := []*domain.CodesetAssignment{{Codeset: &cs2, WebhookID: &webhookID}}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+
+		got, _ = store.DeleteCodesetAssignment(context.TODO(), wfName, &cs2)
+		want = []*domain.CodesetAssignment{}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
+func testGetCodesetAssignment(t *testing.T, newWorkflowStore NewWorkflowStore) {
+	t.Run("no workflow", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+		_, got := store.GetCodesetAssignment(context.TODO(), "no-wf", &cs)
+		assertError(t, got, domain.ErrWorkflowNotFound)
+	})
+
+	t.Run("with workflow, no assignments", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		_, err = store.GetCodesetAssignment(context.TODO(), wfName, &cs)
+		assertError(t, err, domain.ErrWorkflowNotAssignedToCodeset)
+	})
+
+	t.Run("with assignment", func(t *testing.T) {
+		store, done := newWorkflowStore(t)
+		defer done()
+
+		wfName := "test-wf"
+		wf := domain.Workflow{Name: wfName}
+
+		_, err := store.AddWorkflow(context.TODO(), &wf)
+		assertNoError(t, err)
+
+		cs := domain.Codeset{
+			Name: "test-cs",
+		}
+
+		webhookID := (int64)(10)
+
+		store.AddCodesetAssignment(context.TODO(), wfName, &cs, &webhookID)
+
+		got, err := store.GetCodesetAssignment(context.TODO(), wfName, &cs)
+		assertNoError(t, err)
+		want := &domain.CodesetAssignment{Codeset: &cs, WebhookID: &webhookID}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("Unexpected Assignments: %s", diff.PrintWantGot(d))
+		}
+
+	})
+}
@@ -22,6 +22,19 @@ func NewEventListenerBuilder(name, namespace string) *EventListenerBuilder {
 	return b
 }
 
+// Meta sets the Meta structs of the EventListener.
+// Any number of MetaOp modifiers can be passed.
+func (b *EventListenerBuilder) Meta(ops ...MetaOp) {
+	for _, op := range ops {
+		switch o := op.(type) {
+		case ObjectMetaOp:
+			o(&b.EventListener.ObjectMeta)
+		case TypeMetaOp:
+			o(&b.EventListener.TypeMeta)
+		}
+	}
+}
+
 // ServiceAccount sets a ServiceAccountName to the EventListener spec.
 func (b *EventListenerBuilder) ServiceAccount(name string) {
 	b.EventListener.Spec.ServiceAccountName = name
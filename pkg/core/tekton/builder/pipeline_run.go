@@ -58,8 +58,9 @@ func (b *PipelineRunBuilder) PipelineRef(name string) {
 	}
 }
 
-// Workspace adds a WorkspaceBinding to the PipelineRun spec.
-func (b *PipelineRunBuilder) Workspace(name string, accessMode string, size string) {
+// Workspace adds a WorkspaceBinding to the PipelineRun spec, backed by a volume claim for the given storage
+// class, or the default storage class if empty.
+func (b *PipelineRunBuilder) Workspace(name string, accessMode string, size string, storageClass string) {
 	b.PipelineRun.Spec.Workspaces = append(b.PipelineRun.Spec.Workspaces,
 		v1beta1.WorkspaceBinding{
 			Name: name,
@@ -76,6 +77,9 @@ func (b *PipelineRunBuilder) Workspace(name string, accessMode string, size stri
 			},
 		},
 	)
+	if storageClass != "" {
+		b.PipelineRun.Spec.Workspaces[len(b.PipelineRun.Spec.Workspaces)-1].VolumeClaimTemplate.Spec.StorageClassName = &storageClass
+	}
 }
 
 // Param adds a Param to the PipelineRun spec.
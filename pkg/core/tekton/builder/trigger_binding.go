@@ -22,6 +22,19 @@ func NewTriggerBindingBuilder(name, namespace string) *TriggerBindingBuilder {
 	return b
 }
 
+// Meta sets the Meta structs of the TriggerBinding.
+// Any number of MetaOp modifiers can be passed.
+func (b *TriggerBindingBuilder) Meta(ops ...MetaOp) {
+	for _, op := range ops {
+		switch o := op.(type) {
+		case ObjectMetaOp:
+			o(&b.TriggerBinding.ObjectMeta)
+		case TypeMetaOp:
+			o(&b.TriggerBinding.TypeMeta)
+		}
+	}
+}
+
 // Param adds a Param to the TriggerBinding spec.
 func (b *TriggerBindingBuilder) Param(name, value string) {
 	b.TriggerBinding.Spec.Params = append(b.TriggerBinding.Spec.Params, v1alpha1.Param{
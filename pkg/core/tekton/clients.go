@@ -2,15 +2,23 @@ package tekton
 
 import (
 	"fmt"
+	"time"
 
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1beta1"
 	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
 	"github.com/tektoncd/triggers/pkg/client/clientset/versioned/typed/triggers/v1alpha1"
+	"k8s.io/client-go/discovery"
+	k8s "k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 
 	"github.com/fuseml/fuseml-core/pkg/kubernetes"
 )
 
+// statusRequestTimeout is the time that FuseML waits for the kubernetes API to respond to a status check
+const statusRequestTimeout = 5 * time.Second
+
 // Clients holds instances of interfaces for making requests to the tekton controllers.
 type clients struct {
 	PipelineClient        v1beta1.PipelineInterface
@@ -19,11 +27,17 @@ type clients struct {
 	TriggerTemplateClient v1alpha1.TriggerTemplateInterface
 	TriggerBindingClient  v1alpha1.TriggerBindingInterface
 	EventListenerClient   v1alpha1.EventListenerInterface
+	// Discovery and ConfigMapClient (for the tekton installation namespace) are only used to check the
+	// status of the kubernetes API and of the tekton installation
+	Discovery       discovery.DiscoveryInterface
+	ConfigMapClient corev1.ConfigMapInterface
+	Host            string
 }
 
 // NewClients instantiates and returns several clientsets required for making requests to
-// tekton. Clients can make requests within namespace.
-func newClients(namespace string) (*clients, error) {
+// tekton. Clients can make requests within namespace, and read the status of the tekton
+// installation from tektonNamespace.
+func newClients(namespace, tektonNamespace string) (*clients, error) {
 	var err error
 	c := &clients{}
 
@@ -48,5 +62,16 @@ func newClients(namespace string) (*clients, error) {
 	c.TriggerBindingClient = cst.TriggersV1alpha1().TriggerBindings(namespace)
 	c.EventListenerClient = cst.TriggersV1alpha1().EventListeners(namespace)
 
+	// the discovery requests do not accept a context, so they are bound by a timeout instead
+	statusCfg := rest.CopyConfig(cfg)
+	statusCfg.Timeout = statusRequestTimeout
+	kcs, err := k8s.NewForConfig(statusCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client set: %w", err)
+	}
+	c.Discovery = kcs.Discovery()
+	c.ConfigMapClient = kcs.CoreV1().ConfigMaps(tektonNamespace)
+	c.Host = cfg.Host
+
 	return c, nil
 }
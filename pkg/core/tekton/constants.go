@@ -1,26 +1,22 @@
 package tekton
 
 const (
-	pipelineRunPrefix         = "fuseml-"
-	pipelineRunServiceAccount = "fuseml-workloads"
-	triggersServiceAccount    = "tekton-triggers"
-	workspaceAccessMode       = "ReadWriteOnce"
-	workspaceSize             = "2Gi"
-	codesetWorkspaceName      = "source"
-	builderTaskName           = "kaniko"
-	builderPrepTaskName       = "builder-prep"
-	cloneTaskName             = "clone"
-	codesetNameParam          = "codeset-name"
-	codesetVersionParam       = "codeset-version"
-	codesetProjectParam       = "codeset-project"
-	codesetURLParam           = "codeset-url"
-	fuseMLRegistry            = "registry.fuseml-registry"
-	fuseMLRegistryLocal       = "127.0.0.1:30500"
-	imageParamName            = "IMAGE"
-	stepOutputVarName         = "TASK_RESULT"
-	inputsVarPrefix           = "FUSEML_"
-	envVarPrefix              = "FUSEML_ENV_"
-	stepDefaultCmd            = "run"
+	pipelineRunPrefix      = "fuseml-"
+	codesetWorkspaceName   = "source"
+	builderTaskName        = "kaniko"
+	builderPrepTaskName    = "builder-prep"
+	cloneTaskName          = "clone"
+	codesetNameParam       = "codeset-name"
+	codesetVersionParam    = "codeset-version"
+	codesetProjectParam    = "codeset-project"
+	codesetURLParam        = "codeset-url"
+	imageParamName         = "IMAGE"
+	stepOutputVarName      = "TASK_RESULT"
+	inputsVarPrefix        = "FUSEML_"
+	envVarPrefix           = "FUSEML_ENV_"
+	stepDefaultCmd         = "run"
+	pipelinesInfoConfigMap = "pipelines-info"
+	triggersInfoConfigMap  = "triggers-info"
 
 	// LabelCodesetName is the label key for the codeset name
 	LabelCodesetName = "fuseml/codeset-name"
@@ -30,4 +26,7 @@ const (
 	LabelCodesetVersion = "fuseml/codeset-version"
 	// LabelWorkflowRef is the label key for the reference of the workflow
 	LabelWorkflowRef = "fuseml/workflow-ref"
+	// AnnotationTraceContextPrefix prefixes the keys of the pipeline run annotations holding the trace context
+	// (e.g. fuseml/traceparent) of the request that created the run
+	AnnotationTraceContextPrefix = "fuseml/"
 )
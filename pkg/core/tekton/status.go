@@ -0,0 +1,74 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
+)
+
+// DashboardURL returns the URL of the Tekton dashboard used to follow the workflow runs
+func (w *WorkflowBackend) DashboardURL() string {
+	return w.dashboardURL
+}
+
+// CheckDependencies checks that the kubernetes API can be reached and that the Tekton Pipelines and
+// Triggers resources used by FuseML are installed, and reports their versions.
+func (w *WorkflowBackend) CheckDependencies(ctx context.Context) []*domain.DependencyStatus {
+	ctx, span := tracing.Start(ctx, "tekton.CheckDependencies")
+	defer span.End()
+
+	k8sStatus := &domain.DependencyStatus{Name: "kubernetes", Endpoint: w.tektonClients.Host}
+	if info, err := w.tektonClients.Discovery.ServerVersion(); err != nil {
+		k8sStatus.Error = err.Error()
+	} else {
+		k8sStatus.Version = info.GitVersion
+	}
+
+	return []*domain.DependencyStatus{
+		k8sStatus,
+		w.checkResources(ctx, "tekton-pipelines", v1beta1.SchemeGroupVersion.String(), pipelinesInfoConfigMap,
+			"pipelines", "pipelineruns", "tasks"),
+		w.checkResources(ctx, "tekton-triggers", v1alpha1.SchemeGroupVersion.String(), triggersInfoConfigMap,
+			"eventlisteners", "triggerbindings", "triggertemplates"),
+	}
+}
+
+// checkResources checks that the kubernetes API serves the given resources of a group version. The version
+// of the component providing them is read from the config map it publishes its version in, if present.
+func (w *WorkflowBackend) checkResources(ctx context.Context, name, groupVersion, infoConfigMap string,
+	resources ...string) *domain.DependencyStatus {
+	status := &domain.DependencyStatus{Name: name, Endpoint: w.tektonClients.Host + "/apis/" + groupVersion}
+
+	list, err := w.tektonClients.Discovery.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		status.Error = fmt.Sprintf("resources not available: %s", err)
+		return status
+	}
+	served := map[string]bool{}
+	for _, r := range list.APIResources {
+		served[r.Name] = true
+	}
+	missing := []string{}
+	for _, r := range resources {
+		if !served[r] {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) > 0 {
+		status.Error = fmt.Sprintf("resources not available: %s", strings.Join(missing, ", "))
+		return status
+	}
+
+	// older releases do not publish their version, which is not required by FuseML
+	if cm, err := w.tektonClients.ConfigMapClient.Get(ctx, infoConfigMap, metav1.GetOptions{}); err == nil {
+		status.Version = cm.Data["version"]
+	}
+	return status
+}
@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -19,13 +19,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"knative.dev/pkg/apis"
 
+	"github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/core/tekton/builder"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
 const (
-	errDashboardURLMissing = WorkflowBackendErr("value for Tekton Dashboard URL (TEKTON_DASHBOARD_URL) was not provided.")
+	errDashboardURLMissing = WorkflowBackendErr("value for Tekton Dashboard URL (tekton.dashboard-url) was not provided.")
 	errWaitListenerTimeout = WorkflowBackendErr("time out waiting for listener to become ready")
 )
 
@@ -39,28 +41,31 @@ type EnvVarMap map[string]string
 type WorkflowBackend struct {
 	dashboardURL  string
 	namespace     string
-	logger        *log.Logger
+	logger        *slog.Logger
 	tektonClients *clients
+	cfg           *config.Tekton
 }
 
-// NewWorkflowBackend initializes Tekton backend
-func NewWorkflowBackend(logger *log.Logger, namespace string) (*WorkflowBackend, error) {
-	dashboardURL, exists := os.LookupEnv("TEKTON_DASHBOARD_URL")
-	if !exists {
+// NewWorkflowBackend initializes Tekton backend, creating the workflow resources in the given namespace
+func NewWorkflowBackend(logger *slog.Logger, namespace string, cfg *config.Tekton) (*WorkflowBackend, error) {
+	if cfg.DashboardURL == "" {
 		return nil, errDashboardURLMissing
 	}
-	clients, err := newClients(namespace)
+	clients, err := newClients(namespace, cfg.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing tekton workflow backend: %w", err)
 	}
-	return &WorkflowBackend{strings.TrimSuffix(dashboardURL, "/"), namespace, logger, clients}, nil
+	return &WorkflowBackend{strings.TrimSuffix(cfg.DashboardURL, "/"), namespace, logger, clients, cfg}, nil
 }
 
 // CreateWorkflow receives a FuseML workflow and creates a Tekton pipeline from it
-func (w *WorkflowBackend) CreateWorkflow(ctx context.Context, workflow *domain.Workflow) error {
-	pipeline := generatePipeline(*workflow, w.namespace)
-	w.logger.Printf("Creating tekton pipeline for workflow: %s...", workflow.Name)
-	_, err := w.tektonClients.PipelineClient.Create(ctx, pipeline, metav1.CreateOptions{})
+func (w *WorkflowBackend) CreateWorkflow(ctx context.Context, workflow *domain.Workflow) (err error) {
+	ctx, span := tracing.Start(ctx, "tekton.CreateWorkflow")
+	defer tracing.End(span, &err)
+
+	pipeline := generatePipeline(*workflow, w.namespace, w.cfg)
+	w.logger.InfoContext(ctx, "Creating tekton pipeline", "workflow", workflow.Name)
+	_, err = w.tektonClients.PipelineClient.Create(ctx, pipeline, metav1.CreateOptions{})
 	if err != nil {
 		if k8serr.IsAlreadyExists(err) {
 			return domain.ErrWorkflowExists
@@ -71,32 +76,64 @@ func (w *WorkflowBackend) CreateWorkflow(ctx context.Context, workflow *domain.W
 	return nil
 }
 
+// UpdateWorkflow receives a FuseML workflow and updates the Tekton pipeline created from it
+func (w *WorkflowBackend) UpdateWorkflow(ctx context.Context, workflow *domain.Workflow) (err error) {
+	ctx, span := tracing.Start(ctx, "tekton.UpdateWorkflow")
+	defer tracing.End(span, &err)
+
+	pipeline := generatePipeline(*workflow, w.namespace, w.cfg)
+	w.logger.InfoContext(ctx, "Updating tekton pipeline", "workflow", workflow.Name)
+	current, err := w.tektonClients.PipelineClient.Get(ctx, workflow.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return domain.ErrWorkflowNotFound
+		}
+		return fmt.Errorf("error getting tekton pipeline for workflow %q: %w", workflow.Name, err)
+	}
+
+	current.Labels = pipeline.Labels
+	current.Spec = pipeline.Spec
+	_, err = w.tektonClients.PipelineClient.Update(ctx, current, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("error updating tekton pipeline for workflow %q: %w", workflow.Name, err)
+	}
+
+	return nil
+}
+
 // DeleteWorkflow deletes a tekton pipeline with the specified name
-func (w *WorkflowBackend) DeleteWorkflow(ctx context.Context, name string) error {
-	w.logger.Printf("Deleting tekton pipeline: %s...", name)
-	err := w.tektonClients.PipelineClient.Delete(ctx, name, metav1.DeleteOptions{})
+func (w *WorkflowBackend) DeleteWorkflow(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "tekton.DeleteWorkflow")
+	defer tracing.End(span, &err)
+
+	w.logger.InfoContext(ctx, "Deleting tekton pipeline", "pipeline", name)
+	err = w.tektonClients.PipelineClient.Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if !k8serr.IsNotFound(err) {
 			return fmt.Errorf("error deleting tekton pipeline %q: %w", name, err)
 		}
-		w.logger.Printf("Tekton pipeline %q not found, skipping delete...", name)
+		w.logger.InfoContext(ctx, "Tekton pipeline not found, skipping delete", "pipeline", name)
 	}
 	return nil
 }
 
 // CreateWorkflowRun creates a PipelineRun with its default values for the specified workflow and codeset
-func (w *WorkflowBackend) CreateWorkflowRun(ctx context.Context, workflowName string, codeset *domain.Codeset) error {
+func (w *WorkflowBackend) CreateWorkflowRun(ctx context.Context, workflowName string, codeset *domain.Codeset) (err error) {
+	ctx, span := tracing.Start(ctx, "tekton.CreateWorkflowRun")
+	defer tracing.End(span, &err)
+
 	pipeline, err := w.tektonClients.PipelineClient.Get(ctx, workflowName, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("error getting tekton pipeline %q: %w", workflowName, err)
 	}
 
-	pipelineRun, err := generatePipelineRun(pipeline, codeset)
+	pipelineRun, err := generatePipelineRun(pipeline, codeset, w.cfg)
 	if err != nil {
 		return fmt.Errorf("error generating tekton pipeline run for workflow %q: %w", workflowName, err)
 	}
+	injectTraceContext(ctx, &pipelineRun.ObjectMeta)
 
-	w.logger.Printf("Creating tekton pipeline run for workflow: %s...", workflowName)
+	w.logger.InfoContext(ctx, "Creating tekton pipeline run", "workflow", workflowName)
 	_, err = w.tektonClients.PipelineRunClient.Create(ctx, pipelineRun, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("error creating tekton pipeline run %q: %w", pipelineRun.Name, err)
@@ -105,7 +142,10 @@ func (w *WorkflowBackend) CreateWorkflowRun(ctx context.Context, workflowName st
 }
 
 // GetWorkflowRuns returns a list of WorkflowRun for the given Workflow
-func (w *WorkflowBackend) GetWorkflowRuns(ctx context.Context, wf *domain.Workflow, filter *domain.WorkflowRunFilter) ([]*domain.WorkflowRun, error) {
+func (w *WorkflowBackend) GetWorkflowRuns(ctx context.Context, wf *domain.Workflow, filter *domain.WorkflowRunFilter) (_ []*domain.WorkflowRun, err error) {
+	ctx, span := tracing.Start(ctx, "tekton.GetWorkflowRuns")
+	defer tracing.End(span, &err)
+
 	labelSelector := fmt.Sprintf("%s=%s", LabelWorkflowRef, wf.Name)
 	if filter.CodesetName != "" {
 		labelSelector = fmt.Sprintf("%s,%s=%s", labelSelector, LabelCodesetName, filter.CodesetName)
@@ -135,19 +175,22 @@ func (w *WorkflowBackend) GetWorkflowRuns(ctx context.Context, wf *domain.Workfl
 }
 
 // CreateWorkflowListener creates tekton resources required to have a listener ready for triggering the pipeline
-func (w *WorkflowBackend) CreateWorkflowListener(ctx context.Context, workflowName string, timeout time.Duration) (*domain.WorkflowListener, error) {
+func (w *WorkflowBackend) CreateWorkflowListener(ctx context.Context, workflowName string, timeout time.Duration) (_ *domain.WorkflowListener, err error) {
+	ctx, span := tracing.Start(ctx, "tekton.CreateWorkflowListener")
+	defer tracing.End(span, &err)
+
 	pipeline, err := w.tektonClients.PipelineClient.Get(ctx, workflowName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error getting tekton pipeline %q: %w", workflowName, err)
 	}
 
-	triggerTemplate := generateTriggerTemplate(pipeline)
+	triggerTemplate := generateTriggerTemplate(pipeline, w.cfg)
 	_, err = w.tektonClients.TriggerTemplateClient.Get(ctx, workflowName, metav1.GetOptions{})
 	if err != nil {
 		if !k8serr.IsNotFound(err) {
 			return nil, fmt.Errorf("error getting tekton trigger template %q: %w", workflowName, err)
 		}
-		w.logger.Printf("Creating tekton trigger template for workflow: %s...", workflowName)
+		w.logger.InfoContext(ctx, "Creating tekton trigger template", "workflow", workflowName)
 		var tt *v1alpha1.TriggerTemplate
 		tt, err = w.tektonClients.TriggerTemplateClient.Create(ctx, triggerTemplate, metav1.CreateOptions{})
 		if err != nil {
@@ -162,7 +205,7 @@ func (w *WorkflowBackend) CreateWorkflowListener(ctx context.Context, workflowNa
 		if !k8serr.IsNotFound(err) {
 			return nil, fmt.Errorf("error getting tekton trigger binding %q: %w", workflowName, err)
 		}
-		w.logger.Printf("Creating tekton trigger binding for workflow: %s...", workflowName)
+		w.logger.InfoContext(ctx, "Creating tekton trigger binding", "workflow", workflowName)
 		var tb *v1alpha1.TriggerBinding
 		tb, err = w.tektonClients.TriggerBindingClient.Create(ctx, triggerBinding, metav1.CreateOptions{})
 		if err != nil {
@@ -171,14 +214,14 @@ func (w *WorkflowBackend) CreateWorkflowListener(ctx context.Context, workflowNa
 		defer w.tektonDeleteIfError(ctx, &err, tb)
 	}
 
-	eventListener := generateEventListener(triggerTemplate, triggerBinding)
+	eventListener := generateEventListener(triggerTemplate, triggerBinding, w.cfg)
 	var el *v1alpha1.EventListener
 	el, err = w.tektonClients.EventListenerClient.Get(ctx, workflowName, metav1.GetOptions{})
 	if err != nil {
 		if !k8serr.IsNotFound(err) {
 			return nil, fmt.Errorf("error getting tekton event listener %q: %w", workflowName, err)
 		}
-		w.logger.Printf("Creating tekton event listener for workflow: %s...", workflowName)
+		w.logger.InfoContext(ctx, "Creating tekton event listener", "workflow", workflowName)
 		el, err = w.tektonClients.EventListenerClient.Create(ctx, eventListener, metav1.CreateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("error creating tekton event listener %q: %w", workflowName, err)
@@ -189,7 +232,10 @@ func (w *WorkflowBackend) CreateWorkflowListener(ctx context.Context, workflowNa
 	listenerURL := fmt.Sprintf("http://el-%s.%s.svc.cluster.local:8080", workflowName, w.namespace)
 	if timeout > 0 {
 		interval := 1 * time.Second
-		if err = waitFor(w.eventListenerReady(ctx, el.Name), interval, timeout); err != nil {
+		waitCtx, waitSpan := tracing.Start(ctx, "tekton.waitForEventListener")
+		err = waitFor(w.eventListenerReady(waitCtx, el.Name), interval, timeout)
+		tracing.End(waitSpan, &err)
+		if err != nil {
 			return nil, errWaitListenerTimeout
 		}
 
@@ -206,38 +252,44 @@ func (w *WorkflowBackend) CreateWorkflowListener(ctx context.Context, workflowNa
 }
 
 // DeleteWorkflowListener deletes all tekton resources associated to the specified listener name
-func (w *WorkflowBackend) DeleteWorkflowListener(ctx context.Context, name string) error {
-	w.logger.Printf("Deleting tekton event listener: %s...", name)
-	err := w.tektonClients.EventListenerClient.Delete(ctx, name, metav1.DeleteOptions{})
+func (w *WorkflowBackend) DeleteWorkflowListener(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.Start(ctx, "tekton.DeleteWorkflowListener")
+	defer tracing.End(span, &err)
+
+	w.logger.InfoContext(ctx, "Deleting tekton event listener", "listener", name)
+	err = w.tektonClients.EventListenerClient.Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if !k8serr.IsNotFound(err) {
 			return fmt.Errorf("error deleting tekton event listener %q: %w", name, err)
 		}
-		w.logger.Printf("Tekton event listener %q not found, skipping delete...", name)
+		w.logger.InfoContext(ctx, "Tekton event listener not found, skipping delete", "listener", name)
 	}
 
-	w.logger.Printf("Deleting tekton trigger binding: %s...", name)
+	w.logger.InfoContext(ctx, "Deleting tekton trigger binding", "listener", name)
 	err = w.tektonClients.TriggerBindingClient.Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if !k8serr.IsNotFound(err) {
 			return fmt.Errorf("error deleting tekton trigger binding %q: %w", name, err)
 		}
-		w.logger.Printf("Tekton trigger binding %q not found, skipping delete...", name)
+		w.logger.InfoContext(ctx, "Tekton trigger binding not found, skipping delete", "listener", name)
 	}
 
-	w.logger.Printf("Deleting tekton trigger template: %s...", name)
+	w.logger.InfoContext(ctx, "Deleting tekton trigger template", "listener", name)
 	err = w.tektonClients.TriggerTemplateClient.Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if !k8serr.IsNotFound(err) {
 			return fmt.Errorf("error deleting tekton trigger template %q: %w", name, err)
 		}
-		w.logger.Printf("Tekton trigger template %q not found, skipping delete...", name)
+		w.logger.InfoContext(ctx, "Tekton trigger template not found, skipping delete", "listener", name)
 	}
 	return nil
 }
 
 // GetWorkflowListener returns the listener for a given workflow
 func (w *WorkflowBackend) GetWorkflowListener(ctx context.Context, workflowName string) (wl *domain.WorkflowListener, err error) {
+	ctx, span := tracing.Start(ctx, "tekton.GetWorkflowListener")
+	defer tracing.End(span, &err)
+
 	el, err := w.tektonClients.EventListenerClient.Get(ctx, workflowName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error getting tekton event listener %q: %w", workflowName, err)
@@ -253,6 +305,47 @@ func (w *WorkflowBackend) GetWorkflowListener(ctx context.Context, workflowName
 	return
 }
 
+// ListWorkflows returns the names of the workflows referenced by the tekton pipelines, along with the time
+// when the pipelines were created
+func (w *WorkflowBackend) ListWorkflows(ctx context.Context) (_ map[string]time.Time, err error) {
+	ctx, span := tracing.Start(ctx, "tekton.ListWorkflows")
+	defer tracing.End(span, &err)
+
+	pipelines, err := w.tektonClients.PipelineClient.List(ctx, metav1.ListOptions{LabelSelector: LabelWorkflowRef})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tekton pipelines: %w", err)
+	}
+	workflows := make(map[string]time.Time, len(pipelines.Items))
+	for _, p := range pipelines.Items {
+		workflows[p.Labels[LabelWorkflowRef]] = p.CreationTimestamp.Time
+	}
+	return workflows, nil
+}
+
+// ListWorkflowListeners returns the listeners of the workflows, which are the tekton event listeners labelled
+// with a reference to a workflow. Listeners created before they were labelled are not returned.
+func (w *WorkflowBackend) ListWorkflowListeners(ctx context.Context) (_ []*domain.WorkflowListener, err error) {
+	ctx, span := tracing.Start(ctx, "tekton.ListWorkflowListeners")
+	defer tracing.End(span, &err)
+
+	els, err := w.tektonClients.EventListenerClient.List(ctx, metav1.ListOptions{LabelSelector: LabelWorkflowRef})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tekton event listeners: %w", err)
+	}
+	listeners := make([]*domain.WorkflowListener, 0, len(els.Items))
+	for _, el := range els.Items {
+		available := listenerIsAvailable(el.Status)
+		url := fmt.Sprintf("http://el-%s.%s.svc.cluster.local:8080", el.Name, w.namespace)
+		if el.Status.Address != nil && el.Status.Address.URL != nil {
+			url = el.Status.Address.URL.String()
+		}
+		listeners = append(listeners, &domain.WorkflowListener{Name: el.Name, Available: available, URL: url,
+			DashboardURL: fmt.Sprintf("%s/#/namespaces/%s/eventlisteners/%s", w.dashboardURL, w.namespace, el.Name),
+			Created:      el.CreationTimestamp.Time})
+	}
+	return listeners, nil
+}
+
 func (e WorkflowBackendErr) Error() string {
 	return string(e)
 }
@@ -287,7 +380,7 @@ func listenerIsAvailable(status v1alpha1.EventListenerStatus) bool {
 	return status.Address.URL != nil
 }
 
-func generatePipeline(w domain.Workflow, namespace string) *v1beta1.Pipeline {
+func generatePipeline(w domain.Workflow, namespace string, cfg *config.Tekton) *v1beta1.Pipeline {
 	resolver := newVariablesResolver()
 	pb := builder.NewPipelineBuilder(w.Name, namespace)
 	// label the pipeline with a reference to the workflow name
@@ -406,13 +499,16 @@ STEPS:
 		}
 		// if image is parametrized add 'IMAGE' param, resolving it
 		if strings.Contains(step.Image, "{{") {
-			// The kubernetes nodes are unable to resolve the local FuseML registry
-			// (registry.fuseml-registry), in that way, when the step uses an image
-			// from the local FuseML registry, replace registry.fuseml-registry with
-			// 127.0.0.1:30500
+			// The kubernetes nodes may be unable to resolve some registries (e.g. the
+			// local FuseML registry, registry.fuseml-registry), in that way, when the
+			// step uses an image from one of them, replace the registry with the
+			// address known to the nodes (e.g. 127.0.0.1:30500)
 			image := resolver.resolve(step.Image)
-			if strings.HasPrefix(image, fuseMLRegistry) {
-				image = strings.Replace(image, fuseMLRegistry, fuseMLRegistryLocal, 1)
+			for _, r := range cfg.Registries {
+				if strings.HasPrefix(image, r.From) {
+					image = strings.Replace(image, r.From, r.To, 1)
+					break
+				}
 			}
 			taskParams[imageParamName] = image
 		}
@@ -421,7 +517,7 @@ STEPS:
 	return &pb.Pipeline
 }
 
-func generatePipelineRun(p *v1beta1.Pipeline, codeset *domain.Codeset) (*v1beta1.PipelineRun, error) {
+func generatePipelineRun(p *v1beta1.Pipeline, codeset *domain.Codeset, cfg *config.Tekton) (*v1beta1.PipelineRun, error) {
 	codesetVersion := "main"
 	prb := builder.NewPipelineRunBuilder(fmt.Sprintf("%s%s-%s-", pipelineRunPrefix, codeset.Project, codeset.Name))
 
@@ -444,10 +540,10 @@ func generatePipelineRun(p *v1beta1.Pipeline, codeset *domain.Codeset) (*v1beta1
 
 	prb.Meta(builder.Label(LabelCodesetName, codeset.Name), builder.Label(LabelCodesetProject, codeset.Project),
 		builder.Label(LabelCodesetVersion, codesetVersion), builder.Label(LabelWorkflowRef, p.Labels[LabelWorkflowRef]))
-	prb.ServiceAccount(pipelineRunServiceAccount)
+	prb.ServiceAccount(cfg.ServiceAccount)
 	prb.PipelineRef(p.Name)
 	for _, ws := range p.Spec.Workspaces {
-		prb.Workspace(ws.Name, workspaceAccessMode, workspaceSize)
+		prb.Workspace(ws.Name, cfg.Workspace.AccessMode, cfg.Workspace.Size, cfg.Workspace.StorageClass)
 	}
 
 	for _, res := range p.Spec.Resources {
@@ -458,8 +554,9 @@ func generatePipelineRun(p *v1beta1.Pipeline, codeset *domain.Codeset) (*v1beta1
 	return &prb.PipelineRun, nil
 }
 
-func generateTriggerTemplate(p *v1beta1.Pipeline) *v1alpha1.TriggerTemplate {
+func generateTriggerTemplate(p *v1beta1.Pipeline, cfg *config.Tekton) *v1alpha1.TriggerTemplate {
 	ttb := builder.NewTriggerTemplateBuilder(p.Name, p.Namespace)
+	ttb.Meta(builder.Label(LabelWorkflowRef, p.Labels[LabelWorkflowRef]))
 	prb := builder.NewPipelineRunBuilder(pipelineRunPrefix)
 	resolver := newVariablesResolver()
 	var codesetProject string
@@ -490,7 +587,7 @@ func generateTriggerTemplate(p *v1beta1.Pipeline) *v1alpha1.TriggerTemplate {
 	prb.GenerateName(fmt.Sprintf("%s%s-%s-", pipelineRunPrefix, codesetProject, codesetName))
 
 	for _, ws := range p.Spec.Workspaces {
-		prb.Workspace(ws.Name, workspaceAccessMode, workspaceSize)
+		prb.Workspace(ws.Name, cfg.Workspace.AccessMode, cfg.Workspace.Size, cfg.Workspace.StorageClass)
 	}
 
 	for _, res := range p.Spec.Resources {
@@ -499,7 +596,7 @@ func generateTriggerTemplate(p *v1beta1.Pipeline) *v1alpha1.TriggerTemplate {
 		}
 	}
 
-	prb.ServiceAccount(pipelineRunServiceAccount)
+	prb.ServiceAccount(cfg.ServiceAccount)
 	prb.PipelineRef(p.Name)
 
 	prBytes, err := json.Marshal(prb.PipelineRun)
@@ -520,6 +617,7 @@ func generateTriggerBinding(template *v1alpha1.TriggerTemplate) *v1alpha1.Trigge
 	}
 
 	tbb := builder.NewTriggerBindingBuilder(template.Name, template.Namespace)
+	tbb.Meta(builder.Label(LabelWorkflowRef, template.Labels[LabelWorkflowRef]))
 
 	for _, param := range template.Spec.Params {
 		if v, ok := webhookParamsMap[param.Name]; ok {
@@ -529,9 +627,10 @@ func generateTriggerBinding(template *v1alpha1.TriggerTemplate) *v1alpha1.Trigge
 	return &tbb.TriggerBinding
 }
 
-func generateEventListener(template *v1alpha1.TriggerTemplate, binding *v1alpha1.TriggerBinding) *v1alpha1.EventListener {
+func generateEventListener(template *v1alpha1.TriggerTemplate, binding *v1alpha1.TriggerBinding, cfg *config.Tekton) *v1alpha1.EventListener {
 	elb := builder.NewEventListenerBuilder(template.Name, template.Namespace)
-	elb.ServiceAccount(triggersServiceAccount)
+	elb.Meta(builder.Label(LabelWorkflowRef, template.Labels[LabelWorkflowRef]))
+	elb.ServiceAccount(cfg.TriggersServiceAccount)
 	elb.TriggerBinding(template.Name, binding.Name)
 	return &elb.EventListener
 }
@@ -610,8 +709,10 @@ func getInputCodesetPath(inputs []*domain.WorkflowStepInput) string {
 func (w *WorkflowBackend) toWorkflowRun(wf *domain.Workflow, p v1beta1.PipelineRun) *domain.WorkflowRun {
 
 	wfr := domain.WorkflowRun{
-		Name:        p.ObjectMeta.Name,
-		WorkflowRef: wf.Name,
+		Name:           p.ObjectMeta.Name,
+		WorkflowRef:    wf.Name,
+		CodesetName:    p.Labels[LabelCodesetName],
+		CodesetProject: p.Labels[LabelCodesetProject],
 	}
 
 	if p.Status.StartTime != nil {
@@ -695,13 +796,13 @@ func (w *WorkflowBackend) tektonDeleteIfError(ctx context.Context, err *error, t
 	if *err != nil {
 		switch tw := tektonWorkload.(type) {
 		case *v1alpha1.TriggerTemplate:
-			w.logger.Printf("Deleting TriggerTemplate: %s... (creating listener failed)", tw.Name)
+			w.logger.InfoContext(ctx, "Creating listener failed, deleting tekton trigger template", "listener", tw.Name)
 			w.tektonClients.TriggerTemplateClient.Delete(ctx, tw.Name, metav1.DeleteOptions{})
 		case *v1alpha1.TriggerBinding:
-			w.logger.Printf("Deleting TriggerBinding: %s... (creating listener failed)", tw.Name)
+			w.logger.InfoContext(ctx, "Creating listener failed, deleting tekton trigger binding", "listener", tw.Name)
 			w.tektonClients.TriggerBindingClient.Delete(ctx, tw.Name, metav1.DeleteOptions{})
 		case *v1alpha1.EventListener:
-			w.logger.Printf("Deleting EventListener: %s... (creating listener failed)", tw.Name)
+			w.logger.InfoContext(ctx, "Creating listener failed, deleting tekton event listener", "listener", tw.Name)
 			w.tektonClients.EventListenerClient.Delete(ctx, tw.Name, metav1.DeleteOptions{})
 		}
 	}
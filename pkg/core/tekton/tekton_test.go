@@ -6,8 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,15 +20,22 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	faketriggersclient "github.com/tektoncd/triggers/pkg/client/injection/client/fake"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sversion "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/apis"
 	v1 "knative.dev/pkg/apis/duck/v1"
 	knalpha1 "knative.dev/pkg/apis/duck/v1alpha1"
 	knbeta1 "knative.dev/pkg/apis/duck/v1beta1"
 	rtesting "knative.dev/pkg/reconciler/testing"
 
+	"github.com/fuseml/fuseml-core/pkg/core/config"
 	"github.com/fuseml/fuseml-core/pkg/domain"
 )
 
@@ -51,7 +59,7 @@ func TestCreateWorkflow(t *testing.T) {
 		err := b.CreateWorkflow(ctx, &w)
 
 		assertError(t, err, nil)
-		assertStrings(t, strings.TrimSuffix(logsOutput.String(), "\n"), "Creating tekton pipeline for workflow: mlflow-sklearn-e2e...")
+		assertStrings(t, strings.TrimSuffix(logsOutput.String(), "\n"), `level=INFO msg="Creating tekton pipeline" workflow=mlflow-sklearn-e2e`)
 
 		got, err := b.tektonClients.PipelineClient.Get(ctx, w.Name, metav1.GetOptions{})
 		if err != nil {
@@ -84,6 +92,43 @@ func TestCreateWorkflow(t *testing.T) {
 	})
 }
 
+func TestUpdateWorkflow(t *testing.T) {
+	t.Run("update", func(t *testing.T) {
+		ctx, b, logsOutput := initBackend(t)
+
+		w := domain.Workflow{}
+		readYaml(t, fuseMLWorkflow, &w)
+
+		err := b.CreateWorkflow(ctx, &w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logsOutput.Reset()
+
+		w.Description = "updated description"
+		err = b.UpdateWorkflow(ctx, &w)
+
+		assertError(t, err, nil)
+		assertStrings(t, strings.TrimSuffix(logsOutput.String(), "\n"), `level=INFO msg="Updating tekton pipeline" workflow=mlflow-sklearn-e2e`)
+
+		got, err := b.tektonClients.PipelineClient.Get(ctx, w.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get Pipeline %q: %s", w.Name, err)
+		}
+		assertStrings(t, got.Spec.Description, w.Description)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctx, b, _ := initBackend(t)
+
+		w := domain.Workflow{}
+		readYaml(t, fuseMLWorkflow, &w)
+
+		err := b.UpdateWorkflow(ctx, &w)
+		assertError(t, err, domain.ErrWorkflowNotFound)
+	})
+}
+
 func TestDeleteWorkflow(t *testing.T) {
 	t.Run("delete", func(t *testing.T) {
 		ctx, b, logsOutput := initBackend(t)
@@ -109,7 +154,7 @@ func TestDeleteWorkflow(t *testing.T) {
 			t.Errorf("Expected 0 Pipeline, got %d", len(pipelines.Items))
 		}
 
-		expectedLog := fmt.Sprintf("Deleting tekton pipeline: %s...\n", w.Name)
+		expectedLog := fmt.Sprintf("level=INFO msg=\"Deleting tekton pipeline\" pipeline=%s\n", w.Name)
 		assertStrings(t, logsOutput.String(), expectedLog)
 	})
 
@@ -121,8 +166,8 @@ func TestDeleteWorkflow(t *testing.T) {
 
 		assertError(t, err, nil)
 
-		expectedLog := fmt.Sprintf(`Deleting tekton pipeline: %s...
-Tekton pipeline %q not found, skipping delete...
+		expectedLog := fmt.Sprintf(`level=INFO msg="Deleting tekton pipeline" pipeline=%s
+level=INFO msg="Tekton pipeline not found, skipping delete" pipeline=%s
 `, name, name)
 		assertStrings(t, logsOutput.String(), expectedLog)
 	})
@@ -168,10 +213,39 @@ func TestCreateWorkflowRun(t *testing.T) {
 		t.Errorf("Unexpected PipelineRun: %s", diff.PrintWantGot(d))
 	}
 
-	expectedLog := fmt.Sprintf("Creating tekton pipeline run for workflow: %s...\n", w.Name)
+	expectedLog := fmt.Sprintf("level=INFO msg=\"Creating tekton pipeline run\" workflow=%s\n", w.Name)
 	assertStrings(t, logsOutput.String(), expectedLog)
 }
 
+func TestCreateWorkflowRunTraceContext(t *testing.T) {
+	ctx, b, _ := initBackend(t)
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	w := domain.Workflow{}
+	readYaml(t, fuseMLWorkflow, &w)
+	if err := b.CreateWorkflow(ctx, &w); err != nil {
+		t.Fatal(err)
+	}
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx = trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled, Remote: true,
+	}))
+	if err := b.CreateWorkflowRun(ctx, w.Name, createCodeset(t, 1, 1)); err != nil {
+		t.Fatalf("Failed to create workflow run %q: %s", w.Name, err)
+	}
+
+	runs, err := b.tektonClients.PipelineRunClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list PipelineRuns: %s", err)
+	}
+	got := runs.Items[0].Annotations[AnnotationTraceContextPrefix+"traceparent"]
+	assertStrings(t, got, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+}
+
 func TestGetWorkflowRuns(t *testing.T) {
 	t.Run("all", func(t *testing.T) {
 		ctx, b, _ := initBackend(t)
@@ -196,6 +270,8 @@ func TestGetWorkflowRuns(t *testing.T) {
 			want = append(want, &domain.WorkflowRun{
 				Name:           runName,
 				WorkflowRef:    w.Name,
+				CodesetName:    cs.Name,
+				CodesetProject: cs.Project,
 				Inputs:         []*domain.WorkflowRunInput{{Input: w.Inputs[0], Value: fmt.Sprintf("%s:main", cs.URL)}, {Input: w.Inputs[1], Value: w.Inputs[1].Default}},
 				Outputs:        []*domain.WorkflowRunOutput{{Output: w.Outputs[0]}},
 				StartTime:      runStartTime,
@@ -238,6 +314,8 @@ func TestGetWorkflowRuns(t *testing.T) {
 			wants = append(wants, &domain.WorkflowRun{
 				Name:           runName,
 				WorkflowRef:    w.Name,
+				CodesetName:    cs.Name,
+				CodesetProject: cs.Project,
 				Inputs:         []*domain.WorkflowRunInput{{Input: w.Inputs[0], Value: fmt.Sprintf("%s:main", cs.URL)}, {Input: w.Inputs[1], Value: w.Inputs[1].Default}},
 				Outputs:        []*domain.WorkflowRunOutput{{Output: w.Outputs[0]}},
 				StartTime:      runStartTime,
@@ -355,6 +433,8 @@ func TestGetWorkflowRuns(t *testing.T) {
 			wants = append(wants, &domain.WorkflowRun{
 				Name:           runName,
 				WorkflowRef:    w.Name,
+				CodesetName:    cs.Name,
+				CodesetProject: cs.Project,
 				Inputs:         []*domain.WorkflowRunInput{{Input: w.Inputs[0], Value: fmt.Sprintf("%s:main", cs.URL)}, {Input: w.Inputs[1], Value: w.Inputs[1].Default}},
 				Outputs:        []*domain.WorkflowRunOutput{{Output: w.Outputs[0]}},
 				StartTime:      runStartTime,
@@ -451,9 +531,9 @@ func TestCreateWorkflowListener(t *testing.T) {
 			t.Errorf("Unexpected WorkflowListener: %s", diff.PrintWantGot(d))
 		}
 
-		expectedLog := `Creating tekton trigger template for workflow: mlflow-sklearn-e2e...
-Creating tekton trigger binding for workflow: mlflow-sklearn-e2e...
-Creating tekton event listener for workflow: mlflow-sklearn-e2e...
+		expectedLog := `level=INFO msg="Creating tekton trigger template" workflow=mlflow-sklearn-e2e
+level=INFO msg="Creating tekton trigger binding" workflow=mlflow-sklearn-e2e
+level=INFO msg="Creating tekton event listener" workflow=mlflow-sklearn-e2e
 `
 
 		assertStrings(t, logsOutput.String(), expectedLog)
@@ -579,12 +659,12 @@ Creating tekton event listener for workflow: mlflow-sklearn-e2e...
 			t.Errorf("Expected 0 Event Listeners, got %d", len(eventListeners.Items))
 		}
 
-		expectedLog := `Creating tekton trigger template for workflow: mlflow-sklearn-e2e...
-Creating tekton trigger binding for workflow: mlflow-sklearn-e2e...
-Creating tekton event listener for workflow: mlflow-sklearn-e2e...
-Deleting EventListener: mlflow-sklearn-e2e... (creating listener failed)
-Deleting TriggerBinding: mlflow-sklearn-e2e... (creating listener failed)
-Deleting TriggerTemplate: mlflow-sklearn-e2e... (creating listener failed)
+		expectedLog := `level=INFO msg="Creating tekton trigger template" workflow=mlflow-sklearn-e2e
+level=INFO msg="Creating tekton trigger binding" workflow=mlflow-sklearn-e2e
+level=INFO msg="Creating tekton event listener" workflow=mlflow-sklearn-e2e
+level=INFO msg="Creating listener failed, deleting tekton event listener" listener=mlflow-sklearn-e2e
+level=INFO msg="Creating listener failed, deleting tekton trigger binding" listener=mlflow-sklearn-e2e
+level=INFO msg="Creating listener failed, deleting tekton trigger template" listener=mlflow-sklearn-e2e
 `
 		assertStrings(t, logsOutput.String(), expectedLog)
 	})
@@ -636,9 +716,9 @@ func TestDeleteWorkflowListener(t *testing.T) {
 			t.Errorf("Expected 0 TriggerTemplate, got %d", len(tts.Items))
 		}
 
-		expectedLog := fmt.Sprintf(`Deleting tekton event listener: %s...
-Deleting tekton trigger binding: %s...
-Deleting tekton trigger template: %s...
+		expectedLog := fmt.Sprintf(`level=INFO msg="Deleting tekton event listener" listener=%s
+level=INFO msg="Deleting tekton trigger binding" listener=%s
+level=INFO msg="Deleting tekton trigger template" listener=%s
 `, wfListener.Name, wfListener.Name, wfListener.Name)
 		assertStrings(t, logsOutput.String(), expectedLog)
 	})
@@ -650,12 +730,12 @@ Deleting tekton trigger template: %s...
 		err := b.DeleteWorkflowListener(ctx, name)
 		assertError(t, err, nil)
 
-		expectedLog := fmt.Sprintf(`Deleting tekton event listener: %s...
-Tekton event listener %q not found, skipping delete...
-Deleting tekton trigger binding: %s...
-Tekton trigger binding %q not found, skipping delete...
-Deleting tekton trigger template: %s...
-Tekton trigger template %q not found, skipping delete...
+		expectedLog := fmt.Sprintf(`level=INFO msg="Deleting tekton event listener" listener=%s
+level=INFO msg="Tekton event listener not found, skipping delete" listener=%s
+level=INFO msg="Deleting tekton trigger binding" listener=%s
+level=INFO msg="Tekton trigger binding not found, skipping delete" listener=%s
+level=INFO msg="Deleting tekton trigger template" listener=%s
+level=INFO msg="Tekton trigger template not found, skipping delete" listener=%s
 `, name, name, name, name, name, name)
 		assertStrings(t, logsOutput.String(), expectedLog)
 	})
@@ -705,6 +785,90 @@ func TestGetWorkflowListener(t *testing.T) {
 	}
 }
 
+func TestListWorkflows(t *testing.T) {
+	ctx, b, _ := initBackend(t)
+
+	w := domain.Workflow{}
+	readYaml(t, fuseMLWorkflow, &w)
+	wfName := w.Name
+	for i := 0; i < 2; i++ {
+		w.Name = fmt.Sprintf("%s-%d", wfName, i)
+		if err := b.CreateWorkflow(ctx, &w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// pipelines and event listeners that do not reference a workflow are not listed
+	_, err := b.tektonClients.PipelineClient.Create(ctx, &v1beta1.Pipeline{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.tektonClients.EventListenerClient.Create(ctx, &v1alpha1.EventListener{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.createTestListener(ctx, t, wfName+"-1", true)
+
+	workflows, err := b.ListWorkflows(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list workflows: %s", err)
+	}
+	// the fake clients do not set the creation time
+	if d := cmp.Diff(map[string]time.Time{wfName + "-0": {}, wfName + "-1": {}}, workflows); d != "" {
+		t.Errorf("Unexpected workflows: %s", diff.PrintWantGot(d))
+	}
+
+	listeners, err := b.ListWorkflowListeners(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list listeners: %s", err)
+	}
+	want := []*domain.WorkflowListener{{
+		Name:         wfName + "-1",
+		URL:          fmt.Sprintf("http://el-%s-1.%s.svc.cluster.local:8080", wfName, b.namespace),
+		Available:    true,
+		DashboardURL: fmt.Sprintf("%s/#/namespaces/%s/eventlisteners/%s-1", b.dashboardURL, b.namespace, wfName),
+	}}
+	if d := cmp.Diff(want, listeners); d != "" {
+		t.Errorf("Unexpected listeners: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	ctx, b, _ := initBackend(t)
+
+	discovery := b.tektonClients.Discovery.(*fakediscovery.FakeDiscovery)
+	discovery.FakedServerVersion = &k8sversion.Info{GitVersion: "v1.20.7"}
+	discovery.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "tekton.dev/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "pipelines"}, {Name: "pipelineruns"}, {Name: "tasks"}},
+		},
+		{
+			GroupVersion: "triggers.tekton.dev/v1alpha1",
+			APIResources: []metav1.APIResource{{Name: "eventlisteners"}, {Name: "triggertemplates"}},
+		},
+	}
+	_, err := b.tektonClients.ConfigMapClient.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: pipelinesInfoConfigMap},
+		Data:       map[string]string{"version": "v0.26.0"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*domain.DependencyStatus{
+		{Name: "kubernetes", Endpoint: "https://kubernetes.test", Version: "v1.20.7"},
+		{Name: "tekton-pipelines", Endpoint: "https://kubernetes.test/apis/tekton.dev/v1beta1", Version: "v0.26.0"},
+		{Name: "tekton-triggers", Endpoint: "https://kubernetes.test/apis/triggers.tekton.dev/v1alpha1",
+			Error: "resources not available: triggerbindings"},
+	}
+	got := b.CheckDependencies(ctx)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected dependencies: %s", diff.PrintWantGot(d))
+	}
+}
+
 func assertError(t testing.TB, got, want error) {
 	t.Helper()
 
@@ -726,7 +890,15 @@ func initBackend(t *testing.T) (context context.Context, backend *WorkflowBacken
 
 	context, _ = rtesting.SetupFakeContext(t)
 	logsOutput = &bytes.Buffer{}
-	logger := log.New(logsOutput, "", 0)
+	// leave the time out of the logged records, so that they can be compared
+	logger := slog.New(slog.NewTextHandler(logsOutput, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
 	backend = fakeNewWorkflowBackend(context, t, logger, testNamespace)
 	return
 }
@@ -768,14 +940,21 @@ func newFakeClients(context context.Context, t *testing.T, namespace string) *cl
 	fc.TriggerTemplateClient = tcs.TriggersV1alpha1().TriggerTemplates(namespace)
 	fc.TriggerBindingClient = tcs.TriggersV1alpha1().TriggerBindings(namespace)
 	fc.EventListenerClient = tcs.TriggersV1alpha1().EventListeners(namespace)
+
+	kcs := k8sfake.NewSimpleClientset()
+	fc.Discovery = kcs.Discovery()
+	fc.ConfigMapClient = kcs.CoreV1().ConfigMaps(config.Default().Tekton.Namespace)
+	fc.Host = "https://kubernetes.test"
 	return fc
 }
 
-func fakeNewWorkflowBackend(context context.Context, t *testing.T, logger *log.Logger, namespace string) *WorkflowBackend {
+func fakeNewWorkflowBackend(context context.Context, t *testing.T, logger *slog.Logger, namespace string) *WorkflowBackend {
 	t.Helper()
 
 	clients := newFakeClients(context, t, namespace)
-	return &WorkflowBackend{"http://tekton.test", namespace, logger, clients}
+	cfg := config.Default().Tekton
+	cfg.DashboardURL = "http://tekton.test"
+	return &WorkflowBackend{cfg.DashboardURL, namespace, logger, clients, &cfg}
 }
 
 func createCodeset(t *testing.T, nameID, projectID int) *domain.Codeset {
@@ -848,3 +1027,83 @@ func (b WorkflowBackend) createTestListener(ctx context.Context, t *testing.T, w
 		}
 	}
 }
+
+type recordingRunHandler struct {
+	workflow *domain.Workflow
+	events   chan string
+}
+
+func (h *recordingRunHandler) GetWorkflow(ctx context.Context, name string) (*domain.Workflow, error) {
+	if name != h.workflow.Name {
+		return nil, domain.ErrWorkflowNotFound
+	}
+	return h.workflow, nil
+}
+
+func (h *recordingRunHandler) OnWorkflowRun(ctx context.Context, eventType domain.EventType, run *domain.WorkflowRun) {
+	h.events <- fmt.Sprintf("%s %s %s", eventType, run.Name, run.Status)
+}
+
+func TestWatchWorkflowRuns(t *testing.T) {
+	ctx, b, _ := initBackend(t)
+	ctx, cancel := context.WithCancel(ctx)
+
+	w := domain.Workflow{}
+	readYaml(t, fuseMLWorkflow, &w)
+	err := b.CreateWorkflow(ctx, &w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// runs that exist before the watch starts are not reported
+	b.createTestWorkflowRun(ctx, t, w.Name, createCodeset(t, 1, 1), "existing", "Succeeded", time.Now(), time.Now())
+
+	handler := &recordingRunHandler{&w, make(chan string, 10)}
+	var wg sync.WaitGroup
+	err = b.WatchWorkflowRuns(ctx, &wg, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case got := <-handler.events:
+			assertStrings(t, got, want)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for %q", want)
+		}
+	}
+
+	// the fake client does not set the creation timestamp
+	run, err := b.tektonClients.PipelineRunClient.Get(ctx, "existing", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	run.ObjectMeta = metav1.ObjectMeta{Name: "new", Labels: run.Labels, CreationTimestamp: metav1.Now()}
+	run.Status.Conditions = knbeta1.Conditions{apis.Condition{Reason: "Running"}}
+	run, err = b.tektonClients.PipelineRunClient.Create(ctx, run, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect("created new Running")
+
+	run.Status.Conditions = knbeta1.Conditions{apis.Condition{Reason: "Succeeded"}}
+	_, err = b.tektonClients.PipelineRunClient.Update(ctx, run, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect("updated new Succeeded")
+
+	err = b.tektonClients.PipelineRunClient.Delete(ctx, "new", metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect("deleted new Succeeded")
+
+	cancel()
+	wg.Wait()
+	if len(handler.events) != 0 {
+		t.Errorf("Unexpected event: %s", <-handler.events)
+	}
+}
@@ -0,0 +1,43 @@
+package tekton
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fuseml/fuseml-core/pkg/core/tekton/builder"
+)
+
+// annotationCarrier carries the trace context in the annotations of a tekton resource
+type annotationCarrier struct {
+	meta *metav1.ObjectMeta
+}
+
+// Get returns the value of the annotation for the trace context field.
+func (c annotationCarrier) Get(key string) string {
+	return c.meta.Annotations[AnnotationTraceContextPrefix+key]
+}
+
+// Set sets the annotation for the trace context field.
+func (c annotationCarrier) Set(key, value string) {
+	builder.Annotation(AnnotationTraceContextPrefix+key, value)(c.meta)
+}
+
+// Keys lists the trace context fields found in the annotations.
+func (c annotationCarrier) Keys() []string {
+	keys := []string{}
+	for k := range c.meta.Annotations {
+		if strings.HasPrefix(k, AnnotationTraceContextPrefix) {
+			keys = append(keys, strings.TrimPrefix(k, AnnotationTraceContextPrefix))
+		}
+	}
+	return keys
+}
+
+// injectTraceContext annotates a tekton resource with the trace context in the context, so that the traces
+// of the workflow runs can be correlated with the request that created them.
+func injectTraceContext(ctx context.Context, meta *metav1.ObjectMeta) {
+	otel.GetTextMapPropagator().Inject(ctx, annotationCarrier{meta})
+}
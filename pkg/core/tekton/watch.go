@@ -0,0 +1,73 @@
+package tekton
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+// WatchWorkflowRuns watches the tekton pipeline runs created for FuseML workflows in the background, until
+// the context is cancelled, and reports the workflow runs that are created, updated or deleted to the handler.
+// Pipeline runs that already exist when the watch starts are not reported.
+func (w *WorkflowBackend) WatchWorkflowRuns(ctx context.Context, wg *sync.WaitGroup, handler domain.WorkflowRunHandler) error {
+	runs := w.tektonClients.PipelineRunClient
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				options.LabelSelector = LabelWorkflowRef
+				return runs.List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.LabelSelector = LabelWorkflowRef
+				return runs.Watch(ctx, options)
+			},
+		},
+		&v1beta1.PipelineRun{}, 0, cache.Indexers{},
+	)
+
+	// kubernetes timestamps have a resolution of one second
+	started := metav1.Now().Rfc3339Copy()
+	notify := func(eventType domain.EventType, obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		run, ok := obj.(*v1beta1.PipelineRun)
+		if !ok {
+			return
+		}
+		if eventType == domain.EventCreated && run.CreationTimestamp.Before(&started) {
+			return
+		}
+		wf, err := handler.GetWorkflow(ctx, run.Labels[LabelWorkflowRef])
+		if err != nil {
+			w.logger.WarnContext(ctx, "Ignoring tekton pipeline run", "run", run.Name, "error", err)
+			return
+		}
+		handler.OnWorkflowRun(ctx, eventType, w.toWorkflowRun(wf, *run))
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			notify(domain.EventCreated, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			notify(domain.EventUpdated, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			notify(domain.EventDeleted, obj)
+		},
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		informer.Run(ctx.Done())
+	}()
+	return nil
+}
@@ -0,0 +1,34 @@
+# Training entrypoint for the {{ .Name }} codeset.
+#
+# The FuseML workflow runs this script through `mlflow run`, with MLFLOW_TRACKING_URI
+# and MLFLOW_S3_ENDPOINT_URL already configured to point to the tracking server and
+# the artifact store. Replace the example below with your own training code.
+
+import sys
+
+import mlflow
+import mlflow.sklearn
+import numpy as np
+from sklearn.datasets import load_diabetes
+from sklearn.linear_model import ElasticNet
+from sklearn.metrics import mean_squared_error
+from sklearn.model_selection import train_test_split
+
+
+if __name__ == "__main__":
+    alpha = float(sys.argv[1]) if len(sys.argv) > 1 else 0.5
+    l1_ratio = float(sys.argv[2]) if len(sys.argv) > 2 else 0.1
+
+    X, y = load_diabetes(return_X_y=True)
+    X_train, X_test, y_train, y_test = train_test_split(X, y, random_state=42)
+
+    with mlflow.start_run():
+        model = ElasticNet(alpha=alpha, l1_ratio=l1_ratio, random_state=42)
+        model.fit(X_train, y_train)
+
+        rmse = np.sqrt(mean_squared_error(y_test, model.predict(X_test)))
+
+        mlflow.log_param("alpha", alpha)
+        mlflow.log_param("l1_ratio", l1_ratio)
+        mlflow.log_metric("rmse", rmse)
+        mlflow.sklearn.log_model(model, "model", registered_model_name="{{ .Name }}")
@@ -26,19 +26,23 @@ func (ws *WorkflowStore) GetWorkflow(ctx context.Context, name string) (*domain.
 	return nil, domain.ErrWorkflowNotFound
 }
 
-// GetWorkflows returns all workflows or the one that matches a given name.
-func (ws *WorkflowStore) GetWorkflows(ctx context.Context, name *string) (result []*domain.Workflow) {
-	result = []*domain.Workflow{}
+// GetWorkflows returns the page of workflows selected by the list options, or the one that matches a given name.
+func (ws *WorkflowStore) GetWorkflows(ctx context.Context, name *string, opts *domain.ListOptions) ([]*domain.Workflow, string, error) {
+	result := []*domain.Workflow{}
 	if name != nil {
 		if wf, ok := ws.items[*name]; ok {
 			result = append(result, wf)
 		}
-		return
+	} else {
+		for _, wf := range ws.items {
+			result = append(result, wf)
+		}
 	}
-	for _, wf := range ws.items {
-		result = append(result, wf)
+	next, err := domain.Paginate(&result, opts, domain.WorkflowSortFields, domain.WorkflowDefaultSort)
+	if err != nil {
+		return nil, "", err
 	}
-	return
+	return result, next, nil
 }
 
 // AddWorkflow adds a new workflow based on the Workflow structure provided as argument
@@ -50,6 +54,18 @@ func (ws *WorkflowStore) AddWorkflow(ctx context.Context, w *domain.Workflow) (*
 	return w, nil
 }
 
+// UpdateWorkflow replaces the definition of a workflow in the store, keeping its stored codeset assignments
+func (ws *WorkflowStore) UpdateWorkflow(ctx context.Context, w *domain.Workflow) (*domain.Workflow, error) {
+	stored, exists := ws.items[w.Name]
+	if !exists {
+		return nil, domain.ErrWorkflowNotFound
+	}
+	wf := *w
+	wf.AssignedTo = stored.AssignedTo
+	ws.items[w.Name] = &wf
+	return &wf, nil
+}
+
 // DeleteWorkflow deletes the workflow from the store
 func (ws *WorkflowStore) DeleteWorkflow(ctx context.Context, name string) error {
 	wf, found := ws.items[name]
@@ -105,6 +121,22 @@ func (ws *WorkflowStore) AddCodesetAssignment(ctx context.Context, workflowName
 	return wf.GetCodesetAssignments(ctx), nil
 }
 
+// UpdateCodesetAssignmentWebhook changes the webhook of a codeset assignment of a workflow
+func (ws *WorkflowStore) UpdateCodesetAssignmentWebhook(ctx context.Context, workflowName string, codeset *domain.Codeset,
+	webhookID *int64) error {
+	wf, ok := ws.items[workflowName]
+	if !ok {
+		return domain.ErrWorkflowNotFound
+	}
+
+	assignment, err := wf.GetCodesetAssignment(ctx, codeset)
+	if err != nil {
+		return err
+	}
+	assignment.WebhookID = webhookID
+	return nil
+}
+
 // DeleteCodesetAssignment deletes a codeset assignment from the list of assigned codesets of a workflow
 func (ws *WorkflowStore) DeleteCodesetAssignment(ctx context.Context, workflowName string, codeset *domain.Codeset) ([]*domain.CodesetAssignment, error) {
 	wf, ok := ws.items[workflowName]
@@ -2,13 +2,68 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
+const (
+	// ErrApplicationNotFound describes the error message returned when trying to get an application that does not exist.
+	ErrApplicationNotFound = ApplicationErr("Application with the specified name not found")
+	// ErrApplicationExists describes the error message returned when trying to register an application with the same
+	// name as an application created by a different workflow.
+	ErrApplicationExists = ApplicationErr("Application with the specified name already registered by a different workflow")
+)
+
+// ApplicationState describes the health of an application, as determined by probing it
+type ApplicationState string
+
+const (
+	// ApplicationReady is the state of an application whose URL is reachable and whose kubernetes resources exist.
+	ApplicationReady = ApplicationState("ready")
+	// ApplicationDegraded is the state of an application that is only partially available.
+	ApplicationDegraded = ApplicationState("degraded")
+	// ApplicationMissing is the state of an application that is not available at all.
+	ApplicationMissing = ApplicationState("missing")
+)
+
+// ApplicationErr are expected errors returned when performing operations on applications
+type ApplicationErr string
+
+// Error returns the error message
+func (e ApplicationErr) Error() string {
+	return string(e)
+}
+
+// ApplicationManager describes the interface for an Application Manager
+type ApplicationManager interface {
+	// RegisterApplication registers a new application, or replaces an existing one created by the same workflow.
+	RegisterApplication(ctx context.Context, app *Application) (*Application, error)
+	// UpdateApplication updates an existing application.
+	UpdateApplication(ctx context.Context, app *Application) (*Application, error)
+	// GetApplicationStatus probes the application URL and kubernetes resources and returns its status.
+	GetApplicationStatus(ctx context.Context, name string) (*ApplicationStatus, error)
+	// GetApplication retrieves an application.
+	GetApplication(ctx context.Context, name string) (*Application, error)
+	// GetApplications returns the page of applications, filtered by type and workflow, selected by the list
+	// options, along with the continue token for the next page.
+	GetApplications(ctx context.Context, appType, workflow *string, opts *ListOptions) ([]*Application, string, error)
+	// DeleteApplication deletes an application and its kubernetes resources.
+	DeleteApplication(ctx context.Context, name string) error
+	// GetProjectApplications returns the applications that belong to a project.
+	GetProjectApplications(ctx context.Context, project string) ([]*Application, error)
+}
+
+// ApplicationSortFields are the fields that application lists can be ordered by
+var ApplicationSortFields = SortFields{"name": {"Name"}, "type": {"Type", "Name"}, "workflow": {"Workflow", "Name"}}
+
+// ApplicationDefaultSort is the order of application lists when no sort field is given
+const ApplicationDefaultSort = "name"
+
 // ApplicationStore is an inteface to application stores
 type ApplicationStore interface {
 	Find(context.Context, string) *Application
-	GetAll(context.Context, *string, *string) ([]*Application, error)
+	GetAll(context.Context, *string, *string, *ListOptions) ([]*Application, string, error)
 	Add(context.Context, *Application) (*Application, error)
+	Update(context.Context, *Application) (*Application, error)
 	Delete(context.Context, string) error
 }
 
@@ -37,3 +92,27 @@ type KubernetesResource struct {
 	// The kind of Kubernetes resource
 	Kind string
 }
+
+// ApplicationStatus describes the result of probing an application
+type ApplicationStatus struct {
+	// The overall state of the Application
+	State ApplicationState
+	// Whether the Application URL responded to requests
+	URLReachable bool
+	// Details about the problems encountered while probing the Application
+	Message string
+	// The status of each Kubernetes resource describing the Application
+	Resources []*KubernetesResourceStatus
+	// The time when the Application was probed
+	Checked time.Time
+}
+
+// KubernetesResourceStatus describes whether a Kubernetes resource that forms the application exists
+type KubernetesResourceStatus struct {
+	// The name of the Kubernetes resource
+	Name string
+	// The kind of Kubernetes resource
+	Kind string
+	// Whether the resource exists in the Kubernetes cluster
+	Exists bool
+}
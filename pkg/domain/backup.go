@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+const (
+	// ErrInvalidBackup describes the error returned when trying to restore a snapshot that was not produced by
+	// a FuseML backup, or that was produced by a newer, incompatible version. It is wrapped by errors that
+	// describe the problem.
+	ErrInvalidBackup = BackupErr("invalid backup")
+	// ErrStoreNotEmpty describes the error returned when trying to restore a snapshot into a FuseML server that
+	// already stores data.
+	ErrStoreNotEmpty = BackupErr("backups can only be restored into an empty FuseML server")
+)
+
+// BackupErr are expected errors returned when backing up or restoring the FuseML state
+type BackupErr string
+
+// Error returns the error message
+func (e BackupErr) Error() string {
+	return string(e)
+}
+
+// RestoreResult describes the outcome of a restore
+type RestoreResult struct {
+	// ServerVersion is the version of the FuseML server the snapshot was taken from.
+	ServerVersion string
+	// Created is the time when the snapshot was taken.
+	Created time.Time
+	// Workflows is the number of workflows restored.
+	Workflows int
+	// Assignments is the number of codeset assignments restored.
+	Assignments int
+	// Recreated is true if the Tekton resources of the workflows and the webhooks of their assignments were
+	// re-created.
+	Recreated bool
+	// Errors lists the resources that could not be re-created.
+	Errors []string
+}
+
+// BackupStore is implemented by the stores that are able to take a consistent snapshot of all the data they
+// hold and to load it back.
+type BackupStore interface {
+	// Backup writes a consistent snapshot of the store data.
+	Backup(ctx context.Context, w io.Writer) error
+	// Restore loads a snapshot written by Backup. It returns ErrStoreNotEmpty if the store already holds data.
+	Restore(ctx context.Context, r io.Reader) error
+}
+
+// BackupManager describes the interface for a backup manager
+type BackupManager interface {
+	// Backup returns a stream with a versioned snapshot of the FuseML state.
+	Backup(ctx context.Context) (io.ReadCloser, error)
+	// Restore loads a snapshot returned by Backup into an empty FuseML server and, if recreate is set,
+	// re-creates the Tekton resources of the restored workflows and the webhooks of their codeset assignments.
+	Restore(ctx context.Context, r io.Reader, recreate bool) (*RestoreResult, error)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 // Codeset represents a codeset artifact
@@ -18,34 +19,51 @@ type Codeset struct {
 	URL string
 }
 
-// CodesetSubscriber is an interface for objects interested in operations performed on
-// a specific codeset
-type CodesetSubscriber interface {
-	OnDeletingCodeset(ctx context.Context, c *Codeset)
+// Webhook is a webhook of a codeset, notifying a workflow listener of the changes pushed to the codeset
+type Webhook struct {
+	// ID identifies the webhook within the codeset
+	ID int64
+	// URL is the address the webhook posts to
+	URL string
+	// Created is the time when the webhook was created
+	Created time.Time
 }
 
+// CodesetSortFields are the fields that codeset lists can be ordered by
+var CodesetSortFields = SortFields{"name": {"Name", "Project"}, "project": {"Project", "Name"}}
+
+// CodesetDefaultSort is the order of codeset lists when no sort field is given
+const CodesetDefaultSort = "project"
+
 // CodesetStore is an interface to codeset stores
 type CodesetStore interface {
 	Find(ctx context.Context, project, name string) (*Codeset, error)
-	GetAll(ctx context.Context, project, label *string) ([]*Codeset, error)
+	// GetAll returns the page of codesets matching given project and label selected by the list options,
+	// along with the continue token for the next page.
+	GetAll(ctx context.Context, project, label *string, opts *ListOptions) ([]*Codeset, string, error)
 	Add(ctx context.Context, c *Codeset) (*Codeset, *string, *string, error)
 	CreateWebhook(context.Context, *Codeset, string) (*int64, error)
+	// GetWebhooks returns the webhooks of a codeset.
+	GetWebhooks(context.Context, *Codeset) ([]*Webhook, error)
 	DeleteWebhook(context.Context, *Codeset, *int64) error
 	Delete(ctx context.Context, project, name string) error
-	Subscribe(ctx context.Context, watcher CodesetSubscriber, codeset *Codeset) error
-	Unsubscribe(ctx context.Context, watcher CodesetSubscriber, codeset *Codeset) error
 }
 
 // GitAdminClient describes the interface of a Git admin client
 type GitAdminClient interface {
-	PrepareRepository(*Codeset, *string) (*string, *string, error)
-	CreateRepoWebhook(string, string, *string) (*int64, error)
-	DeleteRepoWebhook(string, string, *int64) error
-	GetRepositories(org, label *string) ([]*Codeset, error)
-	GetRepository(org, name string) (*Codeset, error)
-	DeleteRepository(org, name string) error
-	GetProjects() ([]*Project, error)
-	GetProject(org string) (*Project, error)
-	DeleteProject(org string) error
-	CreateProject(string, string, bool) (*Project, error)
+	PrepareRepository(ctx context.Context, codeset *Codeset, listenerURL *string) (*string, *string, error)
+	CreateRepoWebhook(ctx context.Context, org, name string, listenerURL *string) (*int64, error)
+	DeleteRepoWebhook(ctx context.Context, org, name string, hookID *int64) error
+	GetRepoWebhooks(ctx context.Context, org, name string) ([]*Webhook, error)
+	GetRepositories(ctx context.Context, org, label *string, opts *ListOptions) ([]*Codeset, string, error)
+	GetRepository(ctx context.Context, org, name string) (*Codeset, error)
+	DeleteRepository(ctx context.Context, org, name string) error
+	GetProjects(ctx context.Context, opts *ListOptions) ([]*Project, string, error)
+	GetProject(ctx context.Context, org string) (*Project, error)
+	DeleteProject(ctx context.Context, org string) error
+	CreateProject(ctx context.Context, name, desc string, ignoreExisting bool) (*Project, error)
+	GetProjectMembers(ctx context.Context, org string) ([]*User, error)
+	AddProjectMember(ctx context.Context, org string, user *User) (*string, error)
+	RemoveProjectMember(ctx context.Context, org, userName string) error
+	ResetUserPassword(ctx context.Context, org, userName string) (*string, error)
 }
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	// ErrCodesetTemplateNotFound describes the error message returned when trying to get a codeset template that does not exist.
+	ErrCodesetTemplateNotFound = CodesetTemplateErr("could not find a codeset template with the specified name")
+)
+
+// CodesetTemplateErr are expected errors returned from the CodesetTemplateStore
+type CodesetTemplateErr string
+
+// Error returns the error message
+func (e CodesetTemplateErr) Error() string {
+	return string(e)
+}
+
+// CodesetTemplate describes a set of files that can be used to scaffold a new codeset. The template
+// advertises the contents of the codesets it produces using the same attributes that runnables use
+// to describe their codeset inputs, which makes it possible to find templates that are compatible
+// with a given runnable.
+type CodesetTemplate struct {
+	// Unique template name
+	Name string
+	// Template description
+	Description string
+	// Attributes describing the contents of codesets generated from this template
+	Codeset RunnableCodesetArtifact
+	// Map of template files, indexed by their path relative to the codeset root directory. File
+	// contents may use text/template directives that are rendered when the codeset is scaffolded.
+	Files map[string]string
+}
+
+// CodesetTemplateStore is an interface to codeset template stores
+type CodesetTemplateStore interface {
+	// Find returns the codeset template with the given name.
+	Find(ctx context.Context, name string) (*CodesetTemplate, error)
+	// GetAll returns all codeset templates that produce codesets matching the supplied codeset artifact
+	// descriptor. If the descriptor is nil, all templates are returned.
+	GetAll(ctx context.Context, codeset *RunnableCodesetArtifact) ([]*CodesetTemplate, error)
+}
+
+// Matches returns true if the codesets generated from the template satisfy the type, function and
+// format requirements of the supplied codeset artifact descriptor. Empty requirements match any value.
+func (t *CodesetTemplate) Matches(codeset *RunnableCodesetArtifact) bool {
+	if codeset == nil {
+		return true
+	}
+	return matchesAny(t.Codeset.Type, codeset.Type) &&
+		matchesAny(t.Codeset.Function, codeset.Function) &&
+		matchesAny(t.Codeset.Format, codeset.Format)
+}
+
+// matchesAny returns true if the list of requested values is empty or if at least one of its values
+// is also present in the list of provided values
+func matchesAny(provided, requested []string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+	for _, r := range requested {
+		for _, p := range provided {
+			if strings.EqualFold(r, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -3,6 +3,14 @@ package domain
 const (
 	// ErrProjectExists is the error message returned when trying to create a project (org) that already exists.
 	ErrProjectExists = projectErr("Project with that name already exists")
+	// ErrProjectNotFound is the error message returned when trying to access a project (org) that does not exist.
+	ErrProjectNotFound = projectErr("Project with that name does not exist")
+	// ErrProjectMemberExists is the error message returned when trying to add a user that is already a member of the project.
+	ErrProjectMemberExists = projectErr("User is already a member of the project")
+	// ErrProjectMemberNotFound is the error message returned when trying to access a user that is not a member of the project.
+	ErrProjectMemberNotFound = projectErr("User is not a member of the project")
+	// ErrProjectNotEmpty is the error message returned when trying to delete a project that still has codesets, without cascading.
+	ErrProjectNotEmpty = projectErr("Project still has codesets. Delete them first or delete the project with cascade")
 )
 
 type projectErr string
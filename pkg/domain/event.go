@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+)
+
+// EventType describes the operation that triggered an event
+type EventType string
+
+// ResourceKind describes the kind of resource an event refers to
+type ResourceKind string
+
+const (
+	// EventCreated is published after a resource is created
+	EventCreated = EventType("created")
+	// EventUpdated is published after a resource is updated
+	EventUpdated = EventType("updated")
+	// EventDeleting is published before a resource is deleted, while it can still be accessed
+	EventDeleting = EventType("deleting")
+	// EventDeleted is published after a resource managed outside of FuseML (e.g. a workflow run)
+	// is deleted. The event object holds the last known state of the resource.
+	EventDeleted = EventType("deleted")
+)
+
+const (
+	// CodesetResource identifies events published for codesets. The event object is a *Codeset.
+	CodesetResource = ResourceKind("codeset")
+	// ProjectResource identifies events published for projects. The event object is a *Project.
+	ProjectResource = ResourceKind("project")
+	// WorkflowResource identifies events published for workflows. The event object is a *Workflow.
+	WorkflowResource = ResourceKind("workflow")
+	// ExtensionResource identifies events published for extensions. The event object is an *Extension.
+	// Changes made to services, endpoints and credentials are published as updates of their extension.
+	ExtensionResource = ResourceKind("extension")
+	// WorkflowRunResource identifies events published for workflow runs, as they are observed in the workflow
+	// backend. The event object is a *WorkflowRun.
+	WorkflowRunResource = ResourceKind("workflowrun")
+	// WorkflowAssignmentResource identifies events published when a workflow is assigned to, or unassigned
+	// from, a codeset. The event object is a *WorkflowCodesetAssignment.
+	WorkflowAssignmentResource = ResourceKind("assignment")
+)
+
+// Event describes an operation performed on a FuseML resource
+type Event struct {
+	// Type is the operation performed on the resource
+	Type EventType
+	// Kind is the kind of resource the operation was performed on
+	Kind ResourceKind
+	// Object is the resource the operation was performed on
+	Object interface{}
+}
+
+// EventSubscriber is an interface for objects interested in operations performed on resources
+type EventSubscriber interface {
+	// OnEvent is called for every event published for the resource kinds the subscriber
+	// is subscribed to
+	OnEvent(ctx context.Context, event *Event)
+}
+
+// EventBus is an interface for dispatching resource events to the subsystems interested in them,
+// without the subsystems having to know about each other
+type EventBus interface {
+	// Subscribe adds a subscriber interested in the events published for the given resource kinds.
+	// If no kinds are given, the subscriber receives all events.
+	Subscribe(subscriber EventSubscriber, kinds ...ResourceKind)
+	// Unsubscribe removes a subscriber
+	Unsubscribe(subscriber EventSubscriber)
+	// Publish delivers an event to the interested subscribers. Publish only returns after all
+	// subscribers have processed the event.
+	Publish(ctx context.Context, event *Event)
+}
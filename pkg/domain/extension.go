@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
@@ -21,6 +22,19 @@ const (
 	EETExternal = "external"
 )
 
+// ExtensionServiceEndpointHealth is the type used for the ExtensionServiceEndpointStatus Health field
+type ExtensionServiceEndpointHealth string
+
+// Valid values that can be used with ExtensionServiceEndpointHealth
+const (
+	// EEHUnknown is the health of an endpoint that hasn't been probed yet
+	EEHUnknown ExtensionServiceEndpointHealth = "unknown"
+	// EEHHealthy is the health of an endpoint that responded to the last probe
+	EEHHealthy ExtensionServiceEndpointHealth = "healthy"
+	// EEHUnhealthy is the health of an endpoint that failed the last probe
+	EEHUnhealthy ExtensionServiceEndpointHealth = "unhealthy"
+)
+
 // ExtensionServiceCredentialsScope is the type used for the ExtensionServiceCredentials Scope field
 type ExtensionServiceCredentialsScope string
 
@@ -64,6 +78,10 @@ type Extension struct {
 	Updated time.Time
 	// Services is a list of services that are part of this extension
 	Services map[string]*ExtensionService
+	// Marks an extension managed by the extension discovery controller, which keeps it in sync with
+	// the annotated kubernetes resources it was discovered from. Replacing a discovered extension through
+	// the API takes it out of the control of the discovery controller.
+	Discovered bool
 }
 
 // ExtensionService is a service provided by an extension. A service is represented by a
@@ -118,6 +136,28 @@ type ExtensionServiceEndpoint struct {
 	Created time.Time
 	// The time when the extension was last updated
 	Updated time.Time
+	// Operational status, as determined by the extension health checker
+	Status ExtensionServiceEndpointStatus
+}
+
+// ExtensionServiceEndpointStatus describes the operational status of an extension endpoint
+type ExtensionServiceEndpointStatus struct {
+	// Whether the endpoint responded to the last probe. An empty value is equivalent to EEHUnknown
+	Health ExtensionServiceEndpointHealth
+	// Details about the last failed probe
+	Message string
+	// The time when the endpoint was last probed
+	LastChecked time.Time
+	// The time when the endpoint last responded to a probe
+	LastSeen time.Time
+}
+
+// GetHealth returns the endpoint health, defaulting to EEHUnknown if the endpoint hasn't been probed yet
+func (s ExtensionServiceEndpointStatus) GetHealth() ExtensionServiceEndpointHealth {
+	if s.Health == "" {
+		return EEHUnknown
+	}
+	return s.Health
 }
 
 // ExtensionServiceCredentials is a group of configuration values that can be generally used to embed information
@@ -358,6 +398,54 @@ func (e *ErrExtensionServiceCredentialsNotFound) Error() string {
 		e.ExtensionID, e.ServiceID, e.CredentialsID)
 }
 
+// ErrExtensionInUse is the error returned when trying to remove an extension, extension service or set of
+// credentials that workflows are bound to
+type ErrExtensionInUse struct {
+	// Path of the element being removed (e.g. extension, extension/service or extension/service/credentials)
+	Element   string
+	Workflows []string
+}
+
+// NewErrExtensionInUse creates a new ErrExtensionInUse error
+func NewErrExtensionInUse(element string, workflows []string) *ErrExtensionInUse {
+	return &ErrExtensionInUse{element, workflows}
+}
+
+func (e *ErrExtensionInUse) Error() string {
+	return fmt.Sprintf("cannot remove '%s', it is used by workflows: %s", e.Element, strings.Join(e.Workflows, ", "))
+}
+
+// ErrInvalidConfiguration is the error returned by various registry methods when a service, endpoint or set of
+// credentials lacks configuration entries required by the schema of the service resource type
+type ErrInvalidConfiguration struct {
+	Kind     ExtensionElementKind
+	Element  string
+	Resource string
+	Missing  []string
+}
+
+// NewErrInvalidConfiguration creates a new ErrInvalidConfiguration error
+func NewErrInvalidConfiguration(kind ExtensionElementKind, element, resource string, missing []string) *ErrInvalidConfiguration {
+	return &ErrInvalidConfiguration{kind, element, resource, missing}
+}
+
+func (e *ErrInvalidConfiguration) Error() string {
+	return fmt.Sprintf("%s '%s' is missing configuration entries required for '%s' resources: %s",
+		e.Kind, e.Element, e.Resource, strings.Join(e.Missing, ", "))
+}
+
+// ExtensionSortFields are the fields that extension lists can be ordered by
+var ExtensionSortFields = SortFields{
+	"id":      {"ID"},
+	"product": {"Product", "ID"},
+	"zone":    {"Zone", "ID"},
+	"created": {"Created", "ID"},
+	"updated": {"Updated", "ID"},
+}
+
+// ExtensionDefaultSort is the order of extension lists when no sort field is given
+const ExtensionDefaultSort = "id"
+
 // ExtensionRegistry defines the public interface implemented by the extension registry
 type ExtensionRegistry interface {
 	// Register a new extension, with all participating services, endpoints and credentials
@@ -368,8 +456,9 @@ type ExtensionRegistry interface {
 	AddEndpoint(ctx context.Context, extensionID string, serviceID string, endpoint *ExtensionServiceEndpoint) (*ExtensionServiceEndpoint, error)
 	// Add a set of credentials to an existing extension service
 	AddCredentials(ctx context.Context, extensionID string, serviceID string, credentials *ExtensionServiceCredentials) (*ExtensionServiceCredentials, error)
-	// List all registered extensions that match the supplied query parameters
-	ListExtensions(ctx context.Context, query *ExtensionQuery) (result []*Extension, err error)
+	// List the page of registered extensions that match the supplied query parameters, selected by the list
+	// options, along with the continue token for the next page
+	ListExtensions(ctx context.Context, query *ExtensionQuery, opts *ListOptions) (result []*Extension, next string, err error)
 	// Retrieve an extension by ID and, optionally, its entire service/endpoint/credentials subtree
 	GetExtension(ctx context.Context, extensionID string) (*Extension, error)
 	// Retrieve an extension service by ID and, optionally, its entire endpoint/credentials subtree
@@ -386,6 +475,8 @@ type ExtensionRegistry interface {
 	UpdateEndpoint(ctx context.Context, extensionID string, serviceID string, endpoint *ExtensionServiceEndpoint) error
 	// Update a set of credentials belonging to a service
 	UpdateCredentials(ctx context.Context, extensionID string, serviceID string, credentials *ExtensionServiceCredentials) error
+	// Update the operational status of an endpoint belonging to a service
+	UpdateEndpointStatus(ctx context.Context, extensionID string, serviceID string, endpointURL string, status ExtensionServiceEndpointStatus) error
 	// Remove an extension from the registry, along with all its services, endpoints and credentials
 	RemoveExtension(ctx context.Context, extensionID string) error
 	// Remove an extension service from the registry, along with all its endpoints and credentials
@@ -394,8 +485,22 @@ type ExtensionRegistry interface {
 	RemoveEndpoint(ctx context.Context, extensionID, serviceID, endpointID string) error
 	// Remove a set of extension credentials from the registry
 	RemoveCredentials(ctx context.Context, extensionID, serviceID, credentialsID string) error
+	// Import extensions into the registry. Extensions, services, endpoints and credentials that are not yet registered
+	// are added, those that are already registered are updated and those missing from the imported extensions are
+	// left untouched. Returns the changes made to the registry, or the changes that would be made in dry-run mode.
+	ImportExtensions(ctx context.Context, extensions []*Extension, options *ExtensionImportOptions) ([]*ExtensionChange, error)
+	// Export all registered extensions, with their services, endpoints and, optionally, credentials
+	ExportExtensions(ctx context.Context, excludeCredentials bool) ([]*Extension, error)
+	// List the schemas of the known service resource types, or only the schema of the given resource type
+	ListResourceSchemas(ctx context.Context, resource string) ([]*ExtensionResourceSchema, error)
 	// Run a query on the extension registry to find one or more ways to access extensions matching given search parameters
 	GetExtensionAccessDescriptors(ctx context.Context, query *ExtensionQuery) ([]*ExtensionAccessDescriptor, error)
+	// Record the bindings between a workflow and extensions, releasing the previous bindings of the workflow
+	RecordUsage(ctx context.Context, workflowName string, usage []*ExtensionUsage) error
+	// Release all bindings between a workflow and extensions
+	ReleaseUsage(ctx context.Context, workflowName string) error
+	// List the bindings between workflows and extensions that match the supplied filter
+	GetUsage(ctx context.Context, filter *ExtensionUsageFilter) ([]*ExtensionUsage, error)
 }
 
 // ExtensionStore defines the interface required to store extensions.
@@ -404,8 +509,8 @@ type ExtensionStore interface {
 	AddExtension(ctx context.Context, extension *Extension) (*Extension, error)
 	// GetExtension retrieves an extension by its ID.
 	GetExtension(ctx context.Context, extensionID string) (*Extension, error)
-	// ListExtensions retrieves all stored extensions.
-	ListExtensions(ctx context.Context, query *ExtensionQuery) []*Extension
+	// ListExtensions retrieves the page of stored extensions matching the query selected by the list options.
+	ListExtensions(ctx context.Context, query *ExtensionQuery, opts *ListOptions) ([]*Extension, string, error)
 	// UpdateExtension updates an existing extension.
 	UpdateExtension(ctx context.Context, newExtension *Extension) error
 	// DeleteExtension deletes an extension from the store.
@@ -428,6 +533,9 @@ type ExtensionStore interface {
 	ListExtensionServiceEndpoints(ctx context.Context, extensionID string, serviceID string) ([]*ExtensionServiceEndpoint, error)
 	// UpdateExtensionServiceEndpoint updates an endpoint belonging to an extension service.
 	UpdateExtensionServiceEndpoint(ctx context.Context, extensionID string, serviceID string, newEndpoint *ExtensionServiceEndpoint) error
+	// UpdateExtensionServiceEndpointStatus updates the operational status of an endpoint, without
+	// affecting its other attributes or update time.
+	UpdateExtensionServiceEndpointStatus(ctx context.Context, extensionID string, serviceID string, endpointURL string, status ExtensionServiceEndpointStatus) error
 	// DeleteExtensionServiceEndpoint deletes an extension endpoint from an extension service.
 	DeleteExtensionServiceEndpoint(ctx context.Context, extensionID, serviceID, endpointID string) error
 	// AddExtensionServiceCredentials adds a new credential to an extension service.
@@ -572,6 +680,7 @@ func (e *Extension) UpdateService(newService *ExtensionService) error {
 
 	newService.Created = service.Created
 	newService.Updated = time.Now()
+	newService.InheritEndpointStatus(service)
 
 	for _, endpoint := range newService.ListEndpoints() {
 		_, err := service.GetEndpoint(endpoint.URL)
@@ -901,6 +1010,7 @@ func (es *ExtensionService) UpdateEndpoint(newEndpoint *ExtensionServiceEndpoint
 
 	newEndpoint.Created = endpoint.Created
 	newEndpoint.Updated = time.Now()
+	newEndpoint.Status = endpoint.Status
 
 	es.Endpoints[endpoint.URL] = newEndpoint
 	return nil
@@ -920,6 +1030,17 @@ func (es *ExtensionService) UpdateCredentials(newCredentials *ExtensionServiceCr
 	return nil
 }
 
+// InheritEndpointStatus copies the operational status of the endpoints that are also part of the old
+// version of the service. The status is maintained by the health checker and must not be lost when
+// the service is updated.
+func (es *ExtensionService) InheritEndpointStatus(oldService *ExtensionService) {
+	for _, endpoint := range es.ListEndpoints() {
+		if oldEndpoint, err := oldService.GetEndpoint(endpoint.URL); err == nil {
+			endpoint.Status = oldEndpoint.Status
+		}
+	}
+}
+
 // DeleteEndpoint deletes an endpoint from the service.
 func (es *ExtensionService) DeleteEndpoint(endpointID string) error {
 	_, err := es.GetEndpoint(endpointID)
@@ -0,0 +1,50 @@
+package domain
+
+// ExtensionChangeAction is the type used for the ExtensionChange Action field
+type ExtensionChangeAction string
+
+// Valid values that can be used with ExtensionChangeAction
+const (
+	// ECACreate marks an element that is added to the registry
+	ECACreate ExtensionChangeAction = "create"
+	// ECAUpdate marks an element that already exists in the registry and is updated
+	ECAUpdate ExtensionChangeAction = "update"
+)
+
+// ExtensionElementKind is the type used for the ExtensionChange Kind field
+type ExtensionElementKind string
+
+// Valid values that can be used with ExtensionElementKind
+const (
+	// EEKExtension is an extension
+	EEKExtension ExtensionElementKind = "extension"
+	// EEKService is an extension service
+	EEKService ExtensionElementKind = "service"
+	// EEKEndpoint is an extension service endpoint
+	EEKEndpoint ExtensionElementKind = "endpoint"
+	// EEKCredentials is a set of extension service credentials
+	EEKCredentials ExtensionElementKind = "credentials"
+)
+
+// ExtensionChange describes a change made to the extension registry when importing extensions
+type ExtensionChange struct {
+	// Kind of registry element that is changed
+	Kind ExtensionElementKind
+	// Path of the changed element (e.g. extension, extension/service, extension/service/endpoint-URL or
+	// extension/service/credentials)
+	Element string
+	// Action performed on the element
+	Action ExtensionChangeAction
+	// Names of the element attributes that are changed, for updated elements. Only the attribute names are
+	// recorded, to avoid disclosing credentials.
+	Fields []string
+}
+
+// ExtensionImportOptions controls how extensions are imported into the extension registry
+type ExtensionImportOptions struct {
+	// Only compute the changes that the import would make, without applying them
+	DryRun bool
+	// Ignore the credentials of the imported extension services. The credentials already registered are
+	// left untouched.
+	ExcludeCredentials bool
+}
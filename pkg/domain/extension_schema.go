@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"sort"
+)
+
+// ExtensionConfigurationKey describes a configuration entry that services, endpoints or credentials
+// of a known resource type may carry
+type ExtensionConfigurationKey struct {
+	// Configuration entry key (e.g. AWS_ACCESS_KEY_ID)
+	Name string
+	// Description of the configuration entry
+	Description string
+	// Marks a configuration entry that must be present
+	Required bool
+}
+
+// ExtensionResourceSchema declares the configuration entries expected for extension services of a
+// known resource type (e.g. s3, git), as well as for their endpoints and credentials. Schemas are open:
+// configuration entries that are not declared in the schema are allowed.
+type ExtensionResourceSchema struct {
+	// Service resource type described by the schema
+	Resource string
+	// Description of the resource type
+	Description string
+	// Category that services of this resource type usually belong to
+	Category string
+	// Configuration entries for services
+	ServiceConfiguration []*ExtensionConfigurationKey
+	// Configuration entries for service endpoints
+	EndpointConfiguration []*ExtensionConfigurationKey
+	// Configuration entries for service credentials
+	CredentialsConfiguration []*ExtensionConfigurationKey
+}
+
+// ValidateService checks that a service, along with the endpoints and credentials it includes, carries
+// all the configuration entries required by the schema
+func (s *ExtensionResourceSchema) ValidateService(extensionID string, service *ExtensionService) error {
+	element := extensionID + "/" + service.ID
+	err := s.validate(EEKService, element, s.ServiceConfiguration, service.Configuration)
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range service.ListEndpoints() {
+		err = s.ValidateEndpoint(element, endpoint)
+		if err != nil {
+			return err
+		}
+	}
+	for _, credentials := range service.ListCredentials() {
+		err = s.ValidateCredentials(element, credentials)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateEndpoint checks that an endpoint carries all the configuration entries required by the schema
+func (s *ExtensionResourceSchema) ValidateEndpoint(serviceElement string, endpoint *ExtensionServiceEndpoint) error {
+	return s.validate(EEKEndpoint, serviceElement+"/"+endpoint.URL, s.EndpointConfiguration, endpoint.Configuration)
+}
+
+// ValidateCredentials checks that a set of credentials carries all the configuration entries required by the schema
+func (s *ExtensionResourceSchema) ValidateCredentials(serviceElement string, credentials *ExtensionServiceCredentials) error {
+	return s.validate(EEKCredentials, serviceElement+"/"+credentials.ID, s.CredentialsConfiguration, credentials.Configuration)
+}
+
+func (s *ExtensionResourceSchema) validate(kind ExtensionElementKind, element string, keys []*ExtensionConfigurationKey,
+	configuration map[string]string) error {
+	missing := []string{}
+	for _, key := range keys {
+		if _, ok := configuration[key.Name]; key.Required && !ok {
+			missing = append(missing, key.Name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return NewErrInvalidConfiguration(kind, element, s.Resource, missing)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ExtensionUsage records the binding of a workflow step to an extension service, endpoint and set of
+// credentials, made when the extension requirements of the workflow are resolved. Usage records are kept
+// after the binding is released, as an audit trail of extension accesses.
+type ExtensionUsage struct {
+	// Name of the workflow
+	Workflow string
+	// Name of the workflow step
+	Step string
+	// Name of the step extension requirement
+	Requirement string
+	// ID of the extension bound to the step
+	ExtensionID string
+	// ID of the extension service bound to the step
+	ServiceID string
+	// URL of the extension endpoint bound to the step
+	EndpointURL string
+	// ID of the set of credentials bound to the step, if any
+	CredentialsID string
+	// The time when the binding was made
+	Bound time.Time
+	// The time when the binding was released, because the workflow was deleted or its extension
+	// requirements were resolved again. Zero for bindings still in use.
+	Released time.Time
+}
+
+// Active returns true if the binding is still in use
+func (u *ExtensionUsage) Active() bool {
+	return u.Released.IsZero()
+}
+
+// ExtensionUsageFilter selects extension usage records. Empty fields match all records.
+type ExtensionUsageFilter struct {
+	Workflow      string
+	ExtensionID   string
+	ServiceID     string
+	CredentialsID string
+	// Also include released bindings
+	All bool
+}
+
+// Matches returns true if the usage record matches the filter
+func (f *ExtensionUsageFilter) Matches(u *ExtensionUsage) bool {
+	if f == nil {
+		return true
+	}
+	return (f.All || u.Active()) &&
+		(f.Workflow == "" || f.Workflow == u.Workflow) &&
+		(f.ExtensionID == "" || f.ExtensionID == u.ExtensionID) &&
+		(f.ServiceID == "" || f.ServiceID == u.ServiceID) &&
+		(f.CredentialsID == "" || f.CredentialsID == u.CredentialsID)
+}
+
+// ExtensionUsageStore defines the interface required to store extension usage records
+type ExtensionUsageStore interface {
+	// AddUsage adds new usage records
+	AddUsage(ctx context.Context, usage []*ExtensionUsage) error
+	// ReleaseUsage marks all active usage records of a workflow as released
+	ReleaseUsage(ctx context.Context, workflowName string, released time.Time) error
+	// GetUsage retrieves the usage records that match a filter, ordered by the time when the bindings were made
+	GetUsage(ctx context.Context, filter *ExtensionUsageFilter) ([]*ExtensionUsage, error)
+}
@@ -0,0 +1,272 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListOptions controls the paging and the ordering of the results returned by list operations
+type ListOptions struct {
+	// Maximum number of results to return. Zero means no limit.
+	Limit int
+	// Token returned along with the previous page of results, used to retrieve the next page
+	Continue string
+	// Name of the field used to order the results, prefixed with '-' for descending order
+	Sort string
+}
+
+// SortFields maps the field names accepted in ListOptions.Sort to the struct fields used to order the results.
+// Additional struct fields are used to order results having the same value for the first one.
+type SortFields map[string][]string
+
+// ErrInvalidListOptions is the error returned by list operations when the supplied paging or sorting
+// options are not valid
+type ErrInvalidListOptions struct {
+	Reason string
+}
+
+// NewErrInvalidListOptions creates a new ErrInvalidListOptions error
+func NewErrInvalidListOptions(format string, a ...interface{}) *ErrInvalidListOptions {
+	return &ErrInvalidListOptions{fmt.Sprintf(format, a...)}
+}
+
+func (e *ErrInvalidListOptions) Error() string {
+	return fmt.Sprintf("invalid list options: %s", e.Reason)
+}
+
+// ListWindow is the range of ordered results selected by a set of list options
+type ListWindow struct {
+	// Struct fields used to order the results
+	Fields []string
+	// Whether the results are in descending order
+	Descending bool
+	// Sort key of the last result of the previous page, holding the values of the sort fields. The window
+	// starts with the result that follows it, or with the first result if there is no previous page.
+	After []interface{}
+	// Maximum number of results. Zero means no limit.
+	Limit int
+
+	sort string
+}
+
+// continueToken is the content of the continue tokens, which carry the sort key of the last result returned,
+// so that the next page starts after it even if results were added or removed in the meantime
+type continueToken struct {
+	Sort  string   `json:"sort"`
+	After []string `json:"after"`
+}
+
+// Window validates the list options against the supported sort fields and returns the range of results
+// they select. Results are ordered by defaultSort when the options don't specify a sort field.
+// Nil options select all results.
+func (o *ListOptions) Window(fields SortFields, defaultSort string) (*ListWindow, error) {
+	if o == nil {
+		o = &ListOptions{}
+	}
+	if o.Limit < 0 {
+		return nil, NewErrInvalidListOptions("limit must not be negative")
+	}
+
+	sortBy := o.Sort
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+	name := strings.TrimPrefix(sortBy, "-")
+	structFields, ok := fields[name]
+	if !ok {
+		names := make([]string, 0, len(fields))
+		for n := range fields {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, NewErrInvalidListOptions("cannot sort by '%s', supported fields: %s", name, strings.Join(names, ", "))
+	}
+	w := &ListWindow{Fields: structFields, Descending: strings.HasPrefix(sortBy, "-"), Limit: o.Limit, sort: sortBy}
+
+	if o.Continue != "" {
+		data, err := base64.RawURLEncoding.DecodeString(o.Continue)
+		if err != nil {
+			return nil, NewErrInvalidListOptions("malformed continue token")
+		}
+		token := continueToken{}
+		if err := json.Unmarshal(data, &token); err != nil || len(token.After) != len(structFields) {
+			return nil, NewErrInvalidListOptions("malformed continue token")
+		}
+		if token.Sort != sortBy {
+			return nil, NewErrInvalidListOptions("continue token was issued for a different sort order")
+		}
+		w.After = make([]interface{}, len(token.After))
+		for i, v := range token.After {
+			if w.After[i], err = decodeKeyValue(v); err != nil {
+				return nil, NewErrInvalidListOptions("malformed continue token")
+			}
+		}
+	}
+	return w, nil
+}
+
+// Follows reports whether the given result, a struct or a pointer to a struct holding the sort fields,
+// comes after the last result of the previous page, i.e. whether it may be part of the window
+func (w *ListWindow) Follows(item interface{}) bool {
+	if w.After == nil {
+		return true
+	}
+	v := reflect.Indirect(reflect.ValueOf(item))
+	for i, field := range w.Fields {
+		a, b := v.FieldByName(field), reflect.ValueOf(w.After[i])
+		if keyType(a) != keyType(b) {
+			// the token does not match the results, e.g. because it was altered
+			return false
+		}
+		c := compareValues(a, b)
+		if c != 0 {
+			return (c > 0) != w.Descending
+		}
+	}
+	return false
+}
+
+// Next returns the continue token for the page following the window, which starts after the given last
+// result of the window, if more results are available, or an empty string otherwise
+func (w *ListWindow) Next(last interface{}, more bool) string {
+	if !more || w.Limit == 0 {
+		return ""
+	}
+	v := reflect.Indirect(reflect.ValueOf(last))
+	token := continueToken{Sort: w.sort, After: make([]string, len(w.Fields))}
+	for i, field := range w.Fields {
+		token.After[i] = encodeKeyValue(v.FieldByName(field))
+	}
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Sort orders the slice pointed to by items using the window sort fields
+func (w *ListWindow) Sort(items interface{}) {
+	v := reflect.ValueOf(items).Elem()
+	sort.SliceStable(v.Interface(), func(i, j int) bool {
+		a, b := reflect.Indirect(v.Index(i)), reflect.Indirect(v.Index(j))
+		for _, field := range w.Fields {
+			c := compareValues(a.FieldByName(field), b.FieldByName(field))
+			if c != 0 {
+				return (c < 0) != w.Descending
+			}
+		}
+		return false
+	})
+}
+
+// Apply orders the slice pointed to by items and cuts it down to the results in the window. It returns
+// the continue token for the next page.
+func (w *ListWindow) Apply(items interface{}) string {
+	w.Sort(items)
+	v := reflect.ValueOf(items).Elem()
+	n := v.Len()
+	start := sort.Search(n, func(i int) bool { return w.Follows(v.Index(i).Interface()) })
+	end := n
+	if w.Limit > 0 && start+w.Limit < n {
+		end = start + w.Limit
+	}
+	v.Set(v.Slice(start, end))
+	if end == start {
+		return ""
+	}
+	return w.Next(v.Index(end-start-1).Interface(), end < n)
+}
+
+// Paginate orders the slice pointed to by items and cuts it down to the page of results selected by the
+// list options, returning the continue token for the next page. Nil options leave the slice untouched.
+func Paginate(items interface{}, opts *ListOptions, fields SortFields, defaultSort string) (string, error) {
+	if opts == nil {
+		return "", nil
+	}
+	w, err := opts.Window(fields, defaultSort)
+	if err != nil {
+		return "", err
+	}
+	return w.Apply(items), nil
+}
+
+func compareValues(a, b reflect.Value) int {
+	if t, ok := a.Interface().(time.Time); ok {
+		u := b.Interface().(time.Time)
+		switch {
+		case t.Before(u):
+			return -1
+		case t.After(u):
+			return 1
+		}
+		return 0
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case a.Int() < b.Int():
+			return -1
+		case a.Int() > b.Int():
+			return 1
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch {
+		case a.Uint() < b.Uint():
+			return -1
+		case a.Uint() > b.Uint():
+			return 1
+		}
+	}
+	return 0
+}
+
+// keyType returns the type of a sort field value, as encoded in continue tokens
+func keyType(v reflect.Value) string {
+	if _, ok := v.Interface().(time.Time); ok {
+		return "t"
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "i"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "u"
+	}
+	return "s"
+}
+
+// encodeKeyValue encodes the value of a sort field for a continue token, prefixed with its type
+func encodeKeyValue(v reflect.Value) string {
+	switch keyType(v) {
+	case "t":
+		return "t:" + v.Interface().(time.Time).Format(time.RFC3339Nano)
+	case "i":
+		return "i:" + strconv.FormatInt(v.Int(), 10)
+	case "u":
+		return "u:" + strconv.FormatUint(v.Uint(), 10)
+	}
+	return "s:" + v.String()
+}
+
+// decodeKeyValue decodes a sort field value encoded by encodeKeyValue
+func decodeKeyValue(s string) (interface{}, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed key value %q", s)
+	}
+	switch parts[0] {
+	case "t":
+		return time.Parse(time.RFC3339Nano, parts[1])
+	case "i":
+		return strconv.ParseInt(parts[1], 10, 64)
+	case "u":
+		return strconv.ParseUint(parts[1], 10, 64)
+	case "s":
+		return parts[1], nil
+	}
+	return nil, fmt.Errorf("malformed key value %q", s)
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// ErrNotificationTargetNotFound describes the error message returned when trying to access a notification
+	// target that does not exist.
+	ErrNotificationTargetNotFound = NotificationErr("Notification target with the specified name not found")
+	// ErrNotificationTargetExists describes the error message returned when trying to register a notification
+	// target with the same name as an existing one.
+	ErrNotificationTargetExists = NotificationErr("Notification target with the specified name already exists")
+	// ErrInvalidNotificationTarget describes the error message returned when trying to register a notification
+	// target that is not valid. It is wrapped by errors that describe the problem.
+	ErrInvalidNotificationTarget = NotificationErr("Invalid notification target")
+)
+
+// NotificationErr are expected errors returned when performing operations on notification targets
+type NotificationErr string
+
+// Error returns the error message
+func (e NotificationErr) Error() string {
+	return string(e)
+}
+
+// NotificationTargetType describes how notifications are delivered to a target
+type NotificationTargetType string
+
+const (
+	// WebhookTarget targets receive notifications as JSON documents POSTed to a URL, signed with the
+	// target secret, if one is set.
+	WebhookTarget = NotificationTargetType("webhook")
+	// SlackTarget targets receive notifications as messages POSTed to a Slack incoming webhook URL.
+	SlackTarget = NotificationTargetType("slack")
+	// EmailTarget targets receive notifications as emails sent to a list of recipients.
+	EmailTarget = NotificationTargetType("email")
+)
+
+// NotificationDeliveryStatus describes the outcome of delivering a notification
+type NotificationDeliveryStatus string
+
+const (
+	// DeliveryPending is the status of a notification that is still being delivered, or retried.
+	DeliveryPending = NotificationDeliveryStatus("pending")
+	// DeliverySucceeded is the status of a notification that was delivered.
+	DeliverySucceeded = NotificationDeliveryStatus("succeeded")
+	// DeliveryFailed is the status of a notification that could not be delivered after all retries.
+	DeliveryFailed = NotificationDeliveryStatus("failed")
+)
+
+// NotificationTarget describes a destination for the notifications sent when workflow runs change state
+type NotificationTarget struct {
+	// Name uniquely identifies the notification target
+	Name string
+	// Description of the notification target
+	Description string
+	// Type describes how notifications are delivered to the target
+	Type NotificationTargetType
+	// Workflow limits the notifications to the runs of a workflow. Empty for all workflows.
+	Workflow string
+	// Project limits the notifications to the runs triggered by the codesets of a project. Empty for all projects.
+	Project string
+	// Statuses limits the notifications to the runs that reach one of the given statuses. Empty for all statuses.
+	Statuses []string
+	// URL where webhook and slack notifications are POSTed
+	URL string
+	// Secret used to sign webhook notifications
+	Secret string
+	// Recipients of email notifications
+	Recipients []string
+	// Template used to format the notification message, using the text/template syntax. The default template
+	// is used if not set.
+	Template string
+	// Created is the time when the notification target was registered
+	Created time.Time
+}
+
+// Matches returns true if the target is interested in a workflow run that reached its current status
+func (t *NotificationTarget) Matches(run *WorkflowRun) bool {
+	if t.Workflow != "" && t.Workflow != run.WorkflowRef {
+		return false
+	}
+	if t.Project != "" && t.Project != run.CodesetProject {
+		return false
+	}
+	if len(t.Statuses) == 0 {
+		return true
+	}
+	for _, s := range t.Statuses {
+		if s == run.Status {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationDelivery records an attempt to deliver a notification to a target
+type NotificationDelivery struct {
+	// ID uniquely identifies the delivery
+	ID uint64
+	// Target is the name of the notification target
+	Target string
+	// Workflow is the name of the workflow the run belongs to
+	Workflow string
+	// Run is the name of the workflow run that changed state
+	Run string
+	// RunStatus is the status reached by the workflow run
+	RunStatus string
+	// Status is the outcome of the delivery
+	Status NotificationDeliveryStatus
+	// Attempts is the number of times the delivery was attempted
+	Attempts int
+	// Error describes the last delivery failure
+	Error string
+	// Created is the time when the notification was generated
+	Created time.Time
+	// Updated is the time of the last delivery attempt
+	Updated time.Time
+}
+
+// NotificationSortFields are the fields that notification target lists can be ordered by
+var NotificationSortFields = SortFields{"name": {"Name"}, "type": {"Type", "Name"}, "created": {"Created", "Name"}}
+
+// NotificationDefaultSort is the order of notification target lists when no sort field is given
+const NotificationDefaultSort = "name"
+
+// NotificationDeliverySortFields are the fields that notification delivery lists can be ordered by
+var NotificationDeliverySortFields = SortFields{"created": {"Created", "ID"}, "status": {"Status", "Created", "ID"}}
+
+// NotificationDeliveryDefaultSort is the order of notification delivery lists when no sort field is given
+const NotificationDeliveryDefaultSort = "-created"
+
+// NotificationManager describes the interface for managing notification targets
+type NotificationManager interface {
+	// RegisterTarget registers a new notification target.
+	RegisterTarget(ctx context.Context, target *NotificationTarget) (*NotificationTarget, error)
+	// GetTarget retrieves a notification target.
+	GetTarget(ctx context.Context, name string) (*NotificationTarget, error)
+	// GetTargets returns the page of notification targets, filtered by workflow and project, selected by the
+	// list options, along with the continue token for the next page.
+	GetTargets(ctx context.Context, workflow, project string, opts *ListOptions) ([]*NotificationTarget, string, error)
+	// DeleteTarget deletes a notification target and its delivery log.
+	DeleteTarget(ctx context.Context, name string) error
+	// GetDeliveries returns the page of deliveries made to a notification target selected by the list options,
+	// along with the continue token for the next page.
+	GetDeliveries(ctx context.Context, target string, opts *ListOptions) ([]*NotificationDelivery, string, error)
+}
+
+// NotificationStore defines the interface required to store notification targets and their delivery log
+type NotificationStore interface {
+	// AddTarget adds a notification target to the store.
+	AddTarget(ctx context.Context, target *NotificationTarget) (*NotificationTarget, error)
+	// GetTarget retrieves a notification target from the store.
+	GetTarget(ctx context.Context, name string) (*NotificationTarget, error)
+	// GetTargets returns the page of notification targets, filtered by workflow and project, selected by the
+	// list options, along with the continue token for the next page. Empty filters match all targets.
+	GetTargets(ctx context.Context, workflow, project string, opts *ListOptions) ([]*NotificationTarget, string, error)
+	// DeleteTarget deletes a notification target and its delivery log from the store.
+	DeleteTarget(ctx context.Context, name string) error
+	// AddDelivery adds a delivery to the delivery log and assigns its ID.
+	AddDelivery(ctx context.Context, delivery *NotificationDelivery) error
+	// UpdateDelivery replaces a delivery in the delivery log.
+	UpdateDelivery(ctx context.Context, delivery *NotificationDelivery) error
+	// GetDeliveries returns the page of deliveries made to a notification target selected by the list options,
+	// along with the continue token for the next page.
+	GetDeliveries(ctx context.Context, target string, opts *ListOptions) ([]*NotificationDelivery, string, error)
+}
@@ -20,10 +20,37 @@ type User struct {
 	Email string
 }
 
+// UserCredentials holds the git credentials issued to a project member
+type UserCredentials struct {
+	// The user name used to access the project repositories
+	Username string
+	// The password used to access the project repositories. Only set when new credentials are issued.
+	Password *string
+}
+
+// ProjectSortFields are the fields that project lists can be ordered by
+var ProjectSortFields = SortFields{"name": {"Name"}}
+
+// ProjectDefaultSort is the order of project lists when no sort field is given
+const ProjectDefaultSort = "name"
+
 // ProjectStore is an interface to project stores
 type ProjectStore interface {
 	Find(ctx context.Context, name string) (*Project, error)
-	GetAll(ctx context.Context) ([]*Project, error)
-	Delete(ctx context.Context, name string) error
+	// GetAll returns the page of projects selected by the list options, along with the continue token
+	// for the next page.
+	GetAll(ctx context.Context, opts *ListOptions) ([]*Project, string, error)
+	// Delete removes the project. The codesets of the project, including their git repositories, are only
+	// deleted with cascade, otherwise ErrProjectNotEmpty is returned for projects that have codesets.
+	Delete(ctx context.Context, name string, cascade bool) error
 	Create(ctx context.Context, name, desc string) (*Project, error)
+	// ListMembers returns the users that are members of the project.
+	ListMembers(ctx context.Context, name string) ([]*User, error)
+	// AddMember adds a user to the project, creating a dedicated git account for the user if one
+	// does not exist already. The password is only returned for newly created accounts.
+	AddMember(ctx context.Context, name string, user *User) (*UserCredentials, error)
+	// RemoveMember removes a user from the project.
+	RemoveMember(ctx context.Context, name, userName string) error
+	// RotateCredentials replaces the git password of a project member and returns the new credentials.
+	RotateCredentials(ctx context.Context, name, userName string) (*UserCredentials, error)
 }
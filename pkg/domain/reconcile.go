@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// ErrNoReconcileReport describes the error returned when asking for the report of the last reconciliation
+	// before the workflows were reconciled by the server.
+	ErrNoReconcileReport = ReconcileErr("the workflows were not reconciled yet")
+)
+
+// ReconcileErr are expected errors returned when reconciling the workflows
+type ReconcileErr string
+
+// Error returns the error message
+func (e ReconcileErr) Error() string {
+	return string(e)
+}
+
+// The kinds of resources fixed by the workflow reconciler
+const (
+	// ReconcilePipeline is the workflow backend resource that runs a workflow.
+	ReconcilePipeline = "pipeline"
+	// ReconcileListener is the workflow backend resource that triggers a workflow when codesets change.
+	ReconcileListener = "listener"
+	// ReconcileWebhook is the codeset webhook that notifies a workflow listener of the changes pushed to the codeset.
+	ReconcileWebhook = "webhook"
+)
+
+// ReconcileAction is the action taken to fix a resource
+type ReconcileAction string
+
+const (
+	// ReconcileCreated is the action taken to fix a resource that is missing.
+	ReconcileCreated ReconcileAction = "created"
+	// ReconcileDeleted is the action taken to fix a resource that no workflow or assignment refers to.
+	ReconcileDeleted ReconcileAction = "deleted"
+	// ReconcileAdopted is the action taken to fix an assignment that does not refer to the webhook created for it.
+	ReconcileAdopted ReconcileAction = "adopted"
+)
+
+// ReconcileFix describes a resource that did not match the state stored for the workflows and how it was fixed
+type ReconcileFix struct {
+	// Action is the action taken, or that would be taken by a dry run, to fix the resource.
+	Action ReconcileAction
+	// Resource is the kind of resource fixed.
+	Resource string
+	// Workflow is the name of the workflow the resource belongs to.
+	Workflow string
+	// Codeset identifies the codeset of a webhook, as project/name.
+	Codeset string
+	// Error describes why the fix failed. It is empty if the fix succeeded.
+	Error string
+}
+
+// ReconcileReport describes the outcome of a reconciliation
+type ReconcileReport struct {
+	// Started is the time when the reconciliation started.
+	Started time.Time
+	// Finished is the time when the reconciliation finished.
+	Finished time.Time
+	// DryRun is true if the fixes were only reported, and not made.
+	DryRun bool
+	// Fixes lists the resources that were fixed.
+	Fixes []*ReconcileFix
+	// Errors lists the problems that prevented some resources from being checked.
+	Errors []string
+}
+
+// WorkflowReconciler compares the state stored for the workflows and their codeset assignments with the
+// resources held by the workflow backend and the codeset webhooks, and fixes the resources that drifted.
+type WorkflowReconciler interface {
+	// Reconcile re-creates the missing resources and deletes the resources that no workflow or assignment refers
+	// to. With dryRun set, the fixes are reported but not made.
+	Reconcile(ctx context.Context, dryRun bool) (*ReconcileReport, error)
+	// LastReport returns the report of the last reconciliation. It returns ErrNoReconcileReport if the
+	// workflows were not reconciled yet.
+	LastReport(ctx context.Context) (*ReconcileReport, error)
+}
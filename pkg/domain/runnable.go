@@ -258,9 +258,17 @@ type RunnableOutputRunnable struct {
 	RunnableRunnableArtifact
 }
 
+// RunnableSortFields are the fields that runnable lists can be ordered by
+var RunnableSortFields = SortFields{"id": {"ID"}, "kind": {"Kind", "ID"}, "created": {"Created", "ID"}}
+
+// RunnableDefaultSort is the order of runnable lists when no sort field is given
+const RunnableDefaultSort = "id"
+
 // RunnableStore defines the public interface that needs to be implemented by all runnable stores
 type RunnableStore interface {
-	Find(ctx context.Context, id string, kind string, labels map[string]string) (res []*Runnable, err error)
+	// Find returns the page of runnables matching the query selected by the list options, along with the
+	// continue token for the next page.
+	Find(ctx context.Context, id string, kind string, labels map[string]string, opts *ListOptions) (res []*Runnable, next string, err error)
 	Register(ctx context.Context, r *Runnable) (res *Runnable, err error)
 	Get(ctx context.Context, name string) (res *Runnable, err error)
 }
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+)
+
+// DependencyStatus describes the connectivity to a service FuseML depends on
+type DependencyStatus struct {
+	// Name identifies the dependency (e.g. gitea, kubernetes).
+	Name string
+	// Endpoint is the address FuseML uses to reach the dependency, if any.
+	Endpoint string
+	// Version is the version reported by the dependency, if it could be determined.
+	Version string
+	// Error describes why the dependency cannot be used. Empty if the dependency is available.
+	Error string
+}
+
+// Available returns true if the dependency can be used by FuseML.
+func (d *DependencyStatus) Available() bool {
+	return d.Error == ""
+}
+
+// DependencyChecker is implemented by the components that connect to services FuseML depends on, to report
+// whether those services can be reached.
+type DependencyChecker interface {
+	// CheckDependencies checks the connectivity to the services the component depends on.
+	CheckDependencies(ctx context.Context) []*DependencyStatus
+}
+
+// ServerStatus describes the configuration of the FuseML server and the status of its dependencies
+type ServerStatus struct {
+	// Namespace is the kubernetes namespace where FuseML creates its resources.
+	Namespace string
+	// DashboardURL is the URL of the Tekton dashboard used to follow the workflow runs.
+	DashboardURL string
+	// Dependencies lists the status of the services FuseML depends on.
+	Dependencies []*DependencyStatus
+}
+
+// Ready returns true if all the services FuseML depends on are available.
+func (s *ServerStatus) Ready() bool {
+	for _, d := range s.Dependencies {
+		if !d.Available() {
+			return false
+		}
+	}
+	return true
+}
+
+// StatusManager describes the interface for a status manager
+type StatusManager interface {
+	// GetStatus checks the dependencies of the FuseML server and returns its status.
+	GetStatus(ctx context.Context) *ServerStatus
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+)
+
+const (
+	// ErrWatchExpired describes the error returned when a watch cannot be resumed from the requested resource
+	// version, because the events that followed it are no longer available. Clients should list the resources
+	// again and start a new watch.
+	ErrWatchExpired = WatchErr("the requested resource version is too old, list the resources and watch again")
+	// ErrInvalidWatch describes the error returned when a watch is requested for resource kinds that cannot be
+	// watched, or from a resource version that was not issued by the server.
+	ErrInvalidWatch = WatchErr("invalid watch request")
+)
+
+// WatchErr are expected errors returned when watching resources
+type WatchErr string
+
+func (e WatchErr) Error() string {
+	return string(e)
+}
+
+// WatchEventType describes the change reported by a watch event
+type WatchEventType string
+
+const (
+	// WatchAdded reports a resource that was created
+	WatchAdded = WatchEventType("added")
+	// WatchModified reports a resource that was updated
+	WatchModified = WatchEventType("modified")
+	// WatchDeleted reports a resource that was deleted
+	WatchDeleted = WatchEventType("deleted")
+	// WatchBookmark reports the current resource version, without a resource change. It is sent when a
+	// watch starts, after the replayed events, and periodically while there are no changes.
+	WatchBookmark = WatchEventType("bookmark")
+)
+
+// WatchEvent is a change notification delivered to the clients watching FuseML resources
+type WatchEvent struct {
+	// Type is the change made to the resource
+	Type WatchEventType
+	// Kind is the kind of resource that changed. Empty for bookmarks.
+	Kind ResourceKind
+	// ResourceVersion identifies the position of the event in the stream of changes. A watch can be
+	// resumed from it, to receive only the events that followed.
+	ResourceVersion string
+	// Object is the state of the resource after the change or, for deleted resources, before it.
+	// Nil for bookmarks.
+	Object interface{}
+}
+
+// Watcher is an interface for streaming the changes made to FuseML resources to interested clients
+type Watcher interface {
+	// Watch returns a channel that receives the events published for the given resource kinds (or for all
+	// watchable kinds, if none is given), until the context is cancelled. If a resource version is given,
+	// the events that followed it are delivered first. A bookmark event follows them. The channel is closed when the context is cancelled
+	// or when the client falls too far behind, in which case it should resume from the last received event.
+	Watch(ctx context.Context, kinds []ResourceKind, resourceVersion string) (<-chan *WatchEvent, error)
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -167,6 +168,10 @@ type WorkflowRun struct {
 	Name string
 	// WorkflowRef is the reference to the workflow.
 	WorkflowRef string
+	// CodesetName is the name of the codeset that triggered the run.
+	CodesetName string
+	// CodesetProject is the project of the codeset that triggered the run.
+	CodesetProject string
 	// Inputs is the list of workflow inputs used on a run.
 	Inputs []*WorkflowRunInput
 	// Outputs is the list of workflow outputs from a run.
@@ -203,6 +208,14 @@ type WorkflowAssignment struct {
 	Codesets []*CodesetAssignment
 }
 
+// WorkflowCodesetAssignment describes the assignment of a workflow to a single codeset.
+type WorkflowCodesetAssignment struct {
+	// Workflow is the name of the assigned workflow.
+	Workflow string
+	// Codeset is the codeset the workflow is assigned to.
+	Codeset *Codeset
+}
+
 // WorkflowAssignmentStatus represents the status of a workflow assignment.
 type WorkflowAssignmentStatus struct {
 	// Available is weather the assignment is available.
@@ -233,6 +246,8 @@ type WorkflowListener struct {
 	URL string
 	// DashboardURL is the URL to the dashboard for the listener.
 	DashboardURL string
+	// Created is the time when the listener was created.
+	Created time.Time
 }
 
 // CodesetAssignment describes a codeset that has a workflow assigned to it through its webhook ID.
@@ -243,6 +258,24 @@ type CodesetAssignment struct {
 	WebhookID *int64
 }
 
+// WorkflowSortFields are the fields that workflow lists can be ordered by
+var WorkflowSortFields = SortFields{"name": {"Name"}, "created": {"Created", "Name"}}
+
+// WorkflowDefaultSort is the order of workflow lists when no sort field is given
+const WorkflowDefaultSort = "name"
+
+// WorkflowRunSortFields are the fields that workflow run lists can be ordered by
+var WorkflowRunSortFields = SortFields{
+	"name":      {"Name"},
+	"workflow":  {"WorkflowRef", "StartTime"},
+	"started":   {"StartTime", "Name"},
+	"completed": {"CompletionTime", "Name"},
+	"status":    {"Status", "StartTime"},
+}
+
+// WorkflowRunDefaultSort is the order of workflow run lists when no sort field is given
+const WorkflowRunDefaultSort = "-started"
+
 // WorkflowErr are expected errors returned when performing operations on workflows,
 type WorkflowErr string
 
@@ -252,10 +285,14 @@ type WorkflowManager interface {
 	CreateWorkflow(ctx context.Context, workflow *Workflow) (*Workflow, error)
 	// GetWorkflow retrieves a workflow.
 	GetWorkflow(ctx context.Context, name string) (*Workflow, error)
-	// GetWorkflows returns a list of workflows.
-	GetWorkflows(ctx context.Context, name *string) []*Workflow
+	// GetWorkflows returns the page of workflows selected by the list options, along with the continue
+	// token for the next page.
+	GetWorkflows(ctx context.Context, name *string, opts *ListOptions) ([]*Workflow, string, error)
 	// DeleteWorkflow deletes a workflow.
 	DeleteWorkflow(ctx context.Context, name string) error
+	// RefreshWorkflow resolves the extension references of a workflow again and updates the workflow with the
+	// current extension endpoints and credentials.
+	RefreshWorkflow(ctx context.Context, name string) (*Workflow, error)
 	// AssignToCodeset assigns a workflow to a codeset.
 	AssignToCodeset(ctx context.Context, name, codesetProject, codesetName string) (*WorkflowListener, *int64, error)
 	// UnassignFromCodeset removes a workflow assignment from a codeset.
@@ -264,8 +301,9 @@ type WorkflowManager interface {
 	GetAllCodesetAssignments(ctx context.Context, name *string) map[string][]*CodesetAssignment
 	// GetAssignmentStatus returns the status of a workflow assignment.
 	GetAssignmentStatus(ctx context.Context, name string) *WorkflowAssignmentStatus
-	// GetWorkflowRuns returns all the workflow runs for a workflow.
-	GetWorkflowRuns(ctx context.Context, filter *WorkflowRunFilter) ([]*WorkflowRun, error)
+	// GetWorkflowRuns returns the page of workflow runs matching the filter selected by the list options,
+	// along with the continue token for the next page.
+	GetWorkflowRuns(ctx context.Context, filter *WorkflowRunFilter, opts *ListOptions) ([]*WorkflowRun, string, error)
 }
 
 // WorkflowStore is an interface for workflow stores.
@@ -274,12 +312,19 @@ type WorkflowStore interface {
 	AddWorkflow(ctx context.Context, w *Workflow) (*Workflow, error)
 	// GetWorkflow returns a workflow.
 	GetWorkflow(ctx context.Context, name string) (*Workflow, error)
-	// ListWorkflows returns a list of workflows.
-	GetWorkflows(ctx context.Context, name *string) []*Workflow
+	// GetWorkflows returns the page of workflows selected by the list options, along with the continue
+	// token for the next page.
+	GetWorkflows(ctx context.Context, name *string, opts *ListOptions) ([]*Workflow, string, error)
+	// UpdateWorkflow replaces the definition of a workflow in the store, keeping its stored codeset assignments,
+	// and returns the updated workflow.
+	UpdateWorkflow(ctx context.Context, w *Workflow) (*Workflow, error)
 	// DeleteWorkflow deletes a workflow from the store.
 	DeleteWorkflow(ctx context.Context, name string) error
 	// AddCodesetAssignment adds a codeset assignment to the store.
 	AddCodesetAssignment(ctx context.Context, workflowName string, codeset *Codeset, webhook *int64) ([]*CodesetAssignment, error)
+	// UpdateCodesetAssignmentWebhook changes the webhook of a codeset assignment, leaving the workflow and its
+	// other assignments unchanged.
+	UpdateCodesetAssignmentWebhook(ctx context.Context, workflowName string, codeset *Codeset, webhook *int64) error
 	// GetCodesetAssignment returns the assignment for a workflow and a codeset.
 	GetCodesetAssignment(ctx context.Context, workflowName string, codeset *Codeset) (*CodesetAssignment, error)
 	// GetCodesetAssignments returns the codeset assignments for a workflow.
@@ -294,11 +339,13 @@ type WorkflowStore interface {
 type WorkflowBackend interface {
 	// CreateWorkflow creates a new workflow.
 	CreateWorkflow(ctx context.Context, workflow *Workflow) error
+	// UpdateWorkflow updates an existing workflow.
+	UpdateWorkflow(ctx context.Context, workflow *Workflow) error
 	// DeleteWorkflow deletes a workflow.
 	DeleteWorkflow(ctx context.Context, workflowName string) error
 	// CreateWorkflowRun creates a new workflow run.
 	CreateWorkflowRun(ctx context.Context, workflowName string, codeset *Codeset) error
-	// GetWorkflowRuns returns a list of workflow runs.
+	// GetWorkflowRuns returns a list of workflow runs. The filter must not be nil.
 	GetWorkflowRuns(ctx context.Context, workflow *Workflow, filter *WorkflowRunFilter) ([]*WorkflowRun, error)
 	// CreateWorkflowListener creates a new workflow listener.
 	CreateWorkflowListener(ctx context.Context, workflowName string, timeout time.Duration) (*WorkflowListener, error)
@@ -306,6 +353,23 @@ type WorkflowBackend interface {
 	DeleteWorkflowListener(ctx context.Context, workflowName string) error
 	// GetWorkflowListener returns a workflow listener for a workflow.
 	GetWorkflowListener(ctx context.Context, workflowName string) (*WorkflowListener, error)
+	// ListWorkflows returns the names of the workflows that the backend holds resources for, along with the
+	// time when the resources were created.
+	ListWorkflows(ctx context.Context) (map[string]time.Time, error)
+	// ListWorkflowListeners returns the workflow listeners held by the backend, named after their workflows.
+	ListWorkflowListeners(ctx context.Context) ([]*WorkflowListener, error)
+	// WatchWorkflowRuns watches the workflow runs in the background, until the context is cancelled, and
+	// reports the runs that are created, updated or deleted to the handler.
+	WatchWorkflowRuns(ctx context.Context, wg *sync.WaitGroup, handler WorkflowRunHandler) error
+}
+
+// WorkflowRunHandler receives the changes made to workflow runs, as they are observed by a workflow backend.
+type WorkflowRunHandler interface {
+	// GetWorkflow returns the workflow with the specified name, used to describe its runs.
+	GetWorkflow(ctx context.Context, name string) (*Workflow, error)
+	// OnWorkflowRun is called every time a workflow run is created (EventCreated), updated (EventUpdated)
+	// or deleted (EventDeleted).
+	OnWorkflowRun(ctx context.Context, eventType EventType, run *WorkflowRun)
 }
 
 // AssignToCodeset assigns a workflow to a codeset.
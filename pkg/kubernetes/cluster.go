@@ -3,7 +3,8 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -13,19 +14,36 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
+	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
+
+	"github.com/fuseml/fuseml-core/pkg/metrics"
+	"github.com/fuseml/fuseml-core/pkg/tracing"
 )
 
 // Cluster holds the config information for Kubernetes cluster
 type Cluster struct {
 	restConfig *rest.Config
-	logger     *log.Logger
+	logger     *slog.Logger
 }
 
-// GetClientConfig fetchs the kubernetes config of current cluster
+// GetClientConfig fetchs the kubernetes config of current cluster. The clients created from the config
+// collect the latency and outcome metrics of their requests, and trace them.
 func GetClientConfig() (*rest.Config, error) {
+	config, err := loadClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	config.WrapTransport = transport.Wrappers(config.WrapTransport, func(rt http.RoundTripper) http.RoundTripper {
+		return tracing.RoundTripper("kubernetes", metrics.RoundTripper("kubernetes", rt))
+	})
+	return config, nil
+}
+
+func loadClientConfig() (*rest.Config, error) {
 	if _, inCluster := os.LookupEnv("KUBERNETES_SERVICE_HOST"); inCluster {
 		return rest.InClusterConfig()
 	}
@@ -39,7 +57,7 @@ func GetClientConfig() (*rest.Config, error) {
 }
 
 // NewCluster returns new cluster struct initialized with KUBECONFIG from environment
-func NewCluster(logger *log.Logger) (*Cluster, error) {
+func NewCluster(logger *slog.Logger) (*Cluster, error) {
 
 	config, err := GetClientConfig()
 	if err != nil {
@@ -49,39 +67,73 @@ func NewCluster(logger *log.Logger) (*Cluster, error) {
 	return &Cluster{config, logger}, nil
 }
 
-// DeleteResource deletes kuberneres resource from current cluster, identified by name, namespace and kind
-func (c *Cluster) DeleteResource(ctx context.Context, name, namespace, kind string) error {
-	c.logger.Printf("want to delete resource %s of kind %s in %s namespace", name, kind, namespace)
+// NewClientset returns a kubernetes clientset initialized with KUBECONFIG from environment
+func NewClientset() (k8s.Interface, error) {
+	config, err := GetClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting kubernetes client config: %w", err)
+	}
+	return k8s.NewForConfig(config)
+}
+
+// resourceClient returns the dynamic client used to access resources of a given kind in a namespace
+func (c *Cluster) resourceClient(namespace, kind string) (dynamic.ResourceInterface, error) {
 	// Prepare a RESTMapper to find GVR
 	dc, err := discovery.NewDiscoveryClientForConfig(c.restConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
 
 	gvk, err := mapper.KindFor(schema.GroupVersionResource{Resource: kind})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Find GVR
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// create the dynamic client
 	dynClient, err := dynamic.NewForConfig(c.restConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// get REST interface
-	dr := dynClient.Resource(mapping.Resource).Namespace(namespace)
+	return dynClient.Resource(mapping.Resource).Namespace(namespace), nil
+}
+
+// DeleteResource deletes kuberneres resource from current cluster, identified by name, namespace and kind
+func (c *Cluster) DeleteResource(ctx context.Context, name, namespace, kind string) error {
+	c.logger.InfoContext(ctx, "Deleting resource", "name", name, "kind", kind, "namespace", namespace)
+	dr, err := c.resourceClient(namespace, kind)
+	if err != nil {
+		return err
+	}
 
 	err = dr.Delete(ctx, name, metav1.DeleteOptions{})
 	if !k8serr.IsNotFound(err) {
 		return err
 	}
-	c.logger.Print("resource not found, no need to delete")
+	c.logger.InfoContext(ctx, "Resource not found, no need to delete", "name", name, "kind", kind, "namespace", namespace)
 	return nil
 }
+
+// ResourceExists checks whether a kubernetes resource, identified by name, namespace and kind, exists in the current cluster
+func (c *Cluster) ResourceExists(ctx context.Context, name, namespace, kind string) (bool, error) {
+	dr, err := c.resourceClient(namespace, kind)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = dr.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
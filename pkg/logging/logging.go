@@ -0,0 +1,121 @@
+// Package logging sets up the structured, leveled logger used by the FuseML core server. The records
+// logged with a request context are annotated with the ID of the request and of its trace, so that the
+// records of a request can be correlated with each other, with the request log and with the request trace.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"goa.design/goa/v3/middleware"
+)
+
+const (
+	// FormatText writes log records as key=value pairs
+	FormatText = "text"
+	// FormatJSON writes log records as JSON objects, one per line
+	FormatJSON = "json"
+
+	// RequestIDKey is the key of the request ID attribute
+	RequestIDKey = "request_id"
+	// TraceIDKey is the key of the trace ID attribute
+	TraceIDKey = "trace_id"
+)
+
+// Config describes how log records are written
+type Config struct {
+	// Level is the minimum level of the records that are written: debug, info, warn or error.
+	Level string
+	// Format is the format of the records: text or json.
+	Format string
+}
+
+// New returns a logger writing the records to w, as described by the configuration.
+func New(w io.Writer, cfg *Config) (*slog.Logger, error) {
+	var level slog.Level
+	if cfg.Level != "" && level.UnmarshalText([]byte(cfg.Level)) != nil {
+		return nil, fmt.Errorf("invalid log level %q (valid levels: debug, info, warn, error)", cfg.Level)
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var h slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case FormatText, "":
+		h = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q (valid formats: %s, %s)", cfg.Format, FormatText, FormatJSON)
+	}
+	return slog.New(NewContextHandler(h)), nil
+}
+
+// Discard returns a logger that drops all records
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// contextHandler annotates the records with the request and trace IDs found in their context
+type contextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler returns a handler that annotates the records with the request and trace IDs found in
+// their context before passing them to h.
+func NewContextHandler(h slog.Handler) slog.Handler {
+	return &contextHandler{h}
+}
+
+// Handle adds the request and trace IDs to the record and hands it over to the wrapped handler.
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := ctx.Value(middleware.RequestIDKey).(string); ok {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String(TraceIDKey, sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler whose records include the given attributes.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler that nests the attributes of the records under the given group.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name)}
+}
+
+// goaLogger logs the records of the goa request logging middleware
+type goaLogger struct {
+	logger *slog.Logger
+}
+
+// Adapter returns the logger used by the goa request logging middleware. The request ID logged by the
+// middleware is logged under the same key as the request ID of the other records.
+func Adapter(logger *slog.Logger) middleware.Logger {
+	return &goaLogger{logger}
+}
+
+// Log logs the request or response described by the key/value pairs.
+func (l *goaLogger) Log(keyvals ...interface{}) error {
+	msg := "Request"
+	args := make([]interface{}, 0, len(keyvals))
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		switch key {
+		case "id":
+			key = RequestIDKey
+		case "status":
+			msg = "Response"
+		}
+		args = append(args, key, keyvals[i+1])
+	}
+	l.logger.Info(msg, args...)
+	return nil
+}
@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"goa.design/goa/v3/middleware"
+)
+
+func TestNew(t *testing.T) {
+	for _, cfg := range []Config{{Level: "verbose"}, {Format: "xml"}} {
+		if _, err := New(&bytes.Buffer{}, &cfg); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+
+	var out bytes.Buffer
+	logger, err := New(&out, &Config{Level: "warn", Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	logger.Info("dropped")
+	logger.Warn("kept", "workflow", "wf1")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q: %s", out.String(), err)
+	}
+	if record["msg"] != "kept" || record["level"] != "WARN" || record["workflow"] != "wf1" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestContextHandler(t *testing.T) {
+	var out bytes.Buffer
+	logger, _ := New(&out, &Config{Format: FormatJSON})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	logger.With("component", "test").InfoContext(ctx, "with context")
+	logger.Info("without context")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d records want 2", len(lines))
+	}
+	var record map[string]interface{}
+	json.Unmarshal([]byte(lines[0]), &record)
+	if record[RequestIDKey] != "req-1" || record[TraceIDKey] != traceID.String() || record["component"] != "test" {
+		t.Errorf("unexpected record %v", record)
+	}
+	record = nil
+	json.Unmarshal([]byte(lines[1]), &record)
+	if _, ok := record[RequestIDKey]; ok {
+		t.Errorf("unexpected request ID in record %v", record)
+	}
+}
+
+func TestAdapter(t *testing.T) {
+	var out bytes.Buffer
+	logger, _ := New(&out, &Config{})
+
+	adapter := Adapter(logger)
+	adapter.Log("id", "req-1", "req", "GET /projects", "from", "127.0.0.1")
+	adapter.Log("id", "req-1", "status", 200, "bytes", 2)
+
+	want := []string{
+		`msg=Request request_id=req-1 req="GET /projects" from=127.0.0.1`,
+		`msg=Response request_id=req-1 status=200 bytes=2`,
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d records want %d", len(lines), len(want))
+	}
+	for i, w := range want {
+		if !strings.HasSuffix(lines[i], w) {
+			t.Errorf("got record %q want it to end with %q", lines[i], w)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package metrics defines the Prometheus metrics exposed by the FuseML core server, along with the
+// middleware that collects the request metrics for the HTTP and gRPC servers and for the clients
+// used to reach external services.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	grpcmdlwr "goa.design/goa/v3/grpc/middleware"
+	httpmdlwr "goa.design/goa/v3/http/middleware"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Namespace prefixes the names of all the metrics exposed by FuseML
+const Namespace = "fuseml"
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests handled, by service, method and status code.",
+	}, []string{"service", "method", "code"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent handling HTTP requests, by service and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method"})
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC requests handled, by service, method and status code.",
+	}, []string{"service", "method", "code"})
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent handling gRPC requests, by service and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method"})
+	clientRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "client",
+		Name:      "requests_total",
+		Help: "Number of requests made to external services, by client, HTTP method and status code. " +
+			"Requests that fail without a response are counted with the \"error\" code.",
+	}, []string{"client", "method", "code"})
+	clientDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "client",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent waiting for external services to respond, by client and HTTP method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"client", "method"})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequests, httpDuration, grpcRequests, grpcDuration, clientRequests, clientDuration)
+}
+
+// Handler returns the HTTP handler that exposes the metrics registered with the default Prometheus registry
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+type callKey struct{}
+
+// call records the goa service method that handled a request. The transport middleware sees the request
+// before it is routed, so the method is filled in by the endpoint middleware, further down the chain.
+type call struct {
+	service string
+	method  string
+}
+
+func withCall(ctx context.Context) (context.Context, *call) {
+	c := &call{}
+	return context.WithValue(ctx, callKey{}, c), c
+}
+
+// Endpoint is the goa endpoint middleware that records the service method handling a request, for the HTTP
+// and gRPC middleware to label the request metrics with.
+func Endpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		if c, ok := ctx.Value(callKey{}).(*call); ok {
+			c.service, _ = ctx.Value(goa.ServiceKey).(string)
+			c.method, _ = ctx.Value(goa.MethodKey).(string)
+		}
+		return e(ctx, req)
+	}
+}
+
+// HTTP returns the middleware that collects the request count and latency metrics of the HTTP server.
+// Only the requests that reach a service method are counted, so requests to unknown paths and the
+// metrics scrapes are not.
+func HTTP() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+			ctx, c := withCall(r.Context())
+			rw := httpmdlwr.CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+			if c.service == "" {
+				return
+			}
+			code := rw.StatusCode
+			if code == 0 {
+				code = http.StatusOK
+			}
+			httpRequests.WithLabelValues(c.service, c.method, strconv.Itoa(code)).Inc()
+			httpDuration.WithLabelValues(c.service, c.method).Observe(time.Since(started).Seconds())
+		})
+	}
+}
+
+// UnaryServer returns the interceptor that collects the request count and latency metrics of the
+// gRPC server for unary methods.
+func UnaryServer() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+		ctx, c := withCall(ctx)
+		resp, err := handler(ctx, req)
+		observeGRPC(c, started, err)
+		return resp, err
+	}
+}
+
+// StreamServer returns the interceptor that collects the request count and latency metrics of the
+// gRPC server for streaming methods. The latency of a streaming request is the lifetime of the stream.
+func StreamServer() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+		ctx, c := withCall(ss.Context())
+		err := handler(srv, grpcmdlwr.NewWrappedServerStream(ctx, ss))
+		observeGRPC(c, started, err)
+		return err
+	}
+}
+
+func observeGRPC(c *call, started time.Time, err error) {
+	if c.service == "" {
+		return
+	}
+	grpcRequests.WithLabelValues(c.service, c.method, status.Code(err).String()).Inc()
+	grpcDuration.WithLabelValues(c.service, c.method).Observe(time.Since(started).Seconds())
+}
+
+// roundTripper collects the request count and latency metrics of the requests made by a client
+type roundTripper struct {
+	client string
+	next   http.RoundTripper
+}
+
+// RoundTripper returns a http.RoundTripper that collects the request count and latency metrics of the
+// requests made by the named client through the given round tripper, or http.DefaultTransport if nil.
+func RoundTripper(client string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &roundTripper{client, next}
+}
+
+// RoundTrip executes a single HTTP transaction and records its outcome.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := rt.next.RoundTrip(req)
+	clientDuration.WithLabelValues(rt.client, req.Method).Observe(time.Since(started).Seconds())
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	clientRequests.WithLabelValues(rt.client, req.Method, code).Inc()
+	return resp, err
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceMethod returns an endpoint wrapped with the endpoint middleware, and a function that calls it the
+// way the goa generated servers do
+func serviceMethod(service, method string, err error) func(ctx context.Context) error {
+	e := Endpoint(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, err
+	})
+	return func(ctx context.Context) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, method)
+		ctx = context.WithValue(ctx, goa.ServiceKey, service)
+		_, err := e(ctx, nil)
+		return err
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	call := serviceMethod("project", "get", nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/", func(w http.ResponseWriter, r *http.Request) {
+		call(r.Context())
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/projects", func(w http.ResponseWriter, r *http.Request) {
+		call(r.Context())
+		w.Write([]byte("[]"))
+	})
+	handler := HTTP()(mux)
+
+	for _, path := range []string{"/projects", "/projects/p1", "/projects/p2", "/unknown"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues("project", "get", "200")); got != 1 {
+		t.Errorf("got %v successful requests want 1", got)
+	}
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues("project", "get", "404")); got != 2 {
+		t.Errorf("got %v not found requests want 2", got)
+	}
+	// requests that are not routed to a service method are not counted
+	if got := testutil.CollectAndCount(httpRequests); got != 2 {
+		t.Errorf("got %d request counters want 2", got)
+	}
+	if got := testutil.CollectAndCount(httpDuration); got != 1 {
+		t.Errorf("got %d request duration histograms want 1", got)
+	}
+}
+
+func TestUnaryServer(t *testing.T) {
+	interceptor := UnaryServer()
+	for _, err := range []error{nil, status.Error(codes.NotFound, "not found"), nil} {
+		call := serviceMethod("workflow", "get", err)
+		interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/workflow.Workflow/Get"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, call(ctx)
+			})
+	}
+
+	if got := testutil.ToFloat64(grpcRequests.WithLabelValues("workflow", "get", "OK")); got != 2 {
+		t.Errorf("got %v successful requests want 2", got)
+	}
+	if got := testutil.ToFloat64(grpcRequests.WithLabelValues("workflow", "get", "NotFound")); got != 1 {
+		t.Errorf("got %v not found requests want 1", got)
+	}
+}
+
+func TestRoundTripper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	client := &http.Client{Transport: RoundTripper("test", nil)}
+
+	for _, method := range []string{http.MethodGet, http.MethodGet, http.MethodDelete} {
+		req, _ := http.NewRequest(method, srv.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+	srv.Close()
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Fatalf("expected the request to fail")
+	}
+
+	want := map[[2]string]float64{
+		{http.MethodGet, "200"}:    2,
+		{http.MethodDelete, "500"}: 1,
+		{http.MethodGet, "error"}:  1,
+	}
+	for labels, count := range want {
+		if got := testutil.ToFloat64(clientRequests.WithLabelValues("test", labels[0], labels[1])); got != count {
+			t.Errorf("got %v %s requests with code %s want %v", got, labels[0], labels[1], count)
+		}
+	}
+	if got := testutil.CollectAndCount(clientDuration); got != 2 {
+		t.Errorf("got %d request duration histograms want 2", got)
+	}
+}
@@ -0,0 +1,101 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/fuseml/fuseml-core/gen/admin"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
+)
+
+// admin service implementation.
+type adminsrvc struct {
+	logger     *slog.Logger
+	mgr        domain.BackupManager
+	reconciler domain.WorkflowReconciler
+}
+
+// NewAdminService returns the admin service implementation.
+func NewAdminService(logger *slog.Logger, mgr domain.BackupManager, reconciler domain.WorkflowReconciler) admin.Service {
+	return &adminsrvc{logger, mgr, reconciler}
+}
+
+// Stream a consistent snapshot of the state stored by FuseML.
+func (s *adminsrvc) Backup(ctx context.Context) (body io.ReadCloser, err error) {
+	s.logger.DebugContext(ctx, "admin.backup")
+	return s.mgr.Backup(ctx)
+}
+
+// Restore a snapshot produced by the backup method into a FuseML server that does not store any data yet.
+func (s *adminsrvc) Restore(ctx context.Context, p *admin.RestorePayload, req io.ReadCloser) (res *admin.RestoreResult, err error) {
+	s.logger.DebugContext(ctx, "admin.restore")
+	defer req.Close()
+
+	result, err := s.mgr.Restore(ctx, req, p.Recreate)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBackup) {
+			return nil, admin.MakeBadRequest(err)
+		}
+		if err == domain.ErrStoreNotEmpty {
+			return nil, admin.MakeConflict(err)
+		}
+		return nil, err
+	}
+	return &admin.RestoreResult{
+		ServerVersion: util.RefString(result.ServerVersion),
+		Created:       util.RefString(result.Created.Format(time.RFC3339)),
+		Workflows:     result.Workflows,
+		Assignments:   result.Assignments,
+		Recreated:     result.Recreated,
+		Errors:        result.Errors,
+	}, nil
+}
+
+// Reconcile the workflow resources with the stored workflows and codeset assignments.
+func (s *adminsrvc) Reconcile(ctx context.Context, p *admin.ReconcilePayload) (res *admin.ReconcileReport, err error) {
+	s.logger.DebugContext(ctx, "admin.reconcile")
+
+	report, err := s.reconciler.Reconcile(ctx, p.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return reconcileReportDomainToRest(report), nil
+}
+
+// Retrieve the report of the last reconciliation run by the server.
+func (s *adminsrvc) GetReconcileReport(ctx context.Context) (res *admin.ReconcileReport, err error) {
+	s.logger.DebugContext(ctx, "admin.getReconcileReport")
+
+	report, err := s.reconciler.LastReport(ctx)
+	if err != nil {
+		if err == domain.ErrNoReconcileReport {
+			return nil, admin.MakeNotFound(err)
+		}
+		return nil, err
+	}
+	return reconcileReportDomainToRest(report), nil
+}
+
+func reconcileReportDomainToRest(report *domain.ReconcileReport) *admin.ReconcileReport {
+	res := &admin.ReconcileReport{
+		Started:  report.Started.Format(time.RFC3339),
+		Finished: report.Finished.Format(time.RFC3339),
+		DryRun:   report.DryRun,
+		Fixes:    make([]*admin.ReconcileFix, 0, len(report.Fixes)),
+		Errors:   report.Errors,
+	}
+	for _, fix := range report.Fixes {
+		res.Fixes = append(res.Fixes, &admin.ReconcileFix{
+			Action:   string(fix.Action),
+			Resource: fix.Resource,
+			Workflow: fix.Workflow,
+			Codeset:  util.RefString(fix.Codeset),
+			Error:    util.RefString(fix.Error),
+		})
+	}
+	return res
+}
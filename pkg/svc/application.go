@@ -2,12 +2,13 @@ package svc
 
 import (
 	"context"
-	"github.com/pkg/errors"
-	"log"
+	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/fuseml/fuseml-core/gen/application"
 	"github.com/fuseml/fuseml-core/pkg/domain"
-	"github.com/fuseml/fuseml-core/pkg/kubernetes"
+	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
 func appRestToDomain(ra *application.Application) (a *domain.Application, err error) {
@@ -54,66 +55,143 @@ func appDomainToRest(a *domain.Application) (ret *application.Application) {
 	return ret
 }
 
+func appStatusDomainToRest(st *domain.ApplicationStatus) (ret *application.ApplicationStatus) {
+	ret = &application.ApplicationStatus{
+		State:        string(st.State),
+		URLReachable: st.URLReachable,
+		Checked:      st.Checked.Format(time.RFC3339),
+	}
+	if st.Message != "" {
+		ret.Message = &st.Message
+	}
+	for _, res := range st.Resources {
+		ret.K8sResources = append(ret.K8sResources,
+			&application.KubernetesResourceStatus{
+				Name:   res.Name,
+				Kind:   res.Kind,
+				Exists: res.Exists,
+			},
+		)
+	}
+	return ret
+}
+
 // application service implementation.
 type applicationsrvc struct {
-	logger *log.Logger
-	store  domain.ApplicationStore
+	logger *slog.Logger
+	mgr    domain.ApplicationManager
 }
 
 // NewApplicationService returns the application service implementation.
-func NewApplicationService(logger *log.Logger, store domain.ApplicationStore) application.Service {
-	return &applicationsrvc{logger, store}
+func NewApplicationService(logger *slog.Logger, mgr domain.ApplicationManager) application.Service {
+	return &applicationsrvc{logger, mgr}
 }
 
 // Retrieve information about applications registered in FuseML.
-func (s *applicationsrvc) List(ctx context.Context, p *application.ListPayload) (res []*application.Application, err error) {
-	s.logger.Print("application.list")
-	items, err := s.store.GetAll(ctx, p.Type, p.Workflow)
-	res = make([]*application.Application, 0, len(items))
+func (s *applicationsrvc) List(ctx context.Context, p *application.ListPayload) (res *application.ListResult, err error) {
+	s.logger.DebugContext(ctx, "application.list")
+	items, next, err := s.mgr.GetApplications(ctx, p.Type, p.Workflow, listOptionsToDomain(p.Limit, p.Continue, p.Sort))
+	if err != nil {
+		if isInvalidListOptions(err) {
+			return nil, application.MakeBadRequest(err)
+		}
+		return nil, err
+	}
+	res = &application.ListResult{Items: make([]*application.Application, 0, len(items)), Continue: util.RefString(next)}
 	for _, a := range items {
-		res = append(res, appDomainToRest(a))
+		res.Items = append(res.Items, appDomainToRest(a))
+	}
+	if p.Status {
+		// probing may take a while for unreachable applications, so probe them concurrently
+		var wg sync.WaitGroup
+		for _, a := range res.Items {
+			wg.Add(1)
+			go func(a *application.Application) {
+				defer wg.Done()
+				st, err := s.mgr.GetApplicationStatus(ctx, a.Name)
+				if err != nil {
+					s.logger.WarnContext(ctx, "Failed probing application", "application", a.Name, "error", err)
+					return
+				}
+				a.Status = appStatusDomainToRest(st)
+			}(a)
+		}
+		wg.Wait()
+	}
+	if err := selectFields(res.Items, p.Fields, "name", "type", "url", "workflow", "k8s_namespace"); err != nil {
+		return nil, application.MakeBadRequest(err)
 	}
-	return res, err
+	return res, nil
 }
 
 // Register a application with the FuseML application store.
 func (s *applicationsrvc) Register(ctx context.Context, a *application.Application) (res *application.Application, err error) {
-	s.logger.Print("application.register")
+	s.logger.DebugContext(ctx, "application.register")
+	app, err := appRestToDomain(a)
+	if err != nil {
+		return nil, application.MakeBadRequest(err)
+	}
+	app, err = s.mgr.RegisterApplication(ctx, app)
+	if err != nil {
+		if err == domain.ErrApplicationExists {
+			return nil, application.MakeConflict(err)
+		}
+		return nil, err
+	}
+	return appDomainToRest(app), nil
+}
+
+// Update an Application registered by FuseML.
+func (s *applicationsrvc) Update(ctx context.Context, a *application.Application) (res *application.Application, err error) {
+	s.logger.DebugContext(ctx, "application.update")
 	app, err := appRestToDomain(a)
 	if err != nil {
 		return nil, application.MakeBadRequest(err)
 	}
-	app, err = s.store.Add(ctx, app)
-	return appDomainToRest(app), err
+	app, err = s.mgr.UpdateApplication(ctx, app)
+	if err != nil {
+		if err == domain.ErrApplicationNotFound {
+			return nil, application.MakeNotFound(err)
+		}
+		return nil, err
+	}
+	return appDomainToRest(app), nil
 }
 
 // Retrieve an Application from FuseML.
 func (s *applicationsrvc) Get(ctx context.Context, p *application.GetPayload) (res *application.Application, err error) {
-	s.logger.Print("application.get")
+	s.logger.DebugContext(ctx, "application.get")
 
-	app := s.store.Find(ctx, p.Name)
-	if app == nil {
-		return nil, application.MakeNotFound(errors.New("Application with the specified name not found"))
+	app, err := s.mgr.GetApplication(ctx, p.Name)
+	if err != nil {
+		if err == domain.ErrApplicationNotFound {
+			return nil, application.MakeNotFound(err)
+		}
+		return nil, err
 	}
 	return appDomainToRest(app), nil
 }
 
-// Delete an Application registered by FuseML.
-func (s *applicationsrvc) Delete(ctx context.Context, p *application.DeletePayload) error {
-	s.logger.Print("application.delete")
-	app := s.store.Find(ctx, p.Name)
-	if app == nil {
-		return application.MakeNotFound(errors.New("Application with the specified name not found"))
-	}
-	cluster, err := kubernetes.NewCluster(s.logger)
+// Probe an Application registered by FuseML and retrieve its status.
+func (s *applicationsrvc) Status(ctx context.Context, p *application.StatusPayload) (res *application.ApplicationStatus, err error) {
+	s.logger.DebugContext(ctx, "application.status")
+
+	st, err := s.mgr.GetApplicationStatus(ctx, p.Name)
 	if err != nil {
-		return errors.Wrap(err, "Failed initializing kubernetes cluster")
-	}
-	for _, r := range app.K8sResources {
-		err := cluster.DeleteResource(ctx, r.Name, app.K8sNamespace, r.Kind)
-		if err != nil {
-			return errors.Wrap(err, "Failed deleting kubernetes resource "+r.Name)
+		if err == domain.ErrApplicationNotFound {
+			return nil, application.MakeNotFound(err)
 		}
+		return nil, err
+	}
+	return appStatusDomainToRest(st), nil
+}
+
+// Delete an Application registered by FuseML.
+func (s *applicationsrvc) Delete(ctx context.Context, p *application.DeletePayload) error {
+	s.logger.DebugContext(ctx, "application.delete")
+	err := s.mgr.DeleteApplication(ctx, p.Name)
+	if err == domain.ErrApplicationNotFound {
+		return application.MakeNotFound(err)
 	}
-	return s.store.Delete(ctx, p.Name)
+	return err
 }
@@ -2,21 +2,26 @@ package svc
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/fuseml/fuseml-core/gen/codeset"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
 // codeset service implementation.
 type codesetsrvc struct {
-	logger *log.Logger
-	store  domain.CodesetStore
+	logger        *slog.Logger
+	store         domain.CodesetStore
+	templateStore domain.CodesetTemplateStore
+	runnableStore domain.RunnableStore
 }
 
 // NewCodesetService returns the codeset service implementation.
-func NewCodesetService(logger *log.Logger, store domain.CodesetStore) codeset.Service {
-	return &codesetsrvc{logger, store}
+func NewCodesetService(logger *slog.Logger, store domain.CodesetStore, templateStore domain.CodesetTemplateStore,
+	runnableStore domain.RunnableStore) codeset.Service {
+	return &codesetsrvc{logger, store, templateStore, runnableStore}
 }
 
 func codesetRestToDomain(restCodeset *codeset.Codeset) (res *domain.Codeset, err error) {
@@ -42,20 +47,43 @@ func codesetDomainToRest(c *domain.Codeset) (res *codeset.Codeset) {
 	return
 }
 
+func codesetTemplateDomainToRest(t *domain.CodesetTemplate) *codeset.CodesetTemplate {
+	return &codeset.CodesetTemplate{
+		Name:        t.Name,
+		Description: t.Description,
+		Codeset: &codeset.CodesetArgumentDesc{
+			Type:         t.Codeset.Type,
+			Function:     t.Codeset.Function,
+			Format:       t.Codeset.Format,
+			Requirements: t.Codeset.Requirements,
+		},
+		Files: t.Files,
+	}
+}
+
 // Retrieve information about codesets registered in FuseML.
-func (s *codesetsrvc) List(ctx context.Context, p *codeset.ListPayload) (res []*codeset.Codeset, err error) {
-	s.logger.Print("codeset.list")
-	items, err := s.store.GetAll(ctx, p.Project, p.Label)
-	res = make([]*codeset.Codeset, 0, len(items))
+func (s *codesetsrvc) List(ctx context.Context, p *codeset.ListPayload) (res *codeset.ListResult, err error) {
+	s.logger.DebugContext(ctx, "codeset.list")
+	items, next, err := s.store.GetAll(ctx, p.Project, p.Label, listOptionsToDomain(p.Limit, p.Continue, p.Sort))
+	if err != nil {
+		if isInvalidListOptions(err) {
+			return nil, codeset.MakeBadRequest(err)
+		}
+		return nil, err
+	}
+	res = &codeset.ListResult{Items: make([]*codeset.Codeset, 0, len(items)), Continue: util.RefString(next)}
 	for _, c := range items {
-		res = append(res, codesetDomainToRest(c))
+		res.Items = append(res.Items, codesetDomainToRest(c))
+	}
+	if err := selectFields(res.Items, p.Fields, "name", "project"); err != nil {
+		return nil, codeset.MakeBadRequest(err)
 	}
-	return res, err
+	return res, nil
 }
 
 // Register a codeset with the FuseML codeset codesetStore.
 func (s *codesetsrvc) Register(ctx context.Context, p *codeset.RegisterPayload) (*codeset.RegisterResult, error) {
-	s.logger.Print("codeset.register")
+	s.logger.DebugContext(ctx, "codeset.register")
 	c, err := codesetRestToDomain(&codeset.Codeset{
 		Name:        p.Name,
 		Project:     p.Project,
@@ -79,7 +107,7 @@ func (s *codesetsrvc) Register(ctx context.Context, p *codeset.RegisterPayload)
 
 // Retrieve an Codeset from FuseML.
 func (s *codesetsrvc) Get(ctx context.Context, p *codeset.GetPayload) (res *codeset.Codeset, err error) {
-	s.logger.Print("codeset.get")
+	s.logger.DebugContext(ctx, "codeset.get")
 	c, err := s.store.Find(ctx, p.Project, p.Name)
 	if err != nil {
 		return nil, codeset.MakeBadRequest(err)
@@ -88,6 +116,74 @@ func (s *codesetsrvc) Get(ctx context.Context, p *codeset.GetPayload) (res *code
 }
 
 func (s *codesetsrvc) Delete(ctx context.Context, p *codeset.DeletePayload) error {
-	s.logger.Print("codeset.delete")
+	s.logger.DebugContext(ctx, "codeset.delete")
 	return s.store.Delete(ctx, p.Project, p.Name)
 }
+
+// Retrieve the templates that can be used to scaffold new Codesets.
+func (s *codesetsrvc) ListTemplates(ctx context.Context, p *codeset.ListTemplatesPayload) (res []*codeset.CodesetTemplate, err error) {
+	s.logger.DebugContext(ctx, "codeset.listTemplates")
+	query := &domain.RunnableCodesetArtifact{}
+	if p.Type != nil {
+		query.Type = []string{*p.Type}
+	}
+	if p.Function != nil {
+		query.Function = []string{*p.Function}
+	}
+	if p.Format != nil {
+		query.Format = []string{*p.Format}
+	}
+	templates, err := s.templateStore.GetAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// when a runnable is supplied, keep only the templates that generate codesets
+	// accepted by at least one of the runnable's codeset inputs
+	var codesetInputs []*domain.RunnableCodesetArtifact
+	if p.Runnable != nil {
+		r, err := s.runnableStore.Get(ctx, *p.Runnable)
+		if err != nil {
+			return nil, err
+		}
+		if r == nil {
+			return nil, codeset.MakeNotFound(fmt.Errorf("could not find a runnable with ID %s", *p.Runnable))
+		}
+		for _, input := range r.Inputs {
+			if c, ok := input.(*domain.RunnableInputCodeset); ok {
+				codesetInputs = append(codesetInputs, &c.RunnableCodesetArtifact)
+			}
+		}
+	}
+
+	res = make([]*codeset.CodesetTemplate, 0, len(templates))
+	for _, t := range templates {
+		if p.Runnable != nil && !matchesAnyCodesetInput(t, codesetInputs) {
+			continue
+		}
+		res = append(res, codesetTemplateDomainToRest(t))
+	}
+	return res, nil
+}
+
+// Retrieve a Codeset template, including the contents of its files.
+func (s *codesetsrvc) GetTemplate(ctx context.Context, p *codeset.GetTemplatePayload) (res *codeset.CodesetTemplate, err error) {
+	s.logger.DebugContext(ctx, "codeset.getTemplate")
+	t, err := s.templateStore.Find(ctx, p.Name)
+	if err != nil {
+		if err == domain.ErrCodesetTemplateNotFound {
+			return nil, codeset.MakeNotFound(err)
+		}
+		return nil, err
+	}
+	return codesetTemplateDomainToRest(t), nil
+}
+
+func matchesAnyCodesetInput(t *domain.CodesetTemplate, inputs []*domain.RunnableCodesetArtifact) bool {
+	for _, input := range inputs {
+		if t.Matches(input) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,7 @@ package svc
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/url"
 	"time"
 
@@ -13,12 +13,12 @@ import (
 
 // extension registry service implementation.
 type extensionRegistrySvc struct {
-	logger   *log.Logger
+	logger   *slog.Logger
 	registry domain.ExtensionRegistry
 }
 
 // NewExtensionRegistryService returns the extension registry service implementation.
-func NewExtensionRegistryService(logger *log.Logger, registry domain.ExtensionRegistry) extension.Service {
+func NewExtensionRegistryService(logger *slog.Logger, registry domain.ExtensionRegistry) extension.Service {
 	return &extensionRegistrySvc{logger, registry}
 }
 
@@ -197,11 +197,28 @@ func extensionEndpointToRest(extensionID string, serviceID string, endpoint *dom
 		ServiceID:     util.RefString(serviceID),
 		Type:          util.RefString(string(endpoint.Type)),
 		Configuration: endpoint.Configuration,
-		// TODO: Add registered and updated fields
-		Status: &extension.ExtensionEndpointStatus{},
+		Status:        extensionEndpointStatusToRest(endpoint),
 	}
 }
 
+func extensionEndpointStatusToRest(endpoint *domain.ExtensionServiceEndpoint) *extension.ExtensionEndpointStatus {
+	status := &extension.ExtensionEndpointStatus{
+		Registered: endpoint.Created.Format(time.RFC3339),
+		Updated:    endpoint.Updated.Format(time.RFC3339),
+		Health:     string(endpoint.Status.GetHealth()),
+	}
+	if endpoint.Status.Message != "" {
+		status.Message = util.RefString(endpoint.Status.Message)
+	}
+	if !endpoint.Status.LastChecked.IsZero() {
+		status.LastChecked = util.RefString(endpoint.Status.LastChecked.Format(time.RFC3339))
+	}
+	if !endpoint.Status.LastSeen.IsZero() {
+		status.LastSeen = util.RefString(endpoint.Status.LastSeen.Format(time.RFC3339))
+	}
+	return status
+}
+
 func extensionEndpointListToRest(extensionID string, serviceID string, endpoints map[string]*domain.ExtensionServiceEndpoint) []*extension.ExtensionEndpoint {
 	restEndpoints := []*extension.ExtensionEndpoint{}
 	for _, endpoint := range endpoints {
@@ -268,7 +285,7 @@ func errToRest(err error) error {
 
 // Register an extension with the FuseML extension registry.
 func (s *extensionRegistrySvc) RegisterExtension(ctx context.Context, req *extension.Extension) (*extension.Extension, error) {
-	s.logger.Print("extension.registerExtension")
+	s.logger.DebugContext(ctx, "extension.registerExtension")
 	domainExt, err := extensionToDomain(req)
 	if err != nil {
 		return nil, errToRest(err)
@@ -282,7 +299,7 @@ func (s *extensionRegistrySvc) RegisterExtension(ctx context.Context, req *exten
 
 // Retrieve information about an extension.
 func (s *extensionRegistrySvc) GetExtension(ctx context.Context, req *extension.GetExtensionPayload) (res *extension.Extension, err error) {
-	s.logger.Print("extension.getExtension")
+	s.logger.DebugContext(ctx, "extension.getExtension")
 	extension, err := s.registry.GetExtension(ctx, req.ID)
 	if err != nil {
 		return nil, errToRest(err)
@@ -291,16 +308,20 @@ func (s *extensionRegistrySvc) GetExtension(ctx context.Context, req *extension.
 }
 
 // List extensions registered in FuseML
-func (s *extensionRegistrySvc) ListExtensions(ctx context.Context, query *extension.ExtensionQuery) (res []*extension.Extension, err error) {
-	s.logger.Print("extension.listExtensions")
-	extensions, err := s.registry.ListExtensions(ctx, extensionQueryToDomain(query))
+func (s *extensionRegistrySvc) ListExtensions(ctx context.Context, query *extension.ExtensionQuery) (res *extension.ListExtensionsResult, err error) {
+	s.logger.DebugContext(ctx, "extension.listExtensions")
+	extensions, next, err := s.registry.ListExtensions(ctx, extensionQueryToDomain(query),
+		listOptionsToDomain(query.Limit, query.Continue, query.Sort))
 	if err != nil {
 		return nil, errToRest(err)
 	}
 
-	res = make([]*extension.Extension, len(extensions))
+	res = &extension.ListExtensionsResult{Items: make([]*extension.Extension, len(extensions)), Continue: util.RefString(next)}
 	for i, extension := range extensions {
-		res[i] = extensionToRest(ctx, extension)
+		res.Items[i] = extensionToRest(ctx, extension)
+	}
+	if err := selectFields(res.Items, query.Fields); err != nil {
+		return nil, errToRest(err)
 	}
 
 	return res, nil
@@ -308,7 +329,7 @@ func (s *extensionRegistrySvc) ListExtensions(ctx context.Context, query *extens
 
 // Update an extension registered in FuseML
 func (s *extensionRegistrySvc) UpdateExtension(ctx context.Context, req *extension.Extension) (res *extension.Extension, err error) {
-	s.logger.Print("extension.updateExtension")
+	s.logger.DebugContext(ctx, "extension.updateExtension")
 	domainExt, err := extensionToDomain(req)
 	if err != nil {
 		return nil, errToRest(err)
@@ -347,7 +368,7 @@ func (s *extensionRegistrySvc) UpdateExtension(ctx context.Context, req *extensi
 
 // Delete an extension and its subtree of services, endpoints and credentials
 func (s *extensionRegistrySvc) DeleteExtension(ctx context.Context, req *extension.DeleteExtensionPayload) (err error) {
-	s.logger.Print("extension.deleteExtension")
+	s.logger.DebugContext(ctx, "extension.deleteExtension")
 	err = s.registry.RemoveExtension(ctx, req.ID)
 	if err != nil {
 		return errToRest(err)
@@ -393,7 +414,7 @@ func (s *extensionRegistrySvc) ListServices(ctx context.Context, req *extension.
 
 // Update a service belonging to an extension registered in FuseML
 func (s *extensionRegistrySvc) UpdateService(ctx context.Context, req *extension.ExtensionService) (res *extension.ExtensionService, err error) {
-	s.logger.Print("extension.updateService")
+	s.logger.DebugContext(ctx, "extension.updateService")
 	service, err := extensionServiceToDomain(req)
 	if err != nil {
 		return nil, errToRest(err)
@@ -439,7 +460,7 @@ func (s *extensionRegistrySvc) UpdateService(ctx context.Context, req *extension
 
 // Delete an extension service and its subtree of endpoints and credentials
 func (s *extensionRegistrySvc) DeleteService(ctx context.Context, req *extension.DeleteServicePayload) (err error) {
-	s.logger.Print("extension.deleteService")
+	s.logger.DebugContext(ctx, "extension.deleteService")
 	err = s.registry.RemoveService(ctx, req.ExtensionID, req.ID)
 	if err != nil {
 		return errToRest(err)
@@ -450,7 +471,7 @@ func (s *extensionRegistrySvc) DeleteService(ctx context.Context, req *extension
 // Add an endpoint to an existing extension service registered with the FuseML
 // extension registry.
 func (s *extensionRegistrySvc) AddEndpoint(ctx context.Context, req *extension.ExtensionEndpoint) (res *extension.ExtensionEndpoint, err error) {
-	s.logger.Print("extension.addEndpoint")
+	s.logger.DebugContext(ctx, "extension.addEndpoint")
 	endpoint, err := s.registry.AddEndpoint(ctx, util.DerefString(req.ExtensionID), util.DerefString(req.ServiceID), extensionEndpointToDomain(req))
 	if err != nil {
 		return nil, errToRest(err)
@@ -460,7 +481,7 @@ func (s *extensionRegistrySvc) AddEndpoint(ctx context.Context, req *extension.E
 
 // Retrieve information about an endpoint belonging to an extension.
 func (s *extensionRegistrySvc) GetEndpoint(ctx context.Context, req *extension.GetEndpointPayload) (res *extension.ExtensionEndpoint, err error) {
-	s.logger.Print("extension.getEndpoint")
+	s.logger.DebugContext(ctx, "extension.getEndpoint")
 	endpoint, err := s.registry.GetEndpoint(ctx, req.ExtensionID, req.ServiceID, extensionEndpointURLToDomain(&req.URL))
 	if err != nil {
 		return nil, errToRest(err)
@@ -470,7 +491,7 @@ func (s *extensionRegistrySvc) GetEndpoint(ctx context.Context, req *extension.G
 
 // List all endpoints associated with an extension service registered in FuseML
 func (s *extensionRegistrySvc) ListEndpoints(ctx context.Context, req *extension.ListEndpointsPayload) (res []*extension.ExtensionEndpoint, err error) {
-	s.logger.Print("extension.listEndpoints")
+	s.logger.DebugContext(ctx, "extension.listEndpoints")
 	svc, err := s.registry.GetService(ctx, req.ExtensionID, req.ServiceID)
 	if err != nil {
 		return nil, errToRest(err)
@@ -481,7 +502,7 @@ func (s *extensionRegistrySvc) ListEndpoints(ctx context.Context, req *extension
 
 // Update an endpoint belonging to an extension service registered in FuseML
 func (s *extensionRegistrySvc) UpdateEndpoint(ctx context.Context, req *extension.ExtensionEndpoint) (res *extension.ExtensionEndpoint, err error) {
-	s.logger.Print("extension.updateEndpoint")
+	s.logger.DebugContext(ctx, "extension.updateEndpoint")
 	endpoint := extensionEndpointToDomain(req)
 	ep, err := s.registry.GetEndpoint(ctx, util.DerefString(req.ExtensionID), util.DerefString(req.ServiceID), extensionEndpointURLToDomain(&endpoint.URL))
 	if err != nil {
@@ -507,7 +528,7 @@ func (s *extensionRegistrySvc) UpdateEndpoint(ctx context.Context, req *extensio
 
 // Delete an extension endpoint
 func (s *extensionRegistrySvc) DeleteEndpoint(ctx context.Context, req *extension.DeleteEndpointPayload) (err error) {
-	s.logger.Print("extension.deleteEndpoint")
+	s.logger.DebugContext(ctx, "extension.deleteEndpoint")
 	err = s.registry.RemoveEndpoint(ctx, req.ExtensionID, req.ServiceID, extensionEndpointURLToDomain(&req.URL))
 	if err != nil {
 		return errToRest(err)
@@ -527,7 +548,7 @@ func (s *extensionRegistrySvc) AddCredentials(ctx context.Context, req *extensio
 
 // Retrieve information about a set of credentials belonging to an extension.
 func (s *extensionRegistrySvc) GetCredentials(ctx context.Context, req *extension.GetCredentialsPayload) (res *extension.ExtensionCredentials, err error) {
-	s.logger.Print("extension.getCredentials")
+	s.logger.DebugContext(ctx, "extension.getCredentials")
 	credentials, err := s.registry.GetCredentials(ctx, req.ExtensionID, req.ServiceID, req.ID)
 	if err != nil {
 		return nil, errToRest(err)
@@ -538,7 +559,7 @@ func (s *extensionRegistrySvc) GetCredentials(ctx context.Context, req *extensio
 // List all credentials associated with an extension service registered in
 // FuseML
 func (s *extensionRegistrySvc) ListCredentials(ctx context.Context, req *extension.ListCredentialsPayload) (res []*extension.ExtensionCredentials, err error) {
-	s.logger.Print("extension.listCredentials")
+	s.logger.DebugContext(ctx, "extension.listCredentials")
 	svc, err := s.registry.GetService(ctx, req.ExtensionID, req.ServiceID)
 	if err != nil {
 		return nil, errToRest(err)
@@ -550,7 +571,7 @@ func (s *extensionRegistrySvc) ListCredentials(ctx context.Context, req *extensi
 // Update a set of credentials belonging to an extension service registered in
 // FuseML
 func (s *extensionRegistrySvc) UpdateCredentials(ctx context.Context, req *extension.ExtensionCredentials) (res *extension.ExtensionCredentials, err error) {
-	s.logger.Print("extension.updateCredentials")
+	s.logger.DebugContext(ctx, "extension.updateCredentials")
 	credentials := extensionCredentialsToDomain(req)
 	cred, err := s.registry.GetCredentials(ctx, util.DerefString(req.ExtensionID), util.DerefString(req.ServiceID), credentials.ID)
 	if err != nil {
@@ -586,10 +607,128 @@ func (s *extensionRegistrySvc) UpdateCredentials(ctx context.Context, req *exten
 
 // Delete a set of extension credentials
 func (s *extensionRegistrySvc) DeleteCredentials(ctx context.Context, req *extension.DeleteCredentialsPayload) (err error) {
-	s.logger.Print("extension.deleteCredentials")
+	s.logger.DebugContext(ctx, "extension.deleteCredentials")
 	err = s.registry.RemoveCredentials(ctx, req.ExtensionID, req.ServiceID, req.ID)
 	if err != nil {
 		return errToRest(err)
 	}
 	return nil
 }
+
+// List the workflows and workflow steps that are bound to an extension, extension service or set of credentials
+func (s *extensionRegistrySvc) ListUsage(ctx context.Context, req *extension.ListUsagePayload) (res []*extension.ExtensionUsage, err error) {
+	s.logger.DebugContext(ctx, "extension.listUsage")
+	usage, err := s.registry.GetUsage(ctx, &domain.ExtensionUsageFilter{
+		ExtensionID:   req.ExtensionID,
+		ServiceID:     util.DerefString(req.ServiceID),
+		CredentialsID: util.DerefString(req.CredentialsID),
+		All:           req.All,
+	})
+	if err != nil {
+		return nil, errToRest(err)
+	}
+
+	res = make([]*extension.ExtensionUsage, len(usage))
+	for i, u := range usage {
+		res[i] = extensionUsageToRest(u)
+	}
+	return res, nil
+}
+
+// Import extensions into the registry.
+func (s *extensionRegistrySvc) ImportExtensions(ctx context.Context, req *extension.ImportExtensionsPayload) (res []*extension.ExtensionChange, err error) {
+	s.logger.DebugContext(ctx, "extension.importExtensions")
+	extensions := make([]*domain.Extension, len(req.Extensions))
+	for i, ext := range req.Extensions {
+		extensions[i], err = extensionToDomain(ext)
+		if err != nil {
+			return nil, extension.MakeBadRequest(err)
+		}
+	}
+	changes, err := s.registry.ImportExtensions(ctx, extensions, &domain.ExtensionImportOptions{
+		DryRun:             req.DryRun,
+		ExcludeCredentials: req.ExcludeCredentials,
+	})
+	if err != nil {
+		return nil, extension.MakeBadRequest(err)
+	}
+
+	res = make([]*extension.ExtensionChange, len(changes))
+	for i, change := range changes {
+		res[i] = &extension.ExtensionChange{
+			Kind:    string(change.Kind),
+			Element: change.Element,
+			Action:  string(change.Action),
+			Fields:  change.Fields,
+		}
+	}
+	return res, nil
+}
+
+// Export all registered extensions.
+func (s *extensionRegistrySvc) ExportExtensions(ctx context.Context, req *extension.ExportExtensionsPayload) (res []*extension.Extension, err error) {
+	s.logger.DebugContext(ctx, "extension.exportExtensions")
+	extensions, err := s.registry.ExportExtensions(ctx, req.ExcludeCredentials)
+	if err != nil {
+		return nil, errToRest(err)
+	}
+
+	res = make([]*extension.Extension, len(extensions))
+	for i, extension := range extensions {
+		res[i] = extensionToRest(ctx, extension)
+	}
+	return res, nil
+}
+
+// List the configuration schemas of the known extension service resource types.
+func (s *extensionRegistrySvc) ListResourceSchemas(ctx context.Context, req *extension.ListResourceSchemasPayload) (res []*extension.ExtensionResourceSchema, err error) {
+	s.logger.DebugContext(ctx, "extension.listResourceSchemas")
+	schemas, err := s.registry.ListResourceSchemas(ctx, util.DerefString(req.Resource))
+	if err != nil {
+		return nil, errToRest(err)
+	}
+
+	res = make([]*extension.ExtensionResourceSchema, len(schemas))
+	for i, schema := range schemas {
+		res[i] = &extension.ExtensionResourceSchema{
+			Resource:                 schema.Resource,
+			Description:              util.RefString(schema.Description),
+			Category:                 util.RefString(schema.Category),
+			ServiceConfiguration:     extensionConfigurationKeysToRest(schema.ServiceConfiguration),
+			EndpointConfiguration:    extensionConfigurationKeysToRest(schema.EndpointConfiguration),
+			CredentialsConfiguration: extensionConfigurationKeysToRest(schema.CredentialsConfiguration),
+		}
+	}
+	return res, nil
+}
+
+func extensionConfigurationKeysToRest(keys []*domain.ExtensionConfigurationKey) []*extension.ExtensionConfigurationKey {
+	res := make([]*extension.ExtensionConfigurationKey, len(keys))
+	for i, key := range keys {
+		res[i] = &extension.ExtensionConfigurationKey{
+			Name:        key.Name,
+			Description: util.RefString(key.Description),
+			Required:    key.Required,
+		}
+	}
+	return res
+}
+
+func extensionUsageToRest(usage *domain.ExtensionUsage) *extension.ExtensionUsage {
+	res := &extension.ExtensionUsage{
+		Workflow:    usage.Workflow,
+		Step:        usage.Step,
+		Requirement: usage.Requirement,
+		ExtensionID: usage.ExtensionID,
+		ServiceID:   usage.ServiceID,
+		EndpointURL: usage.EndpointURL,
+		Bound:       usage.Bound.Format(time.RFC3339),
+	}
+	if usage.CredentialsID != "" {
+		res.CredentialsID = util.RefString(usage.CredentialsID)
+	}
+	if !usage.Active() {
+		res.Released = util.RefString(usage.Released.Format(time.RFC3339))
+	}
+	return res
+}
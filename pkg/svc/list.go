@@ -0,0 +1,66 @@
+package svc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
+)
+
+// listOptionsToDomain converts the paging and sorting parameters of a list request
+func listOptionsToDomain(limit *int, cont, sort *string) *domain.ListOptions {
+	opts := &domain.ListOptions{
+		Continue: util.DerefString(cont),
+		Sort:     util.DerefString(sort),
+	}
+	if limit != nil {
+		opts.Limit = *limit
+	}
+	return opts
+}
+
+// isInvalidListOptions checks if an error, or an error that it wraps, is caused by invalid paging or
+// sorting parameters
+func isInvalidListOptions(err error) bool {
+	var invalid *domain.ErrInvalidListOptions
+	return errors.As(err, &invalid)
+}
+
+// fieldName normalizes the name of a field, so that the API names of the fields, e.g. "k8s_namespace",
+// match the names of the struct fields holding them, e.g. "K8sNamespace"
+func fieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// selectFields clears the fields of the list items that are neither selected nor required. Nothing is
+// cleared if no field is selected. It fails if a selected field is not a field of the items.
+func selectFields[T any](items []*T, selected []string, required ...string) error {
+	if len(selected) == 0 {
+		return nil
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	known := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		known[fieldName(t.Field(i).Name)] = true
+	}
+	keep := map[string]bool{}
+	for _, f := range append(selected, required...) {
+		if !known[fieldName(f)] {
+			return domain.NewErrInvalidListOptions("cannot select '%s', it is not a field of the results", f)
+		}
+		keep[fieldName(f)] = true
+	}
+
+	for _, item := range items {
+		v := reflect.ValueOf(item).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			if !keep[fieldName(t.Field(i).Name)] {
+				v.Field(i).Set(reflect.Zero(t.Field(i).Type))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package svc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fuseml/fuseml-core/gen/application"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+)
+
+func TestSelectFields(t *testing.T) {
+	newItems := func() []*application.Application {
+		desc := "serving the model"
+		return []*application.Application{{
+			Name:         "app",
+			Type:         "predictor",
+			Description:  &desc,
+			URL:          "http://app",
+			Workflow:     "wf",
+			K8sNamespace: "ns",
+		}}
+	}
+	required := []string{"name", "type", "url", "workflow", "k8s_namespace"}
+
+	t.Run("no selection", func(t *testing.T) {
+		items := newItems()
+		if err := selectFields(items, nil, required...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if items[0].Description == nil {
+			t.Error("expected all the fields to be returned")
+		}
+	})
+
+	t.Run("required fields only", func(t *testing.T) {
+		items := newItems()
+		if err := selectFields(items, []string{"name"}, required...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if items[0].Description != nil {
+			t.Error("expected the description to be cleared")
+		}
+		if items[0].K8sNamespace != "ns" || items[0].URL != "http://app" {
+			t.Errorf("expected the required fields to be kept, got %+v", items[0])
+		}
+	})
+
+	t.Run("selected field", func(t *testing.T) {
+		items := newItems()
+		if err := selectFields(items, []string{"description"}, required...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if items[0].Description == nil {
+			t.Error("expected the description to be kept")
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		items := newItems()
+		err := selectFields(items, []string{"owner"}, required...)
+		var invalid *domain.ErrInvalidListOptions
+		if !errors.As(err, &invalid) {
+			t.Fatalf("expected an invalid list options error, got %v", err)
+		}
+		if items[0].Description == nil {
+			t.Error("expected the items to be left untouched")
+		}
+	})
+}
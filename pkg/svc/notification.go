@@ -0,0 +1,154 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/fuseml/fuseml-core/gen/notification"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
+)
+
+func notificationTargetRestToDomain(nt *notification.NotificationTarget) *domain.NotificationTarget {
+	return &domain.NotificationTarget{
+		Name:        nt.Name,
+		Description: util.DerefString(nt.Description),
+		Type:        domain.NotificationTargetType(nt.Type),
+		Workflow:    util.DerefString(nt.Workflow),
+		Project:     util.DerefString(nt.Project),
+		Statuses:    nt.Statuses,
+		URL:         util.DerefString(nt.URL),
+		Secret:      util.DerefString(nt.Secret),
+		Recipients:  nt.Recipients,
+		Template:    util.DerefString(nt.Template),
+	}
+}
+
+// the secret used to sign webhook notifications is write-only, so it is not part of the returned target
+func notificationTargetDomainToRest(t *domain.NotificationTarget) *notification.NotificationTarget {
+	return &notification.NotificationTarget{
+		Name:        t.Name,
+		Description: util.RefString(t.Description),
+		Type:        string(t.Type),
+		Workflow:    util.RefString(t.Workflow),
+		Project:     util.RefString(t.Project),
+		Statuses:    t.Statuses,
+		URL:         util.RefString(t.URL),
+		Recipients:  t.Recipients,
+		Template:    util.RefString(t.Template),
+		Created:     util.RefString(t.Created.Format(time.RFC3339)),
+	}
+}
+
+func notificationDeliveryDomainToRest(d *domain.NotificationDelivery) *notification.NotificationDelivery {
+	return &notification.NotificationDelivery{
+		ID:        d.ID,
+		Target:    d.Target,
+		Workflow:  d.Workflow,
+		Run:       d.Run,
+		RunStatus: d.RunStatus,
+		Status:    string(d.Status),
+		Attempts:  d.Attempts,
+		Error:     util.RefString(d.Error),
+		Created:   d.Created.Format(time.RFC3339),
+		Updated:   d.Updated.Format(time.RFC3339),
+	}
+}
+
+// notification service implementation.
+type notificationsrvc struct {
+	logger *slog.Logger
+	mgr    domain.NotificationManager
+}
+
+// NewNotificationService returns the notification service implementation.
+func NewNotificationService(logger *slog.Logger, mgr domain.NotificationManager) notification.Service {
+	return &notificationsrvc{logger, mgr}
+}
+
+// Retrieve information about the notification targets registered in FuseML.
+func (s *notificationsrvc) List(ctx context.Context, p *notification.ListPayload) (res *notification.ListResult, err error) {
+	s.logger.DebugContext(ctx, "notification.list")
+	items, next, err := s.mgr.GetTargets(ctx, util.DerefString(p.Workflow), util.DerefString(p.Project),
+		listOptionsToDomain(p.Limit, p.Continue, p.Sort))
+	if err != nil {
+		if isInvalidListOptions(err) {
+			return nil, notification.MakeBadRequest(err)
+		}
+		return nil, err
+	}
+	res = &notification.ListResult{Items: make([]*notification.NotificationTarget, 0, len(items)), Continue: util.RefString(next)}
+	for _, t := range items {
+		res.Items = append(res.Items, notificationTargetDomainToRest(t))
+	}
+	if err := selectFields(res.Items, p.Fields, "name", "type"); err != nil {
+		return nil, notification.MakeBadRequest(err)
+	}
+	return res, nil
+}
+
+// Register a notification target with FuseML.
+func (s *notificationsrvc) Register(ctx context.Context, p *notification.NotificationTarget) (res *notification.NotificationTarget, err error) {
+	s.logger.DebugContext(ctx, "notification.register")
+	target, err := s.mgr.RegisterTarget(ctx, notificationTargetRestToDomain(p))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidNotificationTarget) {
+			return nil, notification.MakeBadRequest(err)
+		}
+		if err == domain.ErrNotificationTargetExists {
+			return nil, notification.MakeConflict(err)
+		}
+		return nil, err
+	}
+	return notificationTargetDomainToRest(target), nil
+}
+
+// Retrieve a notification target registered with FuseML.
+func (s *notificationsrvc) Get(ctx context.Context, p *notification.GetPayload) (res *notification.NotificationTarget, err error) {
+	s.logger.DebugContext(ctx, "notification.get")
+	target, err := s.mgr.GetTarget(ctx, p.Name)
+	if err != nil {
+		if err == domain.ErrNotificationTargetNotFound {
+			return nil, notification.MakeNotFound(err)
+		}
+		return nil, err
+	}
+	return notificationTargetDomainToRest(target), nil
+}
+
+// Delete a notification target and its delivery log.
+func (s *notificationsrvc) Delete(ctx context.Context, p *notification.DeletePayload) error {
+	s.logger.DebugContext(ctx, "notification.delete")
+	err := s.mgr.DeleteTarget(ctx, p.Name)
+	if err == domain.ErrNotificationTargetNotFound {
+		return notification.MakeNotFound(err)
+	}
+	return err
+}
+
+// Retrieve the log of notifications delivered to a notification target.
+func (s *notificationsrvc) Deliveries(ctx context.Context, p *notification.DeliveriesPayload) (res *notification.DeliveriesResult, err error) {
+	s.logger.DebugContext(ctx, "notification.deliveries")
+	items, next, err := s.mgr.GetDeliveries(ctx, p.Name, listOptionsToDomain(p.Limit, p.Continue, p.Sort))
+	if err != nil {
+		if err == domain.ErrNotificationTargetNotFound {
+			return nil, notification.MakeNotFound(err)
+		}
+		if isInvalidListOptions(err) {
+			return nil, notification.MakeBadRequest(err)
+		}
+		return nil, err
+	}
+	res = &notification.DeliveriesResult{Items: make([]*notification.NotificationDelivery, 0, len(items)), Continue: util.RefString(next)}
+	for _, d := range items {
+		res.Items = append(res.Items, notificationDeliveryDomainToRest(d))
+	}
+	err = selectFields(res.Items, p.Fields, "id", "target", "workflow", "run", "runStatus", "status", "attempts",
+		"created", "updated")
+	if err != nil {
+		return nil, notification.MakeBadRequest(err)
+	}
+	return res, nil
+}
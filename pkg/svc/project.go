@@ -2,21 +2,33 @@ package svc
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/fuseml/fuseml-core/gen/project"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
+// recentRunsLimit is the maximum number of workflow runs listed for each codeset in a project summary
+const recentRunsLimit = 5
+
 // project service implementation.
 type projectsrvc struct {
-	logger *log.Logger
-	store  domain.ProjectStore
+	logger       *slog.Logger
+	store        domain.ProjectStore
+	codesetStore domain.CodesetStore
+	workflowMgr  domain.WorkflowManager
+	appMgr       domain.ApplicationManager
 }
 
 // NewProjectService returns the project service implementation.
-func NewProjectService(logger *log.Logger, store domain.ProjectStore) project.Service {
-	return &projectsrvc{logger, store}
+func NewProjectService(logger *slog.Logger, store domain.ProjectStore, codesetStore domain.CodesetStore,
+	workflowMgr domain.WorkflowManager, appMgr domain.ApplicationManager) project.Service {
+	return &projectsrvc{logger, store, codesetStore, workflowMgr, appMgr}
 }
 
 func projectDomainToRest(p *domain.Project) (res *project.Project) {
@@ -36,20 +48,47 @@ func projectDomainToRest(p *domain.Project) (res *project.Project) {
 	return
 }
 
+func userCredentialsDomainToRest(c *domain.UserCredentials) *project.UserCredentials {
+	return &project.UserCredentials{
+		Username: c.Username,
+		Password: c.Password,
+	}
+}
+
+// projectError maps the errors returned by project operations to service errors
+func projectError(err error) error {
+	switch errors.Cause(err) {
+	case domain.ErrProjectNotFound, domain.ErrProjectMemberNotFound:
+		return project.MakeNotFound(err)
+	case domain.ErrProjectMemberExists, domain.ErrProjectNotEmpty:
+		return project.MakeConflict(err)
+	}
+	return err
+}
+
 // Retrieve information about projects registered in FuseML.
-func (s *projectsrvc) List(ctx context.Context) (res []*project.Project, err error) {
-	s.logger.Print("project.list")
-	items, err := s.store.GetAll(ctx)
-	res = make([]*project.Project, 0, len(items))
+func (s *projectsrvc) List(ctx context.Context, p *project.ListPayload) (res *project.ListResult, err error) {
+	s.logger.DebugContext(ctx, "project.list")
+	items, next, err := s.store.GetAll(ctx, listOptionsToDomain(p.Limit, p.Continue, p.Sort))
+	if err != nil {
+		if isInvalidListOptions(err) {
+			return nil, project.MakeBadRequest(err)
+		}
+		return nil, err
+	}
+	res = &project.ListResult{Items: make([]*project.Project, 0, len(items)), Continue: util.RefString(next)}
 	for _, c := range items {
-		res = append(res, projectDomainToRest(c))
+		res.Items = append(res.Items, projectDomainToRest(c))
+	}
+	if err := selectFields(res.Items, p.Fields, "name"); err != nil {
+		return nil, project.MakeBadRequest(err)
 	}
-	return res, err
+	return res, nil
 }
 
 // Retrieve an Project from FuseML.
 func (s *projectsrvc) Get(ctx context.Context, p *project.GetPayload) (res *project.Project, err error) {
-	s.logger.Print("project.get")
+	s.logger.DebugContext(ctx, "project.get")
 	c, err := s.store.Find(ctx, p.Name)
 	if err != nil {
 		return nil, project.MakeBadRequest(err)
@@ -58,7 +97,7 @@ func (s *projectsrvc) Get(ctx context.Context, p *project.GetPayload) (res *proj
 }
 
 func (s *projectsrvc) Create(ctx context.Context, p *project.CreatePayload) (res *project.Project, err error) {
-	s.logger.Print("project.create")
+	s.logger.DebugContext(ctx, "project.create")
 	c, err := s.store.Create(ctx, p.Name, p.Description)
 	if err != nil {
 		if err == domain.ErrProjectExists {
@@ -70,6 +109,117 @@ func (s *projectsrvc) Create(ctx context.Context, p *project.CreatePayload) (res
 }
 
 func (s *projectsrvc) Delete(ctx context.Context, p *project.DeletePayload) error {
-	s.logger.Print("project.delete")
-	return s.store.Delete(ctx, p.Name)
+	s.logger.DebugContext(ctx, "project.delete")
+	return projectError(s.store.Delete(ctx, p.Name, p.Cascade))
+}
+
+// Retrieve a summary of the resources that belong to a FuseML Project.
+func (s *projectsrvc) Summary(ctx context.Context, p *project.SummaryPayload) (res *project.ProjectSummary, err error) {
+	s.logger.DebugContext(ctx, "project.summary")
+	prj, err := s.store.Find(ctx, p.Name)
+	if err != nil {
+		return nil, projectError(err)
+	}
+	res = &project.ProjectSummary{Project: projectDomainToRest(prj)}
+
+	codesets, _, err := s.codesetStore.GetAll(ctx, &p.Name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	assignments := s.workflowMgr.GetAllCodesetAssignments(ctx, nil)
+	for _, c := range codesets {
+		cs := &project.ProjectCodesetSummary{Name: c.Name, URL: &c.URL}
+		for wfName, wfAssignments := range assignments {
+			for _, a := range wfAssignments {
+				if a.Codeset.Project == c.Project && a.Codeset.Name == c.Name {
+					cs.Workflows = append(cs.Workflows, wfName)
+				}
+			}
+		}
+		sort.Strings(cs.Workflows)
+
+		runs, _, err := s.workflowMgr.GetWorkflowRuns(ctx, &domain.WorkflowRunFilter{CodesetName: c.Name, CodesetProject: c.Project}, nil)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(runs, func(i, j int) bool { return runs[i].StartTime.After(runs[j].StartTime) })
+		if len(runs) > recentRunsLimit {
+			runs = runs[:recentRunsLimit]
+		}
+		for _, r := range runs {
+			cs.Runs = append(cs.Runs, &project.ProjectWorkflowRun{
+				Name:      r.Name,
+				Workflow:  r.WorkflowRef,
+				StartTime: r.StartTime.Format(time.RFC3339),
+				Status:    r.Status,
+			})
+		}
+		res.Codesets = append(res.Codesets, cs)
+	}
+
+	apps, err := s.appMgr.GetProjectApplications(ctx, p.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range apps {
+		app := &project.Application{
+			Name:         a.Name,
+			Type:         a.Type,
+			URL:          a.URL,
+			Workflow:     a.Workflow,
+			K8sNamespace: a.K8sNamespace,
+		}
+		if a.Description != "" {
+			app.Description = &a.Description
+		}
+		for _, r := range a.K8sResources {
+			app.K8sResources = append(app.K8sResources, &project.KubernetesResource{Name: r.Name, Kind: r.Kind})
+		}
+		res.Applications = append(res.Applications, app)
+	}
+	return res, nil
+}
+
+// Retrieve the members of a FuseML Project.
+func (s *projectsrvc) ListMembers(ctx context.Context, p *project.ListMembersPayload) (res []*project.User, err error) {
+	s.logger.DebugContext(ctx, "project.listMembers")
+	users, err := s.store.ListMembers(ctx, p.Name)
+	if err != nil {
+		return nil, projectError(err)
+	}
+	res = make([]*project.User, 0, len(users))
+	for _, u := range users {
+		res = append(res, &project.User{Name: u.Name, Email: u.Email})
+	}
+	return res, nil
+}
+
+// Add a user to a FuseML Project.
+func (s *projectsrvc) AddMember(ctx context.Context, p *project.AddMemberPayload) (res *project.UserCredentials, err error) {
+	s.logger.DebugContext(ctx, "project.addMember")
+	user := &domain.User{Name: p.User}
+	if p.Email != nil {
+		user.Email = *p.Email
+	}
+	c, err := s.store.AddMember(ctx, p.Name, user)
+	if err != nil {
+		return nil, projectError(err)
+	}
+	return userCredentialsDomainToRest(c), nil
+}
+
+// Remove a user from a FuseML Project.
+func (s *projectsrvc) RemoveMember(ctx context.Context, p *project.RemoveMemberPayload) error {
+	s.logger.DebugContext(ctx, "project.removeMember")
+	return projectError(s.store.RemoveMember(ctx, p.Name, p.User))
+}
+
+// Replace the git credentials of a Project member with newly generated ones.
+func (s *projectsrvc) RotateCredentials(ctx context.Context, p *project.RotateCredentialsPayload) (res *project.UserCredentials, err error) {
+	s.logger.DebugContext(ctx, "project.rotateCredentials")
+	c, err := s.store.RotateCredentials(ctx, p.Name, p.User)
+	if err != nil {
+		return nil, projectError(err)
+	}
+	return userCredentialsDomainToRest(c), nil
 }
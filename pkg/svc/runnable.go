@@ -4,18 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/fuseml/fuseml-core/gen/runnable"
 	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
 )
 
 // runnable service example implementation.
 // The example methods log the requests and return zero values.
 type runnablesrvc struct {
-	logger *log.Logger
+	logger *slog.Logger
 	store  domain.RunnableStore
 }
 
@@ -28,7 +29,7 @@ const (
 )
 
 // NewRunnableService returns the runnable service implementation.
-func NewRunnableService(logger *log.Logger, store domain.RunnableStore) runnable.Service {
+func NewRunnableService(logger *slog.Logger, store domain.RunnableStore) runnable.Service {
 	return &runnablesrvc{logger, store}
 }
 
@@ -540,8 +541,8 @@ func runnableDomainToRest(r *domain.Runnable) (res *runnable.Runnable) {
 }
 
 // Retrieve information about runnables registered in FuseML.
-func (s *runnablesrvc) List(ctx context.Context, p *runnable.ListPayload) (res []*runnable.Runnable, err error) {
-	s.logger.Print("runnable.list")
+func (s *runnablesrvc) List(ctx context.Context, p *runnable.ListPayload) (res *runnable.ListResult, err error) {
+	s.logger.DebugContext(ctx, "runnable.list")
 	idQuery := ""
 	if p.ID != nil {
 		idQuery = *p.ID
@@ -550,17 +551,26 @@ func (s *runnablesrvc) List(ctx context.Context, p *runnable.ListPayload) (res [
 	if p.Kind != nil {
 		kindQuery = *p.Kind
 	}
-	items, err := s.store.Find(ctx, idQuery, kindQuery, p.Labels)
-	res = make([]*runnable.Runnable, 0, len(items))
+	items, next, err := s.store.Find(ctx, idQuery, kindQuery, p.Labels, listOptionsToDomain(p.Limit, p.Continue, p.Sort))
+	if err != nil {
+		if isInvalidListOptions(err) {
+			return nil, runnable.MakeBadRequest(err)
+		}
+		return nil, err
+	}
+	res = &runnable.ListResult{Items: make([]*runnable.Runnable, 0, len(items)), Continue: util.RefString(next)}
 	for _, r := range items {
-		res = append(res, runnableDomainToRest(r))
+		res.Items = append(res.Items, runnableDomainToRest(r))
+	}
+	if err := selectFields(res.Items, p.Fields, "id", "container"); err != nil {
+		return nil, runnable.MakeBadRequest(err)
 	}
-	return res, err
+	return res, nil
 }
 
 // Register a runnable with the FuseML runnable runnableStore.
 func (s *runnablesrvc) Register(ctx context.Context, p *runnable.Runnable) (res *runnable.Runnable, err error) {
-	s.logger.Print("runnable.register")
+	s.logger.DebugContext(ctx, "runnable.register")
 	r, err := runnableRestToDomain(p)
 	if err != nil {
 		return p, runnable.MakeBadRequest(err)
@@ -574,7 +584,7 @@ func (s *runnablesrvc) Register(ctx context.Context, p *runnable.Runnable) (res
 
 // Retrieve a Runnable from FuseML.
 func (s *runnablesrvc) Get(ctx context.Context, p *runnable.GetPayload) (res *runnable.Runnable, err error) {
-	s.logger.Print("runnable.get")
+	s.logger.DebugContext(ctx, "runnable.get")
 	r, err := s.store.Get(ctx, p.ID)
 	if r == nil {
 		return nil, runnable.MakeNotFound(errors.New(err.Error()))
@@ -0,0 +1,80 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/fuseml/fuseml-core/gen/status"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
+	"github.com/fuseml/fuseml-core/pkg/version"
+)
+
+func dependencyStatusDomainToRest(d *domain.DependencyStatus) *status.DependencyStatus {
+	return &status.DependencyStatus{
+		Name:      d.Name,
+		Endpoint:  util.RefString(d.Endpoint),
+		Version:   util.RefString(d.Version),
+		Available: d.Available(),
+		Error:     util.RefString(d.Error),
+	}
+}
+
+// status service implementation.
+type statussrvc struct {
+	logger *slog.Logger
+	mgr    domain.StatusManager
+}
+
+// NewStatusService returns the status service implementation.
+func NewStatusService(logger *slog.Logger, mgr domain.StatusManager) status.Service {
+	return &statussrvc{logger, mgr}
+}
+
+// Retrieve the server configuration and the connectivity and version of its dependencies.
+func (s *statussrvc) Get(ctx context.Context) (res *status.ServerStatus, err error) {
+	s.logger.DebugContext(ctx, "status.get")
+	st := s.mgr.GetStatus(ctx)
+
+	v := version.GetInfo()
+	res = &status.ServerStatus{
+		Version: &status.VersionInfo{
+			Version:        &v.Version,
+			GitCommit:      &v.GitCommit,
+			BuildDate:      &v.BuildDate,
+			GolangVersion:  &v.GoVersion,
+			GolangCompiler: &v.Compiler,
+			Platform:       &v.Platform,
+		},
+		Namespace:    st.Namespace,
+		DashboardURL: util.RefString(st.DashboardURL),
+		Ready:        st.Ready(),
+		Dependencies: make([]*status.DependencyStatus, 0, len(st.Dependencies)),
+	}
+	for _, d := range st.Dependencies {
+		res.Dependencies = append(res.Dependencies, dependencyStatusDomainToRest(d))
+	}
+	return res, nil
+}
+
+// Liveness probe: succeeds as long as the server is able to handle requests.
+func (s *statussrvc) Live(ctx context.Context) (err error) {
+	return nil
+}
+
+// Readiness probe: succeeds if all the services the server depends on are available.
+func (s *statussrvc) Ready(ctx context.Context) (err error) {
+	st := s.mgr.GetStatus(ctx)
+	if st.Ready() {
+		return nil
+	}
+	unavailable := []string{}
+	for _, d := range st.Dependencies {
+		if !d.Available() {
+			unavailable = append(unavailable, fmt.Sprintf("%s (%s)", d.Name, d.Error))
+		}
+	}
+	return status.MakeUnavailable(fmt.Errorf("dependencies not available: %s", strings.Join(unavailable, ", ")))
+}
@@ -2,7 +2,7 @@ package svc
 
 import (
 	"context"
-	"log"
+	"log/slog"
 
 	gversion "github.com/fuseml/fuseml-core/gen/version"
 	"github.com/fuseml/fuseml-core/pkg/version"
@@ -10,17 +10,17 @@ import (
 
 // version service implementation.
 type versionsrvc struct {
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewVersionService returns the version service implementation.
-func NewVersionService(logger *log.Logger) gversion.Service {
+func NewVersionService(logger *slog.Logger) gversion.Service {
 	return &versionsrvc{logger}
 }
 
 // Retrieve an Codeset from FuseML.
 func (s *versionsrvc) Get(ctx context.Context) (res *gversion.VersionInfo, err error) {
-	s.logger.Print("version.get")
+	s.logger.DebugContext(ctx, "version.get")
 
 	v := version.GetInfo()
 
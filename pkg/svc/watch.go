@@ -0,0 +1,103 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jinzhu/copier"
+
+	"github.com/fuseml/fuseml-core/gen/watch"
+	"github.com/fuseml/fuseml-core/gen/workflow"
+	"github.com/fuseml/fuseml-core/pkg/domain"
+	"github.com/fuseml/fuseml-core/pkg/util"
+)
+
+// watchBookmarkInterval is the interval at which bookmarks are sent while there are no changes, to keep
+// idle connections open and to detect the clients that went away
+const watchBookmarkInterval = 30 * time.Second
+
+// watch service implementation.
+type watchsrvc struct {
+	logger  *slog.Logger
+	watcher domain.Watcher
+}
+
+// NewWatchService returns the watch service implementation.
+func NewWatchService(logger *slog.Logger, watcher domain.Watcher) watch.Service {
+	return &watchsrvc{logger, watcher}
+}
+
+// Watch workflow runs, workflow assignments, codesets and extensions for changes.
+func (s *watchsrvc) Watch(ctx context.Context, p *watch.WatchPayload, stream watch.WatchServerStream) error {
+	s.logger.DebugContext(ctx, "watch.watch")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	kinds := make([]domain.ResourceKind, len(p.Kinds))
+	for i, k := range p.Kinds {
+		kinds[i] = domain.ResourceKind(k)
+	}
+	events, err := s.watcher.Watch(ctx, kinds, util.DerefString(p.ResourceVersion))
+	if err != nil {
+		if errors.Is(err, domain.ErrWatchExpired) {
+			return watch.MakeGone(err)
+		}
+		return watch.MakeBadRequest(err)
+	}
+	defer stream.Close()
+
+	ticker := time.NewTicker(watchBookmarkInterval)
+	defer ticker.Stop()
+	resourceVersion := ""
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// the watch was closed, the client should resume from the last event
+				return nil
+			}
+			if err := stream.Send(watchEventToRest(ctx, event)); err != nil {
+				return err
+			}
+			resourceVersion = event.ResourceVersion
+			ticker.Reset(watchBookmarkInterval)
+		case <-ticker.C:
+			if err := stream.Send(&watch.WatchEvent{Type: string(domain.WatchBookmark), ResourceVersion: resourceVersion}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func watchEventToRest(ctx context.Context, event *domain.WatchEvent) *watch.WatchEvent {
+	res := &watch.WatchEvent{
+		Type:            string(event.Type),
+		Kind:            util.RefString(string(event.Kind)),
+		ResourceVersion: event.ResourceVersion,
+	}
+
+	// the watch service has its own copy of the types shared with the other services, which have the same
+	// fields, so the results of the existing conversions are copied over
+	switch object := event.Object.(type) {
+	case *domain.WorkflowRun:
+		res.WorkflowRun = &watch.WorkflowRun{}
+		copier.Copy(res.WorkflowRun, workflowRunDomainToRest(object))
+	case *domain.WorkflowCodesetAssignment:
+		res.Assignment = &watch.WorkflowAssignment{}
+		copier.Copy(res.Assignment, &workflow.WorkflowAssignment{
+			Workflow: object.Workflow,
+			Codesets: []*workflow.Codeset{(*workflow.Codeset)(codesetDomainToRest(object.Codeset))},
+		})
+	case *domain.Codeset:
+		res.Codeset = (*watch.Codeset)(codesetDomainToRest(object))
+	case *domain.Extension:
+		res.Extension = &watch.Extension{}
+		copier.Copy(res.Extension, extensionToRest(ctx, object))
+	}
+	return res
+}
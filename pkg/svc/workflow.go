@@ -2,7 +2,7 @@ package svc
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -14,31 +14,41 @@ import (
 // workflow service example implementation.
 // The example methods log the requests and return zero values.
 type workflowsrvc struct {
-	logger *log.Logger
+	logger *slog.Logger
 	mgr    domain.WorkflowManager
 }
 
 // NewWorkflowService returns the workflow service implementation.
-func NewWorkflowService(logger *log.Logger, workflowManager domain.WorkflowManager) workflow.Service {
+func NewWorkflowService(logger *slog.Logger, workflowManager domain.WorkflowManager) workflow.Service {
 	return &workflowsrvc{logger, workflowManager}
 }
 
 // List Workflows.
-func (s *workflowsrvc) List(ctx context.Context, w *workflow.ListPayload) (res []*workflow.Workflow, err error) {
-	s.logger.Print("workflow.list")
-	workflows := s.mgr.GetWorkflows(ctx, w.Name)
+func (s *workflowsrvc) List(ctx context.Context, w *workflow.ListPayload) (res *workflow.ListResult, err error) {
+	s.logger.DebugContext(ctx, "workflow.list")
+	workflows, next, err := s.mgr.GetWorkflows(ctx, w.Name, listOptionsToDomain(w.Limit, w.Continue, w.Sort))
+	if err != nil {
+		if isInvalidListOptions(err) {
+			return nil, workflow.MakeBadRequest(err)
+		}
+		return nil, err
+	}
+	res = &workflow.ListResult{Items: make([]*workflow.Workflow, 0, len(workflows)), Continue: util.RefString(next)}
 	for _, w := range workflows {
-		res = append(res, workflowDomainToRest(w))
+		res.Items = append(res.Items, workflowDomainToRest(w))
+	}
+	if err := selectFields(res.Items, w.Fields, "name", "steps"); err != nil {
+		return nil, workflow.MakeBadRequest(err)
 	}
 	return
 }
 
 // Create a new Workflow.
 func (s *workflowsrvc) Create(ctx context.Context, w *workflow.Workflow) (res *workflow.Workflow, err error) {
-	s.logger.Print("workflow.create")
+	s.logger.DebugContext(ctx, "workflow.create")
 	wf, err := s.mgr.CreateWorkflow(ctx, workflowRestToDomain(w))
 	if err != nil {
-		s.logger.Print(err)
+		s.logger.ErrorContext(ctx, "Failed to create workflow", "workflow", w.Name, "error", err)
 		if err == domain.ErrWorkflowExists {
 			return nil, workflow.MakeConflict(err)
 		}
@@ -49,10 +59,10 @@ func (s *workflowsrvc) Create(ctx context.Context, w *workflow.Workflow) (res *w
 
 // Get a Workflow.
 func (s *workflowsrvc) Get(ctx context.Context, w *workflow.GetPayload) (res *workflow.Workflow, err error) {
-	s.logger.Print("workflow.get")
+	s.logger.DebugContext(ctx, "workflow.get")
 	wf, err := s.mgr.GetWorkflow(ctx, w.Name)
 	if err != nil {
-		s.logger.Print(err)
+		s.logger.ErrorContext(ctx, "Failed to get workflow", "workflow", w.Name, "error", err)
 		if err == domain.ErrWorkflowNotFound {
 			return nil, workflow.MakeNotFound(err)
 		}
@@ -61,12 +71,26 @@ func (s *workflowsrvc) Get(ctx context.Context, w *workflow.GetPayload) (res *wo
 	return workflowDomainToRest(wf), nil
 }
 
+// Refresh resolves the extension references of a Workflow again.
+func (s *workflowsrvc) Refresh(ctx context.Context, r *workflow.RefreshPayload) (res *workflow.Workflow, err error) {
+	s.logger.DebugContext(ctx, "workflow.refresh")
+	wf, err := s.mgr.RefreshWorkflow(ctx, r.Name)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to refresh workflow", "workflow", r.Name, "error", err)
+		if err == domain.ErrWorkflowNotFound {
+			return nil, workflow.MakeNotFound(err)
+		}
+		return nil, workflow.MakeBadRequest(err)
+	}
+	return workflowDomainToRest(wf), nil
+}
+
 // Delete a Workflow and its assignments.
 func (s *workflowsrvc) Delete(ctx context.Context, d *workflow.DeletePayload) (err error) {
-	s.logger.Print("workflow.delete")
+	s.logger.DebugContext(ctx, "workflow.delete")
 	err = s.mgr.DeleteWorkflow(ctx, d.Name)
 	if err != nil {
-		s.logger.Print(err)
+		s.logger.ErrorContext(ctx, "Failed to delete workflow", "workflow", d.Name, "error", err)
 		return
 	}
 	return
@@ -74,10 +98,10 @@ func (s *workflowsrvc) Delete(ctx context.Context, d *workflow.DeletePayload) (e
 
 // Assign a Workflow to a Codeset.
 func (s *workflowsrvc) Assign(ctx context.Context, w *workflow.AssignPayload) (err error) {
-	s.logger.Print("workflow.assign")
+	s.logger.DebugContext(ctx, "workflow.assign")
 	_, _, err = s.mgr.AssignToCodeset(ctx, w.Name, w.CodesetProject, w.CodesetName)
 	if err != nil {
-		s.logger.Print(err)
+		s.logger.ErrorContext(ctx, "Failed to assign workflow", "workflow", w.Name, "project", w.CodesetProject, "codeset", w.CodesetName, "error", err)
 		// FIXME: codeset needs to thrown a known error when trying to get a codeset that does not exist
 		// to properly compare the returned error.
 		if err == domain.ErrWorkflowNotFound || strings.Contains(err.Error(), "Fetching Codeset failed") {
@@ -89,10 +113,10 @@ func (s *workflowsrvc) Assign(ctx context.Context, w *workflow.AssignPayload) (e
 
 // Unassign a Workflow from a Codeset.
 func (s *workflowsrvc) Unassign(ctx context.Context, u *workflow.UnassignPayload) (err error) {
-	s.logger.Print("workflow.unassign")
+	s.logger.DebugContext(ctx, "workflow.unassign")
 	err = s.mgr.UnassignFromCodeset(ctx, u.Name, u.CodesetProject, u.CodesetName)
 	if err != nil {
-		s.logger.Print(err)
+		s.logger.ErrorContext(ctx, "Failed to unassign workflow", "workflow", u.Name, "project", u.CodesetProject, "codeset", u.CodesetName, "error", err)
 		if err == domain.ErrWorkflowNotFound || strings.Contains(err.Error(), "Fetching Codeset failed") || err == domain.ErrWorkflowNotAssignedToCodeset {
 			return workflow.MakeNotFound(err)
 		}
@@ -102,7 +126,7 @@ func (s *workflowsrvc) Unassign(ctx context.Context, u *workflow.UnassignPayload
 
 // ListAssignments lists Workflow assignments.
 func (s *workflowsrvc) ListAssignments(ctx context.Context, w *workflow.ListAssignmentsPayload) (assignments []*workflow.WorkflowAssignment, err error) {
-	s.logger.Print("workflow.listAssignments")
+	s.logger.DebugContext(ctx, "workflow.listAssignments")
 	domainAssignments := s.mgr.GetAllCodesetAssignments(ctx, w.Name)
 	if err != nil {
 		return nil, err
@@ -117,8 +141,8 @@ func (s *workflowsrvc) ListAssignments(ctx context.Context, w *workflow.ListAssi
 }
 
 // List Workflow runs.
-func (s *workflowsrvc) ListRuns(ctx context.Context, w *workflow.ListRunsPayload) ([]*workflow.WorkflowRun, error) {
-	s.logger.Print("workflow.listRuns")
+func (s *workflowsrvc) ListRuns(ctx context.Context, w *workflow.ListRunsPayload) (*workflow.ListRunsResult, error) {
+	s.logger.DebugContext(ctx, "workflow.listRuns")
 	filter := domain.WorkflowRunFilter{WorkflowName: w.Name}
 	if w.CodesetName != nil {
 		filter.CodesetName = *w.CodesetName
@@ -129,11 +153,19 @@ func (s *workflowsrvc) ListRuns(ctx context.Context, w *workflow.ListRunsPayload
 	if w.Status != nil {
 		filter.Status = []string{*w.Status}
 	}
-	domainRuns, err := s.mgr.GetWorkflowRuns(ctx, &filter)
+	domainRuns, next, err := s.mgr.GetWorkflowRuns(ctx, &filter, listOptionsToDomain(w.Limit, w.Continue, w.Sort))
 	if err != nil {
+		if isInvalidListOptions(err) {
+			return nil, workflow.MakeBadRequest(err)
+		}
 		return nil, err
 	}
-	return workflowRunsDomainToRest(domainRuns), nil
+	runs := workflowRunsDomainToRest(domainRuns)
+	err = selectFields(runs, w.Fields, "name", "workflowRef", "startTime", "completionTime", "status")
+	if err != nil {
+		return nil, workflow.MakeBadRequest(err)
+	}
+	return &workflow.ListRunsResult{Items: runs, Continue: util.RefString(next)}, nil
 }
 
 func workflowRestToDomain(restWf *workflow.Workflow) *domain.Workflow {
@@ -0,0 +1,157 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	grpcmdlwr "goa.design/goa/v3/grpc/middleware"
+	httpmdlwr "goa.design/goa/v3/http/middleware"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Endpoint is the goa endpoint middleware that names the span of the request after the service method
+// handling it, since the transport middleware starts the span before the request is routed.
+func Endpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		service, _ := ctx.Value(goa.ServiceKey).(string)
+		method, _ := ctx.Value(goa.MethodKey).(string)
+		span := trace.SpanFromContext(ctx)
+		span.SetName(fmt.Sprintf("%s.%s", service, method))
+		span.SetAttributes(semconv.RPCServiceKey.String(service), semconv.RPCMethodKey.String(method))
+		return e(ctx, req)
+	}
+}
+
+// HTTP returns the middleware that traces the requests handled by the HTTP server. The trace context
+// received with the request, if any, becomes the parent of the request span.
+func HTTP() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := Start(ctx, "HTTP "+r.Method, trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("", "", r)...))
+			defer span.End()
+
+			rw := httpmdlwr.CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+			code := rw.StatusCode
+			if code == 0 {
+				code = http.StatusOK
+			}
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(code))
+			if code >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(code))
+			}
+		})
+	}
+}
+
+// metadataCarrier carries the trace context in the metadata of a gRPC request
+type metadataCarrier metadata.MD
+
+// Get returns the first value of the metadata key.
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// Set sets the value of the metadata key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the metadata keys.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func startGRPC(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	return Start(ctx, strings.TrimPrefix(fullMethod, "/"), trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.RPCSystemKey.String("grpc")))
+}
+
+func endGRPC(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(s.Code())))
+	if err != nil {
+		span.SetStatus(codes.Error, s.Message())
+	}
+	span.End()
+}
+
+// UnaryServer returns the interceptor that traces the requests handled by the gRPC server for unary methods.
+func UnaryServer() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startGRPC(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endGRPC(span, err)
+		return resp, err
+	}
+}
+
+// StreamServer returns the interceptor that traces the requests handled by the gRPC server for streaming
+// methods. The span of a streaming request covers the lifetime of the stream.
+func StreamServer() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startGRPC(ss.Context(), info.FullMethod)
+		err := handler(srv, grpcmdlwr.NewWrappedServerStream(ctx, ss))
+		endGRPC(span, err)
+		return err
+	}
+}
+
+// roundTripper traces the requests made by a client and propagates the trace context to the server
+type roundTripper struct {
+	client string
+	next   http.RoundTripper
+}
+
+// RoundTripper returns a http.RoundTripper that traces the requests made by the named client through the
+// given round tripper, or http.DefaultTransport if nil.
+func RoundTripper(client string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &roundTripper{client, next}
+}
+
+// RoundTrip executes a single HTTP transaction within a client span.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Start(req.Context(), fmt.Sprintf("%s HTTP %s", rt.client, req.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPClientAttributesFromHTTPRequest(req)...),
+		trace.WithAttributes(attribute.String("fuseml.client", rt.client)))
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(resp.StatusCode))
+	return resp, nil
+}
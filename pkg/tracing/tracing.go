@@ -0,0 +1,118 @@
+// Package tracing configures the OpenTelemetry tracing of the FuseML core server, and provides the
+// middleware that traces the requests handled by the HTTP and gRPC servers and the requests made to
+// external services.
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// instrumentationName identifies the FuseML instrumentation in the exported spans
+const instrumentationName = "github.com/fuseml/fuseml-core"
+
+// Config describes how traces are exported
+type Config struct {
+	// Endpoint is the address (host:port) of the OTLP gRPC endpoint the traces are exported to. Traces are
+	// not exported if empty, but the trace context is still propagated.
+	Endpoint string
+	// Insecure disables the transport security of the connection to the OTLP endpoint.
+	Insecure bool
+	// SampleRatio is the fraction of the traces started by FuseML that are sampled. Traces started by the
+	// callers of FuseML are sampled if the caller sampled them.
+	SampleRatio float64
+	// ServiceName is the name FuseML is identified by in the exported traces.
+	ServiceName string
+	// ServiceVersion is the version of FuseML reported in the exported traces.
+	ServiceVersion string
+}
+
+// Setup configures the propagation of the trace context, in the W3C Trace Context format, and the export of
+// traces, if an endpoint is configured. It returns the function that flushes the traces that have not been
+// exported yet and stops exporting traces.
+func Setup(ctx context.Context, cfg *Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptrace.New(ctx, &otlpClient{endpoint: cfg.Endpoint, insecure: cfg.Insecure})
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(cfg.ServiceName), semconv.ServiceVersionKey.String(cfg.ServiceVersion)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span, as a child of the span in the context, if any. The returned context holds the new span.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End marks the span as failed if the error points to an error, and ends it. It is meant to be deferred
+// with the address of the named error result of the traced function.
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// otlpClient sends the traces to an OTLP endpoint using gRPC
+type otlpClient struct {
+	endpoint string
+	insecure bool
+	conn     *grpc.ClientConn
+	client   coltracepb.TraceServiceClient
+}
+
+// Start connects to the OTLP endpoint. The connection is established in the background.
+func (c *otlpClient) Start(ctx context.Context) error {
+	creds := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+	if c.insecure {
+		creds = grpc.WithInsecure()
+	}
+	conn, err := grpc.DialContext(ctx, c.endpoint, creds)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	c.client = coltracepb.NewTraceServiceClient(conn)
+	return nil
+}
+
+// Stop closes the connection to the OTLP endpoint.
+func (c *otlpClient) Stop(ctx context.Context) error {
+	return c.conn.Close()
+}
+
+// UploadTraces sends a batch of spans to the OTLP endpoint.
+func (c *otlpClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	_, err := c.client.Export(ctx, &coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+	return err
+}
@@ -0,0 +1,135 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// recordSpans makes the spans started by the test available to it as soon as they end
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		provider.Shutdown(context.Background())
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	return exporter
+}
+
+func TestEnd(t *testing.T) {
+	exporter := recordSpans(t)
+
+	traced := func(fail bool) (err error) {
+		_, span := Start(context.Background(), "traced")
+		defer End(span, &err)
+		if fail {
+			return errors.New("failed")
+		}
+		return nil
+	}
+	traced(false)
+	traced(true)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans want 2", len(spans))
+	}
+	if got := spans[0].Status.Code; got != codes.Unset {
+		t.Errorf("got status %v for the successful call want %v", got, codes.Unset)
+	}
+	if got := spans[1].Status; got.Code != codes.Error || got.Description != "failed" {
+		t.Errorf("got status %v for the failed call want %v (failed)", got, codes.Error)
+	}
+	if got := len(spans[1].Events); got != 1 {
+		t.Errorf("got %d events for the failed call want 1", got)
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	exporter := recordSpans(t)
+
+	e := Endpoint(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	handler := HTTP()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goa.ServiceKey, "project")
+		ctx = context.WithValue(ctx, goa.MethodKey, "get")
+		e(ctx, nil)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/p1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "project.get" {
+		t.Errorf("got span name %q want %q", span.Name, "project.get")
+	}
+	if got := span.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("got trace ID %s want the trace ID received with the request", got)
+	}
+	if got := span.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("got parent span ID %s want the span ID received with the request", got)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("got status %v want %v", span.Status.Code, codes.Error)
+	}
+}
+
+func TestRoundTripper(t *testing.T) {
+	exporter := recordSpans(t)
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: RoundTripper("test", nil)}
+
+	ctx, parent := Start(context.Background(), "parent")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans want 2", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "test HTTP GET" {
+		t.Errorf("got span name %q want %q", span.Name, "test HTTP GET")
+	}
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("the request span is not a child of the span in the request context")
+	}
+	if want := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"; traceparent != want {
+		t.Errorf("got traceparent header %q want %q", traceparent, want)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("got status %v want %v", span.Status.Code, codes.Error)
+	}
+}
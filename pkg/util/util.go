@@ -61,3 +61,29 @@ func DerefBool(s *bool, defaultValue ...bool) bool {
 	}
 	return db
 }
+
+// RefInt converts an int value into an int reference. The reference can also take
+// a nil value to indicate a default value
+func RefInt(i int, defaultValue ...int) *int {
+	di := 0
+	if len(defaultValue) > 0 {
+		di = defaultValue[0]
+	}
+	if i == di {
+		return nil
+	}
+	return &i
+}
+
+// DerefInt converts an int reference into an int value. If the reference is nil,
+// the default value is returned instead
+func DerefInt(i *int, defaultValue ...int) int {
+	di := 0
+	if len(defaultValue) > 0 {
+		di = defaultValue[0]
+	}
+	if i != nil {
+		return *i
+	}
+	return di
+}